@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"fxtester/internal/common"
 	"fxtester/internal/gen"
@@ -22,18 +23,26 @@ func main() {
 	if err := hdr.Init(); err != nil {
 		e.Logger.Fatalf("failed to initialize BarService: %v", err)
 	}
+	hdr.StartKeyRotation(context.Background(), e.Logger)
+	hdr.StartIdpMetadataRefresh(context.Background(), e.Logger)
+	hdr.StartAuditPrune(context.Background(), e.Logger)
 
 	// ミドルウェアの設定
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:     common.GetConfig().Server.AllowOrigins,
 		AllowCredentials: true,
-		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodDelete},
+		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodDelete},
 	}))
 	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Format: "time=${time_rfc3339}, method=${method}, uri=${uri}, status=${status}\n",
 	}))
 	e.Use(lang.ErrorHandler())
+	authMiddleware, err := hdr.AuthMiddleware()
+	if err != nil {
+		e.Logger.Fatalf("failed to create AuthMiddleware: %v", err)
+	}
+	e.Use(authMiddleware)
 
 	// サービスの開始
 	gen.RegisterHandlers(e, hdr)
@@ -0,0 +1,96 @@
+// jwtkey DBに保存するJWTの署名鍵を作成・ローテーションするコマンド
+//
+//	jwtkey generate               署名鍵が無い用途の鍵を作成する
+//	jwtkey rotate [-purpose 用途]  署名鍵を直ちにローテーションする (用途の省略時は全ての用途)
+//	jwtkey prune                  猶予期間を過ぎた退役済みの鍵を削除する
+//	jwtkey list                   署名鍵の一覧を表示する (鍵の値は表示しない)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"fxtester/internal/db"
+	"fxtester/internal/net"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s generate | rotate [-purpose %v] | prune | list\n", os.Args[0], net.Purposes())
+	os.Exit(2)
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	idb := &db.DB{}
+	if err := idb.Init(); err != nil {
+		fail("DBの接続に失敗しました: %v", err)
+	}
+	store := net.NewKeyStore(idb)
+
+	switch os.Args[1] {
+	case "generate":
+		created, err := store.Generate()
+		if err != nil {
+			fail("署名鍵の作成に失敗しました: %v", err)
+		}
+		fmt.Printf("署名鍵を作成しました: %v\n", created)
+
+	case "rotate":
+		fs := flag.NewFlagSet("rotate", flag.ExitOnError)
+		purpose := fs.String("purpose", "", "ローテーションする用途 (省略時は全ての用途)")
+		fs.Parse(os.Args[2:])
+
+		purposes := net.Purposes()
+		if *purpose != "" {
+			if !slices.Contains(purposes, *purpose) {
+				usage()
+			}
+			purposes = []string{*purpose}
+		}
+		for _, p := range purposes {
+			if err := store.Rotate(p); err != nil {
+				fail("署名鍵のローテーションに失敗しました: purpose=%s: %v", p, err)
+			}
+			fmt.Printf("署名鍵をローテーションしました: %s\n", p)
+		}
+
+	case "prune":
+		deleted, err := store.Prune()
+		if err != nil {
+			fail("署名鍵の削除に失敗しました: %v", err)
+		}
+		fmt.Printf("退役済みの署名鍵を%d件削除しました\n", deleted)
+
+	case "list":
+		keys, err := store.Keys()
+		if err != nil {
+			fail("署名鍵の取得に失敗しました: %v", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PURPOSE\tKID\tCREATED_AT\tRETIRED_AT")
+		for _, k := range keys {
+			retiredAt := "-"
+			if k.RetiredAt != nil {
+				retiredAt = k.RetiredAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", k.Purpose, k.Kid, k.CreatedAt.Format(time.RFC3339), retiredAt)
+		}
+		w.Flush()
+
+	default:
+		usage()
+	}
+}
@@ -1,14 +1,58 @@
+// keycloak realmの宣言ファイルをkeycloakに適用するコマンド
+//
+//	keycloak [-spec 宣言ファイル] [-dry-run]
+//
+// realmの現在の状態と宣言ファイルの差分を表示し、宣言したリソースを作成・更新する (realmは削除しない)。
+// -dry-runの場合は差分の表示のみ行う
 package main
 
 import (
+	"encoding/base64"
+	"encoding/pem"
 	"errors"
+	"flag"
 	"fmt"
 	"fxtester/internal/common"
 	"fxtester/internal/keycloak"
+	"os"
 	"strings"
 )
 
+// realmSpecData realmの宣言ファイルに埋め込む値
+type realmSpecData struct {
+	Config *common.Config
+	// SPの証明書 (keycloakのクライアント属性の形式。SPの鍵ペアを設定していない場合は空)
+	SpCertificate string
+	// アサーションを暗号化する場合はtrue
+	EncryptAssertions bool
+	// idPからのSAMLメッセージをHTTP-POSTバインディングで送信させる場合はtrue
+	ForcePostBinding bool
+}
+
 func main() {
+	specPath := flag.String("spec", common.GetConfig().Saml.Keycloak.RealmSpecPath, "realmの宣言ファイルのパス")
+	dryRun := flag.Bool("dry-run", false, "変更内容を表示するのみで適用しない")
+	flag.Parse()
+
+	data := realmSpecData{
+		Config: common.GetConfig(),
+		// saml.bindingがredirectの場合は、idPからのSAMLメッセージもHTTP-Redirectバインディングで送信させる
+		ForcePostBinding: common.GetConfig().Saml.Binding != common.SamlBindingRedirect,
+	}
+	// SPの鍵ペアを設定した場合は、署名したAuthnRequest・LogoutRequestのみ受け付け、必要に応じてアサーションを暗号化する
+	if spConfig := common.GetConfig().Saml.Sp; spConfig.CertPath != "" {
+		certificate, err := readCertificate(spConfig.CertPath)
+		if err != nil {
+			panic(fmt.Sprintf("SPの証明書の読み込みに失敗しました: %v", err))
+		}
+		data.SpCertificate = certificate
+		data.EncryptAssertions = spConfig.EncryptAssertions
+	}
+	spec, err := keycloak.LoadRealmSpec(*specPath, data)
+	if err != nil {
+		panic(fmt.Sprintf("realmの宣言ファイルの読み込みに失敗しました: %v", err))
+	}
+
 	param := keycloak.ClientParam{
 		keycloak.KeyUser:    common.GetConfig().Saml.Keycloak.AdminUser.Username,
 		keycloak.KeyPass:    common.GetConfig().Saml.Keycloak.AdminUser.Password,
@@ -19,59 +63,31 @@ func main() {
 		panic(fmt.Sprintf("ログインに失敗しました: %v", err))
 	}
 
-	realmName := common.GetConfig().Saml.Keycloak.RealmName
-
-	// Realm作成前に古いRealmを削除する
-	c.DeleteRealm(realmName)
-
-	// Realmの作成
-	if err := c.CreateRealm(realmName); err != nil {
-		panic(fmt.Sprintf("Realmの作成に失敗しました: %v", err))
-	}
-
-	// クライアントの作成
-	var req keycloak.ClientRepresentation
-	req.Id = common.GetConfig().Saml.Keycloak.NewClientId
-	req.ClientId = common.GetConfig().Saml.EntityId
-	req.Protocol = keycloak.ProtocolSAML
-	req.RedirectUris = []string{common.GetConfig().Saml.ValidRedirectURI}
-	req.Attributes = map[string]string{
-		keycloak.AttributeSamlClientSignature:         "false",
-		keycloak.AttributeValidPostLogoutRedirectURIs: common.GetConfig().Saml.ValidPostLogoutRedirectURI,
-		keycloak.AttributeLogoutServicePostBindingURL: common.GetConfig().Saml.LogoutServicePostBindingURL,
-		keycloak.AttributeNameIdFormat:                "email",
+	// realmの現在の状態との差分を計画する
+	plan, err := keycloak.NewPlan(c, spec)
+	if err != nil {
+		panic(fmt.Sprintf("realmの変更の計画に失敗しました: %v", err))
 	}
-	if err := c.CreateClient(realmName, req); err != nil {
-		panic(fmt.Sprintf("クライアントの作成に失敗しました: %v", err))
+	plan.Write(os.Stdout)
+	if *dryRun || len(plan.Actions) <= 0 {
+		return
 	}
 
-	protocolMapper, scope, err := func() (*keycloak.ProtocolMapperRepresentation, *keycloak.ClientScopeRepresentation, error) {
-		if scope, err := c.GetClientScope(realmName, "role_list"); err != nil {
-			return nil, nil, err
-		} else {
-			for _, mp := range scope.ProtocolMappers {
-				if mp.Name == "role list" {
-					return &mp, scope, nil
-				}
-			}
-		}
-		return nil, nil, errors.New("no found role_list")
-	}()
-	if err != nil {
-		panic(fmt.Sprintf("role_listのProtocolMapperの取得に失敗しました: %v", err))
+	if err := plan.Apply(c); err != nil {
+		panic(fmt.Sprintf("realmの変更の適用に失敗しました: %v", err))
 	}
+	fmt.Println("realmの変更を適用しました")
+}
 
-	// Single Role Attributeを有効にする
-	protocolMapper.Config["single"] = true
-
-	if err := c.UpdateProtocolMapper(realmName, scope.Id, *protocolMapper); err != nil {
-		panic(fmt.Sprintf("Single Role Attributeの変更に失敗しました: %v", err))
+// readCertificate PEMの証明書を読み込み、keycloakのクライアント属性の形式 (DERのbase64) に変換する
+func readCertificate(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
 	}
-
-	for _, user := range common.GetConfig().Saml.Keycloak.NewUsers {
-		if err := c.CreateUser(realmName, user.Username, user.Email, user.Password); err != nil {
-			panic(fmt.Sprintf("%sユーザの作成に失敗しました: %v", user.Username, err))
-		}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", errors.New("no certificate found")
 	}
-
+	return base64.StdEncoding.EncodeToString(block.Bytes), nil
 }
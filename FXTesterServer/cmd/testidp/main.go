@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"fxtester/internal/common"
+	"fxtester/internal/oidc/oidctest"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const TestIdPPort = 8002
+
+// OpenID Connectのログインを動作確認するためのスタブのOpenID Provider。
+// settings/config.yamlのoidc.issuerを https://localhost:8002 とし、auth.providerをoidcとして使用する
+func main() {
+	email := flag.String("email", "test@test.co.jp", "ログインさせるユーザのEmail (認可リクエストのlogin_hintで上書きできる)")
+	roles := flag.String("roles", "admin", "IDトークンに含めるロール (カンマ区切り)")
+	flag.Parse()
+
+	idp, err := oidctest.NewStubIdP(common.GetConfig().Oidc.ClientId, *email, splitRoles(*roles))
+	if err != nil {
+		log.Fatal(err)
+	}
+	idp.Issuer = fmt.Sprintf("https://localhost:%d", TestIdPPort)
+
+	addr := fmt.Sprintf(":%d", TestIdPPort)
+	sslCertPath := common.GetConfig().Server.Ssl.CertPath
+	sslKeyPath := common.GetConfig().Server.Ssl.KeyPath
+	log.Printf("issuer=%s, clientId=%s, email=%s", idp.Issuer, idp.ClientId, idp.Email)
+	log.Fatal(http.ListenAndServeTLS(addr, sslCertPath, sslKeyPath, idp.Handler()))
+}
+
+func splitRoles(v string) []string {
+	roles := []string{}
+	for _, role := range strings.Split(v, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
@@ -169,8 +169,16 @@ SET role app_user;
 CREATE TABLE IF NOT EXISTS fxtester_schema.user (
     id BIGINT PRIMARY KEY
     , email varchar UNIQUE NOT NULL
-    , access_token varchar
-    , refresh_token varchar
+    , roles varchar[] NOT NULL DEFAULT '{}' -- SAMLアサーションから同期するロール (e.g. admin)
+    , preferences jsonb NOT NULL DEFAULT '{}' -- ユーザの設定 (ロケール・タイムゾーン・CSV情報のテンプレート・表示設定)
+    -- ここから SAMLアサーションの属性からログイン毎に同期する項目 (settings/config.yamlのsaml.attributeMapping)
+    , display_name varchar NOT NULL DEFAULT ''
+    , given_name varchar NOT NULL DEFAULT ''
+    , family_name varchar NOT NULL DEFAULT ''
+    , groups varchar[] NOT NULL DEFAULT '{}'
+    , locale varchar NOT NULL DEFAULT '' -- idPのロケール (ユーザの設定のロケールとは別)
+    -- ここまで
+    , disabled_at TIMESTAMP WITH TIME ZONE -- idPで無効化されたためプロビジョニングを解除した日時 (NULLの場合は有効)
 );
 
 -- シーケンスの作成 (0は初期値として使用するため統一的に1始まりとする)
@@ -203,25 +211,946 @@ CREATE OR REPLACE FUNCTION fxtester_schema.select_user_with_email(p_email varcha
 RETURNS TABLE(
     id bigint,
     email varchar,
-    access_token varchar,
-    refresh_token varchar
+    roles varchar[]
 ) AS $$
 BEGIN
     RETURN QUERY
-    SELECT *
+    SELECT u.id, u.email, u.roles
     FROM fxtester_schema.user u
     WHERE u.email = p_email;
 END;
 $$ LANGUAGE plpgsql;
 
 /**
- * ストアドプロシージャー名: update_token
- * 機能: 指定ユーザとアクセストークン、リフレッシュトークンを関連付けします
- * 利用例: call call fxtester_schema.update_token(1, 'access_token_xxx', 'refresh_token_yyy')
+ * 関数名: select_user_with_id
+ * 機能: 指定したIDと一致するユーザ情報 (設定・idPから同期した属性を含む) を返却します
+ * 利用例: SELECT * FROM fxtester_schema.select_user_with_id(1);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_user_with_id(p_user_id bigint)
+RETURNS TABLE(
+    id bigint,
+    email varchar,
+    roles varchar[],
+    preferences jsonb,
+    display_name varchar,
+    given_name varchar,
+    family_name varchar,
+    groups varchar[],
+    locale varchar,
+    disabled_at TIMESTAMP WITH TIME ZONE
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT u.id, u.email, u.roles, u.preferences, u.display_name, u.given_name, u.family_name, u.groups, u.locale, u.disabled_at
+    FROM fxtester_schema.user u
+    WHERE u.id = p_user_id;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * ストアドプロシージャー名: update_user_roles
+ * 機能: 指定ユーザのロールを置き換えます
+ * 利用例: call fxtester_schema.update_user_roles(1, ARRAY['admin']::varchar[]);
+ */
+create or replace procedure fxtester_schema.update_user_roles(p_user_id bigint, p_roles varchar[])
+AS $$
+BEGIN
+    UPDATE fxtester_schema.user u SET roles = p_roles WHERE u.id = p_user_id;
+END;
+$$ language plpgsql;
+
+/**
+ * ストアドプロシージャー名: update_user_attributes
+ * 機能: 指定ユーザのidPから同期する属性を置き換えます (NULLを指定した項目は維持します)。
+ *       idPでログインできたユーザのため、プロビジョニングの解除も取り消します
+ * 利用例: call fxtester_schema.update_user_attributes(1, 'Taro Yamada', 'Taro', 'Yamada', ARRAY['traders']::varchar[], 'ja');
+ */
+create or replace procedure fxtester_schema.update_user_attributes(
+    p_user_id bigint
+    , p_display_name varchar
+    , p_given_name varchar
+    , p_family_name varchar
+    , p_groups varchar[]
+    , p_locale varchar
+)
+AS $$
+BEGIN
+    UPDATE fxtester_schema.user u SET
+        display_name = COALESCE(p_display_name, u.display_name)
+        , given_name = COALESCE(p_given_name, u.given_name)
+        , family_name = COALESCE(p_family_name, u.family_name)
+        , groups = COALESCE(p_groups, u.groups)
+        , locale = COALESCE(p_locale, u.locale)
+        , disabled_at = NULL
+    WHERE u.id = p_user_id;
+END;
+$$ language plpgsql;
+
+/**
+ * 関数名: select_user_preferences
+ * 機能: 指定ユーザの設定を返却します (ユーザが存在しない場合はNULLを返却します)
+ * 利用例: SELECT fxtester_schema.select_user_preferences(1);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_user_preferences(p_user_id bigint)
+RETURNS jsonb AS $$
+DECLARE
+    v_preferences jsonb;
+BEGIN
+    SELECT u.preferences INTO v_preferences FROM fxtester_schema.user u WHERE u.id = p_user_id;
+    RETURN v_preferences;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * ストアドプロシージャー名: update_user_preferences
+ * 機能: 指定ユーザの設定に指定した項目を上書きします (指定しなかった項目は維持します)
+ * 利用例: call fxtester_schema.update_user_preferences(1, '{"locale": "en"}');
+ */
+create or replace procedure fxtester_schema.update_user_preferences(p_user_id bigint, p_preferences jsonb)
+AS $$
+BEGIN
+    UPDATE fxtester_schema.user u SET preferences = u.preferences || p_preferences WHERE u.id = p_user_id;
+END;
+$$ language plpgsql;
+
+/**
+ * 関数名: select_users
+ * 機能: 全てのユーザ情報 (idPから同期した属性を含む) をIDの昇順で返却します
+ * 利用例: SELECT * FROM fxtester_schema.select_users();
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_users()
+RETURNS TABLE(
+    id bigint,
+    email varchar,
+    roles varchar[],
+    display_name varchar,
+    given_name varchar,
+    family_name varchar,
+    groups varchar[],
+    locale varchar,
+    disabled_at TIMESTAMP WITH TIME ZONE
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT u.id, u.email, u.roles, u.display_name, u.given_name, u.family_name, u.groups, u.locale, u.disabled_at
+    FROM fxtester_schema.user u
+    ORDER BY u.id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- ログインセッション (ログイン毎に作成し、端末毎に一覧・ログアウトできるようにする)
+CREATE TABLE IF NOT EXISTS fxtester_schema.user_session (
+    id varchar PRIMARY KEY
+    , user_id BIGINT NOT NULL REFERENCES fxtester_schema.user(id) ON DELETE CASCADE
+    , access_token varchar NOT NULL
+    , refresh_token varchar NOT NULL
+    , device varchar NOT NULL DEFAULT ''
+    , ip_address varchar NOT NULL DEFAULT ''
+    , user_agent varchar NOT NULL DEFAULT ''
+    , saml_session_index varchar NOT NULL DEFAULT '' -- idP起点のシングルログアウトで対象のセッションを特定するため
+    , created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+    , last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS user_session_user_id_idx ON fxtester_schema.user_session (user_id, last_seen_at DESC);
+
+/**
+ * ストアドプロシージャー名: create_user_session
+ * 機能: ログインセッションを追加します
+ * 利用例: call fxtester_schema.create_user_session('session-id', 1, 'access_token_xxx', 'refresh_token_yyy', 'Chrome on Windows', '192.0.2.1', 'Mozilla/5.0 ...', '_session-index');
+ */
+create or replace procedure fxtester_schema.create_user_session(
+    p_id varchar
+    , p_user_id bigint
+    , p_access_token varchar
+    , p_refresh_token varchar
+    , p_device varchar
+    , p_ip_address varchar
+    , p_user_agent varchar
+    , p_saml_session_index varchar
+)
+AS $$
+BEGIN
+    INSERT INTO fxtester_schema.user_session (id, user_id, access_token, refresh_token, device, ip_address, user_agent, saml_session_index)
+    VALUES (p_id, p_user_id, p_access_token, p_refresh_token, p_device, p_ip_address, p_user_agent, p_saml_session_index);
+END;
+$$ language plpgsql;
+
+/**
+ * ストアドプロシージャー名: update_user_session_token
+ * 機能: ログインセッションのアクセストークン、リフレッシュトークンを更新し、最終アクセスの日時・IPアドレス・User-Agentを記録します
+ * 利用例: call fxtester_schema.update_user_session_token('session-id', 'access_token_xxx', 'refresh_token_yyy', '192.0.2.1', 'Mozilla/5.0 ...');
  */
-create or replace procedure fxtester_schema.update_token(p_user_id bigint, p_access_token varchar, p_refresh_token varchar)
+create or replace procedure fxtester_schema.update_user_session_token(
+    p_id varchar
+    , p_access_token varchar
+    , p_refresh_token varchar
+    , p_ip_address varchar
+    , p_user_agent varchar
+)
 AS $$
 BEGIN
-    UPDATE fxtester_schema.user u SET access_token=p_access_token, refresh_token=p_refresh_token WHERE u.id = p_user_id;
+    UPDATE fxtester_schema.user_session s
+    SET access_token = p_access_token
+        , refresh_token = p_refresh_token
+        , ip_address = p_ip_address
+        , user_agent = p_user_agent
+        , last_seen_at = CURRENT_TIMESTAMP
+    WHERE s.id = p_id;
+END;
+$$ language plpgsql;
+
+/**
+ * 関数名: check_session_access_token
+ * 機能: 指定ユーザのログインセッションのアクセストークンと一致するかを返却します。
+ *       一致した場合は最終アクセス日時を更新します (書き込みを減らすため1分毎とする)
+ * 利用例: SELECT fxtester_schema.check_session_access_token('session-id', 1, 'access_token_xxx');
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.check_session_access_token(p_id varchar, p_user_id bigint, p_access_token varchar)
+RETURNS boolean AS $$
+BEGIN
+    UPDATE fxtester_schema.user_session s
+    SET last_seen_at = CURRENT_TIMESTAMP
+    WHERE s.id = p_id AND s.user_id = p_user_id AND s.access_token = p_access_token
+        AND s.last_seen_at < CURRENT_TIMESTAMP - interval '1 minute';
+    IF FOUND THEN
+        RETURN true;
+    END IF;
+
+    RETURN EXISTS(
+        SELECT 1
+        FROM fxtester_schema.user_session s
+        WHERE s.id = p_id AND s.user_id = p_user_id AND s.access_token = p_access_token
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: check_session_refresh_token
+ * 機能: 指定ユーザのログインセッションのリフレッシュトークンと一致するかを返却します (セッションが存在しない場合はNULL)。
+ *       セッションの行をロックするため、トランザクション内で呼び出した場合は同一セッションのトークンの更新はコミットまで待機します
+ * 利用例: SELECT fxtester_schema.check_session_refresh_token('session-id', 1, 'refresh_token_yyy');
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.check_session_refresh_token(p_id varchar, p_user_id bigint, p_refresh_token varchar)
+RETURNS boolean AS $$
+DECLARE
+    v_refresh_token varchar;
+BEGIN
+    SELECT s.refresh_token INTO v_refresh_token
+    FROM fxtester_schema.user_session s
+    WHERE s.id = p_id AND s.user_id = p_user_id
+    FOR UPDATE;
+    IF NOT FOUND THEN
+        RETURN NULL;
+    END IF;
+    RETURN v_refresh_token = p_refresh_token;
 END;
-$$ language plpgsql;
\ No newline at end of file
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: select_user_sessions
+ * 機能: 指定ユーザのログインセッションを最終アクセス日時の新しい順で返却します
+ * 利用例: SELECT * FROM fxtester_schema.select_user_sessions(1);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_user_sessions(p_user_id bigint)
+RETURNS TABLE(
+    id varchar,
+    device varchar,
+    ip_address varchar,
+    user_agent varchar,
+    created_at TIMESTAMP WITH TIME ZONE,
+    last_seen_at TIMESTAMP WITH TIME ZONE
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT s.id, s.device, s.ip_address, s.user_agent, s.created_at, s.last_seen_at
+    FROM fxtester_schema.user_session s
+    WHERE s.user_id = p_user_id
+    ORDER BY s.last_seen_at DESC, s.created_at DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: delete_user_session
+ * 機能: 指定ユーザのログインセッションを削除し、削除できたかを返却します
+ * 利用例: SELECT fxtester_schema.delete_user_session(1, 'session-id');
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.delete_user_session(p_user_id bigint, p_id varchar)
+RETURNS boolean AS $$
+BEGIN
+    DELETE FROM fxtester_schema.user_session s WHERE s.user_id = p_user_id AND s.id = p_id;
+    RETURN FOUND;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: delete_user_sessions
+ * 機能: 指定ユーザのSAMLのセッションインデックスが一致するログインセッションを削除し、削除した件数を返却します。
+ *       セッションインデックスが空文字の場合はユーザの全てのログインセッションを削除します
+ * 利用例: SELECT fxtester_schema.delete_user_sessions(1, '_session-index');
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.delete_user_sessions(p_user_id bigint, p_saml_session_index varchar)
+RETURNS bigint AS $$
+DECLARE
+    deleted bigint;
+BEGIN
+    DELETE FROM fxtester_schema.user_session s
+    WHERE s.user_id = p_user_id AND (p_saml_session_index = '' OR s.saml_session_index = p_saml_session_index);
+    GET DIAGNOSTICS deleted = ROW_COUNT;
+    RETURN deleted;
+END;
+$$ LANGUAGE plpgsql;
+
+-- 個人用APIトークン (スクリプト等からAuthorization: Bearerで使用する。トークンはハッシュ値のみ保存する)
+CREATE TABLE IF NOT EXISTS fxtester_schema.api_token (
+    id varchar PRIMARY KEY
+    , user_id BIGINT NOT NULL REFERENCES fxtester_schema.user(id) ON DELETE CASCADE
+    , name varchar NOT NULL
+    , token_hash varchar UNIQUE NOT NULL -- トークンのSHA-256 (16進数)
+    , token_prefix varchar NOT NULL -- 一覧でトークンを見分けるための先頭の数文字
+    , scopes varchar[] NOT NULL DEFAULT '{}'
+    , created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+    , expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+    , last_used_at TIMESTAMP WITH TIME ZONE
+    , UNIQUE (user_id, name)
+);
+
+/**
+ * 関数名: create_api_token
+ * 機能: 個人用APIトークンを追加します。同じユーザに同じ名前のトークンが存在する場合は追加せずにfalseを返却します
+ * 利用例: SELECT fxtester_schema.create_api_token('token-id', 1, 'ci', 'e3b0c442...', 'fxt_AbCd', ARRAY['analysis']::varchar[], '2025-01-01T00:00:00Z');
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.create_api_token(
+    p_id varchar
+    , p_user_id bigint
+    , p_name varchar
+    , p_token_hash varchar
+    , p_token_prefix varchar
+    , p_scopes varchar[]
+    , p_expires_at TIMESTAMP WITH TIME ZONE
+)
+RETURNS boolean AS $$
+BEGIN
+    INSERT INTO fxtester_schema.api_token (id, user_id, name, token_hash, token_prefix, scopes, expires_at)
+    VALUES (p_id, p_user_id, p_name, p_token_hash, p_token_prefix, p_scopes, p_expires_at)
+    ON CONFLICT (user_id, name) DO NOTHING;
+    RETURN FOUND;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: select_api_tokens
+ * 機能: 指定ユーザの個人用APIトークンを作成日時の新しい順で返却します (期限切れのトークンを含む)
+ * 利用例: SELECT * FROM fxtester_schema.select_api_tokens(1);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_api_tokens(p_user_id bigint)
+RETURNS TABLE(
+    id varchar,
+    name varchar,
+    token_prefix varchar,
+    scopes varchar[],
+    created_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT t.id, t.name, t.token_prefix, t.scopes, t.created_at, t.expires_at, t.last_used_at
+    FROM fxtester_schema.api_token t
+    WHERE t.user_id = p_user_id
+    ORDER BY t.created_at DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: find_api_token
+ * 機能: ハッシュ値が一致する有効期限内の個人用APIトークンと、その所有ユーザを返却します。
+ *       一致した場合は最終使用日時を更新します (書き込みを減らすため1分毎とする)
+ * 利用例: SELECT * FROM fxtester_schema.find_api_token('e3b0c442...');
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.find_api_token(p_token_hash varchar)
+RETURNS TABLE(
+    id varchar,
+    user_id bigint,
+    email varchar,
+    roles varchar[],
+    scopes varchar[]
+) AS $$
+BEGIN
+    UPDATE fxtester_schema.api_token t
+    SET last_used_at = CURRENT_TIMESTAMP
+    WHERE t.token_hash = p_token_hash
+        AND (t.last_used_at IS NULL OR t.last_used_at < CURRENT_TIMESTAMP - interval '1 minute');
+
+    RETURN QUERY
+    SELECT t.id, u.id, u.email, u.roles, t.scopes
+    FROM fxtester_schema.api_token t
+    INNER JOIN fxtester_schema.user u ON u.id = t.user_id
+    WHERE t.token_hash = p_token_hash AND CURRENT_TIMESTAMP < t.expires_at;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: delete_api_token
+ * 機能: 指定ユーザの個人用APIトークンを削除し、削除できたかを返却します
+ * 利用例: SELECT fxtester_schema.delete_api_token(1, 'token-id');
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.delete_api_token(p_user_id bigint, p_id varchar)
+RETURNS boolean AS $$
+BEGIN
+    DELETE FROM fxtester_schema.api_token t WHERE t.user_id = p_user_id AND t.id = p_id;
+    RETURN FOUND;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * ストアドプロシージャー名: deprovision_user
+ * 機能: idPで無効化されたユーザのプロビジョニングを解除します。
+ *       無効化した日時を記録し、全てのログインセッションと個人用APIトークンを削除します (ユーザのデータは残します)
+ * 利用例: call fxtester_schema.deprovision_user(1);
+ */
+create or replace procedure fxtester_schema.deprovision_user(p_user_id bigint)
+AS $$
+BEGIN
+    UPDATE fxtester_schema.user u SET disabled_at = COALESCE(u.disabled_at, CURRENT_TIMESTAMP) WHERE u.id = p_user_id;
+    DELETE FROM fxtester_schema.user_session s WHERE s.user_id = p_user_id;
+    DELETE FROM fxtester_schema.api_token t WHERE t.user_id = p_user_id;
+END;
+$$ language plpgsql;
+
+-- JWTの署名鍵 (用途毎に最新の鍵で署名し、ローテーション後も猶予期間中は古い鍵で検証する)
+CREATE TABLE IF NOT EXISTS fxtester_schema.jwt_signing_key (
+    kid varchar PRIMARY KEY
+    , purpose varchar NOT NULL
+    , secret bytea NOT NULL
+    , created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+    , retired_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS jwt_signing_key_purpose_idx ON fxtester_schema.jwt_signing_key (purpose, created_at DESC);
+
+/**
+ * 関数名: select_jwt_signing_keys
+ * 機能: 全ての用途の署名鍵を作成日時の昇順で返却します
+ * 利用例: SELECT * FROM fxtester_schema.select_jwt_signing_keys();
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_jwt_signing_keys()
+RETURNS TABLE(
+    kid varchar,
+    purpose varchar,
+    secret bytea,
+    created_at TIMESTAMP WITH TIME ZONE,
+    retired_at TIMESTAMP WITH TIME ZONE
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT k.kid, k.purpose, k.secret, k.created_at, k.retired_at
+    FROM fxtester_schema.jwt_signing_key k
+    ORDER BY k.created_at, k.kid;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: rotate_jwt_signing_key
+ * 機能: 用途の現在の署名鍵を退役させ、新しい署名鍵を追加します。追加した場合はtrueを返却します。
+ *       p_min_age_secを指定した場合は現在の鍵がその秒数より古い場合のみ、NULLの場合は現在の鍵が無い場合のみ追加します。
+ *       複数のサーバから同時に呼び出されても二重にローテーションしないよう、用途毎にロックを取得します
+ * 利用例: SELECT fxtester_schema.rotate_jwt_signing_key('access', 'kid_xxx', '\x00', 0);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.rotate_jwt_signing_key(p_purpose varchar, p_kid varchar, p_secret bytea, p_min_age_sec bigint)
+RETURNS boolean AS $$
+DECLARE
+    v_created_at TIMESTAMP WITH TIME ZONE;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('jwt_signing_key:' || p_purpose));
+
+    SELECT max(k.created_at) INTO v_created_at
+    FROM fxtester_schema.jwt_signing_key k
+    WHERE k.purpose = p_purpose AND k.retired_at IS NULL;
+
+    IF v_created_at IS NOT NULL THEN
+        IF p_min_age_sec IS NULL OR v_created_at > CURRENT_TIMESTAMP - make_interval(secs => p_min_age_sec) THEN
+            RETURN false;
+        END IF;
+    END IF;
+
+    UPDATE fxtester_schema.jwt_signing_key k SET retired_at = CURRENT_TIMESTAMP
+    WHERE k.purpose = p_purpose AND k.retired_at IS NULL;
+
+    INSERT INTO fxtester_schema.jwt_signing_key (kid, purpose, secret)
+    VALUES (p_kid, p_purpose, p_secret);
+    RETURN true;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: delete_retired_jwt_signing_keys
+ * 機能: 退役してから猶予期間(秒)を過ぎた署名鍵を削除し、削除した件数を返却します
+ * 利用例: SELECT fxtester_schema.delete_retired_jwt_signing_keys(691200);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.delete_retired_jwt_signing_keys(p_grace_sec bigint)
+RETURNS bigint AS $$
+DECLARE
+    deleted bigint;
+BEGIN
+    DELETE FROM fxtester_schema.jwt_signing_key k
+    WHERE k.retired_at IS NOT NULL AND k.retired_at < CURRENT_TIMESTAMP - make_interval(secs => p_grace_sec);
+    GET DIAGNOSTICS deleted = ROW_COUNT;
+    RETURN deleted;
+END;
+$$ LANGUAGE plpgsql;
+
+-- 使用済みのSAMLアサーションのID (ACSへの同じアサーションの再送を拒否するため、有効期限まで保持する)
+CREATE TABLE IF NOT EXISTS fxtester_schema.saml_assertion (
+    id varchar PRIMARY KEY
+    , expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS saml_assertion_expires_at_idx ON fxtester_schema.saml_assertion (expires_at);
+
+/**
+ * 関数名: consume_saml_assertion
+ * 機能: SAMLアサーションのIDを使用済みとして記録し、初めて使用された場合はtrue、使用済みの場合はfalseを返却します。
+ *       有効期限を過ぎたIDは記録する前に削除します
+ * 利用例: SELECT fxtester_schema.consume_saml_assertion('assertion-id', '2024-10-08 09:19:26+00');
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.consume_saml_assertion(p_id varchar, p_expires_at TIMESTAMP WITH TIME ZONE)
+RETURNS boolean AS $$
+BEGIN
+    DELETE FROM fxtester_schema.saml_assertion a WHERE a.expires_at < CURRENT_TIMESTAMP;
+
+    INSERT INTO fxtester_schema.saml_assertion (id, expires_at)
+    VALUES (p_id, p_expires_at)
+    ON CONFLICT (id) DO NOTHING;
+    RETURN FOUND;
+END;
+$$ LANGUAGE plpgsql;
+
+-- シンボルのメタデータ (settings/config.yamlのsymbolsをサーバ起動時に登録する)
+CREATE TABLE IF NOT EXISTS fxtester_schema.symbol (
+    name varchar PRIMARY KEY
+    , base_currency varchar(3) NOT NULL
+    , quote_currency varchar(3) NOT NULL
+    , digits integer NOT NULL CONSTRAINT digits_check CHECK (digits >= 0)
+    , pip_size DECIMAL NOT NULL CONSTRAINT pip_size_check CHECK (pip_size > 0)
+    , contract_size DECIMAL NOT NULL CONSTRAINT contract_size_check CHECK (contract_size > 0)
+    , sessions jsonb NOT NULL DEFAULT '[]'
+);
+
+/**
+ * ストアドプロシージャー名: upsert_symbol
+ * 機能: シンボルのメタデータを登録します。登録済みの場合は更新します
+ * 利用例: call fxtester_schema.upsert_symbol('USDJPY', 'USD', 'JPY', 3, 0.01, 100000, '[{"name": "tokyo", "open": "00:00", "close": "09:00"}]')
+ */
+create or replace procedure fxtester_schema.upsert_symbol(
+    p_name varchar
+    , p_base_currency varchar
+    , p_quote_currency varchar
+    , p_digits integer
+    , p_pip_size DECIMAL
+    , p_contract_size DECIMAL
+    , p_sessions jsonb
+)
+AS $$
+BEGIN
+    INSERT INTO fxtester_schema.symbol (name, base_currency, quote_currency, digits, pip_size, contract_size, sessions)
+    VALUES (p_name, p_base_currency, p_quote_currency, p_digits, p_pip_size, p_contract_size, p_sessions)
+    ON CONFLICT (name)
+    DO UPDATE SET
+        base_currency = EXCLUDED.base_currency
+        , quote_currency = EXCLUDED.quote_currency
+        , digits = EXCLUDED.digits
+        , pip_size = EXCLUDED.pip_size
+        , contract_size = EXCLUDED.contract_size
+        , sessions = EXCLUDED.sessions;
+END;
+$$ language plpgsql;
+
+/**
+ * 関数名: select_symbols
+ * 機能: 登録済みのシンボルのメタデータをシンボル名順に返却します
+ * 利用例: SELECT * FROM fxtester_schema.select_symbols();
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_symbols()
+RETURNS TABLE(
+    name varchar,
+    base_currency varchar,
+    quote_currency varchar,
+    digits integer,
+    pip_size DECIMAL,
+    contract_size DECIMAL,
+    sessions jsonb
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT *
+    FROM fxtester_schema.symbol s
+    ORDER BY s.name;
+END;
+$$ LANGUAGE plpgsql;
+
+-- バックテストの実行履歴 (ユーザ毎に保存し、後から比較できるようにする)
+CREATE TABLE IF NOT EXISTS fxtester_schema.backtest_run (
+    id BIGINT PRIMARY KEY
+    , user_id BIGINT NOT NULL REFERENCES fxtester_schema.user(id) ON DELETE CASCADE
+    , kind varchar NOT NULL CONSTRAINT kind_check CHECK (kind IN ('single', 'portfolio'))
+    , parameters jsonb NOT NULL
+    , datasets jsonb NOT NULL DEFAULT '[]'
+    , created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS backtest_run_user_id_idx ON fxtester_schema.backtest_run (user_id, created_at DESC);
+
+-- バックテストで約定した取引 (金額は口座通貨建て)
+CREATE TABLE IF NOT EXISTS fxtester_schema.backtest_trade (
+    run_id BIGINT REFERENCES fxtester_schema.backtest_run(id) ON DELETE CASCADE
+    , seq integer
+    , symbol varchar NOT NULL
+    , side varchar NOT NULL CONSTRAINT side_check CHECK (side IN ('buy', 'sell'))
+    , lots DECIMAL NOT NULL
+    , entry_time TIMESTAMP WITH TIME ZONE NOT NULL
+    , entry_price DECIMAL NOT NULL
+    , exit_time TIMESTAMP WITH TIME ZONE NOT NULL
+    , exit_price DECIMAL NOT NULL
+    , exit_reason varchar NOT NULL
+    , gross_profit DECIMAL NOT NULL
+    , spread_cost DECIMAL NOT NULL
+    , slippage_cost DECIMAL NOT NULL
+    , commission DECIMAL NOT NULL
+    , swap DECIMAL NOT NULL
+    , net_profit DECIMAL NOT NULL
+    , pips DECIMAL
+    , PRIMARY KEY (run_id, seq)
+);
+
+-- バックテストの集計値 (symbolが空文字の場合は全シンボルの集計)
+CREATE TABLE IF NOT EXISTS fxtester_schema.backtest_metric (
+    run_id BIGINT REFERENCES fxtester_schema.backtest_run(id) ON DELETE CASCADE
+    , symbol varchar NOT NULL DEFAULT ''
+    , name varchar NOT NULL
+    , value DECIMAL NOT NULL
+    , PRIMARY KEY (run_id, symbol, name)
+);
+
+CREATE SEQUENCE IF NOT EXISTS fxtester_schema.backtest_run_id MINVALUE 1 OWNED BY fxtester_schema.backtest_run.id;
+
+/**
+ * 関数名: create_backtest_run
+ * 機能: バックテストの実行履歴を追加し、追加した実行履歴のIDを返却する
+ * 利用例: select fxtester_schema.create_backtest_run(1, 'single', '{"symbols": ["USDJPY"]}', '[]');
+ */
+create or replace function fxtester_schema.create_backtest_run(
+    p_user_id bigint
+    , p_kind varchar
+    , p_parameters jsonb
+    , p_datasets jsonb
+)
+returns bigint
+AS $$
+DECLARE
+    new_id bigint;
+BEGIN
+    INSERT INTO fxtester_schema.backtest_run (id, user_id, kind, parameters, datasets)
+    VALUES (nextval('fxtester_schema.backtest_run_id'), p_user_id, p_kind, p_parameters, p_datasets) returning id INTO new_id;
+    return new_id;
+END;
+$$ language plpgsql;
+
+/**
+ * ストアドプロシージャー名: insert_backtest_trades
+ * 機能: JSON配列で指定した取引をバックテストの実行履歴に追加します
+ * 利用例: call fxtester_schema.insert_backtest_trades(1, '[{"seq": 0, "symbol": "USDJPY", "side": "buy", ...}]')
+ */
+create or replace procedure fxtester_schema.insert_backtest_trades(p_run_id bigint, p_trades jsonb)
+AS $$
+BEGIN
+    INSERT INTO fxtester_schema.backtest_trade (
+        run_id, seq, symbol, side, lots, entry_time, entry_price, exit_time, exit_price, exit_reason
+        , gross_profit, spread_cost, slippage_cost, commission, swap, net_profit, pips
+    )
+    SELECT
+        p_run_id, t.seq, t.symbol, t.side, t.lots, t.entry_time, t.entry_price, t.exit_time, t.exit_price, t.exit_reason
+        , t.gross_profit, t.spread_cost, t.slippage_cost, t.commission, t.swap, t.net_profit, t.pips
+    FROM jsonb_to_recordset(p_trades) AS t(
+        seq integer
+        , symbol varchar
+        , side varchar
+        , lots DECIMAL
+        , entry_time TIMESTAMP WITH TIME ZONE
+        , entry_price DECIMAL
+        , exit_time TIMESTAMP WITH TIME ZONE
+        , exit_price DECIMAL
+        , exit_reason varchar
+        , gross_profit DECIMAL
+        , spread_cost DECIMAL
+        , slippage_cost DECIMAL
+        , commission DECIMAL
+        , swap DECIMAL
+        , net_profit DECIMAL
+        , pips DECIMAL
+    );
+END;
+$$ language plpgsql;
+
+/**
+ * ストアドプロシージャー名: insert_backtest_metrics
+ * 機能: JSON配列で指定した集計値をバックテストの実行履歴に追加します
+ * 利用例: call fxtester_schema.insert_backtest_metrics(1, '[{"symbol": "", "name": "netProfit", "value": 1000}]')
+ */
+create or replace procedure fxtester_schema.insert_backtest_metrics(p_run_id bigint, p_metrics jsonb)
+AS $$
+BEGIN
+    INSERT INTO fxtester_schema.backtest_metric (run_id, symbol, name, value)
+    SELECT p_run_id, m.symbol, m.name, m.value
+    FROM jsonb_to_recordset(p_metrics) AS m(symbol varchar, name varchar, value DECIMAL);
+END;
+$$ language plpgsql;
+
+/**
+ * 関数名: select_backtest_runs
+ * 機能: 指定ユーザのバックテストの実行履歴を実行日時の新しい順に返却します
+ * 利用例: SELECT * FROM fxtester_schema.select_backtest_runs(1);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_backtest_runs(p_user_id bigint)
+RETURNS TABLE(
+    id bigint,
+    user_id bigint,
+    kind varchar,
+    parameters jsonb,
+    datasets jsonb,
+    created_at TIMESTAMP WITH TIME ZONE
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT *
+    FROM fxtester_schema.backtest_run r
+    WHERE r.user_id = p_user_id
+    ORDER BY r.created_at DESC, r.id DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: select_backtest_run
+ * 機能: 指定ユーザが所有する指定IDのバックテストの実行履歴を返却します
+ * 利用例: SELECT * FROM fxtester_schema.select_backtest_run(1, 1);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_backtest_run(p_user_id bigint, p_run_id bigint)
+RETURNS TABLE(
+    id bigint,
+    user_id bigint,
+    kind varchar,
+    parameters jsonb,
+    datasets jsonb,
+    created_at TIMESTAMP WITH TIME ZONE
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT *
+    FROM fxtester_schema.backtest_run r
+    WHERE r.user_id = p_user_id AND r.id = p_run_id;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: select_backtest_trades
+ * 機能: 指定したバックテストの取引を約定順に返却します
+ * 利用例: SELECT * FROM fxtester_schema.select_backtest_trades(1);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_backtest_trades(p_run_id bigint)
+RETURNS TABLE(
+    run_id bigint,
+    seq integer,
+    symbol varchar,
+    side varchar,
+    lots DECIMAL,
+    entry_time TIMESTAMP WITH TIME ZONE,
+    entry_price DECIMAL,
+    exit_time TIMESTAMP WITH TIME ZONE,
+    exit_price DECIMAL,
+    exit_reason varchar,
+    gross_profit DECIMAL,
+    spread_cost DECIMAL,
+    slippage_cost DECIMAL,
+    commission DECIMAL,
+    swap DECIMAL,
+    net_profit DECIMAL,
+    pips DECIMAL
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT *
+    FROM fxtester_schema.backtest_trade t
+    WHERE t.run_id = p_run_id
+    ORDER BY t.seq;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: select_backtest_metrics
+ * 機能: 指定したバックテスト(複数指定可)の集計値を返却します
+ * 利用例: SELECT * FROM fxtester_schema.select_backtest_metrics(ARRAY[1, 2]::bigint[]);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_backtest_metrics(p_run_ids bigint[])
+RETURNS TABLE(
+    run_id bigint,
+    symbol varchar,
+    name varchar,
+    value DECIMAL
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT *
+    FROM fxtester_schema.backtest_metric m
+    WHERE m.run_id = ANY(p_run_ids)
+    ORDER BY m.run_id, m.symbol, m.name;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: delete_backtest_run
+ * 機能: 指定ユーザが所有する指定IDのバックテストを削除し、削除した件数を返却します (取引・集計値も削除される)。
+ *       ユーザにNULLを指定した場合は所有者に関わらず削除します (管理者による削除)
+ * 利用例: select fxtester_schema.delete_backtest_run(1, 1);
+ */
+create or replace function fxtester_schema.delete_backtest_run(p_user_id bigint, p_run_id bigint)
+returns bigint
+AS $$
+DECLARE
+    deleted bigint;
+BEGIN
+    DELETE FROM fxtester_schema.backtest_run r WHERE (p_user_id IS NULL OR r.user_id = p_user_id) AND r.id = p_run_id;
+    GET DIAGNOSTICS deleted = ROW_COUNT;
+    return deleted;
+END;
+$$ language plpgsql;
+
+-- 監査イベント (ログイン・ログアウト、認証・認可の失敗、データのアップロード・解析等。ユーザの削除後も保持するため外部キーは設定しない)
+CREATE TABLE IF NOT EXISTS fxtester_schema.audit_event (
+    id bigserial PRIMARY KEY
+    , occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+    , event_type varchar NOT NULL -- e.g. login, logout, access, data.analyze
+    , outcome varchar NOT NULL -- success, failure
+    , user_id BIGINT -- ユーザを特定できない場合はNULL
+    , email varchar NOT NULL DEFAULT ''
+    , ip_address varchar NOT NULL DEFAULT ''
+    , user_agent varchar NOT NULL DEFAULT ''
+    , route varchar NOT NULL DEFAULT '' -- e.g. POST /zigzag
+    , error_code BIGINT -- 失敗の原因のエラーコード (FxtError以外の場合はNULL)
+    , detail varchar NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS audit_event_occurred_at_idx ON fxtester_schema.audit_event (occurred_at);
+CREATE INDEX IF NOT EXISTS audit_event_user_id_idx ON fxtester_schema.audit_event (user_id, occurred_at);
+
+/**
+ * ストアドプロシージャー名: insert_audit_event
+ * 機能: 監査イベントを追加します。ユーザにNULLを指定した場合はEmailからユーザを特定します (存在しない場合はNULL)
+ * 利用例: call fxtester_schema.insert_audit_event('login', 'success', NULL, 'test@fxtester.com', '192.168.0.1', 'Mozilla/5.0', 'POST /saml/acs', NULL, 'idp=default')
+ */
+CREATE OR REPLACE PROCEDURE fxtester_schema.insert_audit_event(
+    p_event_type varchar
+    , p_outcome varchar
+    , p_user_id bigint
+    , p_email varchar
+    , p_ip_address varchar
+    , p_user_agent varchar
+    , p_route varchar
+    , p_error_code bigint
+    , p_detail varchar
+)
+AS $$
+DECLARE
+    v_user_id bigint := p_user_id;
+BEGIN
+    IF v_user_id IS NULL AND p_email <> '' THEN
+        SELECT u.id INTO v_user_id FROM fxtester_schema.user u WHERE u.email = p_email;
+    END IF;
+
+    INSERT INTO fxtester_schema.audit_event (event_type, outcome, user_id, email, ip_address, user_agent, route, error_code, detail)
+    VALUES (p_event_type, p_outcome, v_user_id, p_email, p_ip_address, p_user_agent, p_route, p_error_code, p_detail);
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: select_audit_events
+ * 機能: 条件に一致する監査イベントを発生日時の新しい順で返却します。NULLを指定した条件は絞り込みません
+ * 利用例: SELECT * FROM fxtester_schema.select_audit_events('login', 'failure', NULL, NULL, '2024-10-01T00:00:00Z', NULL, 50, 0);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.select_audit_events(
+    p_event_type varchar
+    , p_outcome varchar
+    , p_user_id bigint
+    , p_email varchar
+    , p_from TIMESTAMP WITH TIME ZONE
+    , p_to TIMESTAMP WITH TIME ZONE
+    , p_limit integer
+    , p_offset integer
+)
+RETURNS TABLE(
+    id bigint,
+    occurred_at TIMESTAMP WITH TIME ZONE,
+    event_type varchar,
+    outcome varchar,
+    user_id bigint,
+    email varchar,
+    ip_address varchar,
+    user_agent varchar,
+    route varchar,
+    error_code bigint,
+    detail varchar
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT e.id, e.occurred_at, e.event_type, e.outcome, e.user_id, e.email, e.ip_address, e.user_agent, e.route, e.error_code, e.detail
+    FROM fxtester_schema.audit_event e
+    WHERE (p_event_type IS NULL OR e.event_type = p_event_type)
+        AND (p_outcome IS NULL OR e.outcome = p_outcome)
+        AND (p_user_id IS NULL OR e.user_id = p_user_id)
+        AND (p_email IS NULL OR e.email = p_email)
+        AND (p_from IS NULL OR p_from <= e.occurred_at)
+        AND (p_to IS NULL OR e.occurred_at < p_to)
+    ORDER BY e.occurred_at DESC, e.id DESC
+    LIMIT p_limit OFFSET p_offset;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: count_audit_events
+ * 機能: 条件に一致する監査イベントの件数を返却します。条件はselect_audit_eventsと同じです
+ * 利用例: SELECT fxtester_schema.count_audit_events('login', 'failure', NULL, NULL, '2024-10-01T00:00:00Z', NULL);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.count_audit_events(
+    p_event_type varchar
+    , p_outcome varchar
+    , p_user_id bigint
+    , p_email varchar
+    , p_from TIMESTAMP WITH TIME ZONE
+    , p_to TIMESTAMP WITH TIME ZONE
+)
+RETURNS bigint AS $$
+DECLARE
+    total bigint;
+BEGIN
+    SELECT count(*) INTO total
+    FROM fxtester_schema.audit_event e
+    WHERE (p_event_type IS NULL OR e.event_type = p_event_type)
+        AND (p_outcome IS NULL OR e.outcome = p_outcome)
+        AND (p_user_id IS NULL OR e.user_id = p_user_id)
+        AND (p_email IS NULL OR e.email = p_email)
+        AND (p_from IS NULL OR p_from <= e.occurred_at)
+        AND (p_to IS NULL OR e.occurred_at < p_to);
+    RETURN total;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * 関数名: delete_audit_events
+ * 機能: 発生してから保持期間(秒)を過ぎた監査イベントを削除し、削除した件数を返却します
+ * 利用例: SELECT fxtester_schema.delete_audit_events(31536000);
+ */
+CREATE OR REPLACE FUNCTION fxtester_schema.delete_audit_events(p_retention_sec bigint)
+RETURNS bigint AS $$
+DECLARE
+    deleted bigint;
+BEGIN
+    DELETE FROM fxtester_schema.audit_event e
+    WHERE e.occurred_at < CURRENT_TIMESTAMP - make_interval(secs => p_retention_sec);
+    GET DIAGNOSTICS deleted = ROW_COUNT;
+    RETURN deleted;
+END;
+$$ LANGUAGE plpgsql;
@@ -164,3 +164,58 @@ func Test_FindZigzagBottom(t *testing.T) {
 		})
 	}
 }
+
+func Test_MovingAverage(t *testing.T) {
+	candles := common.ArrayMap(func(v float64) common.Candle {
+		return common.Candle{Close: v}
+	}, []float64{1, 2, 3, 4, 8})
+	ptr := func(v float64) *float64 {
+		return &v
+	}
+
+	tests := []struct {
+		name   string
+		calc   func([]common.Candle, int) []*float64
+		period int
+		want   []*float64
+	}{
+		{
+			name:   "test1_単純移動平均",
+			calc:   SimpleMovingAverage,
+			period: 3,
+			want:   []*float64{nil, nil, ptr(2), ptr(3), ptr(5)},
+		},
+		{
+			name:   "test2_指数移動平均 (最初の値は単純移動平均)",
+			calc:   ExponentialMovingAverage,
+			period: 3,
+			want:   []*float64{nil, nil, ptr(2), ptr(3), ptr(5.5)},
+		},
+		{
+			name:   "test3_期間がローソク足の本数を超える",
+			calc:   ExponentialMovingAverage,
+			period: 6,
+			want:   []*float64{nil, nil, nil, nil, nil},
+		},
+		{
+			name:   "test4_期間が1の場合は終値",
+			calc:   SimpleMovingAverage,
+			period: 1,
+			want:   []*float64{ptr(1), ptr(2), ptr(3), ptr(4), ptr(8)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.calc(candles, tt.period)
+			if len(got) != len(tt.want) {
+				t.Fatalf("len()=%v want=%v", len(got), len(tt.want))
+			}
+			for i := range got {
+				if (got[i] == nil) != (tt.want[i] == nil) || (got[i] != nil && *got[i] != *tt.want[i]) {
+					t.Errorf("[%d]=%v want=%v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package algo
+
+import "fxtester/internal/common"
+
+// SimpleMovingAverage 終値の単純移動平均を返却する。期間に満たない先頭のローソク足の位置はnilとする
+func SimpleMovingAverage(candles []common.Candle, period int) []*float64 {
+	results := make([]*float64, len(candles))
+	if period <= 0 {
+		return results
+	}
+
+	sum := 0.0
+	for i, c := range candles {
+		sum += c.Close
+		if period <= i {
+			sum -= candles[i-period].Close
+		}
+		if period-1 <= i {
+			v := sum / float64(period)
+			results[i] = &v
+		}
+	}
+	return results
+}
+
+// ExponentialMovingAverage 終値の指数移動平均 (平滑化係数は2/(期間+1)) を返却する。
+// 最初の値は期間の単純移動平均とし、期間に満たない先頭のローソク足の位置はnilとする
+func ExponentialMovingAverage(candles []common.Candle, period int) []*float64 {
+	results := SimpleMovingAverage(candles, period)
+	if period <= 0 || len(candles) < period {
+		return results
+	}
+
+	alpha := 2 / float64(period+1)
+	prev := *results[period-1]
+	for i := period; i < len(candles); i++ {
+		v := alpha*candles[i].Close + (1-alpha)*prev
+		results[i] = &v
+		prev = v
+	}
+	return results
+}
@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"context"
+	"fxtester/internal/common"
+	"fxtester/internal/db"
+	"fxtester/internal/lang"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 監査イベントの種類
+const (
+	// idPでのログイン (SAMLのACS・OpenID Connectのコールバック)
+	EventLogin = "login"
+	// シングルログアウト
+	EventLogout = "logout"
+	// 認証ミドルウェアでの認証・認可の失敗
+	EventAccess = "access"
+	// データのアップロード・解析 (ジグザグ、チャート、バックテスト等)
+	EventDataAnalyze = "data.analyze"
+	// データのエクスポート
+	EventDataExport = "data.export"
+	// 保存したデータの削除
+	EventDataDelete = "data.delete"
+)
+
+// 監査イベントの結果
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Event 記録する監査イベント。IPアドレス・ユーザエージェント・ルートはリクエストから取得する
+type Event struct {
+	// イベントの種類 (Event*)
+	Type string
+	// ユーザのID (不明な場合は0。Emailが指定された場合はEmailからユーザを特定する)
+	UserId int64
+	Email  string
+	// 処理のエラー (nilの場合は成功とする)
+	Err error
+	// イベント毎の補足情報 (e.g. idp=default)
+	Detail string
+}
+
+// Recorder 監査イベントをデータベースに記録する。nilの場合は記録しない
+type Recorder struct {
+	dao db.IAuditEventEntityDao
+	// 監査イベントを保持する期間 (0の場合は削除しない)
+	retention time.Duration
+	// 保持期間を過ぎた監査イベントを削除する間隔
+	pruneInterval time.Duration
+}
+
+// NewRecorder 監査イベントをデータベースに記録するRecorderを生成します。保持期間は設定ファイルのauditの設定に従います
+func NewRecorder(idb db.IDB) *Recorder {
+	config := common.GetConfig().Audit
+	return &Recorder{
+		dao:           db.NewAuditEventEntityDao(idb),
+		retention:     time.Duration(config.RetentionDays) * 24 * time.Hour,
+		pruneInterval: time.Duration(config.PruneIntervalHours) * time.Hour,
+	}
+}
+
+// Start 保持期間を過ぎた監査イベントの削除を定期的に行います。ctxがキャンセルされるまで処理を続けます
+func (r *Recorder) Start(ctx context.Context, logger echo.Logger) {
+	if r == nil || r.retention <= 0 || r.pruneInterval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(r.pruneInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := r.Prune()
+				if err != nil {
+					logger.Errorf("failed to prune audit events: %v", err)
+				} else if 0 < deleted {
+					logger.Infof("audit events pruned: %d", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// Prune 保持期間を過ぎた監査イベントを削除し、削除した件数を返却します。保持期間が0の場合は削除しません
+func (r *Recorder) Prune() (int64, error) {
+	if r == nil || r.retention <= 0 {
+		return 0, nil
+	}
+	return r.dao.DeleteAuditEvents(r.retention)
+}
+
+// Record 監査イベントを記録します。
+// 記録に失敗しても本来の処理を失敗させないよう、エラーはログに出力するのみとします
+func (r *Recorder) Record(ctx echo.Context, event Event) {
+	if r == nil {
+		return
+	}
+
+	entity := &db.AuditEventEntity{
+		EventType: event.Type,
+		Outcome:   OutcomeSuccess,
+		Email:     event.Email,
+		IpAddress: ctx.RealIP(),
+		UserAgent: ctx.Request().UserAgent(),
+		Route:     strings.TrimSpace(ctx.Request().Method + " " + ctx.Path()),
+		Detail:    event.Detail,
+	}
+	if event.UserId != 0 {
+		entity.UserId = &event.UserId
+	}
+	if event.Err != nil {
+		entity.Outcome = OutcomeFailure
+		if fxtErr := lang.FindFxtError(event.Err); fxtErr != nil {
+			code := int64(fxtErr.ErrCode)
+			entity.ErrorCode = &code
+		}
+	}
+
+	if err := r.dao.InsertAuditEvent(entity); err != nil {
+		ctx.Logger().Warnf("failed to record the audit event %s: %v", event.Type, err)
+	}
+}
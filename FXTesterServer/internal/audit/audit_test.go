@@ -0,0 +1,159 @@
+package audit
+
+import (
+	"database/sql"
+	"errors"
+	"fxtester/internal/lang"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/labstack/echo/v4"
+)
+
+type MockDB struct {
+	db *sql.DB
+}
+
+func (m *MockDB) Init() error {
+	return nil
+}
+
+func (m *MockDB) GetDB() *sql.DB {
+	return m.db
+}
+
+func Test_Recorder_Record(t *testing.T) {
+	const insertQuery = `call fxtester_schema.insert_audit_event($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	userId := int64(100)
+	forbidden := int64(lang.ErrForbidden)
+
+	tests := []struct {
+		name   string
+		event  Event
+		expect func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:  "test1_成功",
+			event: Event{Type: EventLogin, Email: "test@test.co.jp", Detail: "saml idp=default"},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(insertQuery)).
+					WithArgs(EventLogin, OutcomeSuccess, nil, "test@test.co.jp", "192.0.2.1", "test-agent", "POST /saml/acs", nil, "saml idp=default").
+					WillReturnRows(sqlmock.NewRows([]string{}))
+			},
+		},
+		{
+			name:  "test2_FxtErrorによる失敗",
+			event: Event{Type: EventAccess, UserId: userId, Err: lang.NewFxtError(lang.ErrForbidden)},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(insertQuery)).
+					WithArgs(EventAccess, OutcomeFailure, userId, "", "192.0.2.1", "test-agent", "POST /saml/acs", forbidden, "").
+					WillReturnRows(sqlmock.NewRows([]string{}))
+			},
+		},
+		{
+			name:  "test3_FxtError以外による失敗",
+			event: Event{Type: EventDataAnalyze, Err: errors.New("test")},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(insertQuery)).
+					WithArgs(EventDataAnalyze, OutcomeFailure, nil, "", "192.0.2.1", "test-agent", "POST /saml/acs", nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{}))
+			},
+		},
+		{
+			name:  "test4_記録の失敗は無視する",
+			event: Event{Type: EventLogout},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(insertQuery)).WillReturnError(errors.New("test"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed sqlmock.New(): %v", err)
+			}
+			defer mockDB.Close()
+			tt.expect(mock)
+
+			req := httptest.NewRequest(echo.POST, "https://localhost/saml/acs", nil)
+			req.RemoteAddr = "192.0.2.1:12345"
+			req.Header.Set("User-Agent", "test-agent")
+			ctx := echo.New().NewContext(req, httptest.NewRecorder())
+			ctx.SetPath("/saml/acs")
+
+			NewRecorder(&MockDB{db: mockDB}).Record(ctx, tt.event)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("ExpectationsWereMet()=%v", err)
+			}
+		})
+	}
+
+	t.Run("test5_nilの場合は記録しない", func(t *testing.T) {
+		ctx := echo.New().NewContext(httptest.NewRequest(echo.GET, "https://localhost", nil), httptest.NewRecorder())
+		var recorder *Recorder
+		recorder.Record(ctx, Event{Type: EventLogin})
+	})
+}
+
+func Test_Recorder_Prune(t *testing.T) {
+	const deleteQuery = `select fxtester_schema.delete_audit_events($1)`
+
+	tests := []struct {
+		name        string
+		retention   time.Duration
+		expect      func(mock sqlmock.Sqlmock)
+		wantDeleted int64
+		wantErr     bool
+	}{
+		{
+			name:      "test1_保持期間を過ぎた監査イベントを削除する",
+			retention: 30 * 24 * time.Hour,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(deleteQuery)).WithArgs(int64(30 * 24 * 60 * 60)).
+					WillReturnRows(sqlmock.NewRows([]string{"delete_audit_events"}).AddRow(3))
+			},
+			wantDeleted: 3,
+		},
+		{
+			name:      "test2_保持期間が0の場合は削除しない",
+			retention: 0,
+			expect:    func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name:      "test3_DBエラー",
+			retention: time.Hour,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(deleteQuery)).WillReturnError(errors.New("test"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed sqlmock.New(): %v", err)
+			}
+			defer mockDB.Close()
+			tt.expect(mock)
+
+			recorder := NewRecorder(&MockDB{db: mockDB})
+			recorder.retention = tt.retention
+			deleted, err := recorder.Prune()
+			if (err != nil) != tt.wantErr || deleted != tt.wantDeleted {
+				t.Errorf("Prune()=(%v, %v) want=(%v, wantErr=%v)", deleted, err, tt.wantDeleted, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("ExpectationsWereMet()=%v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package backtest
+
+import (
+	"errors"
+	"fxtester/internal/common"
+	"time"
+)
+
+var ErrOrderOutOfRange = errors.New("backtest: order is out of range")
+
+type Side int
+
+const (
+	Buy Side = iota
+	Sell
+)
+
+// direction 買いの場合は1、売りの場合は-1を返却する
+func (s Side) direction() float64 {
+	if s == Sell {
+		return -1
+	}
+	return 1
+}
+
+// IntrabarPath ローソク足の中での値動きの想定
+type IntrabarPath int
+
+const (
+	// 損切りと利確が同じローソク足で発生した場合は損切りを優先する(悲観的な想定)
+	PathPessimistic IntrabarPath = iota
+	// 始値→高値→安値→終値の順に値動きしたと想定する
+	PathOHLC
+	// 始値→安値→高値→終値の順に値動きしたと想定する
+	PathOLHC
+)
+
+type ExitReason int
+
+const (
+	ExitStopLoss ExitReason = iota
+	ExitTakeProfit
+	ExitEndOfData
+	// 証拠金維持率の低下による強制決済
+	ExitStopOut
+)
+
+// Order 成行注文
+type Order struct {
+	// 発注日時 (この日時以降で最初のローソク足の始値で約定する)
+	Time time.Time
+	Side Side
+	// 取引数量 (0の場合はConfig.Sizingで算出する)
+	Lots float64
+	// 損切り価格 (0の場合は未指定)
+	StopLoss float64
+	// 利確価格 (0の場合は未指定)
+	TakeProfit float64
+}
+
+// Trade 約定した取引。価格は決済通貨建て、金額は口座通貨建て
+type Trade struct {
+	Symbol     string
+	Side       Side
+	Lots       float64
+	EntryTime  time.Time
+	EntryPrice float64
+	ExitTime   time.Time
+	ExitPrice  float64
+	ExitReason ExitReason
+
+	// コスト控除前の損益
+	GrossProfit  float64
+	SpreadCost   float64
+	SlippageCost float64
+	Commission   float64
+	// スワップ損益 (受取りは正、支払いは負)
+	Swap float64
+	// コスト控除後の損益
+	NetProfit float64
+}
+
+// Summary 取引結果の集計
+type Summary struct {
+	TradeCount   int
+	GrossProfit  float64
+	SpreadCost   float64
+	SlippageCost float64
+	Commission   float64
+	Swap         float64
+	NetProfit    float64
+
+	InitialBalance float64
+	// 最終的な口座残高 (Config.Accountが未指定の場合は損益の合計)
+	FinalBalance       float64
+	MarginCallCount    int
+	StopOutCount       int
+	RejectedOrderCount int
+}
+
+type Report struct {
+	Trades  []Trade
+	Summary Summary
+}
+
+type Config struct {
+	// 1ロットあたりの通貨量
+	ContractSize float64
+	Cost         *CostModel
+	// 下位足のローソク足 (e.g. H1に対するM1)。指定した場合は下位足の値動きで損切り・利確の順序を判定する
+	LowerCandles []common.Candle
+	// 下位足が無い場合のローソク足の中での値動きの想定
+	IntrabarPath IntrabarPath
+	// 取引数量が未指定の注文に使用する資金管理モデル
+	Sizing SizingModel
+	// 取引数量の制約
+	Lot     LotRule
+	Account Account
+}
+
+// fill 約定情報
+type fill struct {
+	time time.Time
+	// ローソク足(Bid)上の約定価格
+	raw      float64
+	spread   float64
+	slippage float64
+}
+
+// position 保有中のポジション
+type position struct {
+	order Order
+	entry fill
+	// 必要証拠金
+	margin float64
+}
+
+// Run 注文をローソク足に沿って約定させ、取引コストを含めた結果を返却する。金額は全て決済通貨建て
+func Run(candles []common.Candle, orders []Order, config Config) (*Report, error) {
+	e, err := newEngine([]Instrument{
+		{Candles: candles, Orders: orders, Config: config},
+	}, config.Account, func(currency string, t time.Time) float64 {
+		// 単一シンボルの場合は決済通貨を口座通貨とする
+		return 1
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.run()
+
+	return &Report{
+		Trades:  e.trades,
+		Summary: e.summary(),
+	}, nil
+}
+
+// nextTime candles[i]の次のローソク足の開始時刻を返却する。最後のローソク足の場合はゼロ値を返却する
+func nextTime(candles []common.Candle, i int) time.Time {
+	if i+1 < len(candles) {
+		return candles[i+1].Time
+	}
+	return time.Time{}
+}
+
+// findLowerCandles ローソク足cの期間[c.Time, end)に含まれる下位足の範囲を返却する。endがゼロ値の場合は末尾までを範囲とする
+func findLowerCandles(lowers []common.Candle, start int, c *common.Candle, end time.Time) (int, int) {
+	// 期間より前の下位足を読み飛ばす
+	for start < len(lowers) && lowers[start].Time.Before(c.Time) {
+		start++
+	}
+	last := start
+	for last < len(lowers) && (end.IsZero() || lowers[last].Time.Before(end)) {
+		last++
+	}
+	return start, last
+}
+
+// resolveExit candles[i]で損切り・利確が発生するかを判定し、約定情報を返却する
+func resolveExit(order Order, candles []common.Candle, i int, lowers []common.Candle, config Config) (fill, ExitReason, bool) {
+	cost := config.Cost
+	c := &candles[i]
+
+	exit, reason, ok := func() (fill, ExitReason, bool) {
+		if len(lowers) <= 0 {
+			// 下位足が無い場合は値動きを想定して判定する
+			exit, reason, ok := findExit(order, c, cost.Spread.Spread(c), config.IntrabarPath)
+			exit.time = c.Time
+			return exit, reason, ok
+		}
+
+		// 下位足を時系列に辿り、最初に損切り・利確が発生した下位足で約定させる
+		for j := range lowers {
+			l := &lowers[j]
+			if exit, reason, ok := findExit(order, l, cost.Spread.Spread(l), config.IntrabarPath); ok {
+				exit.time = l.Time
+				return exit, reason, true
+			}
+		}
+		return fill{}, 0, false
+	}()
+	if ok && reason == ExitStopLoss {
+		// 逆指値(損切り)は成行で約定するためスリッページが発生する
+		exit.slippage = cost.Slippage.Slippage(candles, i)
+	}
+	return exit, reason, ok
+}
+
+/*
+ * findExit ローソク足の中で損切り・利確が発生するかを判定する。
+ * 価格はローソク足(Bid)上の値で返却し、売りポジションの決済(Ask)はスプレッドを考慮して判定する。
+ * 損切りと利確が同じローソク足で発生した場合はpathの想定に従って先に到達した方で約定させる。
+ */
+func findExit(order Order, c *common.Candle, spread float64, path IntrabarPath) (fill, ExitReason, bool) {
+	// 決済価格の基準となる価格 (買いはBid、売りはAsk)
+	offset := 0.0
+	if order.Side == Sell {
+		offset = spread
+	}
+	open := c.Open + offset
+	high := c.High + offset
+	low := c.Low + offset
+
+	// 不利な方向への値動きで判定する関数 (買いは下落、売りは上昇)
+	adverse := func(price, limit float64) bool {
+		if order.Side == Buy {
+			return price <= limit
+		}
+		return limit <= price
+	}
+	// 有利な方向への値動きで判定する関数 (買いは上昇、売りは下落)
+	favorable := func(price, limit float64) bool {
+		return adverse(limit, price)
+	}
+
+	sl := order.StopLoss
+	tp := order.TakeProfit
+	// 損切りの判定に使用する極値 (買いは安値、売りは高値)
+	slExtreme, tpExtreme := low, high
+	if order.Side == Sell {
+		slExtreme, tpExtreme = high, low
+	}
+
+	// 窓開けで損切り・利確価格を超えた場合は始値で約定する
+	if sl != 0 && adverse(open, sl) {
+		return fill{raw: c.Open, spread: spread}, ExitStopLoss, true
+	} else if tp != 0 && favorable(open, tp) {
+		return fill{raw: c.Open, spread: spread}, ExitTakeProfit, true
+	}
+
+	hitSl := sl != 0 && adverse(slExtreme, sl)
+	hitTp := tp != 0 && favorable(tpExtreme, tp)
+	stopLoss := fill{raw: sl - offset, spread: spread}
+	takeProfit := fill{raw: tp - offset, spread: spread}
+
+	switch {
+	case hitSl && hitTp:
+		// 同じローソク足で損切り・利確の両方が発生した場合
+		slFirst := path == PathPessimistic ||
+			// 買いの損切りは安値側、売りの損切りは高値側で発生する
+			(path == PathOLHC && order.Side == Buy) ||
+			(path == PathOHLC && order.Side == Sell)
+		if slFirst {
+			return stopLoss, ExitStopLoss, true
+		}
+		return takeProfit, ExitTakeProfit, true
+	case hitSl:
+		return stopLoss, ExitStopLoss, true
+	case hitTp:
+		return takeProfit, ExitTakeProfit, true
+	}
+	return fill{}, 0, false
+}
+
+// settle ポジションを決済し、取引コストを計算する
+func settle(p position, exit fill, reason ExitReason, config Config) Trade {
+	order := p.order
+	entry := p.entry
+	dir := order.Side.direction()
+	size := order.Lots * config.ContractSize
+
+	// スプレッドは買いは新規約定時、売りは決済時に支払う
+	spread := entry.spread
+	entryPrice := entry.raw + entry.spread + entry.slippage
+	exitPrice := exit.raw - exit.slippage
+	if order.Side == Sell {
+		spread = exit.spread
+		entryPrice = entry.raw - entry.slippage
+		exitPrice = exit.raw + exit.spread + exit.slippage
+	}
+
+	gross := dir * (exit.raw - entry.raw) * size
+	spreadCost := spread * size
+	slippageCost := (entry.slippage + exit.slippage) * size
+	commission := config.Cost.CommissionPerLot * order.Lots * 2
+	swap := config.Cost.Swap.Swap(order.Side, order.Lots, entry.time, exit.time)
+
+	return Trade{
+		Side:         order.Side,
+		Lots:         order.Lots,
+		EntryTime:    entry.time,
+		EntryPrice:   entryPrice,
+		ExitTime:     exit.time,
+		ExitPrice:    exitPrice,
+		ExitReason:   reason,
+		GrossProfit:  gross,
+		SpreadCost:   spreadCost,
+		SlippageCost: slippageCost,
+		Commission:   commission,
+		Swap:         swap,
+		NetProfit:    gross - spreadCost - slippageCost - commission + swap,
+	}
+}
+
+// summarize 取引結果を集計する (口座に関する項目は集計しない)
+func summarize(trades []Trade) Summary {
+	s := Summary{
+		TradeCount: len(trades),
+	}
+	for _, t := range trades {
+		s.GrossProfit += t.GrossProfit
+		s.SpreadCost += t.SpreadCost
+		s.SlippageCost += t.SlippageCost
+		s.Commission += t.Commission
+		s.Swap += t.Swap
+		s.NetProfit += t.NetProfit
+		if t.ExitReason == ExitStopOut {
+			s.StopOutCount++
+		}
+	}
+	return s
+}
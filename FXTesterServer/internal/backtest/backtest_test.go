@@ -0,0 +1,534 @@
+package backtest
+
+import (
+	"errors"
+	"fxtester/internal/common"
+	"math"
+	"testing"
+	"time"
+)
+
+// 2024-01-02は火曜日
+var testBaseTime = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func testCandles(values ...[4]float64) []common.Candle {
+	candles := []common.Candle{}
+	for i, v := range values {
+		candles = append(candles, common.Candle{
+			Time:  testBaseTime.Add(time.Duration(i) * time.Hour),
+			Open:  v[0],
+			High:  v[1],
+			Low:   v[2],
+			Close: v[3],
+		})
+	}
+	return candles
+}
+
+func testCostModel(spread, commission float64) *CostModel {
+	return &CostModel{
+		Spread:           &FixedSpread{Value: spread},
+		Slippage:         &NoSlippage{},
+		CommissionPerLot: commission,
+		Swap: SwapModel{
+			TripleDay: time.Wednesday,
+		},
+	}
+}
+
+func nearlyEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func Test_Run(t *testing.T) {
+	type args struct {
+		candles []common.Candle
+		orders  []Order
+		config  Config
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantErr    error
+		wantTrades []Trade
+	}{
+		{
+			name: "買いの利確",
+			args: args{
+				candles: testCandles(
+					[4]float64{100, 100.5, 99.5, 100.2},
+					[4]float64{100.2, 101.2, 100, 101},
+				),
+				orders: []Order{
+					{Time: testBaseTime, Side: Buy, Lots: 1, StopLoss: 99, TakeProfit: 101},
+				},
+				config: Config{ContractSize: 1000, Cost: testCostModel(0.01, 1)},
+			},
+			wantTrades: []Trade{
+				{
+					Side:        Buy,
+					EntryPrice:  100.01,
+					ExitPrice:   101,
+					ExitReason:  ExitTakeProfit,
+					GrossProfit: 1000,
+					SpreadCost:  10,
+					Commission:  2,
+					NetProfit:   988,
+				},
+			},
+		},
+		{
+			name: "売りの損切り(Askで判定)",
+			args: args{
+				candles: testCandles(
+					[4]float64{100, 100.495, 99.9, 100.2},
+				),
+				orders: []Order{
+					{Time: testBaseTime, Side: Sell, Lots: 1, StopLoss: 100.5, TakeProfit: 99},
+				},
+				config: Config{ContractSize: 1000, Cost: testCostModel(0.01, 1)},
+			},
+			wantTrades: []Trade{
+				{
+					Side:        Sell,
+					EntryPrice:  100,
+					ExitPrice:   100.5,
+					ExitReason:  ExitStopLoss,
+					GrossProfit: -490,
+					SpreadCost:  10,
+					Commission:  2,
+					NetProfit:   -502,
+				},
+			},
+		},
+		{
+			name: "同じローソク足で損切りと利確が発生した場合は損切り",
+			args: args{
+				candles: testCandles(
+					[4]float64{100, 102, 98, 100},
+				),
+				orders: []Order{
+					{Time: testBaseTime, Side: Buy, Lots: 1, StopLoss: 99, TakeProfit: 101},
+				},
+				config: Config{ContractSize: 1, Cost: testCostModel(0, 0)},
+			},
+			wantTrades: []Trade{
+				{
+					Side:        Buy,
+					EntryPrice:  100,
+					ExitPrice:   99,
+					ExitReason:  ExitStopLoss,
+					GrossProfit: -1,
+					NetProfit:   -1,
+				},
+			},
+		},
+		{
+			name: "同じローソク足で損切りと利確が発生した場合(O-H-L-Cの想定)",
+			args: args{
+				candles: testCandles(
+					[4]float64{100, 102, 98, 100},
+				),
+				orders: []Order{
+					{Time: testBaseTime, Side: Buy, Lots: 1, StopLoss: 99, TakeProfit: 101},
+				},
+				config: Config{ContractSize: 1, Cost: testCostModel(0, 0), IntrabarPath: PathOHLC},
+			},
+			wantTrades: []Trade{
+				{
+					Side:        Buy,
+					EntryPrice:  100,
+					ExitPrice:   101,
+					ExitReason:  ExitTakeProfit,
+					GrossProfit: 1,
+					NetProfit:   1,
+				},
+			},
+		},
+		{
+			name: "同じローソク足で損切りと利確が発生した場合(O-L-H-Cの想定の売り)",
+			args: args{
+				candles: testCandles(
+					[4]float64{100, 102, 98, 100},
+				),
+				orders: []Order{
+					{Time: testBaseTime, Side: Sell, Lots: 1, StopLoss: 101, TakeProfit: 99},
+				},
+				config: Config{ContractSize: 1, Cost: testCostModel(0, 0), IntrabarPath: PathOLHC},
+			},
+			wantTrades: []Trade{
+				{
+					Side:        Sell,
+					EntryPrice:  100,
+					ExitPrice:   99,
+					ExitReason:  ExitTakeProfit,
+					GrossProfit: 1,
+					NetProfit:   1,
+				},
+			},
+		},
+		{
+			name: "下位足の値動きで損切りと利確の順序を判定",
+			args: args{
+				candles: testCandles(
+					[4]float64{100, 102, 98, 100},
+				),
+				orders: []Order{
+					{Time: testBaseTime, Side: Buy, Lots: 1, StopLoss: 99, TakeProfit: 101},
+				},
+				config: Config{
+					ContractSize: 1,
+					Cost:         testCostModel(0, 0),
+					LowerCandles: []common.Candle{
+						{Time: testBaseTime, Open: 100, High: 100.5, Low: 99.5, Close: 100.4},
+						{Time: testBaseTime.Add(20 * time.Minute), Open: 100.4, High: 102, Low: 100.3, Close: 101.5},
+						{Time: testBaseTime.Add(40 * time.Minute), Open: 101.5, High: 101.6, Low: 98, Close: 100},
+					},
+				},
+			},
+			wantTrades: []Trade{
+				{
+					Side:        Buy,
+					EntryPrice:  100,
+					ExitPrice:   101,
+					ExitReason:  ExitTakeProfit,
+					GrossProfit: 1,
+					NetProfit:   1,
+				},
+			},
+		},
+		{
+			name: "窓開けで損切り価格を超えた場合は始値で約定",
+			args: args{
+				candles: testCandles(
+					[4]float64{100, 100.1, 99.9, 100},
+					[4]float64{98, 98.5, 97.5, 98},
+				),
+				orders: []Order{
+					{Time: testBaseTime, Side: Buy, Lots: 1, StopLoss: 99},
+				},
+				config: Config{ContractSize: 1, Cost: testCostModel(0, 0)},
+			},
+			wantTrades: []Trade{
+				{
+					Side:        Buy,
+					EntryPrice:  100,
+					ExitPrice:   98,
+					ExitReason:  ExitStopLoss,
+					GrossProfit: -2,
+					NetProfit:   -2,
+				},
+			},
+		},
+		{
+			name: "データ終端で決済",
+			args: args{
+				candles: testCandles(
+					[4]float64{100, 100.1, 99.9, 100},
+					[4]float64{100, 100.8, 99.9, 100.5},
+				),
+				orders: []Order{
+					{Time: testBaseTime, Side: Sell, Lots: 2, StopLoss: 101},
+				},
+				config: Config{ContractSize: 1, Cost: testCostModel(0.1, 0)},
+			},
+			wantTrades: []Trade{
+				{
+					Side:        Sell,
+					EntryPrice:  100,
+					ExitPrice:   100.6,
+					ExitReason:  ExitEndOfData,
+					GrossProfit: -1,
+					SpreadCost:  0.2,
+					NetProfit:   -1.2,
+				},
+			},
+		},
+		{
+			name: "ローソク足の範囲外の注文",
+			args: args{
+				candles: testCandles(
+					[4]float64{100, 100.1, 99.9, 100},
+				),
+				orders: []Order{
+					{Time: testBaseTime.Add(time.Hour), Side: Buy, Lots: 1},
+				},
+				config: Config{ContractSize: 1, Cost: testCostModel(0, 0)},
+			},
+			wantErr: ErrOrderOutOfRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Run(tt.args.candles, tt.args.orders, tt.args.config)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Run()=%v wantErr=%v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(report.Trades) != len(tt.wantTrades) {
+				t.Fatalf("len(Run().Trades)=%v want=%v", len(report.Trades), len(tt.wantTrades))
+			}
+
+			for i, want := range tt.wantTrades {
+				got := report.Trades[i]
+				if got.Side != want.Side || got.ExitReason != want.ExitReason ||
+					!nearlyEqual(got.EntryPrice, want.EntryPrice) ||
+					!nearlyEqual(got.ExitPrice, want.ExitPrice) ||
+					!nearlyEqual(got.GrossProfit, want.GrossProfit) ||
+					!nearlyEqual(got.SpreadCost, want.SpreadCost) ||
+					!nearlyEqual(got.Commission, want.Commission) ||
+					!nearlyEqual(got.NetProfit, want.NetProfit) {
+					t.Errorf("Run().Trades[%d]=%+v want=%+v", i, got, want)
+				}
+			}
+
+			// 集計値のチェック
+			if report.Summary.TradeCount != len(tt.wantTrades) {
+				t.Errorf("Summary.TradeCount=%v want=%v", report.Summary.TradeCount, len(tt.wantTrades))
+			}
+			net := 0.0
+			for _, want := range tt.wantTrades {
+				net += want.NetProfit
+			}
+			if !nearlyEqual(report.Summary.NetProfit, net) {
+				t.Errorf("Summary.NetProfit=%v want=%v", report.Summary.NetProfit, net)
+			}
+		})
+	}
+}
+
+func Test_Run_Account(t *testing.T) {
+	account := Account{
+		InitialBalance:  1000,
+		Leverage:        100,
+		MarginCallLevel: 100,
+		StopOutLevel:    50,
+	}
+
+	tests := []struct {
+		name     string
+		candles  []common.Candle
+		orders   []Order
+		sizing   SizingModel
+		wantLots []float64
+		wantExit []ExitReason
+		want     Summary
+	}{
+		{
+			name: "マージンコールの後にロスカット",
+			candles: testCandles(
+				[4]float64{100, 100.2, 99.5, 99.8},
+				[4]float64{99.8, 99.9, 99.3, 99.5},
+				[4]float64{99.5, 99.6, 99.4, 99.5},
+			),
+			orders: []Order{
+				{Time: testBaseTime, Side: Buy, Lots: 1},
+			},
+			wantLots: []float64{1},
+			wantExit: []ExitReason{ExitStopOut},
+			want: Summary{
+				InitialBalance:  1000,
+				FinalBalance:    300,
+				MarginCallCount: 1,
+				StopOutCount:    1,
+			},
+		},
+		{
+			name: "証拠金不足の場合は取引数量を減らす",
+			candles: testCandles(
+				[4]float64{100, 100.1, 100, 100},
+				[4]float64{100, 100.2, 100, 100.1},
+			),
+			orders: []Order{
+				{Time: testBaseTime, Side: Buy, Lots: 5},
+				{Time: testBaseTime.Add(time.Hour), Side: Sell, Lots: 1},
+			},
+			wantLots: []float64{1},
+			wantExit: []ExitReason{ExitEndOfData},
+			want: Summary{
+				InitialBalance:     1000,
+				FinalBalance:       1100,
+				RejectedOrderCount: 1,
+			},
+		},
+		{
+			name: "資金管理モデルで取引数量を算出",
+			candles: testCandles(
+				[4]float64{100, 100.1, 99.9, 100},
+				[4]float64{100, 100.1, 99.5, 99.6},
+			),
+			orders: []Order{
+				{Time: testBaseTime, Side: Buy, StopLoss: 99.5},
+			},
+			// 損失許容額 1000 * 10% = 100、損切りまでの損失 0.5 * 1000 = 500 / lot
+			sizing:   &FixedFractional{RiskPercent: 10},
+			wantLots: []float64{0.2},
+			wantExit: []ExitReason{ExitStopLoss},
+			want: Summary{
+				InitialBalance: 1000,
+				FinalBalance:   900,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Run(tt.candles, tt.orders, Config{
+				ContractSize: 1000,
+				Cost:         testCostModel(0, 0),
+				Sizing:       tt.sizing,
+				Lot:          LotRule{Step: 0.01, Min: 0.01, Max: 100},
+				Account:      account,
+			})
+			if err != nil {
+				t.Fatalf("Run()=%v", err)
+			}
+
+			if len(report.Trades) != len(tt.wantLots) {
+				t.Fatalf("len(Run().Trades)=%v want=%v", len(report.Trades), len(tt.wantLots))
+			}
+			for i, got := range report.Trades {
+				if !nearlyEqual(got.Lots, tt.wantLots[i]) || got.ExitReason != tt.wantExit[i] {
+					t.Errorf("Run().Trades[%d]=%+v wantLots=%v wantExit=%v", i, got, tt.wantLots[i], tt.wantExit[i])
+				}
+			}
+
+			got := report.Summary
+			if !nearlyEqual(got.InitialBalance, tt.want.InitialBalance) || !nearlyEqual(got.FinalBalance, tt.want.FinalBalance) ||
+				got.MarginCallCount != tt.want.MarginCallCount || got.StopOutCount != tt.want.StopOutCount ||
+				got.RejectedOrderCount != tt.want.RejectedOrderCount {
+				t.Errorf("Run().Summary=%+v want=%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SwapModel_Swap(t *testing.T) {
+	swap := SwapModel{
+		LongPerLot:   10,
+		ShortPerLot:  -12,
+		RolloverHour: 21,
+		TripleDay:    time.Wednesday,
+	}
+
+	tests := []struct {
+		name  string
+		side  Side
+		entry time.Time
+		exit  time.Time
+		want  float64
+	}{
+		{
+			name:  "ロールオーバー前に決済",
+			side:  Buy,
+			entry: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			exit:  time.Date(2024, 1, 2, 20, 0, 0, 0, time.UTC),
+			want:  0,
+		},
+		{
+			name:  "火曜日と水曜日(3日分)のロールオーバー",
+			side:  Buy,
+			entry: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			exit:  time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+			want:  40,
+		},
+		{
+			name:  "週末はロールオーバーなし",
+			side:  Sell,
+			entry: time.Date(2024, 1, 5, 22, 0, 0, 0, time.UTC),
+			exit:  time.Date(2024, 1, 8, 20, 0, 0, 0, time.UTC),
+			want:  0,
+		},
+		{
+			name:  "売りポジションのスワップ",
+			side:  Sell,
+			entry: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+			exit:  time.Date(2024, 1, 5, 22, 0, 0, 0, time.UTC),
+			want:  -24,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := swap.Swap(tt.side, 1, tt.entry, tt.exit); !nearlyEqual(got, tt.want) {
+				t.Errorf("Swap()=%v want=%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_NewCostModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  func() common.BacktestSymbolConfig
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			config: func() common.BacktestSymbolConfig {
+				var c common.BacktestSymbolConfig
+				c.Spread.Type = SpreadTypePerCandle
+				c.Slippage.Type = SlippageTypeVolatility
+				c.Swap.TripleDay = "Wednesday"
+				return c
+			},
+		},
+		{
+			name: "不正なスプレッドのタイプ",
+			config: func() common.BacktestSymbolConfig {
+				var c common.BacktestSymbolConfig
+				c.Spread.Type = "unknown"
+				return c
+			},
+			wantErr: true,
+		},
+		{
+			name: "不正なスリッページのタイプ",
+			config: func() common.BacktestSymbolConfig {
+				var c common.BacktestSymbolConfig
+				c.Slippage.Type = "unknown"
+				return c
+			},
+			wantErr: true,
+		},
+		{
+			name: "不正な曜日",
+			config: func() common.BacktestSymbolConfig {
+				var c common.BacktestSymbolConfig
+				c.Swap.TripleDay = "someday"
+				return c
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCostModel(tt.config()); (err != nil) != tt.wantErr {
+				t.Errorf("NewCostModel()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_RandomSlippage(t *testing.T) {
+	candles := testCandles([4]float64{100, 101, 99, 100})
+
+	a := NewRandomSlippage(0.5, 1)
+	b := NewRandomSlippage(0.5, 1)
+	for i := 0; i < 10; i++ {
+		va := a.Slippage(candles, 0)
+		vb := b.Slippage(candles, 0)
+		if va != vb {
+			t.Errorf("Slippage() isn't reproducible: %v vs %v", va, vb)
+		}
+		if va < 0 || 0.5 < va {
+			t.Errorf("Slippage()=%v is out of range", va)
+		}
+	}
+}
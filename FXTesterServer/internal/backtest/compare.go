@@ -0,0 +1,95 @@
+package backtest
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeAxis startからendまでをstep間隔で区切った時刻を返却する (startはstep単位で切り捨て、endを含む)
+func TimeAxis(start, end time.Time, step time.Duration) []time.Time {
+	times := []time.Time{}
+	if step <= 0 || end.Before(start) {
+		return times
+	}
+	for t := start.UTC().Truncate(step); !t.After(end); t = t.Add(step) {
+		times = append(times, t)
+	}
+	return times
+}
+
+// EquityCurve 各時刻までに決済した取引の損益を初期の口座残高に加えた資産曲線を返却する (評価損益は含まない)
+func EquityCurve(trades []Trade, initialBalance float64, times []time.Time) []float64 {
+	sorted := make([]Trade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ExitTime.Before(sorted[j].ExitTime)
+	})
+
+	curve := make([]float64, len(times))
+	equity := initialBalance
+	next := 0
+	for i, t := range times {
+		for ; next < len(sorted) && !t.Before(sorted[next].ExitTime); next++ {
+			equity += sorted[next].NetProfit
+		}
+		curve[i] = equity
+	}
+	return curve
+}
+
+// TradeDiff 他のバックテストに存在しない取引
+type TradeDiff struct {
+	// 取引が存在するバックテストの位置
+	Run int
+	// 取引の位置 (runs[Run][Index])
+	Index int
+	// 取引が存在しないバックテストの位置
+	MissingIn []int
+}
+
+// tradeKey 同じ取引とみなすためのキー (シンボル、売買の方向、約定日時)
+type tradeKey struct {
+	symbol    string
+	side      Side
+	entryTime int64
+}
+
+func newTradeKey(t Trade) tradeKey {
+	return tradeKey{symbol: t.Symbol, side: t.Side, entryTime: t.EntryTime.UnixNano()}
+}
+
+/*
+ * DiffTrades 複数のバックテストの取引を比較し、いずれかのバックテストに存在しない取引を返却する。
+ * シンボル・売買の方向・約定日時が同じ取引を同じ取引とみなし、同じ取引が複数ある場合は件数の差分を存在しない取引とする。
+ */
+func DiffTrades(runs [][]Trade) []TradeDiff {
+	counts := make([]map[tradeKey]int, len(runs))
+	for i, trades := range runs {
+		counts[i] = map[tradeKey]int{}
+		for _, t := range trades {
+			counts[i][newTradeKey(t)]++
+		}
+	}
+
+	diffs := []TradeDiff{}
+	for i, trades := range runs {
+		// 同じキーの取引の出現回数
+		seen := map[tradeKey]int{}
+		for k, t := range trades {
+			key := newTradeKey(t)
+			n := seen[key]
+			seen[key]++
+
+			missing := []int{}
+			for j := range runs {
+				if j != i && counts[j][key] <= n {
+					missing = append(missing, j)
+				}
+			}
+			if 0 < len(missing) {
+				diffs = append(diffs, TradeDiff{Run: i, Index: k, MissingIn: missing})
+			}
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,88 @@
+package backtest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_TimeAxis(t *testing.T) {
+	start := testBaseTime.Add(30 * time.Minute)
+	end := testBaseTime.Add(3 * time.Hour)
+
+	got := TimeAxis(start, end, time.Hour)
+	want := []time.Time{
+		testBaseTime,
+		testBaseTime.Add(time.Hour),
+		testBaseTime.Add(2 * time.Hour),
+		testBaseTime.Add(3 * time.Hour),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TimeAxis()=%v want=%v", got, want)
+	}
+
+	if got := TimeAxis(end, start, time.Hour); len(got) != 0 {
+		t.Errorf("TimeAxis(end, start)=%v want=[]", got)
+	}
+}
+
+func Test_EquityCurve(t *testing.T) {
+	trades := []Trade{
+		{ExitTime: testBaseTime.Add(2 * time.Hour), NetProfit: -50},
+		{ExitTime: testBaseTime.Add(time.Hour), NetProfit: 100},
+	}
+	times := []time.Time{
+		testBaseTime,
+		testBaseTime.Add(time.Hour),
+		testBaseTime.Add(90 * time.Minute),
+		testBaseTime.Add(2 * time.Hour),
+	}
+
+	got := EquityCurve(trades, 1000, times)
+	want := []float64{1000, 1100, 1100, 1050}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EquityCurve()=%v want=%v", got, want)
+	}
+}
+
+func Test_DiffTrades(t *testing.T) {
+	a := Trade{Symbol: "USDJPY", Side: Buy, EntryTime: testBaseTime}
+	b := Trade{Symbol: "USDJPY", Side: Sell, EntryTime: testBaseTime.Add(time.Hour)}
+	c := Trade{Symbol: "EURUSD", Side: Buy, EntryTime: testBaseTime}
+
+	tests := []struct {
+		name string
+		runs [][]Trade
+		want []TradeDiff
+	}{
+		{
+			name: "同じ取引のみの場合",
+			runs: [][]Trade{{a, b}, {b, a}},
+			want: []TradeDiff{},
+		},
+		{
+			name: "片方にしか無い取引がある場合",
+			runs: [][]Trade{{a, b}, {a, c}},
+			want: []TradeDiff{
+				{Run: 0, Index: 1, MissingIn: []int{1}},
+				{Run: 1, Index: 1, MissingIn: []int{0}},
+			},
+		},
+		{
+			name: "同じ取引の件数が異なる場合",
+			runs: [][]Trade{{a, a}, {a}, {a, a}},
+			want: []TradeDiff{
+				{Run: 0, Index: 1, MissingIn: []int{1}},
+				{Run: 2, Index: 1, MissingIn: []int{1}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffTrades(tt.runs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffTrades()=%v want=%v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Package backtest バックテスト関連のパッケージ
+package backtest
+
+import (
+	"fxtester/internal/common"
+	"fxtester/internal/lang"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+const (
+	SpreadTypeFixed     = "fixed"
+	SpreadTypePerCandle = "perCandle"
+
+	SlippageTypeNone       = "none"
+	SlippageTypeRandom     = "random"
+	SlippageTypeVolatility = "volatility"
+)
+
+// SpreadModel 約定時のスプレッドを決定するモデル
+type SpreadModel interface {
+	// Spread 指定したローソク足で約定する際のスプレッド(価格単位)を返却する
+	Spread(candle *common.Candle) float64
+}
+
+// FixedSpread 常に一定のスプレッドを返却するモデル
+type FixedSpread struct {
+	Value float64
+}
+
+func (s *FixedSpread) Spread(candle *common.Candle) float64 {
+	return s.Value
+}
+
+// PerCandleSpread ローソク足毎のスプレッドを返却するモデル
+type PerCandleSpread struct {
+	// ローソク足にスプレッドが格納されていない場合に使用するスプレッド
+	Fallback float64
+}
+
+func (s *PerCandleSpread) Spread(candle *common.Candle) float64 {
+	if candle.Spread <= 0 {
+		return s.Fallback
+	}
+	return candle.Spread
+}
+
+// SlippageModel 約定時のスリッページを決定するモデル
+type SlippageModel interface {
+	// Slippage candles[index]で約定する際のスリッページ(価格単位)を返却する。スリッページは常に不利な方向に働くため正数を返却する
+	Slippage(candles []common.Candle, index int) float64
+}
+
+// NoSlippage スリッページを発生させないモデル
+type NoSlippage struct {
+}
+
+func (s *NoSlippage) Slippage(candles []common.Candle, index int) float64 {
+	return 0
+}
+
+// RandomSlippage 0からMaxまでの一様乱数でスリッページを発生させるモデル
+type RandomSlippage struct {
+	Max float64
+	rnd *rand.Rand
+}
+
+// NewRandomSlippage 乱数シードを指定してRandomSlippageを生成します (同じシードであれば同じ結果が再現される)
+func NewRandomSlippage(max float64, seed int64) *RandomSlippage {
+	return &RandomSlippage{
+		Max: max,
+		rnd: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (s *RandomSlippage) Slippage(candles []common.Candle, index int) float64 {
+	return s.rnd.Float64() * s.Max
+}
+
+// VolatilitySlippage 直前のローソク足の値幅に比例したスリッページを発生させるモデル
+type VolatilitySlippage struct {
+	Factor float64
+}
+
+func (s *VolatilitySlippage) Slippage(candles []common.Candle, index int) float64 {
+	// 約定するローソク足の値幅は約定時点では未確定のため直前のローソク足を参照する
+	prev := index - 1
+	if prev < 0 {
+		prev = 0
+	}
+	c := candles[prev]
+	return (c.High - c.Low) * s.Factor
+}
+
+// SwapModel ロールオーバー毎のスワップを計算するモデル
+type SwapModel struct {
+	// 買いポジション1ロットあたりの1日のスワップ
+	LongPerLot float64
+	// 売りポジション1ロットあたりの1日のスワップ
+	ShortPerLot float64
+	// ロールオーバーの時刻 (UTCの時)
+	RolloverHour int
+	// 3日分のスワップが付与される曜日
+	TripleDay time.Weekday
+}
+
+// Swap entryからexitまでポジションを保有した場合のスワップを返却する
+func (s *SwapModel) Swap(side Side, lots float64, entry, exit time.Time) float64 {
+	perLot := s.LongPerLot
+	if side == Sell {
+		perLot = s.ShortPerLot
+	}
+	if perLot == 0 {
+		return 0
+	}
+
+	days := 0
+	entry = entry.UTC()
+	exit = exit.UTC()
+	rollover := time.Date(entry.Year(), entry.Month(), entry.Day(), s.RolloverHour, 0, 0, 0, time.UTC)
+	for ; !rollover.After(exit); rollover = rollover.AddDate(0, 0, 1) {
+		if !rollover.After(entry) {
+			// 約定前のロールオーバーは対象外
+			continue
+		}
+		switch rollover.Weekday() {
+		case time.Saturday, time.Sunday:
+			// 週末はロールオーバーが発生しない (週末分はTripleDayで付与される)
+			continue
+		case s.TripleDay:
+			days += 3
+		default:
+			days++
+		}
+	}
+
+	return perLot * lots * float64(days)
+}
+
+// CostModel 取引コストのモデル
+type CostModel struct {
+	Spread   SpreadModel
+	Slippage SlippageModel
+	// 1ロットあたりの片道手数料
+	CommissionPerLot float64
+	Swap             SwapModel
+}
+
+// NewCostModel シンボル毎の設定から取引コストのモデルを生成します
+func NewCostModel(config common.BacktestSymbolConfig) (*CostModel, error) {
+	var spread SpreadModel
+	switch config.Spread.Type {
+	case SpreadTypeFixed, "":
+		spread = &FixedSpread{Value: config.Spread.Value}
+	case SpreadTypePerCandle:
+		spread = &PerCandleSpread{Fallback: config.Spread.Value}
+	default:
+		return nil, lang.NewFxtError(lang.ErrCodeConfig)
+	}
+
+	var slippage SlippageModel
+	switch config.Slippage.Type {
+	case SlippageTypeNone, "":
+		slippage = &NoSlippage{}
+	case SlippageTypeRandom:
+		slippage = NewRandomSlippage(config.Slippage.Max, config.Slippage.Seed)
+	case SlippageTypeVolatility:
+		slippage = &VolatilitySlippage{Factor: config.Slippage.Factor}
+	default:
+		return nil, lang.NewFxtError(lang.ErrCodeConfig)
+	}
+
+	tripleDay, err := func() (time.Weekday, error) {
+		if config.Swap.TripleDay == "" {
+			return time.Wednesday, nil
+		}
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.EqualFold(d.String(), config.Swap.TripleDay) {
+				return d, nil
+			}
+		}
+		return 0, lang.NewFxtError(lang.ErrCodeConfig)
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Swap.RolloverHour < 0 || 23 < config.Swap.RolloverHour {
+		return nil, lang.NewFxtError(lang.ErrCodeConfig)
+	}
+
+	return &CostModel{
+		Spread:           spread,
+		Slippage:         slippage,
+		CommissionPerLot: config.CommissionPerLot,
+		Swap: SwapModel{
+			LongPerLot:   config.Swap.LongPerLot,
+			ShortPerLot:  config.Swap.ShortPerLot,
+			RolloverHour: config.Swap.RolloverHour,
+			TripleDay:    tripleDay,
+		},
+	}, nil
+}
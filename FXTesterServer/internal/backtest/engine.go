@@ -0,0 +1,386 @@
+package backtest
+
+import (
+	"fmt"
+	"fxtester/internal/common"
+	"math"
+	"sort"
+	"time"
+)
+
+// Instrument バックテストの対象となるシンボル
+type Instrument struct {
+	Symbol string
+	// 決済通貨 (e.g. USDJPYの場合はJPY)
+	Quote   string
+	Candles []common.Candle
+	Orders  []Order
+	// シンボル毎の設定 (Config.Accountは使用しない)
+	Config Config
+}
+
+// rateFunc 指定した時刻での通貨currencyから口座通貨への換算レートを返却する関数
+type rateFunc func(currency string, t time.Time) float64
+
+// ledger 口座の状態 (金額は口座通貨建て)
+type ledger struct {
+	balance float64
+	// 確定した損益の合計
+	profit float64
+	// マージンコールの状態か
+	marginCall bool
+
+	marginCallCount int
+}
+
+// instrumentState シンボル毎の約定処理の状態
+type instrumentState struct {
+	*Instrument
+	// 発注日時順に並び替えた注文
+	orders []Order
+	// 次に約定させる注文の位置
+	next      int
+	positions []position
+	// 下位足の探索範囲 [lower, lowerEnd)
+	lower, lowerEnd int
+	// 処理中のローソク足の位置 (-1は処理開始前)
+	index    int
+	rejected int
+}
+
+// candle 処理中のローソク足を返却する
+func (s *instrumentState) candle() *common.Candle {
+	return &s.Candles[s.index]
+}
+
+// engine 複数のシンボルで口座を共有して注文を約定させる
+type engine struct {
+	states  []*instrumentState
+	account ledger
+	config  Account
+	rate    rateFunc
+	trades  []Trade
+}
+
+func newEngine(instruments []Instrument, account Account, rate rateFunc) (*engine, error) {
+	e := &engine{
+		account: ledger{balance: account.InitialBalance},
+		config:  account,
+		rate:    rate,
+		trades:  []Trade{},
+	}
+
+	for i := range instruments {
+		inst := &instruments[i]
+
+		// 発注日時順に並び替える (元の配列は変更しない)
+		sorted := make([]Order, len(inst.Orders))
+		copy(sorted, inst.Orders)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Time.Before(sorted[j].Time)
+		})
+
+		candles := inst.Candles
+		if 0 < len(sorted) && (len(candles) <= 0 || candles[len(candles)-1].Time.Before(sorted[len(sorted)-1].Time)) {
+			// ローソク足の範囲外に発注された場合
+			return nil, fmt.Errorf("%w: %v %v", ErrOrderOutOfRange, inst.Symbol, sorted[len(sorted)-1].Time)
+		}
+
+		e.states = append(e.states, &instrumentState{
+			Instrument: inst,
+			orders:     sorted,
+			index:      -1,
+		})
+	}
+
+	return e, nil
+}
+
+// timeline 全シンボルのローソク足の時刻を重複なく時系列順に返却する
+func (e *engine) timeline() []time.Time {
+	times := []time.Time{}
+	for _, s := range e.states {
+		for _, c := range s.Candles {
+			times = append(times, c.Time)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	res := []time.Time{}
+	for _, t := range times {
+		if len(res) <= 0 || !res[len(res)-1].Equal(t) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// run 時刻を揃えて全シンボルのローソク足を進め、注文を約定させる
+func (e *engine) run() {
+	for _, t := range e.timeline() {
+		// 時刻tのローソク足が存在するシンボル
+		active := []*instrumentState{}
+		for _, s := range e.states {
+			if s.index+1 < len(s.Candles) && s.Candles[s.index+1].Time.Equal(t) {
+				s.index++
+				active = append(active, s)
+			}
+		}
+
+		for _, s := range active {
+			e.enter(s, t)
+		}
+		for _, s := range active {
+			e.exit(s)
+		}
+
+		// 証拠金維持率の判定
+		e.checkMargin(t)
+	}
+
+	// データ終端で保有中のポジションは最後のローソク足の終値で決済する
+	for _, s := range e.states {
+		if len(s.Candles) <= 0 {
+			continue
+		}
+		last := len(s.Candles) - 1
+		c := &s.Candles[last]
+		for _, p := range s.positions {
+			e.close(s, p, fill{
+				time:     c.Time,
+				raw:      c.Close,
+				spread:   s.Config.Cost.Spread.Spread(c),
+				slippage: s.Config.Cost.Slippage.Slippage(s.Candles, last),
+			}, ExitEndOfData)
+		}
+		s.positions = nil
+	}
+
+	// 決済日時順に並び替える
+	sort.SliceStable(e.trades, func(i, j int) bool {
+		return e.trades[i].ExitTime.Before(e.trades[j].ExitTime)
+	})
+}
+
+// enter 発注日時を過ぎた注文を始値で約定させる
+func (e *engine) enter(s *instrumentState, t time.Time) {
+	c := s.candle()
+	cost := s.Config.Cost
+	for ; s.next < len(s.orders) && !c.Time.Before(s.orders[s.next].Time); s.next++ {
+		p := position{
+			order: s.orders[s.next],
+			entry: fill{
+				time:     c.Time,
+				raw:      c.Open,
+				spread:   cost.Spread.Spread(c),
+				slippage: cost.Slippage.Slippage(s.Candles, s.index),
+			},
+		}
+		if !e.open(s, &p, t) {
+			s.rejected++
+			continue
+		}
+		s.positions = append(s.positions, p)
+	}
+}
+
+// exit 損切り・利確の判定を行い、発生したポジションを決済する
+func (e *engine) exit(s *instrumentState) {
+	c := s.candle()
+	var lowers []common.Candle
+	s.lower, s.lowerEnd = findLowerCandles(s.Config.LowerCandles, s.lowerEnd, c, nextTime(s.Candles, s.index))
+	if s.lower < s.lowerEnd {
+		lowers = s.Config.LowerCandles[s.lower:s.lowerEnd]
+	}
+
+	remains := []position{}
+	for _, p := range s.positions {
+		exit, reason, ok := resolveExit(p.order, s.Candles, s.index, lowers, s.Config)
+		if !ok {
+			remains = append(remains, p)
+			continue
+		}
+		e.close(s, p, exit, reason)
+	}
+	s.positions = remains
+}
+
+// close ポジションを決済し、損益を口座通貨に換算して口座残高に反映する
+func (e *engine) close(s *instrumentState, p position, exit fill, reason ExitReason) {
+	t := settle(p, exit, reason, s.Config)
+	rate := e.rate(s.Quote, exit.time)
+	t.Symbol = s.Symbol
+	t.GrossProfit *= rate
+	t.SpreadCost *= rate
+	t.SlippageCost *= rate
+	t.Commission *= rate
+	t.Swap *= rate
+	t.NetProfit *= rate
+
+	e.account.balance += t.NetProfit
+	e.account.profit += t.NetProfit
+	e.trades = append(e.trades, t)
+}
+
+// valuation 時刻tでのポジションの評価損益(口座通貨建て)を返却する。worstがtrueの場合はローソク足の中で最も不利な価格で評価する
+func (e *engine) valuation(s *instrumentState, p position, t time.Time, worst bool) float64 {
+	c := s.candle()
+	bid := c.Close
+	if c.Time.Equal(t) {
+		// 時刻tのローソク足が存在する場合
+		bid = c.Open
+		if worst {
+			// 最も不利な価格 (買いは安値、売りは高値)
+			bid = c.Low
+			if p.order.Side == Sell {
+				bid = c.High
+			}
+		}
+	}
+	return unrealized(p, bid, s.Config.Cost.Spread.Spread(c), s.Config) * e.rate(s.Quote, t)
+}
+
+// equity 時刻tでの有効証拠金と必要証拠金の合計を返却する
+func (e *engine) equity(t time.Time, worst bool) (float64, float64) {
+	equity := e.account.balance
+	usedMargin := 0.0
+	for _, s := range e.states {
+		for _, p := range s.positions {
+			equity += e.valuation(s, p, t, worst)
+			usedMargin += p.margin
+		}
+	}
+	return equity, usedMargin
+}
+
+// open 注文の取引数量と必要証拠金を決定する。証拠金が不足する場合は取引数量を減らし、発注できない場合はfalseを返却する
+func (e *engine) open(s *instrumentState, p *position, t time.Time) bool {
+	config := s.Config
+	equity, usedMargin := e.equity(t, false)
+	rate := e.rate(s.Quote, t)
+
+	lots := p.order.Lots
+	if lots <= 0 {
+		if config.Sizing == nil {
+			return false
+		}
+		lots = config.Sizing.Lots(SizingState{
+			Order:        p.order,
+			EntryPrice:   p.entry.raw,
+			Equity:       equity,
+			Profit:       e.account.profit,
+			ContractSize: config.ContractSize,
+			QuoteRate:    rate,
+			Candles:      s.Candles,
+			Index:        s.index,
+		})
+	}
+	lots = config.Lot.Normalize(lots)
+	if lots <= 0 {
+		return false
+	}
+
+	if 0 < e.config.Leverage {
+		// 1ロットあたりの必要証拠金(口座通貨建て)
+		marginPerLot := config.ContractSize * p.entry.raw * rate / e.config.Leverage
+		free := equity - usedMargin
+		if free < marginPerLot*lots {
+			// 余剰証拠金で発注可能な取引数量まで減らす
+			if free <= 0 || marginPerLot <= 0 {
+				return false
+			}
+			lots = config.Lot.Normalize(free / marginPerLot)
+			if lots <= 0 {
+				return false
+			}
+		}
+		p.margin = marginPerLot * lots
+	}
+
+	p.order.Lots = lots
+	return true
+}
+
+// unrealized ローソク足(Bid)上の価格bidで決済した場合の評価損益(決済通貨建て)を返却する (スプレッドを含み、スリッページは含まない)
+func unrealized(p position, bid, spread float64, config Config) float64 {
+	size := p.order.Lots * config.ContractSize
+	if p.order.Side == Sell {
+		return (p.entry.raw - bid - spread) * size
+	}
+	return (bid - p.entry.raw - p.entry.spread) * size
+}
+
+/*
+ * checkMargin 時刻tのローソク足の中で最も不利な価格での証拠金維持率を判定する。
+ * 維持率がロスカット水準を下回った場合は全シンボルの中で評価損失の大きいポジションから順に、水準を上回るまで不利な価格で強制決済する。
+ */
+func (e *engine) checkMargin(t time.Time) {
+	if e.config.Leverage <= 0 {
+		return
+	}
+
+	level := func() float64 {
+		equity, usedMargin := e.equity(t, true)
+		if usedMargin <= 0 {
+			return math.Inf(1)
+		}
+		return equity / usedMargin * 100
+	}
+
+	for level() < e.config.StopOutLevel {
+		// 評価損失が最も大きいポジションを探す
+		var target *instrumentState
+		index := -1
+		loss := math.Inf(1)
+		for _, s := range e.states {
+			for i, p := range s.positions {
+				if v := e.valuation(s, p, t, true); v < loss {
+					target, index, loss = s, i, v
+				}
+			}
+		}
+		if target == nil {
+			break
+		}
+
+		p := target.positions[index]
+		c := target.candle()
+		raw := c.Close
+		if c.Time.Equal(t) {
+			raw = c.Low
+			if p.order.Side == Sell {
+				raw = c.High
+			}
+		}
+		e.close(target, p, fill{
+			time:     t,
+			raw:      raw,
+			spread:   target.Config.Cost.Spread.Spread(c),
+			slippage: target.Config.Cost.Slippage.Slippage(target.Candles, target.index),
+		}, ExitStopOut)
+		target.positions = append(target.positions[:index:index], target.positions[index+1:]...)
+	}
+
+	if level() < e.config.MarginCallLevel {
+		if !e.account.marginCall {
+			e.account.marginCallCount++
+		}
+		e.account.marginCall = true
+	} else {
+		e.account.marginCall = false
+	}
+}
+
+// summary 全シンボルの取引結果と口座の状態を集計する
+func (e *engine) summary() Summary {
+	s := summarize(e.trades)
+	s.InitialBalance = e.config.InitialBalance
+	s.FinalBalance = e.account.balance
+	s.MarginCallCount = e.account.marginCallCount
+	for _, st := range e.states {
+		s.RejectedOrderCount += st.rejected
+	}
+	return s
+}
@@ -0,0 +1,218 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"fxtester/internal/common"
+	"math"
+	"sort"
+	"time"
+)
+
+var ErrRateUnavailable = errors.New("backtest: conversion rate is unavailable")
+
+// PortfolioInstrument ポートフォリオを構成するシンボル
+type PortfolioInstrument struct {
+	Instrument
+	// 基軸通貨 (e.g. USDJPYの場合はUSD)
+	Base string
+}
+
+type PortfolioConfig struct {
+	// 口座通貨 (e.g. JPY)
+	AccountCurrency string
+	Account         Account
+}
+
+// SymbolReport シンボル毎の取引結果
+type SymbolReport struct {
+	Symbol  string
+	Trades  []Trade
+	Summary Summary
+}
+
+// PortfolioReport ポートフォリオの取引結果。金額は全て口座通貨建て
+type PortfolioReport struct {
+	// 全シンボルの取引
+	Trades []Trade
+	// 全シンボルの集計
+	Summary Summary
+	Symbols []SymbolReport
+	// シンボル間の日次損益の相関係数 (Correlation[i][j]はSymbols[i]とSymbols[j]の相関)
+	Correlation [][]float64
+}
+
+// RunPortfolio 複数のシンボルで口座を共有し、時刻を揃えて注文を約定させる。
+// 損益はアップロードされたローソク足から求めたクロスレートで口座通貨に換算する
+func RunPortfolio(instruments []PortfolioInstrument, config PortfolioConfig) (*PortfolioReport, error) {
+	rates := newRateTable(instruments, config.AccountCurrency)
+
+	insts := []Instrument{}
+	for _, inst := range instruments {
+		// 決済通貨を口座通貨に換算できるかチェックする
+		if 0 < len(inst.Candles) {
+			if _, ok := rates.rate(inst.Quote, inst.Candles[0].Time); !ok {
+				return nil, fmt.Errorf("%w: %v -> %v", ErrRateUnavailable, inst.Quote, config.AccountCurrency)
+			}
+		}
+		insts = append(insts, inst.Instrument)
+	}
+
+	e, err := newEngine(insts, config.Account, func(currency string, t time.Time) float64 {
+		rate, _ := rates.rate(currency, t)
+		return rate
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.run()
+
+	// シンボル毎に集計する
+	symbols := []SymbolReport{}
+	for _, s := range e.states {
+		trades := common.ArrayMapSkip(func(t Trade) (Trade, bool) {
+			return t, t.Symbol != s.Symbol
+		}, e.trades)
+		summary := summarize(trades)
+		summary.RejectedOrderCount = s.rejected
+		symbols = append(symbols, SymbolReport{
+			Symbol:  s.Symbol,
+			Trades:  trades,
+			Summary: summary,
+		})
+	}
+
+	return &PortfolioReport{
+		Trades:      e.trades,
+		Summary:     e.summary(),
+		Symbols:     symbols,
+		Correlation: correlation(symbols, e.timeline()),
+	}, nil
+}
+
+// rateTable アップロードされたローソク足から通貨間の換算レートを求める
+type rateTable struct {
+	account string
+	// 通貨ペア(基軸通貨, 決済通貨)毎のローソク足
+	pairs      map[[2]string][]common.Candle
+	currencies []string
+}
+
+func newRateTable(instruments []PortfolioInstrument, account string) *rateTable {
+	r := &rateTable{
+		account: account,
+		pairs:   map[[2]string][]common.Candle{},
+	}
+	currencies := []string{}
+	for _, inst := range instruments {
+		r.pairs[[2]string{inst.Base, inst.Quote}] = inst.Candles
+		currencies = append(currencies, inst.Base, inst.Quote)
+	}
+	r.currencies = common.Set(currencies)
+	return r
+}
+
+// price 時刻tでの通貨ペア(base, quote)の価格を返却する。逆の通貨ペアしか無い場合は逆数を返却する
+func (r *rateTable) price(base, quote string, t time.Time) (float64, bool) {
+	if base == quote {
+		return 1, true
+	}
+	if candles, ok := r.pairs[[2]string{base, quote}]; ok && 0 < len(candles) {
+		return priceAt(candles, t), true
+	}
+	if candles, ok := r.pairs[[2]string{quote, base}]; ok && 0 < len(candles) {
+		if p := priceAt(candles, t); 0 < p {
+			return 1 / p, true
+		}
+	}
+	return 0, false
+}
+
+// rate 時刻tでの通貨currencyから口座通貨への換算レートを返却する。直接の通貨ペアが無い場合は1つの通貨を経由して求める
+func (r *rateTable) rate(currency string, t time.Time) (float64, bool) {
+	if p, ok := r.price(currency, r.account, t); ok {
+		return p, true
+	}
+	for _, via := range r.currencies {
+		p1, ok1 := r.price(currency, via, t)
+		p2, ok2 := r.price(via, r.account, t)
+		if ok1 && ok2 {
+			return p1 * p2, true
+		}
+	}
+	return 0, false
+}
+
+// priceAt 時刻tで確定している価格を返却する。ローソク足の時刻は開始時刻のため、t以前で最後のローソク足は時刻tでは終値が未確定であり、その始値とする。
+// t以前のローソク足が無い場合は最初のローソク足の始値を返却する
+func priceAt(candles []common.Candle, t time.Time) float64 {
+	i := sort.Search(len(candles), func(i int) bool {
+		return t.Before(candles[i].Time)
+	})
+	if i <= 0 {
+		return candles[0].Open
+	}
+	return candles[i-1].Open
+}
+
+// correlation シンボル毎の日次の確定損益(UTC基準)の相関係数行列を返却する。分散が0の場合の相関係数は0とする
+func correlation(symbols []SymbolReport, times []time.Time) [][]float64 {
+	res := make([][]float64, len(symbols))
+	if len(times) <= 0 {
+		for i := range res {
+			res[i] = make([]float64, len(symbols))
+		}
+		return res
+	}
+
+	// 日次の損益の系列を作成する
+	day := 24 * time.Hour
+	first := times[0].UTC().Truncate(day)
+	days := int(times[len(times)-1].UTC().Truncate(day).Sub(first)/day) + 1
+	series := make([][]float64, len(symbols))
+	for i, s := range symbols {
+		series[i] = make([]float64, days)
+		for _, t := range s.Trades {
+			d := int(t.ExitTime.UTC().Truncate(day).Sub(first) / day)
+			if 0 <= d && d < days {
+				series[i][d] += t.NetProfit
+			}
+		}
+	}
+
+	for i := range symbols {
+		res[i] = make([]float64, len(symbols))
+		for j := range symbols {
+			res[i][j] = pearson(series[i], series[j])
+		}
+	}
+	return res
+}
+
+// pearson ピアソンの積率相関係数を返却する
+func pearson(x, y []float64) float64 {
+	n := float64(len(x))
+	if n <= 0 {
+		return 0
+	}
+	meanX, meanY := 0.0, 0.0
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	cov, varX, varY := 0.0, 0.0, 0.0
+	for i := range x {
+		dx := x[i] - meanX
+		dy := y[i] - meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX <= 0 || varY <= 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
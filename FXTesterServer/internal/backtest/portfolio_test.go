@@ -0,0 +1,169 @@
+package backtest
+
+import (
+	"errors"
+	"fxtester/internal/common"
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_RunPortfolio(t *testing.T) {
+	usdjpy := PortfolioInstrument{
+		Base: "USD",
+		Instrument: Instrument{
+			Symbol: "USDJPY",
+			Quote:  "JPY",
+			Candles: testCandles(
+				[4]float64{150, 150.5, 149.5, 150},
+				[4]float64{150, 151.5, 149.8, 151},
+				[4]float64{151, 151.2, 150.5, 151},
+			),
+			Orders: []Order{
+				{Time: testBaseTime, Side: Buy, Lots: 1, TakeProfit: 151},
+			},
+			Config: Config{ContractSize: 1000, Cost: testCostModel(0, 0)},
+		},
+	}
+	// EURUSDはUSDJPYより1時間遅れて開始する
+	eurusd := PortfolioInstrument{
+		Base: "EUR",
+		Instrument: Instrument{
+			Symbol: "EURUSD",
+			Quote:  "USD",
+			Candles: []common.Candle{
+				{Time: testBaseTime.Add(time.Hour), Open: 1.1, High: 1.1, Low: 1.09, Close: 1.095},
+				{Time: testBaseTime.Add(2 * time.Hour), Open: 1.095, High: 1.096, Low: 1.08, Close: 1.09},
+			},
+			Orders: []Order{
+				{Time: testBaseTime.Add(time.Hour), Side: Sell, Lots: 1},
+			},
+			Config: Config{ContractSize: 1000, Cost: testCostModel(0, 0)},
+		},
+	}
+
+	report, err := RunPortfolio([]PortfolioInstrument{usdjpy, eurusd}, PortfolioConfig{
+		AccountCurrency: "JPY",
+		Account:         Account{InitialBalance: 1000000},
+	})
+	if err != nil {
+		t.Fatalf("RunPortfolio()=%v", err)
+	}
+
+	if len(report.Symbols) != 2 {
+		t.Fatalf("len(RunPortfolio().Symbols)=%v want=2", len(report.Symbols))
+	}
+
+	// USDJPY: (151 - 150) * 1000 = 1000円
+	if got := report.Symbols[0].Summary.NetProfit; !nearlyEqual(got, 1000) {
+		t.Errorf("Symbols[0].Summary.NetProfit=%v want=1000", got)
+	}
+	// EURUSD: (1.1 - 1.09) * 1000 = 10ドル、決済時のUSDJPY(151)で換算して1510円
+	if got := report.Symbols[1].Summary.NetProfit; !nearlyEqual(got, 1510) {
+		t.Errorf("Symbols[1].Summary.NetProfit=%v want=1510", got)
+	}
+	if got := report.Summary.FinalBalance; !nearlyEqual(got, 1002510) {
+		t.Errorf("Summary.FinalBalance=%v want=1002510", got)
+	}
+	if got := report.Trades[len(report.Trades)-1].Symbol; got != "EURUSD" {
+		t.Errorf("Trades[last].Symbol=%v want=EURUSD", got)
+	}
+
+	// 相関係数の範囲チェック
+	for i := range report.Correlation {
+		for j, v := range report.Correlation[i] {
+			if math.IsNaN(v) || v < -1 || 1 < v {
+				t.Errorf("Correlation[%d][%d]=%v is out of range", i, j, v)
+			}
+		}
+	}
+}
+
+func Test_RunPortfolio_RateUnavailable(t *testing.T) {
+	gbpchf := PortfolioInstrument{
+		Base: "GBP",
+		Instrument: Instrument{
+			Symbol:  "GBPCHF",
+			Quote:   "CHF",
+			Candles: testCandles([4]float64{1.1, 1.1, 1.1, 1.1}),
+			Config:  Config{ContractSize: 1000, Cost: testCostModel(0, 0)},
+		},
+	}
+
+	_, err := RunPortfolio([]PortfolioInstrument{gbpchf}, PortfolioConfig{AccountCurrency: "JPY"})
+	if !errors.Is(err, ErrRateUnavailable) {
+		t.Errorf("RunPortfolio()=%v want=%v", err, ErrRateUnavailable)
+	}
+}
+
+func Test_rateTable_rate(t *testing.T) {
+	rates := newRateTable([]PortfolioInstrument{
+		{Base: "USD", Instrument: Instrument{Quote: "JPY", Candles: testCandles([4]float64{150, 150, 150, 150})}},
+		{Base: "EUR", Instrument: Instrument{Quote: "USD", Candles: testCandles([4]float64{1.1, 1.1, 1.1, 1.1})}},
+	}, "EUR")
+
+	tests := []struct {
+		currency string
+		want     float64
+	}{
+		{currency: "EUR", want: 1},
+		// EURUSDの逆数
+		{currency: "USD", want: 1 / 1.1},
+		// JPY -> USD -> EUR
+		{currency: "JPY", want: 1 / 150.0 / 1.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			got, ok := rates.rate(tt.currency, testBaseTime)
+			if !ok || !nearlyEqual(got, tt.want) {
+				t.Errorf("rate()=%v,%v want=%v", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func Test_priceAt(t *testing.T) {
+	candles := testCandles(
+		[4]float64{150, 150.5, 149.5, 150.2},
+		[4]float64{150.3, 151.5, 150, 151},
+	)
+
+	tests := []struct {
+		name string
+		time time.Time
+		want float64
+	}{
+		{name: "test1_最初のローソク足より前は最初の始値", time: testBaseTime.Add(-time.Minute), want: 150},
+		{name: "test2_ローソク足の開始時刻は始値 (終値は未確定)", time: testBaseTime.Add(time.Hour), want: 150.3},
+		{name: "test3_ローソク足の途中は始値 (終値は未確定)", time: testBaseTime.Add(90 * time.Minute), want: 150.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := priceAt(candles, tt.time); got != tt.want {
+				t.Errorf("priceAt()=%v want=%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pearson(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []float64
+		want float64
+	}{
+		{name: "正の相関", x: []float64{1, 2, 3}, y: []float64{2, 4, 6}, want: 1},
+		{name: "負の相関", x: []float64{1, 2, 3}, y: []float64{3, 2, 1}, want: -1},
+		{name: "分散が0", x: []float64{1, 1, 1}, y: []float64{1, 2, 3}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pearson(tt.x, tt.y); !nearlyEqual(got, tt.want) {
+				t.Errorf("pearson()=%v want=%v", got, tt.want)
+			}
+		})
+	}
+}
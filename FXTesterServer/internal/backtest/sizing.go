@@ -0,0 +1,182 @@
+package backtest
+
+import (
+	"fxtester/internal/common"
+	"math"
+)
+
+// SizingState 取引数量を決定する時点の口座とポジションの状態
+type SizingState struct {
+	Order Order
+	// 約定予定のローソク足(Bid)上の価格
+	EntryPrice float64
+	// 有効証拠金 (口座残高 + 評価損益、口座通貨建て)
+	Equity float64
+	// 確定した損益の合計(口座通貨建て)
+	Profit float64
+	// 1ロットあたりの通貨量
+	ContractSize float64
+	// 決済通貨から口座通貨への換算レート (0の場合は1とみなす)
+	QuoteRate float64
+	// 約定予定のローソク足の位置 (candles[Index]の始値で約定する)
+	Candles []common.Candle
+	Index   int
+}
+
+// quoteRate 決済通貨から口座通貨への換算レートを返却する
+func (s SizingState) quoteRate() float64 {
+	if s.QuoteRate <= 0 {
+		return 1
+	}
+	return s.QuoteRate
+}
+
+// SizingModel 取引数量(ロット)を決定するモデル
+type SizingModel interface {
+	// Lots 発注する取引数量を返却する。発注しない場合は0を返却する
+	Lots(s SizingState) float64
+}
+
+// FixedLot 常に一定の取引数量で発注するモデル
+type FixedLot struct {
+	Value float64
+}
+
+func (m *FixedLot) Lots(s SizingState) float64 {
+	return m.Value
+}
+
+// FixedFractional 損切り価格までの損失が有効証拠金の一定割合となる取引数量で発注するモデル
+type FixedFractional struct {
+	// 1取引あたりに許容する損失の割合[%]
+	RiskPercent float64
+}
+
+func (m *FixedFractional) Lots(s SizingState) float64 {
+	return riskLots(s, s.Equity*m.RiskPercent/100)
+}
+
+// FixedRatio 確定した利益がDelta増える毎に取引数量を増やすモデル (Ryan Jonesの固定比率法)
+type FixedRatio struct {
+	// 開始時の取引数量
+	BaseLots float64
+	// 取引数量を1単位増やすのに必要な利益
+	Delta float64
+}
+
+func (m *FixedRatio) Lots(s SizingState) float64 {
+	if m.Delta <= 0 || s.Profit <= 0 {
+		return m.BaseLots
+	}
+	// 利益PでN単位になる条件 P = Delta * N * (N-1) / 2 をNについて解く
+	n := math.Floor(0.5 * (1 + math.Sqrt(1+8*s.Profit/m.Delta)))
+	return m.BaseLots * n
+}
+
+// Kelly ケリー基準で算出した割合の損失を許容する取引数量で発注するモデル
+type Kelly struct {
+	// 勝率[0.0~1.0]
+	WinRate float64
+	// 平均利益と平均損失の比率
+	PayoffRatio float64
+	// ケリー基準に掛ける係数 (e.g. ハーフケリーの場合は0.5)
+	Fraction float64
+}
+
+func (m *Kelly) Lots(s SizingState) float64 {
+	if m.PayoffRatio <= 0 {
+		return 0
+	}
+	f := m.WinRate - (1-m.WinRate)/m.PayoffRatio
+	if f <= 0 {
+		// 期待値が負の場合は発注しない
+		return 0
+	}
+	return riskLots(s, s.Equity*f*m.Fraction)
+}
+
+// VolatilityTarget ATRの一定倍の値動きでの損失が有効証拠金の一定割合となる取引数量で発注するモデル
+type VolatilityTarget struct {
+	// 許容する損失の割合[%]
+	RiskPercent float64
+	// ATRの期間
+	ATRPeriod int
+	// ATRに掛ける係数
+	ATRMultiple float64
+}
+
+func (m *VolatilityTarget) Lots(s SizingState) float64 {
+	atr, ok := ATR(s.Candles, s.Index, m.ATRPeriod)
+	if !ok || atr <= 0 || m.ATRMultiple <= 0 || s.ContractSize <= 0 {
+		return 0
+	}
+	return s.Equity * m.RiskPercent / 100 / (atr * m.ATRMultiple * s.ContractSize * s.quoteRate())
+}
+
+// riskLots 損切り価格までの損失がriskとなる取引数量を返却する。損切り価格が未指定の場合は0を返却する
+func riskLots(s SizingState, risk float64) float64 {
+	if s.Order.StopLoss == 0 || s.ContractSize <= 0 || risk <= 0 {
+		return 0
+	}
+	distance := math.Abs(s.EntryPrice - s.Order.StopLoss)
+	if distance <= 0 {
+		return 0
+	}
+	return risk / (distance * s.ContractSize * s.quoteRate())
+}
+
+// ATR candles[index]より前のperiod本のローソク足からATR(Average True Range)を算出する。
+// ローソク足が不足している場合はfalseを返却する (約定するローソク足の値幅は約定時点では未確定のため含めない)
+func ATR(candles []common.Candle, index, period int) (float64, bool) {
+	if period <= 0 || index-period < 0 || len(candles) < index {
+		return 0, false
+	}
+	sum := 0.0
+	for i := index - period; i < index; i++ {
+		c := candles[i]
+		tr := c.High - c.Low
+		if 0 < i {
+			prevClose := candles[i-1].Close
+			tr = math.Max(tr, math.Max(math.Abs(c.High-prevClose), math.Abs(c.Low-prevClose)))
+		}
+		sum += tr
+	}
+	return sum / float64(period), true
+}
+
+// LotRule シンボル毎の取引数量の制約
+type LotRule struct {
+	// 取引数量の刻み (0の場合は刻み無し)
+	Step float64
+	// 最小取引数量
+	Min float64
+	// 最大取引数量 (0の場合は上限無し)
+	Max float64
+}
+
+// Normalize 取引数量を制約に従って丸める。最小取引数量に満たない場合は0を返却する
+func (r *LotRule) Normalize(lots float64) float64 {
+	if 0 < r.Max && r.Max < lots {
+		lots = r.Max
+	}
+	if 0 < r.Step {
+		// 浮動小数点の誤差で1刻み少なくならないように微小値を加える
+		lots = math.Floor(lots/r.Step+1e-9) * r.Step
+	}
+	if lots <= 0 || lots < r.Min {
+		return 0
+	}
+	return lots
+}
+
+// Account 口座の設定
+type Account struct {
+	// 初期の口座残高(口座通貨建て)
+	InitialBalance float64
+	// レバレッジ (0の場合は証拠金の判定を行わない)
+	Leverage float64
+	// マージンコールとなる証拠金維持率[%]
+	MarginCallLevel float64
+	// ロスカットとなる証拠金維持率[%]
+	StopOutLevel float64
+}
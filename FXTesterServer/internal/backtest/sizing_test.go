@@ -0,0 +1,123 @@
+package backtest
+
+import (
+	"testing"
+)
+
+func Test_SizingModel_Lots(t *testing.T) {
+	// ATR(2) = ((101-99) + (102-100)) / 2 = 2
+	candles := testCandles(
+		[4]float64{100, 101, 99, 100},
+		[4]float64{101, 102, 100, 101},
+		[4]float64{101, 101.5, 100.5, 101},
+	)
+
+	state := SizingState{
+		Order:        Order{Side: Buy, StopLoss: 99},
+		EntryPrice:   100,
+		Equity:       100000,
+		ContractSize: 1000,
+		Candles:      candles,
+		Index:        2,
+	}
+
+	tests := []struct {
+		name  string
+		model SizingModel
+		state func(s SizingState) SizingState
+		want  float64
+	}{
+		{
+			name:  "固定ロット",
+			model: &FixedLot{Value: 0.3},
+			want:  0.3,
+		},
+		{
+			name:  "定率法",
+			model: &FixedFractional{RiskPercent: 2},
+			// 100000 * 2% / (1 * 1000)
+			want: 2,
+		},
+		{
+			name:  "定率法(損切り価格が未指定)",
+			model: &FixedFractional{RiskPercent: 2},
+			state: func(s SizingState) SizingState {
+				s.Order.StopLoss = 0
+				return s
+			},
+			want: 0,
+		},
+		{
+			name:  "固定比率法(利益なし)",
+			model: &FixedRatio{BaseLots: 0.1, Delta: 1000},
+			want:  0.1,
+		},
+		{
+			name:  "固定比率法(3単位)",
+			model: &FixedRatio{BaseLots: 0.1, Delta: 1000},
+			state: func(s SizingState) SizingState {
+				// 3単位に必要な利益は 1000 * 3 * 2 / 2 = 3000
+				s.Profit = 3000
+				return s
+			},
+			want: 0.3,
+		},
+		{
+			name:  "ケリー基準",
+			model: &Kelly{WinRate: 0.5, PayoffRatio: 2, Fraction: 0.5},
+			// f = 0.5 - 0.5 / 2 = 0.25, 100000 * 0.25 * 0.5 / (1 * 1000)
+			want: 12.5,
+		},
+		{
+			name:  "ケリー基準(期待値が負)",
+			model: &Kelly{WinRate: 0.3, PayoffRatio: 1, Fraction: 1},
+			want:  0,
+		},
+		{
+			name:  "ボラティリティ",
+			model: &VolatilityTarget{RiskPercent: 1, ATRPeriod: 2, ATRMultiple: 2},
+			// 100000 * 1% / (2 * 2 * 1000)
+			want: 0.25,
+		},
+		{
+			name:  "ボラティリティ(ローソク足が不足)",
+			model: &VolatilityTarget{RiskPercent: 1, ATRPeriod: 3, ATRMultiple: 2},
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := state
+			if tt.state != nil {
+				s = tt.state(s)
+			}
+			if got := tt.model.Lots(s); !nearlyEqual(got, tt.want) {
+				t.Errorf("Lots()=%v want=%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_LotRule_Normalize(t *testing.T) {
+	rule := LotRule{Step: 0.01, Min: 0.01, Max: 50}
+
+	tests := []struct {
+		name string
+		lots float64
+		want float64
+	}{
+		{name: "刻みに合わせて切り捨て", lots: 0.2999, want: 0.29},
+		{name: "刻みちょうど", lots: 0.07, want: 0.07},
+		{name: "最大取引数量を超える", lots: 80, want: 50},
+		{name: "最小取引数量未満", lots: 0.005, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.Normalize(tt.lots); !nearlyEqual(got, tt.want) {
+				t.Errorf("Normalize()=%v want=%v", got, tt.want)
+			}
+		})
+	}
+}
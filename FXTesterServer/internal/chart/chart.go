@@ -0,0 +1,369 @@
+// Package chart ローソク足のチャートをSVG・PNGで描画するパッケージ
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"fxtester/internal/algo"
+	"fxtester/internal/common"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+var ErrNoCandles = errors.New("chart: no candles")
+
+// Format 出力形式
+type Format string
+
+const (
+	FormatSVG Format = "svg"
+	FormatPNG Format = "png"
+)
+
+// ContentType 出力形式のContent-Typeを返却する
+func (f Format) ContentType() string {
+	if f == FormatPNG {
+		return "image/png"
+	}
+	return "image/svg+xml"
+}
+
+// Point 重ねて描画する線の点
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Line ローソク足に重ねて描画する線 (インジケーター等)
+type Line struct {
+	Name string
+	// 線の色 (#rrggbb形式。空文字の場合は既定の色)
+	Color  string
+	Points []Point
+}
+
+// Chart 描画するチャート
+type Chart struct {
+	Candles []common.Candle
+	// ローソク足の位置に対応するジグザグ
+	Zigzags []algo.ZigzagResult
+	Lines   []Line
+	// 画像の大きさ[px]
+	Width, Height int
+}
+
+// 配色
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorGrid       = color.RGBA{0xe6, 0xe6, 0xe6, 0xff}
+	colorAxis       = color.RGBA{0x66, 0x66, 0x66, 0xff}
+	colorUp         = color.RGBA{0x26, 0xa6, 0x9a, 0xff}
+	colorDown       = color.RGBA{0xef, 0x53, 0x50, 0xff}
+	colorZigzag     = color.RGBA{0x1e, 0x3a, 0x8a, 0xff}
+	colorPeak       = color.RGBA{0xd9, 0x77, 0x06, 0xff}
+	colorBottom     = color.RGBA{0x7c, 0x3a, 0xed, 0xff}
+	// 色が未指定の線に順に割り当てる色
+	linePalette = []color.RGBA{
+		{0x25, 0x63, 0xeb, 0xff},
+		{0xdb, 0x27, 0x77, 0xff},
+		{0x65, 0xa3, 0x0d, 0xff},
+		{0x93, 0x33, 0xea, 0xff},
+		{0x0e, 0x74, 0x90, 0xff},
+	}
+)
+
+// 余白[px] (右に価格軸、下に時間軸の目盛りを描画する)
+const (
+	marginLeft   = 10
+	marginTop    = 24
+	marginRight  = 80
+	marginBottom = 30
+
+	// 時間軸のラベル1個あたりに確保する幅
+	timeLabelSpacing = 130
+	// 価格軸の目盛りの最大数
+	maxPriceTicks = 10
+)
+
+// canvas 描画先 (座標は左上を原点とするpx単位)
+type canvas interface {
+	line(x1, y1, x2, y2 float64, c color.RGBA, width float64)
+	rect(x, y, w, h float64, c color.RGBA)
+	circle(x, y, r float64, c color.RGBA)
+	// text 文字列を描画する。yは文字列の垂直方向の中心、alignは水平方向の基準位置
+	text(x, y float64, s string, c color.RGBA, align textAlign)
+	encode(w io.Writer) error
+}
+
+type textAlign int
+
+const (
+	alignLeft textAlign = iota
+	alignCenter
+	alignRight
+)
+
+// Render チャートを指定した形式で描画する
+func (c *Chart) Render(format Format, w io.Writer) error {
+	if len(c.Candles) <= 0 {
+		return ErrNoCandles
+	}
+
+	var cv canvas
+	if format == FormatPNG {
+		cv = newPngCanvas(c.Width, c.Height)
+	} else {
+		cv = newSvgCanvas(c.Width, c.Height)
+	}
+
+	l := c.newLayout()
+	cv.rect(0, 0, float64(c.Width), float64(c.Height), colorBackground)
+	c.drawAxes(cv, l)
+	c.drawCandles(cv, l)
+	c.drawLines(cv, l)
+	c.drawZigzags(cv, l)
+	c.drawLegend(cv)
+
+	return cv.encode(w)
+}
+
+// layout ローソク足の位置と価格を画像上の座標に変換する
+type layout struct {
+	left, top, width, height float64
+	min, max                 float64
+	n                        int
+}
+
+func (c *Chart) newLayout() *layout {
+	l := &layout{
+		left:   marginLeft,
+		top:    marginTop,
+		width:  math.Max(1, float64(c.Width-marginLeft-marginRight)),
+		height: math.Max(1, float64(c.Height-marginTop-marginBottom)),
+		min:    math.Inf(1),
+		max:    math.Inf(-1),
+		n:      len(c.Candles),
+	}
+	for _, candle := range c.Candles {
+		l.min = math.Min(l.min, candle.Low)
+		l.max = math.Max(l.max, candle.High)
+	}
+	for _, line := range c.Lines {
+		for _, p := range line.Points {
+			if l.inRange(c.Candles, p.Time) {
+				l.min = math.Min(l.min, p.Value)
+				l.max = math.Max(l.max, p.Value)
+			}
+		}
+	}
+	// 上下に5%の余白を設ける
+	padding := (l.max - l.min) * 0.05
+	if padding <= 0 {
+		padding = math.Max(math.Abs(l.max)*0.01, 1e-9)
+	}
+	l.min -= padding
+	l.max += padding
+	return l
+}
+
+// inRange 時刻tがローソク足の期間内か
+func (l *layout) inRange(candles []common.Candle, t time.Time) bool {
+	return !t.Before(candles[0].Time) && !t.After(candles[len(candles)-1].Time)
+}
+
+// slot ローソク足1本あたりの幅
+func (l *layout) slot() float64 {
+	return l.width / float64(l.n)
+}
+
+// x ローソク足の位置(小数可)の中心のx座標
+func (l *layout) x(index float64) float64 {
+	return l.left + (index+0.5)*l.slot()
+}
+
+// y 価格のy座標
+func (l *layout) y(price float64) float64 {
+	return l.top + (l.max-price)/(l.max-l.min)*l.height
+}
+
+// indexAt 時刻tのローソク足の位置を返却する。ローソク足の間の時刻は前後のローソク足の位置で補間する
+func (l *layout) indexAt(candles []common.Candle, t time.Time) float64 {
+	for i := range candles {
+		if candles[i].Time.Equal(t) {
+			return float64(i)
+		}
+		if t.Before(candles[i].Time) {
+			if i <= 0 {
+				return 0
+			}
+			prev := candles[i-1].Time
+			return float64(i-1) + float64(t.Sub(prev))/float64(candles[i].Time.Sub(prev))
+		}
+	}
+	return float64(len(candles) - 1)
+}
+
+func (c *Chart) drawAxes(cv canvas, l *layout) {
+	right := l.left + l.width
+	bottom := l.top + l.height
+
+	// 価格の目盛り
+	step := niceStep((l.max - l.min) / 5)
+	digits := int(math.Max(0, -math.Floor(math.Log10(step))))
+	for _, v := range priceTicks(l.min, l.max, step) {
+		y := l.y(v)
+		cv.line(l.left, y, right, y, colorGrid, 1)
+		cv.text(right+6, y, strconv.FormatFloat(v, 'f', digits, 64), colorAxis, alignLeft)
+	}
+
+	// 時間の目盛り (最大6個。ラベルは描画領域に収め、重なる場合は省略する)
+	labels := int(math.Max(1, math.Min(math.Min(6, float64(l.n)), math.Floor(l.width/timeLabelSpacing))))
+	lastRight := math.Inf(-1)
+	for k := 0; k < labels; k++ {
+		i := int(math.Round(float64(k*(l.n-1)) / math.Max(1, float64(labels-1))))
+		x := l.x(float64(i))
+		cv.line(x, l.top, x, bottom, colorGrid, 1)
+
+		label := c.Candles[i].Time.UTC().Format("01/02 15:04")
+		half := float64(len(label)*textWidth) / 2
+		center := math.Max(l.left+half, math.Min(right-half, x))
+		if center-half < lastRight+textWidth {
+			continue
+		}
+		cv.text(center, bottom+14, label, colorAxis, alignCenter)
+		lastRight = center + half
+	}
+
+	// 枠線
+	cv.line(l.left, bottom, right, bottom, colorAxis, 1)
+	cv.line(right, l.top, right, bottom, colorAxis, 1)
+}
+
+func (c *Chart) drawCandles(cv canvas, l *layout) {
+	bodyWidth := math.Max(1, l.slot()*0.6)
+	for i, candle := range c.Candles {
+		col := colorUp
+		if candle.Close < candle.Open {
+			col = colorDown
+		}
+		x := l.x(float64(i))
+		// ヒゲ
+		cv.line(x, l.y(candle.High), x, l.y(candle.Low), col, 1)
+		// 実体 (始値と終値が同じ場合も1px描画する)
+		top := l.y(math.Max(candle.Open, candle.Close))
+		height := math.Max(1, l.y(math.Min(candle.Open, candle.Close))-top)
+		cv.rect(x-bodyWidth/2, top, bodyWidth, height, col)
+	}
+}
+
+func (c *Chart) drawLines(cv canvas, l *layout) {
+	for k, line := range c.Lines {
+		col := lineColor(line, k)
+		prevX, prevY, hasPrev := 0.0, 0.0, false
+		for _, p := range line.Points {
+			if !l.inRange(c.Candles, p.Time) {
+				hasPrev = false
+				continue
+			}
+			x, y := l.x(l.indexAt(c.Candles, p.Time)), l.y(p.Value)
+			if hasPrev {
+				cv.line(prevX, prevY, x, y, col, 1.5)
+			}
+			prevX, prevY, hasPrev = x, y, true
+		}
+	}
+}
+
+func (c *Chart) drawZigzags(cv canvas, l *layout) {
+	type pivot struct {
+		index int
+		peak  bool
+	}
+	pivots := map[pivot]struct{}{}
+	for _, z := range c.Zigzags {
+		if z.PeakIndex < 0 || len(c.Candles) <= z.PeakIndex || z.BottomIndex < 0 || len(c.Candles) <= z.BottomIndex {
+			continue
+		}
+		peakX, peakY := l.x(float64(z.PeakIndex)), l.y(c.Candles[z.PeakIndex].High)
+		bottomX, bottomY := l.x(float64(z.BottomIndex)), l.y(c.Candles[z.BottomIndex].Low)
+		cv.line(peakX, peakY, bottomX, bottomY, colorZigzag, 2)
+		pivots[pivot{z.PeakIndex, true}] = struct{}{}
+		pivots[pivot{z.BottomIndex, false}] = struct{}{}
+	}
+
+	// 天井と底の目印
+	for p := range pivots {
+		if p.peak {
+			cv.circle(l.x(float64(p.index)), l.y(c.Candles[p.index].High), 3.5, colorPeak)
+		} else {
+			cv.circle(l.x(float64(p.index)), l.y(c.Candles[p.index].Low), 3.5, colorBottom)
+		}
+	}
+}
+
+// drawLegend 重ねて描画した線の凡例を上部の余白に描画する
+func (c *Chart) drawLegend(cv canvas) {
+	x := float64(marginLeft)
+	for k, line := range c.Lines {
+		col := lineColor(line, k)
+		cv.rect(x, marginTop/2-2, 12, 4, col)
+		cv.text(x+16, marginTop/2, line.Name, colorAxis, alignLeft)
+		x += 16 + float64(len([]rune(line.Name)))*textWidth + 16
+	}
+}
+
+// lineColor 線の色を返却する。未指定または不正な場合は既定の色を返却する
+func lineColor(line Line, index int) color.RGBA {
+	if c, ok := ParseColor(line.Color); ok {
+		return c
+	}
+	return linePalette[index%len(linePalette)]
+}
+
+// ParseColor #rrggbb形式の色を解析する
+func ParseColor(s string) (color.RGBA, bool) {
+	var r, g, b uint8
+	if len(s) != 7 {
+		return color.RGBA{}, false
+	}
+	if n, err := fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b); err != nil || n != 3 {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{r, g, b, 0xff}, true
+}
+
+// priceTicks min以上max以下のstepの倍数を価格軸の目盛りとして昇順に返却する。
+// 浮動小数点の精度でstepを加算しても値が変わらない場合も終了するよう、最大maxPriceTicks個とする
+func priceTicks(min, max, step float64) []float64 {
+	ticks := []float64{}
+	first := math.Ceil(min / step)
+	for k := 0; k < maxPriceTicks; k++ {
+		v := (first + float64(k)) * step
+		if !(v <= max) || (0 < len(ticks) && v <= ticks[len(ticks)-1]) {
+			break
+		}
+		ticks = append(ticks, v)
+	}
+	return ticks
+}
+
+// niceStep 目盛りの間隔を1・2・5×10^nに丸める
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+	exp := math.Pow(10, math.Floor(math.Log10(raw)))
+	switch f := raw / exp; {
+	case f <= 1:
+		return exp
+	case f <= 2:
+		return 2 * exp
+	case f <= 5:
+		return 5 * exp
+	default:
+		return 10 * exp
+	}
+}
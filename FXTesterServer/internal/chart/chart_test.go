@@ -0,0 +1,186 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"fxtester/internal/algo"
+	"fxtester/internal/common"
+	"image/color"
+	"image/png"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testChart() *Chart {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := []common.Candle{
+		{Time: base, Open: 100, High: 101, Low: 99, Close: 100.5},
+		{Time: base.Add(1 * time.Hour), Open: 100.5, High: 103, Low: 100, Close: 102.5},
+		{Time: base.Add(2 * time.Hour), Open: 102.5, High: 102.8, Low: 98, Close: 98.5},
+		{Time: base.Add(3 * time.Hour), Open: 98.5, High: 100, Low: 97.5, Close: 99.5},
+	}
+	return &Chart{
+		Candles: candles,
+		Zigzags: []algo.ZigzagResult{
+			{StartTime: candles[1].Time, PeakIndex: 1, BottomIndex: 3, Kind: algo.Peak},
+		},
+		Lines: []Line{
+			{Name: "SMA2", Color: "#2563eb", Points: []Point{
+				{Time: base.Add(1 * time.Hour), Value: 101.5},
+				{Time: base.Add(2 * time.Hour), Value: 100.5},
+				{Time: base.Add(3 * time.Hour), Value: 99},
+				// 期間外の点は描画しない
+				{Time: base.Add(5 * time.Hour), Value: 50},
+			}},
+		},
+		Width:  400,
+		Height: 200,
+	}
+}
+
+func Test_Render_SVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testChart().Render(FormatSVG, &buf); err != nil {
+		t.Fatalf("Render()=%v", err)
+	}
+	svg := buf.String()
+
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="400" height="200"`) || !strings.HasSuffix(svg, `</svg>`) {
+		t.Errorf("svg=%v", svg)
+	}
+	for _, want := range []string{
+		// 陽線と陰線
+		`fill="#26a69a"`,
+		`fill="#ef5350"`,
+		// ジグザグの線と天井・底の目印
+		`stroke="#1e3a8a"`,
+		`fill="#d97706"`,
+		`fill="#7c3aed"`,
+		// 重ねた線と凡例
+		`stroke="#2563eb"`,
+		`>SMA2</text>`,
+		// 時間軸の目盛り
+		`>01/01 00:00</text>`,
+		`>01/01 03:00</text>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("svg does not contain %v", want)
+		}
+	}
+	// 重ねた線は期間内の3点を結ぶ2本の線分のみ
+	if n := strings.Count(svg, `stroke="#2563eb"`); n != 2 {
+		t.Errorf("count of line segments=%d want=2", n)
+	}
+}
+
+func Test_Render_PNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testChart().Render(FormatPNG, &buf); err != nil {
+		t.Fatalf("Render()=%v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode()=%v", err)
+	}
+
+	if b := img.Bounds(); b.Dx() != 400 || b.Dy() != 200 {
+		t.Errorf("bounds=%v", b)
+	}
+	// 左上は背景色
+	if got := color.RGBAModel.Convert(img.At(0, 0)); got != colorBackground {
+		t.Errorf("At(0, 0)=%v want=%v", got, colorBackground)
+	}
+	// 陽線・陰線の色が描画されていること
+	found := map[color.RGBA]bool{}
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			found[color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)] = true
+		}
+	}
+	for _, want := range []color.RGBA{colorUp, colorDown, colorZigzag, colorPeak, colorBottom} {
+		if !found[want] {
+			t.Errorf("color %v is not drawn", want)
+		}
+	}
+}
+
+func Test_Render_NoCandles(t *testing.T) {
+	c := &Chart{Width: 100, Height: 100}
+	if err := c.Render(FormatSVG, &bytes.Buffer{}); !errors.Is(err, ErrNoCandles) {
+		t.Errorf("Render()=%v want=%v", err, ErrNoCandles)
+	}
+}
+
+func Test_layout_indexAt(t *testing.T) {
+	c := testChart()
+	l := c.newLayout()
+	base := c.Candles[0].Time
+	tests := []struct {
+		t    time.Time
+		want float64
+	}{
+		{t: base, want: 0},
+		{t: base.Add(2 * time.Hour), want: 2},
+		{t: base.Add(90 * time.Minute), want: 1.5},
+		{t: base.Add(-time.Hour), want: 0},
+		{t: base.Add(10 * time.Hour), want: 3},
+	}
+	for _, tt := range tests {
+		if got := l.indexAt(c.Candles, tt.t); got != tt.want {
+			t.Errorf("indexAt(%v)=%v want=%v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func Test_niceStep(t *testing.T) {
+	for raw, want := range map[float64]float64{0.8: 1, 1.3: 2, 3: 5, 7: 10, 0.0023: 0.005, 150: 200} {
+		if got := niceStep(raw); 1e-12 < got-want || got-want < -1e-12 {
+			t.Errorf("niceStep(%v)=%v want=%v", raw, got, want)
+		}
+	}
+}
+
+func Test_priceTicks(t *testing.T) {
+	tests := []struct {
+		name           string
+		min, max, step float64
+		want           []float64
+	}{
+		{name: "test1_stepの倍数", min: 97.3, max: 103.2, step: 2, want: []float64{98, 100, 102}},
+		{name: "test2_最大数で打ち切る", min: 0, max: 100, step: 1, want: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{name: "test3_精度不足で値が変わらない", min: 1e17, max: 1e17 + 1000, step: 1, want: []float64{1e17}},
+		{name: "test4_範囲外", min: math.Inf(-1), max: math.Inf(1), step: math.Inf(1), want: []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := priceTicks(tt.min, tt.max, tt.step); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("priceTicks()=%v want=%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ParseColor(t *testing.T) {
+	tests := []struct {
+		s      string
+		want   color.RGBA
+		wantOk bool
+	}{
+		{s: "#2563eb", want: color.RGBA{0x25, 0x63, 0xeb, 0xff}, wantOk: true},
+		{s: "#FFFFFF", want: color.RGBA{0xff, 0xff, 0xff, 0xff}, wantOk: true},
+		{s: "2563eb"},
+		{s: "#2563e"},
+		{s: "#zz63eb"},
+		{s: ""},
+	}
+	for _, tt := range tests {
+		got, ok := ParseColor(tt.s)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("ParseColor(%v)=(%v, %v) want=(%v, %v)", tt.s, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"unicode"
+)
+
+// 文字の描画に使用するビットマップフォント (3×5ドット)。フォントに無い文字は空白として描画する
+var glyphs = map[rune][5]string{
+	'0': {"111", "101", "101", "101", "111"},
+	'1': {"010", "110", "010", "010", "111"},
+	'2': {"111", "001", "111", "100", "111"},
+	'3': {"111", "001", "111", "001", "111"},
+	'4': {"101", "101", "111", "001", "001"},
+	'5': {"111", "100", "111", "001", "111"},
+	'6': {"111", "100", "111", "101", "111"},
+	'7': {"111", "001", "001", "010", "010"},
+	'8': {"111", "101", "111", "101", "111"},
+	'9': {"111", "101", "111", "001", "111"},
+	'.': {"000", "000", "000", "000", "010"},
+	'-': {"000", "000", "111", "000", "000"},
+	':': {"000", "010", "000", "010", "000"},
+	'/': {"001", "001", "010", "100", "100"},
+	'(': {"010", "100", "100", "100", "010"},
+	')': {"010", "001", "001", "001", "010"},
+	'_': {"000", "000", "000", "000", "111"},
+	'A': {"010", "101", "111", "101", "101"},
+	'B': {"110", "101", "110", "101", "110"},
+	'C': {"011", "100", "100", "100", "011"},
+	'D': {"110", "101", "101", "101", "110"},
+	'E': {"111", "100", "110", "100", "111"},
+	'F': {"111", "100", "110", "100", "100"},
+	'G': {"011", "100", "101", "101", "011"},
+	'H': {"101", "101", "111", "101", "101"},
+	'I': {"111", "010", "010", "010", "111"},
+	'J': {"001", "001", "001", "101", "010"},
+	'K': {"101", "101", "110", "101", "101"},
+	'L': {"100", "100", "100", "100", "111"},
+	'M': {"101", "111", "111", "101", "101"},
+	'N': {"110", "101", "101", "101", "101"},
+	'O': {"010", "101", "101", "101", "010"},
+	'P': {"110", "101", "110", "100", "100"},
+	'Q': {"010", "101", "101", "110", "011"},
+	'R': {"110", "101", "110", "101", "101"},
+	'S': {"011", "100", "010", "001", "110"},
+	'T': {"111", "010", "010", "010", "010"},
+	'U': {"101", "101", "101", "101", "111"},
+	'V': {"101", "101", "101", "101", "010"},
+	'W': {"101", "101", "111", "111", "101"},
+	'X': {"101", "101", "010", "101", "101"},
+	'Y': {"101", "101", "010", "010", "010"},
+	'Z': {"111", "001", "010", "100", "111"},
+}
+
+// フォントの拡大率と1文字あたりの幅[px] (3ドット+字間1ドット)
+const (
+	glyphScale = 2
+	textWidth  = 4 * glyphScale
+)
+
+// pngCanvas 画像に直接描画する描画先
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+func newPngCanvas(width, height int) *pngCanvas {
+	return &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+}
+
+// fill 矩形を塗りつぶす (画像の範囲外は無視される)
+func (p *pngCanvas) fill(r image.Rectangle, c color.RGBA) {
+	draw.Draw(p.img, r, &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// line Bresenhamのアルゴリズムで線分を描画する。太さは各点に正方形を描画して表現する
+func (p *pngCanvas) line(x1, y1, x2, y2 float64, c color.RGBA, width float64) {
+	size := int(math.Max(1, math.Round(width)))
+	offset := (size - 1) / 2
+	ix1, iy1, ix2, iy2 := int(math.Round(x1)), int(math.Round(y1)), int(math.Round(x2)), int(math.Round(y2))
+
+	dx, dy := abs(ix2-ix1), -abs(iy2-iy1)
+	sx, sy := sign(ix2-ix1), sign(iy2-iy1)
+	e := dx + dy
+	for {
+		p.fill(image.Rect(ix1-offset, iy1-offset, ix1-offset+size, iy1-offset+size), c)
+		if ix1 == ix2 && iy1 == iy2 {
+			return
+		}
+		e2 := 2 * e
+		if dy <= e2 {
+			e += dy
+			ix1 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			iy1 += sy
+		}
+	}
+}
+
+func (p *pngCanvas) rect(x, y, w, h float64, c color.RGBA) {
+	x0, y0 := int(math.Round(x)), int(math.Round(y))
+	p.fill(image.Rect(x0, y0, x0+int(math.Max(1, math.Round(w))), y0+int(math.Max(1, math.Round(h)))), c)
+}
+
+func (p *pngCanvas) circle(x, y, r float64, c color.RGBA) {
+	for py := int(math.Floor(y - r)); py <= int(math.Ceil(y+r)); py++ {
+		for px := int(math.Floor(x - r)); px <= int(math.Ceil(x+r)); px++ {
+			if dx, dy := float64(px)-x, float64(py)-y; dx*dx+dy*dy <= r*r {
+				p.img.SetRGBA(px, py, c)
+			}
+		}
+	}
+}
+
+func (p *pngCanvas) text(x, y float64, s string, c color.RGBA, align textAlign) {
+	runes := []rune(s)
+	width := float64(len(runes)*textWidth - glyphScale)
+	switch align {
+	case alignCenter:
+		x -= width / 2
+	case alignRight:
+		x -= width
+	}
+	left, top := int(math.Round(x)), int(math.Round(y))-5*glyphScale/2
+	for i, r := range runes {
+		glyph, ok := glyphs[unicode.ToUpper(r)]
+		if !ok {
+			continue
+		}
+		for row, bits := range glyph {
+			for col, bit := range bits {
+				if bit != '1' {
+					continue
+				}
+				px, py := left+i*textWidth+col*glyphScale, top+row*glyphScale
+				p.fill(image.Rect(px, py, px+glyphScale, py+glyphScale), c)
+			}
+		}
+	}
+}
+
+func (p *pngCanvas) encode(w io.Writer) error {
+	return png.Encode(w, p.img)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case 0 < v:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,56 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+)
+
+// svgCanvas SVGの要素を順に書き出す描画先
+type svgCanvas struct {
+	buf bytes.Buffer
+}
+
+func newSvgCanvas(width, height int) *svgCanvas {
+	s := &svgCanvas{}
+	fmt.Fprintf(&s.buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`,
+		width, height, width, height)
+	return s
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func (s *svgCanvas) line(x1, y1, x2, y2 float64, c color.RGBA, width float64) {
+	fmt.Fprintf(&s.buf, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="%s" stroke-width="%.2g"/>`, x1, y1, x2, y2, hex(c), width)
+}
+
+func (s *svgCanvas) rect(x, y, w, h float64, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s"/>`, x, y, w, h, hex(c))
+}
+
+func (s *svgCanvas) circle(x, y, r float64, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<circle cx="%.2f" cy="%.2f" r="%.2g" fill="%s"/>`, x, y, r, hex(c))
+}
+
+func (s *svgCanvas) text(x, y float64, str string, c color.RGBA, align textAlign) {
+	anchor := "start"
+	switch align {
+	case alignCenter:
+		anchor = "middle"
+	case alignRight:
+		anchor = "end"
+	}
+	fmt.Fprintf(&s.buf, `<text x="%.2f" y="%.2f" fill="%s" text-anchor="%s" dominant-baseline="central">`, x, y, hex(c), anchor)
+	xml.EscapeText(&s.buf, []byte(str))
+	s.buf.WriteString(`</text>`)
+}
+
+func (s *svgCanvas) encode(w io.Writer) error {
+	s.buf.WriteString(`</svg>`)
+	_, err := s.buf.WriteTo(w)
+	return err
+}
@@ -43,6 +43,12 @@ type Config struct {
 		MaxLifeTimeBySec int `yaml:"maxLifeTimeBySec"`
 	} `yaml:"db"`
 
+	// 認証設定
+	Auth struct {
+		// ログインに使用するidPのプロトコル (saml | oidc)。未指定の場合はsaml
+		Provider string `yaml:"provider"`
+	} `yaml:"auth"`
+
 	// SAML設定
 	Saml struct {
 		Keycloak struct {
@@ -59,12 +65,25 @@ type Config struct {
 				Username string `yaml:"username"`
 				Password string `yaml:"password"`
 				Email    string `yaml:"email"`
+				// ユーザに割り当てるrealmのロール (e.g. admin)
+				Roles []string `yaml:"roles"`
 			} `yaml:"newUsers"`
 			NewClientId string `yaml:"newClientId"`
+			// realmの宣言ファイルのパス (cmd/keycloakで適用する)
+			RealmSpecPath string `yaml:"realmSpecPath"`
 		} `yaml:"keycloak"`
 
-		// idpのmetadata.xmlを返却するURLもしくはファイルパス
+		// idpのmetadata.xmlを返却するURLもしくはファイルパス (既定のidP。名前はdefaultとなる)
 		IdpMetadataUrl string `yaml:"idpMetadataUrl"`
+		// 既定のidP以外に使用するidP (チーム毎にrealmが異なる場合等)
+		Idps []SamlIdpConfig `yaml:"idps"`
+		// idPのメタデータの定期的な再取得 (署名証明書のローテーション対策)
+		MetadataRefresh struct {
+			// 再取得の間隔(秒)。メタデータのcacheDuration・validUntilの方が早い場合はそちらに従う。0の場合は再取得しない
+			IntervalSec int `yaml:"intervalSec"`
+			// 再取得に失敗した場合に再試行するまでの間隔(秒)
+			RetryIntervalSec int `yaml:"retryIntervalSec"`
+		} `yaml:"metadataRefresh"`
 		// ルートURL (リダイレクト先のベースURL)
 		RootURL string `yaml:"rootURL"`
 		// SAMLクライアントのEntityId
@@ -77,8 +96,90 @@ type Config struct {
 		ValidPostLogoutRedirectURI string `yaml:"validPostLogoutRedirectURI"`
 		// Logout Service POST Binding URL
 		LogoutServicePostBindingURL string `yaml:"logoutServicePostBindingURL"`
+		// idPに送信するAuthnRequest・LogoutRequest・LogoutResponseのバインディング (post | redirect)。
+		// idPのメタデータに指定したバインディングのエンドポイントが無い場合はもう一方を使用する。未指定の場合はpost
+		Binding string `yaml:"binding"`
+		// SPの署名・暗号化用の鍵ペア。指定した場合はAuthnRequest・LogoutRequestに署名し、暗号化されたアサーションを復号する
+		Sp struct {
+			// 証明書(PEM)のパス
+			CertPath string `yaml:"certPath"`
+			// 秘密鍵(PEM。RSAのみ)のパス
+			KeyPath string `yaml:"keyPath"`
+			// 署名アルゴリズム (rsa-sha1 | rsa-sha256 | rsa-sha512)。未指定の場合はrsa-sha256
+			SignatureMethod string `yaml:"signatureMethod"`
+			// idPにアサーションの暗号化を要求する (cmd/keycloakでクライアントに設定する)
+			EncryptAssertions bool `yaml:"encryptAssertions"`
+		} `yaml:"sp"`
+		// 使用済みのSAMLアサーションのIDを保持するストア (memory | postgres)。
+		// 複数のサーバでACSを処理する場合はpostgresとする。未指定の場合はmemory
+		AssertionStore string `yaml:"assertionStore"`
+		// ロールを取り出すSAMLアサーションの属性名 (keycloakのrole listマッパーの属性名)
+		RoleAttribute string `yaml:"roleAttribute"`
+		// アプリケーションで使用するロール。idPのロールのうち一致するもののみユーザに保存する
+		Roles []string `yaml:"roles"`
+		// ログイン毎にユーザに同期するSAMLアサーションの属性名 (空の場合はその項目を同期しない)
+		AttributeMapping struct {
+			DisplayName string `yaml:"displayName"`
+			GivenName   string `yaml:"givenName"`
+			FamilyName  string `yaml:"familyName"`
+			Groups      string `yaml:"groups"`
+			Locale      string `yaml:"locale"`
+			// idPでアカウントが無効化されていることを示す属性。値がtrueの場合はログインさせずにプロビジョニングを解除する
+			Disabled string `yaml:"disabled"`
+		} `yaml:"attributeMapping"`
+		// idP起点のシングルログアウトで、ユーザのプロビジョニングを解除するLogoutRequestのReason
+		// (e.g. urn:oasis:names:tc:SAML:2.0:logout:admin)。指定した場合は署名の無いLogoutRequestを受け付けない
+		DeprovisionLogoutReasons []string `yaml:"deprovisionLogoutReasons"`
 	} `yaml:"saml"`
 
+	// OpenID Connect設定 (auth.providerがoidcの場合に使用する)
+	Oidc struct {
+		// OpenID ProviderのIssuer (ディスカバリは{issuer}/.well-known/openid-configurationから取得する)
+		Issuer string `yaml:"issuer"`
+		// OpenID Providerに登録したクライアントID
+		ClientId string `yaml:"clientId"`
+		// クライアントシークレット (パブリッククライアントの場合は空文字とし、PKCEのみで認証する)
+		ClientSecret string `yaml:"clientSecret"`
+		// 認可コードを受け取るコールバックURL (e.g. https://fx-tester-be:8000/oidc/callback)
+		RedirectURL string `yaml:"redirectURL"`
+		// 要求するスコープ (openidは常に要求する)
+		Scopes []string `yaml:"scopes"`
+		// ロールを取り出すIDトークンのクレーム名 (ネストしたクレームは.で区切る。e.g. realm_access.roles)
+		RoleClaim string `yaml:"roleClaim"`
+		// アプリケーションで使用するロール
+		Roles []string `yaml:"roles"`
+	} `yaml:"oidc"`
+
+	// JWTの署名鍵の設定 (鍵はDBに保存し、複数のサーバで共有する)
+	Jwt struct {
+		// 署名鍵を自動でローテーションする間隔(時間)。0の場合は自動でローテーションしない (cmd/jwtkeyで行う)
+		RotationIntervalHours int `yaml:"rotationIntervalHours"`
+		// ローテーション後も古い鍵による検証を許可する期間(時間)。リフレッシュトークンの有効期限以上とすること
+		GracePeriodHours int `yaml:"gracePeriodHours"`
+		// DBから署名鍵を再読み込みする間隔(秒)
+		ReloadIntervalSec int `yaml:"reloadIntervalSec"`
+		// 未知のkidで検証する場合にDBから署名鍵を再読み込みする最短の間隔(秒)
+		UnknownKidReloadIntervalSec int `yaml:"unknownKidReloadIntervalSec"`
+	} `yaml:"jwt"`
+
+	// 監査ログの設定
+	Audit struct {
+		// 監査イベントを保持する期間(日)。0の場合は削除しない
+		RetentionDays int `yaml:"retentionDays"`
+		// 保持期間を過ぎた監査イベントを削除する間隔(時間)
+		PruneIntervalHours int `yaml:"pruneIntervalHours"`
+	} `yaml:"audit"`
+
+	// 個人用APIトークンの設定
+	ApiToken struct {
+		// ユーザ毎に発行できるトークンの上限数
+		MaxPerUser int `yaml:"maxPerUser"`
+		// 有効期限の指定が無い場合の有効日数
+		DefaultExpiresDays int `yaml:"defaultExpiresDays"`
+		// 指定できる有効日数の上限
+		MaxExpiresDays int `yaml:"maxExpiresDays"`
+	} `yaml:"apiToken"`
+
 	// 辞書設定
 	Dict struct {
 		// 辞書ファイルのパス
@@ -90,6 +191,161 @@ type Config struct {
 		// 最大接続数
 		MaxConnections int `yaml:"maxConnections"`
 	} `yaml:"websocket"`
+
+	// シンボルのメタデータ (キーはシンボル名 e.g. USDJPY)。起動時にDBに登録される
+	Symbols map[string]SymbolConfig `yaml:"symbols"`
+
+	// バックテスト設定
+	Backtest struct {
+		// 口座設定の初期値 (リクエストで上書き可能)
+		Account struct {
+			// 初期の口座残高(口座通貨建て)
+			InitialBalance float64 `yaml:"initialBalance"`
+			// レバレッジ
+			Leverage float64 `yaml:"leverage"`
+			// マージンコールとなる証拠金維持率[%]
+			MarginCallLevel float64 `yaml:"marginCallLevel"`
+			// ロスカットとなる証拠金維持率[%]
+			StopOutLevel float64 `yaml:"stopOutLevel"`
+		} `yaml:"account"`
+		// シンボル毎の取引コスト設定 (キーはシンボル名 e.g. USDJPY)
+		Symbols map[string]BacktestSymbolConfig `yaml:"symbols"`
+		// 保存したバックテストの比較の設定
+		Compare struct {
+			// 一度に比較できるバックテストの最大数
+			MaxRuns int `yaml:"maxRuns"`
+			// 資産曲線の時間軸の最大点数
+			MaxPoints int `yaml:"maxPoints"`
+		} `yaml:"compare"`
+	} `yaml:"backtest"`
+
+	// チャート描画設定
+	Chart struct {
+		// 画像の大きさの既定値[px]
+		DefaultWidth  int `yaml:"defaultWidth"`
+		DefaultHeight int `yaml:"defaultHeight"`
+		// 画像の大きさの上限[px]
+		MaxWidth  int `yaml:"maxWidth"`
+		MaxHeight int `yaml:"maxHeight"`
+	} `yaml:"chart"`
+}
+
+// SymbolConfig シンボルのメタデータ
+type SymbolConfig struct {
+	// 基軸通貨 (e.g. USDJPYの場合はUSD)
+	Base string `yaml:"base"`
+	// 決済通貨 (e.g. USDJPYの場合はJPY)
+	Quote string `yaml:"quote"`
+	// 価格の小数点以下の桁数 (e.g. 3)
+	Digits int `yaml:"digits"`
+	// 1pipの価格幅 (e.g. 0.01)
+	PipSize float64 `yaml:"pipSize"`
+	// 1ロットあたりの通貨量 (e.g. 100000)
+	ContractSize float64 `yaml:"contractSize"`
+	// 取引セッション
+	Sessions []SymbolSessionConfig `yaml:"sessions"`
+}
+
+// SymbolSessionConfig シンボルの取引セッション
+type SymbolSessionConfig struct {
+	// セッション名 (e.g. tokyo)
+	Name string `yaml:"name"`
+	// 開始時刻 (UTCのHH:MM)
+	Open string `yaml:"open"`
+	// 終了時刻 (UTCのHH:MM)
+	Close string `yaml:"close"`
+}
+
+// SamlIdpConfig SAMLのidP毎の設定
+type SamlIdpConfig struct {
+	// idPの名前 (/saml/login?idp=で指定する)
+	Name string `yaml:"name"`
+	// idpのmetadata.xmlを返却するURLもしくはファイルパス
+	IdpMetadataUrl string `yaml:"idpMetadataUrl"`
+	// このidPでログインさせるユーザのEmailのドメイン (e.g. example.com)
+	EmailDomains []string `yaml:"emailDomains"`
+}
+
+// DefaultSamlIdpName saml.idpMetadataUrlで指定した既定のidPの名前
+const DefaultSamlIdpName = "default"
+
+// SamlIdps 使用するSAMLのidPを返却する。saml.idpMetadataUrlを指定した場合は既定のidPとして先頭に含める
+func (c *Config) SamlIdps() []SamlIdpConfig {
+	idps := []SamlIdpConfig{}
+	if c.Saml.IdpMetadataUrl != "" {
+		idps = append(idps, SamlIdpConfig{
+			Name:           DefaultSamlIdpName,
+			IdpMetadataUrl: c.Saml.IdpMetadataUrl,
+		})
+	}
+	return append(idps, c.Saml.Idps...)
+}
+
+// BacktestSymbolConfig シンボル毎のバックテスト設定
+type BacktestSymbolConfig struct {
+	// 取引数量の刻み (e.g. 0.01)
+	LotStep float64 `yaml:"lotStep"`
+	// 最小取引数量
+	MinLot float64 `yaml:"minLot"`
+	// 最大取引数量 (0の場合は上限無し)
+	MaxLot float64 `yaml:"maxLot"`
+	// スプレッド設定
+	Spread struct {
+		// スプレッドのタイプ (fixed: 固定, perCandle: ローソク足毎)
+		Type string `yaml:"type"`
+		// 固定スプレッド(価格単位)。perCandleの場合はローソク足にスプレッドが無い時に使用する
+		Value float64 `yaml:"value"`
+	} `yaml:"spread"`
+	// スリッページ設定
+	Slippage struct {
+		// スリッページのタイプ (none: 無し, random: ランダム, volatility: ボラティリティ比例)
+		Type string `yaml:"type"`
+		// randomの場合の最大スリッページ(価格単位)
+		Max float64 `yaml:"max"`
+		// volatilityの場合の直前ローソク足の値幅に対する係数
+		Factor float64 `yaml:"factor"`
+		// randomの場合の乱数シード (結果を再現可能にするため)
+		Seed int64 `yaml:"seed"`
+	} `yaml:"slippage"`
+	// 1ロットあたりの片道手数料(決済通貨建て)
+	CommissionPerLot float64 `yaml:"commissionPerLot"`
+	// スワップ設定
+	Swap struct {
+		// 買いポジション1ロットあたりの1日のスワップ(決済通貨建て、受取りは正)
+		LongPerLot float64 `yaml:"longPerLot"`
+		// 売りポジション1ロットあたりの1日のスワップ(決済通貨建て、受取りは正)
+		ShortPerLot float64 `yaml:"shortPerLot"`
+		// ロールオーバーの時刻 (UTCの時)
+		RolloverHour int `yaml:"rolloverHour"`
+		// 3日分のスワップが付与される曜日 (e.g. wednesday)
+		TripleDay string `yaml:"tripleDay"`
+	} `yaml:"swap"`
+}
+
+// idPに送信するSAMLメッセージのバインディング (saml.binding)
+const (
+	SamlBindingPost     = "post"
+	SamlBindingRedirect = "redirect"
+)
+
+// 使用済みのSAMLアサーションのIDを保持するストア (saml.assertionStore)
+const (
+	SamlAssertionStoreMemory   = "memory"
+	SamlAssertionStorePostgres = "postgres"
+)
+
+// ログインに使用するidPのプロトコル (auth.provider)
+const (
+	AuthProviderSaml = "saml"
+	AuthProviderOidc = "oidc"
+)
+
+// IsAuthProvider ログインに使用するidPのプロトコルが指定したものか返却する (未指定の場合はsamlとする)
+func (c *Config) IsAuthProvider(provider string) bool {
+	if c.Auth.Provider == "" {
+		return provider == AuthProviderSaml
+	}
+	return c.Auth.Provider == provider
 }
 
 var once sync.Once
@@ -11,3 +11,9 @@ var RegexISO8601 = regexp.MustCompile(`^\d{4}-(?:0[1-9]|1[0-2])-(?:0[1-9]|[1-2][
 
 // RegexMT4Date MT4フォーマットの日付
 var RegexMT4Date = regexp.MustCompile(`^(\d{4})\.(0[1-9]|1[0-2]).(0[1-9]|[1-2][0-9]|3[0-1])(?:\s+([0-1][0-9]|2[0-3]):([0-5][0-9]))?$`)
+
+// RegexCurrency 通貨コード (ISO 4217)
+var RegexCurrency = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// RegexHexColor #rrggbb形式の色
+var RegexHexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Code-Hex/synchro/iso8601"
@@ -13,7 +14,17 @@ import (
 var ErrUnknownTimeFormat = errors.New("unknown time format")
 
 func ToTime(v string) (*time.Time, error) {
-	t, err := iso8601.ParseDateTime(v)
+	return ToTimeInLocation(v, time.Local)
+}
+
+// ToTimeInLocation 時間の文字列を解析する。タイムゾーンの指定が無い場合はlocのタイムゾーンとして解釈する
+func ToTimeInLocation(v string, loc *time.Location) (*time.Time, error) {
+	t, err := iso8601.ParseDateTime(v, iso8601.WithInLocation(loc))
+	if i := strings.IndexByte(v, 'T'); err == nil && 0 <= i && !strings.ContainsAny(v[i:], "Z+-") {
+		// タイムゾーンの指定が無い場合はUTCとして解析されるため、locの時間として解釈し直す
+		u := t.UTC()
+		t = time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), u.Nanosecond(), loc)
+	}
 	if err != nil {
 		matches := RegexMT4Date.FindStringSubmatch(v)
 		if len(matches) <= 0 {
@@ -41,7 +52,18 @@ func ToTime(v string) (*time.Time, error) {
 		if err != nil {
 			return nil, err
 		}
-		t = time.Date(year, time.Month(month), day, hour, min, 0, 0, time.Local)
+		t = time.Date(year, time.Month(month), day, hour, min, 0, 0, loc)
 	}
 	return &t, nil
 }
+
+// Timeframes 時間足の名前と期間の対応表
+var Timeframes = map[string]time.Duration{
+	"M1":  time.Minute,
+	"M5":  5 * time.Minute,
+	"M15": 15 * time.Minute,
+	"M30": 30 * time.Minute,
+	"H1":  time.Hour,
+	"H4":  4 * time.Hour,
+	"D1":  24 * time.Hour,
+}
@@ -12,6 +12,8 @@ type Candle struct {
 	Open  float64
 	Close float64
 	Low   float64
+	// スプレッド(価格単位)。0の場合は未指定
+	Spread float64
 }
 
 func (c *Candle) BoxMax() float64 {
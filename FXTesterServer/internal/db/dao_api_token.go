@@ -0,0 +1,125 @@
+package db
+
+import (
+	"fxtester/internal/lang"
+
+	"github.com/lib/pq"
+)
+
+// IApiTokenEntityDao 個人用APIトークンの操作。
+// 認証ミドルウェアがログインセッションと同じDAOでトークンを検証できるよう、UserEntityDaoで実装する
+type IApiTokenEntityDao interface {
+	CreateApiToken(token *ApiTokenEntity) error
+	SelectApiTokens(userId int64) ([]ApiTokenEntity, error)
+	FindApiToken(tokenHash string) (*ApiTokenEntity, *UserEntity, error)
+	DeleteApiToken(userId int64, tokenId string) error
+}
+
+// CreateApiToken 個人用APIトークンを追加する。同じユーザに同じ名前のトークンが存在する場合はErrAlreadyExistsを返却する
+func (u *UserEntityDao) CreateApiToken(token *ApiTokenEntity) error {
+	rows, err := u.IDaoBase.Query("select fxtester_schema.create_api_token($1, $2, $3, $4, $5, $6, $7)",
+		token.TokenId,
+		token.UserId,
+		token.Name,
+		token.TokenHash,
+		token.TokenPrefix,
+		pq.Array(token.Scopes),
+		token.ExpiresAt,
+	)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var created bool
+	if err := rows.Scan(&created); err != nil {
+		return lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	if !created {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// SelectApiTokens 指定ユーザの個人用APIトークンを作成日時の新しい順で返却する (ハッシュ値は取得しない)
+func (u *UserEntityDao) SelectApiTokens(userId int64) ([]ApiTokenEntity, error) {
+	sql := `
+		select
+			id,
+			name,
+			token_prefix,
+			scopes,
+			created_at,
+			expires_at,
+			last_used_at
+		from fxtester_schema.select_api_tokens($1)
+	`
+	rows, err := u.IDaoBase.Query(sql, userId)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	tokens := []ApiTokenEntity{}
+	for rows.Next() {
+		token := ApiTokenEntity{UserId: userId}
+		if err := rows.Scan(&token.TokenId, &token.Name, &token.TokenPrefix, pq.Array(&token.Scopes), &token.CreatedAt, &token.ExpiresAt, &token.LastUsedAt); err != nil {
+			return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// FindApiToken ハッシュ値が一致する有効期限内の個人用APIトークンと、その所有ユーザを返却する。
+// 該当するトークンが無い場合はErrNoDataを返却する
+func (u *UserEntityDao) FindApiToken(tokenHash string) (*ApiTokenEntity, *UserEntity, error) {
+	sql := `
+		select
+			id,
+			user_id,
+			email,
+			roles,
+			scopes
+		from fxtester_schema.find_api_token($1)
+	`
+	rows, err := u.IDaoBase.Query(sql, tokenHash)
+	if err != nil {
+		return nil, nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, nil, ErrNoData
+	}
+
+	token := ApiTokenEntity{TokenHash: tokenHash}
+	var user UserEntity
+	if err := rows.Scan(&token.TokenId, &user.UserId, &user.Email, pq.Array(&user.Roles), pq.Array(&token.Scopes)); err != nil {
+		return nil, nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	token.UserId = user.UserId
+	return &token, &user, nil
+}
+
+// DeleteApiToken 指定ユーザの個人用APIトークンを削除する。該当するトークンが無い場合はErrNoDataを返却する
+func (u *UserEntityDao) DeleteApiToken(userId int64, tokenId string) error {
+	rows, err := u.IDaoBase.Query("select fxtester_schema.delete_api_token($1, $2)", userId, tokenId)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var deleted bool
+	if err := rows.Scan(&deleted); err != nil {
+		return lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	if !deleted {
+		return ErrNoData
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"fxtester/internal/lang"
+	"time"
+)
+
+type IAuditEventEntityDao interface {
+	IDaoBase
+	InsertAuditEvent(event *AuditEventEntity) error
+	SelectAuditEvents(filter *AuditEventFilter) ([]AuditEventEntity, error)
+	CountAuditEvents(filter *AuditEventFilter) (int64, error)
+	DeleteAuditEvents(retention time.Duration) (int64, error)
+}
+
+type AuditEventEntityDao struct {
+	IDaoBase
+}
+
+func NewAuditEventEntityDao(idb IDB) IAuditEventEntityDao {
+	return &AuditEventEntityDao{
+		IDaoBase: &DaoBase{
+			db: idb,
+		},
+	}
+}
+
+// InsertAuditEvent 監査イベントを追加する。ユーザがnilの場合はEmailからユーザを特定する
+func (a *AuditEventEntityDao) InsertAuditEvent(event *AuditEventEntity) error {
+	rows, err := a.IDaoBase.Query("call fxtester_schema.insert_audit_event($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		event.EventType,
+		event.Outcome,
+		event.UserId,
+		event.Email,
+		event.IpAddress,
+		event.UserAgent,
+		event.Route,
+		event.ErrorCode,
+		event.Detail,
+	)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	return nil
+}
+
+// SelectAuditEvents 条件に一致する監査イベントを発生日時の新しい順で返却する
+func (a *AuditEventEntityDao) SelectAuditEvents(filter *AuditEventFilter) ([]AuditEventEntity, error) {
+	sql := `
+		select
+			id,
+			occurred_at,
+			event_type,
+			outcome,
+			user_id,
+			email,
+			ip_address,
+			user_agent,
+			route,
+			error_code,
+			detail
+		from fxtester_schema.select_audit_events($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	rows, err := a.IDaoBase.Query(sql,
+		filter.EventType,
+		filter.Outcome,
+		filter.UserId,
+		filter.Email,
+		filter.From,
+		filter.To,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	events := []AuditEventEntity{}
+	for rows.Next() {
+		event := AuditEventEntity{}
+		if err := rows.Scan(&event.EventId, &event.OccurredAt, &event.EventType, &event.Outcome, &event.UserId,
+			&event.Email, &event.IpAddress, &event.UserAgent, &event.Route, &event.ErrorCode, &event.Detail); err != nil {
+			return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// CountAuditEvents 条件に一致する監査イベントの件数を返却する (LimitとOffsetは使用しない)
+func (a *AuditEventEntityDao) CountAuditEvents(filter *AuditEventFilter) (int64, error) {
+	rows, err := a.IDaoBase.Query("select fxtester_schema.count_audit_events($1, $2, $3, $4, $5, $6)",
+		filter.EventType,
+		filter.Outcome,
+		filter.UserId,
+		filter.Email,
+		filter.From,
+		filter.To,
+	)
+	if err != nil {
+		return 0, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var total int64
+	if err := rows.Scan(&total); err != nil {
+		return 0, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	return total, nil
+}
+
+// DeleteAuditEvents 発生してから保持期間を過ぎた監査イベントを削除し、削除した件数を返却する
+func (a *AuditEventEntityDao) DeleteAuditEvents(retention time.Duration) (int64, error) {
+	rows, err := a.IDaoBase.Query("select fxtester_schema.delete_audit_events($1)", int64(retention.Seconds()))
+	if err != nil {
+		return 0, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var deleted int64
+	if err := rows.Scan(&deleted); err != nil {
+		return 0, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	return deleted, nil
+}
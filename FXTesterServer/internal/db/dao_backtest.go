@@ -0,0 +1,262 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fxtester/internal/lang"
+
+	"github.com/lib/pq"
+)
+
+type IBacktestEntityDao interface {
+	IDaoBase
+	CreateRun(run *BacktestRunEntity) (int64, error)
+	InsertTrades(runId int64, trades []BacktestTradeEntity) error
+	InsertMetrics(runId int64, metrics []BacktestMetricEntity) error
+	SelectRuns(userId int64) ([]BacktestRunEntity, error)
+	SelectRun(userId, runId int64) (*BacktestRunEntity, error)
+	SelectTrades(runId int64) ([]BacktestTradeEntity, error)
+	ScanTrades(runId int64, fn func(t BacktestTradeEntity) error) error
+	SelectMetrics(runIds []int64) ([]BacktestMetricEntity, error)
+	DeleteRun(userId, runId int64) error
+	DeleteAnyRun(runId int64) error
+}
+
+type BacktestEntityDao struct {
+	IDaoBase
+}
+
+func NewBacktestEntityDao(idb IDB) IBacktestEntityDao {
+	return &BacktestEntityDao{
+		IDaoBase: &DaoBase{
+			db: idb,
+		},
+	}
+}
+
+// CreateRun backtest_runテーブルに新規レコードを追加し、追加したレコードのIDを返却する
+func (b *BacktestEntityDao) CreateRun(run *BacktestRunEntity) (int64, error) {
+	rows, err := b.IDaoBase.Query("select fxtester_schema.create_backtest_run($1, $2, $3, $4)",
+		run.UserId, run.Kind, string(run.Parameters), string(run.Datasets))
+	if err != nil {
+		return 0, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(rows.Err())
+	}
+	var newId int64
+	if err := rows.Scan(&newId); err != nil {
+		return 0, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	return newId, nil
+}
+
+// InsertTrades backtest_tradeテーブルに取引を追加する
+func (b *BacktestEntityDao) InsertTrades(runId int64, trades []BacktestTradeEntity) error {
+	bytes, err := json.Marshal(trades)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	rows, err := b.IDaoBase.Query("call fxtester_schema.insert_backtest_trades($1, $2)", runId, string(bytes))
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	return nil
+}
+
+// InsertMetrics backtest_metricテーブルに集計値を追加する
+func (b *BacktestEntityDao) InsertMetrics(runId int64, metrics []BacktestMetricEntity) error {
+	bytes, err := json.Marshal(metrics)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	rows, err := b.IDaoBase.Query("call fxtester_schema.insert_backtest_metrics($1, $2)", runId, string(bytes))
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	return nil
+}
+
+// SelectRuns 指定ユーザのバックテストの実行履歴を実行日時の新しい順に返却する
+func (b *BacktestEntityDao) SelectRuns(userId int64) ([]BacktestRunEntity, error) {
+	sql := `
+		select
+			id,
+			user_id,
+			kind,
+			parameters,
+			datasets,
+			created_at
+		from fxtester_schema.select_backtest_runs($1)
+	`
+	rows, err := b.IDaoBase.Query(sql, userId)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	runs := []BacktestRunEntity{}
+	for rows.Next() {
+		var run BacktestRunEntity
+		if err := rows.Scan(&run.RunId, &run.UserId, &run.Kind, &run.Parameters, &run.Datasets, &run.CreatedAt); err != nil {
+			return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+
+	return runs, nil
+}
+
+// SelectRun 指定ユーザが所有する指定IDのバックテストの実行履歴を返却する。存在しない場合はErrNoDataを返却する
+func (b *BacktestEntityDao) SelectRun(userId, runId int64) (*BacktestRunEntity, error) {
+	sql := `
+		select
+			id,
+			user_id,
+			kind,
+			parameters,
+			datasets,
+			created_at
+		from fxtester_schema.select_backtest_run($1, $2)
+	`
+	rows, err := b.IDaoBase.Query(sql, userId, runId)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, ErrNoData
+	}
+
+	var run BacktestRunEntity
+	if err := rows.Scan(&run.RunId, &run.UserId, &run.Kind, &run.Parameters, &run.Datasets, &run.CreatedAt); err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+
+	return &run, nil
+}
+
+// SelectTrades 指定したバックテストの取引を約定順に返却する
+func (b *BacktestEntityDao) SelectTrades(runId int64) ([]BacktestTradeEntity, error) {
+	trades := []BacktestTradeEntity{}
+	if err := b.ScanTrades(runId, func(t BacktestTradeEntity) error {
+		trades = append(trades, t)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return trades, nil
+}
+
+// ScanTrades 指定したバックテストの取引を約定順に1件ずつ読み込み、fnを呼び出す。
+// 全件をメモリに保持せずに処理するために使用し、fnがエラーを返却した場合は読み込みを中断してそのエラーを返却する
+func (b *BacktestEntityDao) ScanTrades(runId int64, fn func(t BacktestTradeEntity) error) error {
+	sql := `
+		select
+			seq,
+			symbol,
+			side,
+			lots,
+			entry_time,
+			entry_price,
+			exit_time,
+			exit_price,
+			exit_reason,
+			gross_profit,
+			spread_cost,
+			slippage_cost,
+			commission,
+			swap,
+			net_profit,
+			pips
+		from fxtester_schema.select_backtest_trades($1)
+	`
+	rows, err := b.IDaoBase.Query(sql, runId)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t BacktestTradeEntity
+		if err := rows.Scan(&t.Seq, &t.Symbol, &t.Side, &t.Lots, &t.EntryTime, &t.EntryPrice, &t.ExitTime, &t.ExitPrice, &t.ExitReason,
+			&t.GrossProfit, &t.SpreadCost, &t.SlippageCost, &t.Commission, &t.Swap, &t.NetProfit, &t.Pips); err != nil {
+			return lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+
+	return nil
+}
+
+// SelectMetrics 指定したバックテスト(複数指定可)の集計値を返却する
+func (b *BacktestEntityDao) SelectMetrics(runIds []int64) ([]BacktestMetricEntity, error) {
+	sql := `
+		select
+			run_id,
+			symbol,
+			name,
+			value
+		from fxtester_schema.select_backtest_metrics($1)
+	`
+	rows, err := b.IDaoBase.Query(sql, pq.Array(runIds))
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	metrics := []BacktestMetricEntity{}
+	for rows.Next() {
+		var m BacktestMetricEntity
+		if err := rows.Scan(&m.RunId, &m.Symbol, &m.Name, &m.Value); err != nil {
+			return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+		}
+		metrics = append(metrics, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+
+	return metrics, nil
+}
+
+// DeleteRun 指定ユーザが所有する指定IDのバックテストを削除する。存在しない場合はErrNoDataを返却する
+func (b *BacktestEntityDao) DeleteRun(userId, runId int64) error {
+	return b.deleteRun(sql.NullInt64{Int64: userId, Valid: true}, runId)
+}
+
+// DeleteAnyRun 所有者に関わらず指定IDのバックテストを削除する (管理者用)。存在しない場合はErrNoDataを返却する
+func (b *BacktestEntityDao) DeleteAnyRun(runId int64) error {
+	return b.deleteRun(sql.NullInt64{}, runId)
+}
+
+func (b *BacktestEntityDao) deleteRun(userId sql.NullInt64, runId int64) error {
+	rows, err := b.IDaoBase.Query("select fxtester_schema.delete_backtest_run($1, $2)", userId, runId)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return lang.NewFxtError(lang.ErrDBQueryResult).SetCause(rows.Err())
+	}
+	var deleted int64
+	if err := rows.Scan(&deleted); err != nil {
+		return lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	if deleted <= 0 {
+		return ErrNoData
+	}
+	return nil
+}
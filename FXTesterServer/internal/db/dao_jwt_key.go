@@ -0,0 +1,93 @@
+package db
+
+import (
+	"database/sql"
+	"fxtester/internal/lang"
+	"time"
+)
+
+type IJwtSigningKeyEntityDao interface {
+	IDaoBase
+	SelectKeys() ([]JwtSigningKeyEntity, error)
+	RotateKey(purpose, kid string, secret []byte, minAge *time.Duration) (bool, error)
+	DeleteRetiredKeys(gracePeriod time.Duration) (int64, error)
+}
+
+type JwtSigningKeyEntityDao struct {
+	IDaoBase
+}
+
+func NewJwtSigningKeyEntityDao(idb IDB) IJwtSigningKeyEntityDao {
+	return &JwtSigningKeyEntityDao{
+		IDaoBase: &DaoBase{
+			db: idb,
+		},
+	}
+}
+
+// SelectKeys 全ての用途の署名鍵を作成日時の昇順で返却する
+func (j *JwtSigningKeyEntityDao) SelectKeys() ([]JwtSigningKeyEntity, error) {
+	sql := `
+		select
+			kid,
+			purpose,
+			secret,
+			created_at,
+			retired_at
+		from fxtester_schema.select_jwt_signing_keys()
+	`
+	rows, err := j.IDaoBase.Query(sql)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	keys := []JwtSigningKeyEntity{}
+	for rows.Next() {
+		var key JwtSigningKeyEntity
+		if err := rows.Scan(&key.Kid, &key.Purpose, &key.Secret, &key.CreatedAt, &key.RetiredAt); err != nil {
+			return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// RotateKey 用途の現在の署名鍵を退役させ、新しい署名鍵を追加する。追加した場合はtrueを返却する。
+// minAgeを指定した場合は現在の鍵がminAgeより古い場合のみ、nilの場合は現在の鍵が無い場合のみ追加する
+func (j *JwtSigningKeyEntityDao) RotateKey(purpose, kid string, secret []byte, minAge *time.Duration) (bool, error) {
+	var minAgeSec sql.NullInt64
+	if minAge != nil {
+		minAgeSec = sql.NullInt64{Int64: int64(minAge.Seconds()), Valid: true}
+	}
+	rows, err := j.IDaoBase.Query("select fxtester_schema.rotate_jwt_signing_key($1, $2, $3, $4)", purpose, kid, secret, minAgeSec)
+	if err != nil {
+		return false, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return false, lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var rotated bool
+	if err := rows.Scan(&rotated); err != nil {
+		return false, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	return rotated, nil
+}
+
+// DeleteRetiredKeys 退役してから猶予期間を過ぎた署名鍵を削除し、削除した件数を返却する
+func (j *JwtSigningKeyEntityDao) DeleteRetiredKeys(gracePeriod time.Duration) (int64, error) {
+	rows, err := j.IDaoBase.Query("select fxtester_schema.delete_retired_jwt_signing_keys($1)", int64(gracePeriod.Seconds()))
+	if err != nil {
+		return 0, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var deleted int64
+	if err := rows.Scan(&deleted); err != nil {
+		return 0, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,40 @@
+package db
+
+import (
+	"fxtester/internal/lang"
+	"time"
+)
+
+type ISamlAssertionEntityDao interface {
+	IDaoBase
+	ConsumeAssertion(assertionId string, expiresAt time.Time) (bool, error)
+}
+
+type SamlAssertionEntityDao struct {
+	IDaoBase
+}
+
+func NewSamlAssertionEntityDao(idb IDB) ISamlAssertionEntityDao {
+	return &SamlAssertionEntityDao{
+		IDaoBase: &DaoBase{
+			db: idb,
+		},
+	}
+}
+
+// ConsumeAssertion SAMLアサーションのIDを有効期限まで使用済みとして記録する。初めて使用された場合はtrue、使用済みの場合はfalseを返却する
+func (s *SamlAssertionEntityDao) ConsumeAssertion(assertionId string, expiresAt time.Time) (bool, error) {
+	rows, err := s.IDaoBase.Query("select fxtester_schema.consume_saml_assertion($1, $2)", assertionId, expiresAt)
+	if err != nil {
+		return false, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return false, lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var consumed bool
+	if err := rows.Scan(&consumed); err != nil {
+		return false, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	return consumed, nil
+}
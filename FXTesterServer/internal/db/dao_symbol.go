@@ -0,0 +1,69 @@
+package db
+
+import (
+	"fxtester/internal/lang"
+)
+
+type ISymbolEntityDao interface {
+	IDaoBase
+	UpsertSymbol(symbol *SymbolEntity) error
+	SelectSymbols() ([]SymbolEntity, error)
+}
+
+type SymbolEntityDao struct {
+	IDaoBase
+}
+
+func NewSymbolEntityDao(idb IDB) ISymbolEntityDao {
+	return &SymbolEntityDao{
+		IDaoBase: &DaoBase{
+			db: idb,
+		},
+	}
+}
+
+// UpsertSymbol symbolテーブルにレコードを追加する。登録済みの場合は更新する
+func (s *SymbolEntityDao) UpsertSymbol(symbol *SymbolEntity) error {
+	rows, err := s.IDaoBase.Query("call fxtester_schema.upsert_symbol($1, $2, $3, $4, $5, $6, $7)",
+		symbol.Name, symbol.BaseCurrency, symbol.QuoteCurrency, symbol.Digits, symbol.PipSize, symbol.ContractSize, string(symbol.Sessions))
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	return nil
+}
+
+// SelectSymbols 登録済みの全てのシンボルを返却する
+func (s *SymbolEntityDao) SelectSymbols() ([]SymbolEntity, error) {
+	sql := `
+		select
+			name,
+			base_currency,
+			quote_currency,
+			digits,
+			pip_size,
+			contract_size,
+			sessions
+		from fxtester_schema.select_symbols()
+	`
+	rows, err := s.IDaoBase.Query(sql)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	symbols := []SymbolEntity{}
+	for rows.Next() {
+		var symbol SymbolEntity
+		if err := rows.Scan(&symbol.Name, &symbol.BaseCurrency, &symbol.QuoteCurrency, &symbol.Digits,
+			&symbol.PipSize, &symbol.ContractSize, &symbol.Sessions); err != nil {
+			return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+		}
+		symbols = append(symbols, symbol)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+
+	return symbols, nil
+}
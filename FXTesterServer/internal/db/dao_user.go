@@ -3,23 +3,27 @@ package db
 import (
 	"errors"
 	"fxtester/internal/lang"
+
+	"github.com/lib/pq"
 )
 
 var ErrNoData = errors.New("no-data")
-
-type Token struct {
-	AccessToken  string
-	RefreshToken string
-}
+var ErrTokenMismatch = errors.New("token-mismatch")
+var ErrAlreadyExists = errors.New("already-exists")
 
 type IUserEntityDao interface {
 	IDaoBase
 	CreateUser(email string) (*UserEntity, error)
-	UpdateToken(userId int64, accessToken, refreshToken string) error
-	CheckAccessToken(userId int64, accessToken string) error
-	CheckRefreshToken(userId int64, refreshToken string) error
 	SelectWithUserId(userId int64) (*UserEntity, error)
 	SelectWithEmail(email string) (*UserEntity, error)
+	SelectUsers() ([]UserEntity, error)
+	UpdateRoles(userId int64, roles []string) error
+	UpdateAttributes(userId int64, attributes *UserAttributesEntity) error
+	DeprovisionUser(userId int64) error
+	SelectPreferences(userId int64) ([]byte, error)
+	UpdatePreferences(userId int64, preferences []byte) error
+	IUserSessionEntityDao
+	IApiTokenEntityDao
 }
 
 type UserEntityDao struct {
@@ -52,28 +56,42 @@ func (u *UserEntityDao) CreateUser(email string) (user *UserEntity, lastError er
 	return &UserEntity{
 		UserId: newId,
 		Email:  email,
+		Roles:  []string{},
 	}, nil
 }
 
-func (u *UserEntityDao) UpdateToken(userId int64, accessToken, refreshToken string) error {
-	rows, err := u.IDaoBase.Query("call fxtester_schema.update_token($1, $2, $3)", userId, accessToken, refreshToken)
+func (u *UserEntityDao) SelectWithUserId(userId int64) (*UserEntity, error) {
+	sql := `
+		select
+			id,
+			email,
+			roles,
+			preferences,
+			display_name,
+			given_name,
+			family_name,
+			groups,
+			locale,
+			disabled_at
+		from fxtester_schema.select_user_with_id($1)
+	`
+	rows, err := u.IDaoBase.Query(sql, userId)
 	if err != nil {
-		return err
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
 	}
 	defer rows.Close()
-	return nil
-}
 
-func (u *UserEntityDao) CheckAccessToken(userId int64, accessToken string) error {
-	return errors.New("not implements")
-}
+	if !rows.Next() {
+		return nil, ErrNoData
+	}
 
-func (u *UserEntityDao) CheckRefreshToken(userId int64, refreshToken string) error {
-	return errors.New("not implements")
-}
+	var user UserEntity
+	if err := rows.Scan(&user.UserId, &user.Email, pq.Array(&user.Roles), &user.Preferences,
+		&user.DisplayName, &user.GivenName, &user.FamilyName, pq.Array(&user.Groups), &user.Locale, &user.DisabledAt); err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
 
-func (u *UserEntityDao) SelectWithUserId(userId int64) (*UserEntity, error) {
-	return nil, errors.New("not implements")
+	return &user, nil
 }
 
 func (u *UserEntityDao) SelectWithEmail(email string) (*UserEntity, error) {
@@ -81,8 +99,7 @@ func (u *UserEntityDao) SelectWithEmail(email string) (*UserEntity, error) {
 		select
 			id,
 			email,
-			access_token,
-			refresh_token
+			roles
 		from fxtester_schema.select_user_with_email($1)
 	`
 	rows, err := u.IDaoBase.Query(sql, email)
@@ -96,9 +113,107 @@ func (u *UserEntityDao) SelectWithEmail(email string) (*UserEntity, error) {
 	}
 
 	var user UserEntity
-	if err := rows.Scan(&user.UserId, &user.Email, &user.AccessToken, &user.RefreshToken); err != nil {
+	if err := rows.Scan(&user.UserId, &user.Email, pq.Array(&user.Roles)); err != nil {
 		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
 	}
 
 	return &user, nil
 }
+
+// SelectUsers 全てのユーザをIDの昇順で返却する
+func (u *UserEntityDao) SelectUsers() ([]UserEntity, error) {
+	sql := `
+		select
+			id,
+			email,
+			roles,
+			display_name,
+			given_name,
+			family_name,
+			groups,
+			locale,
+			disabled_at
+		from fxtester_schema.select_users()
+	`
+	rows, err := u.IDaoBase.Query(sql)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	users := []UserEntity{}
+	for rows.Next() {
+		var user UserEntity
+		if err := rows.Scan(&user.UserId, &user.Email, pq.Array(&user.Roles),
+			&user.DisplayName, &user.GivenName, &user.FamilyName, pq.Array(&user.Groups), &user.Locale, &user.DisabledAt); err != nil {
+			return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// UpdateRoles 指定ユーザのロールを置き換える
+func (u *UserEntityDao) UpdateRoles(userId int64, roles []string) error {
+	rows, err := u.IDaoBase.Query("call fxtester_schema.update_user_roles($1, $2)", userId, pq.Array(roles))
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	return nil
+}
+
+// UpdateAttributes 指定ユーザのSAMLアサーションから同期する属性を置き換え、プロビジョニングの解除を取り消す
+func (u *UserEntityDao) UpdateAttributes(userId int64, attributes *UserAttributesEntity) error {
+	var groups any
+	if attributes.Groups != nil {
+		groups = pq.Array(attributes.Groups)
+	}
+	rows, err := u.IDaoBase.Query("call fxtester_schema.update_user_attributes($1, $2, $3, $4, $5, $6)",
+		userId, attributes.DisplayName, attributes.GivenName, attributes.FamilyName, groups, attributes.Locale)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	return nil
+}
+
+// DeprovisionUser idPで無効化された指定ユーザのプロビジョニングを解除する (全てのログインセッションと個人用APIトークンを削除する)
+func (u *UserEntityDao) DeprovisionUser(userId int64) error {
+	rows, err := u.IDaoBase.Query("call fxtester_schema.deprovision_user($1)", userId)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	return nil
+}
+
+// SelectPreferences 指定ユーザの設定のJSONを返却する。ユーザが存在しない場合はErrNoDataを返却する
+func (u *UserEntityDao) SelectPreferences(userId int64) ([]byte, error) {
+	rows, err := u.IDaoBase.Query("select fxtester_schema.select_user_preferences($1)", userId)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var preferences []byte
+	if err := rows.Scan(&preferences); err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	if preferences == nil {
+		return nil, ErrNoData
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences 指定ユーザの設定に、JSONで指定した項目を上書きする
+func (u *UserEntityDao) UpdatePreferences(userId int64, preferences []byte) error {
+	rows, err := u.IDaoBase.Query("call fxtester_schema.update_user_preferences($1, $2)", userId, preferences)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	return nil
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"database/sql"
+	"fxtester/internal/lang"
+)
+
+// IUserSessionEntityDao ログインセッションの操作。
+// ユーザの作成とセッションの作成を1トランザクションで行えるよう、UserEntityDaoで実装する
+type IUserSessionEntityDao interface {
+	CreateSession(session *UserSessionEntity) error
+	UpdateSessionToken(sessionId, accessToken, refreshToken, ipAddress, userAgent string) error
+	CheckAccessToken(sessionId string, userId int64, accessToken string) error
+	CheckRefreshToken(sessionId string, userId int64, refreshToken string) error
+	SelectSessions(userId int64) ([]UserSessionEntity, error)
+	DeleteSession(userId int64, sessionId string) error
+	DeleteSessions(userId int64, samlSessionIndex string) (int64, error)
+}
+
+// CreateSession ログインセッションを追加する
+func (u *UserEntityDao) CreateSession(session *UserSessionEntity) error {
+	rows, err := u.IDaoBase.Query("call fxtester_schema.create_user_session($1, $2, $3, $4, $5, $6, $7, $8)",
+		session.SessionId,
+		session.UserId,
+		session.AccessToken,
+		session.RefreshToken,
+		session.Device,
+		session.IpAddress,
+		session.UserAgent,
+		session.SamlSessionIndex,
+	)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	return nil
+}
+
+// UpdateSessionToken ログインセッションのトークンを更新し、最終アクセスのIPアドレスとUser-Agentを記録する
+func (u *UserEntityDao) UpdateSessionToken(sessionId, accessToken, refreshToken, ipAddress, userAgent string) error {
+	rows, err := u.IDaoBase.Query("call fxtester_schema.update_user_session_token($1, $2, $3, $4, $5)", sessionId, accessToken, refreshToken, ipAddress, userAgent)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	return nil
+}
+
+// CheckAccessToken 指定ユーザのログインセッションのアクセストークンと一致するかチェックする。一致しない場合はErrTokenMismatchを返却する
+func (u *UserEntityDao) CheckAccessToken(sessionId string, userId int64, accessToken string) error {
+	rows, err := u.IDaoBase.Query("select fxtester_schema.check_session_access_token($1, $2, $3)", sessionId, userId, accessToken)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var matched bool
+	if err := rows.Scan(&matched); err != nil {
+		return lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	if !matched {
+		return ErrTokenMismatch
+	}
+	return nil
+}
+
+// CheckRefreshToken 指定ユーザのログインセッションのリフレッシュトークンと一致するかチェックする。
+// セッションが存在しない場合はErrNoData、一致しない場合はErrTokenMismatchを返却する。
+// トランザクション内で呼び出した場合はコミットまでセッションの行をロックする
+func (u *UserEntityDao) CheckRefreshToken(sessionId string, userId int64, refreshToken string) error {
+	rows, err := u.IDaoBase.Query("select fxtester_schema.check_session_refresh_token($1, $2, $3)", sessionId, userId, refreshToken)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var matched sql.NullBool
+	if err := rows.Scan(&matched); err != nil {
+		return lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	if !matched.Valid {
+		return ErrNoData
+	}
+	if !matched.Bool {
+		return ErrTokenMismatch
+	}
+	return nil
+}
+
+// SelectSessions 指定ユーザのログインセッションを最終アクセス日時の新しい順で返却する (トークンは取得しない)
+func (u *UserEntityDao) SelectSessions(userId int64) ([]UserSessionEntity, error) {
+	sql := `
+		select
+			id,
+			device,
+			ip_address,
+			user_agent,
+			created_at,
+			last_seen_at
+		from fxtester_schema.select_user_sessions($1)
+	`
+	rows, err := u.IDaoBase.Query(sql, userId)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+
+	sessions := []UserSessionEntity{}
+	for rows.Next() {
+		session := UserSessionEntity{UserId: userId}
+		if err := rows.Scan(&session.SessionId, &session.Device, &session.IpAddress, &session.UserAgent, &session.CreatedAt, &session.LastSeenAt); err != nil {
+			return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// DeleteSession 指定ユーザのログインセッションを削除する。該当するセッションが無い場合はErrNoDataを返却する
+func (u *UserEntityDao) DeleteSession(userId int64, sessionId string) error {
+	rows, err := u.IDaoBase.Query("select fxtester_schema.delete_user_session($1, $2)", userId, sessionId)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var deleted bool
+	if err := rows.Scan(&deleted); err != nil {
+		return lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	if !deleted {
+		return ErrNoData
+	}
+	return nil
+}
+
+// DeleteSessions 指定ユーザのSAMLのセッションインデックスが一致するログインセッションを削除し、削除した件数を返却する。
+// samlSessionIndexが空文字の場合はユーザの全てのログインセッションを削除する
+func (u *UserEntityDao) DeleteSessions(userId int64, samlSessionIndex string) (int64, error) {
+	rows, err := u.IDaoBase.Query("select fxtester_schema.delete_user_sessions($1, $2)", userId, samlSessionIndex)
+	if err != nil {
+		return 0, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, lang.NewFxtError(lang.ErrDBQueryResult)
+	}
+	var deleted int64
+	if err := rows.Scan(&deleted); err != nil {
+		return 0, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	return deleted, nil
+}
@@ -1,8 +1,155 @@
 package db
 
+import "time"
+
 type UserEntity struct {
+	UserId int64
+	Email  string
+	// SAMLアサーションから同期したロール
+	Roles []string
+	// ユーザの設定のJSON (SelectWithUserIdでのみ取得する)
+	Preferences []byte
+	// ここから SAMLアサーションから同期した属性 (SelectWithUserId・SelectUsersでのみ取得する)
+	DisplayName string
+	GivenName   string
+	FamilyName  string
+	Groups      []string
+	Locale      string
+	// ここまで
+	// idPで無効化されたためプロビジョニングを解除した日時 (nilの場合は有効)
+	DisabledAt *time.Time
+}
+
+// UserAttributesEntity SAMLアサーションからユーザに同期する属性 (nilの項目は更新しない)
+type UserAttributesEntity struct {
+	DisplayName *string
+	GivenName   *string
+	FamilyName  *string
+	Groups      []string
+	Locale      *string
+}
+
+// UserSessionEntity ログインセッション (ログイン毎に作成する)
+type UserSessionEntity struct {
+	SessionId    string
 	UserId       int64
-	Email        string
-	AccessToken  *string
-	RefreshToken *string
+	AccessToken  string
+	RefreshToken string
+	// User-Agentから判定した端末の説明
+	Device    string
+	IpAddress string
+	UserAgent string
+	// ログイン時のSAMLアサーションのセッションインデックス
+	SamlSessionIndex string
+	CreatedAt        time.Time
+	LastSeenAt       time.Time
+}
+
+// ApiTokenEntity 個人用APIトークン (トークン自体は保存せずハッシュ値のみ保存する)
+type ApiTokenEntity struct {
+	TokenId string
+	UserId  int64
+	Name    string
+	// トークンのSHA-256 (16進数)
+	TokenHash string
+	// 一覧でトークンを見分けるための先頭の数文字
+	TokenPrefix string
+	Scopes      []string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	// 未使用の場合はnil
+	LastUsedAt *time.Time
+}
+
+type SymbolEntity struct {
+	Name          string
+	BaseCurrency  string
+	QuoteCurrency string
+	Digits        int
+	PipSize       float64
+	ContractSize  float64
+	// 取引セッションのJSON配列
+	Sessions []byte
+}
+
+type BacktestRunEntity struct {
+	RunId  int64
+	UserId int64
+	// バックテストの種類 (single | portfolio)
+	Kind string
+	// 実行パラメータのJSON
+	Parameters []byte
+	// 入力データのJSON配列
+	Datasets  []byte
+	CreatedAt time.Time
+}
+
+// BacktestTradeEntity バックテストで約定した取引 (JSON配列に変換してストアドプロシージャーに渡す)
+type BacktestTradeEntity struct {
+	Seq          int       `json:"seq"`
+	Symbol       string    `json:"symbol"`
+	Side         string    `json:"side"`
+	Lots         float64   `json:"lots"`
+	EntryTime    time.Time `json:"entry_time"`
+	EntryPrice   float64   `json:"entry_price"`
+	ExitTime     time.Time `json:"exit_time"`
+	ExitPrice    float64   `json:"exit_price"`
+	ExitReason   string    `json:"exit_reason"`
+	GrossProfit  float64   `json:"gross_profit"`
+	SpreadCost   float64   `json:"spread_cost"`
+	SlippageCost float64   `json:"slippage_cost"`
+	Commission   float64   `json:"commission"`
+	Swap         float64   `json:"swap"`
+	NetProfit    float64   `json:"net_profit"`
+	Pips         *float64  `json:"pips"`
+}
+
+// BacktestMetricEntity バックテストの集計値 (Symbolが空文字の場合は全シンボルの集計)
+type BacktestMetricEntity struct {
+	RunId  int64   `json:"-"`
+	Symbol string  `json:"symbol"`
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`
+}
+
+type JwtSigningKeyEntity struct {
+	Kid       string
+	Purpose   string
+	Secret    []byte
+	CreatedAt time.Time
+	// 退役日時 (nilの場合は現在の署名鍵)
+	RetiredAt *time.Time
+}
+
+// AuditEventEntity 監査イベント
+type AuditEventEntity struct {
+	EventId    int64
+	OccurredAt time.Time
+	// イベントの種類 (login | logout | access | data.analyze | data.export | data.delete)
+	EventType string
+	// 結果 (success | failure)
+	Outcome string
+	// ユーザを特定できない場合はnil
+	UserId    *int64
+	Email     string
+	IpAddress string
+	UserAgent string
+	// リクエストのメソッドとルート (e.g. POST /zigzag)
+	Route string
+	// 失敗の原因のエラーコード (FxtError以外の場合はnil)
+	ErrorCode *int64
+	Detail    string
+}
+
+// AuditEventFilter 監査イベントの検索条件 (nilの条件は絞り込まない)
+type AuditEventFilter struct {
+	EventType *string
+	Outcome   *string
+	UserId    *int64
+	Email     *string
+	// 発生日時の範囲 [From, To)
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
 }
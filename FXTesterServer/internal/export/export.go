@@ -0,0 +1,226 @@
+// Package export 表形式のデータをCSV・JSON Lines・Excel形式で出力するパッケージ
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format 出力形式
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	FormatXLSX   Format = "xlsx"
+)
+
+// 出力形式とContent-Typeの対応表
+var contentTypes = map[Format]string{
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatNDJSON: "application/x-ndjson",
+	FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Acceptヘッダのメディアタイプと出力形式の対応表
+var mediaTypes = map[string]Format{
+	"text/csv":             FormatCSV,
+	"application/x-ndjson": FormatNDJSON,
+	"application/jsonl":    FormatNDJSON,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": FormatXLSX,
+	"text/*": FormatCSV,
+	"*/*":    FormatCSV,
+}
+
+// ContentType 出力形式のContent-Typeを返却する
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// NegotiateFormat 出力形式を決定する。formatが指定された場合はformatを優先し、未指定の場合はAcceptヘッダから決定する。
+// Acceptヘッダが未指定の場合はCSVとし、対応する形式が無い場合はfalseを返却する
+func NegotiateFormat(format string, accept string) (Format, bool) {
+	if format != "" {
+		f := Format(format)
+		_, ok := contentTypes[f]
+		return f, ok
+	}
+	if strings.TrimSpace(accept) == "" {
+		return FormatCSV, true
+	}
+
+	type mediaRange struct {
+		mediaType string
+		q         float64
+	}
+	ranges := []mediaRange{}
+	for _, v := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(v))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(s, 64); err == nil {
+				q = parsed
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+	}
+	// 品質値の高い順に並び替える (同じ品質値の場合は記述順)
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	for _, r := range ranges {
+		if f, ok := mediaTypes[r.mediaType]; ok && 0 < r.q {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// Column 出力する列
+type Column struct {
+	// JSON Linesのキー
+	Key string
+	// CSV・Excelの見出し (多言語化した文字列)
+	Header string
+}
+
+// Writer 表形式のデータを1行ずつ出力する
+type Writer interface {
+	// WriteRow 1行を出力する。値は列と同じ順に指定する (nilは空欄として出力する)
+	WriteRow(values []any) error
+	// Close 出力を完了する
+	Close() error
+}
+
+// Rows 行を逐次生成する関数。生成した行毎にwriteを呼び出し、writeがエラーを返却した場合は生成を中断してそのエラーを返却する
+type Rows func(write func(values []any) error) error
+
+// WriteRows rowsが生成した行を逐次wに出力し、出力を完了する
+func WriteRows(w Writer, rows Rows) error {
+	if err := rows(w.WriteRow); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// NewWriter 出力形式に応じたWriterを生成し、見出しを出力する。sheetはExcelのシート名
+func NewWriter(format Format, w io.Writer, columns []Column, sheet string) (Writer, error) {
+	switch format {
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w), columns: columns}, nil
+	case FormatXLSX:
+		return newXlsxWriter(w, columns, sheet)
+	default:
+		cw := csv.NewWriter(w)
+		headers := make([]string, len(columns))
+		for i, c := range columns {
+			headers[i] = c.Header
+		}
+		if err := cw.Write(headers); err != nil {
+			return nil, err
+		}
+		return &csvWriter{writer: cw}, nil
+	}
+}
+
+// formatValue 値をCSV・Excelに出力する文字列に変換する。数値の場合はtrueを返却する
+func formatValue(v any) (string, bool) {
+	switch t := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return t, false
+	case *string:
+		if t == nil {
+			return "", false
+		}
+		return *t, false
+	case int:
+		return strconv.Itoa(t), true
+	case int64:
+		return strconv.FormatInt(t, 10), true
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), true
+	case *float32:
+		if t == nil {
+			return "", false
+		}
+		return strconv.FormatFloat(float64(*t), 'f', -1, 32), true
+	case *float64:
+		if t == nil {
+			return "", false
+		}
+		return strconv.FormatFloat(*t, 'f', -1, 64), true
+	case time.Time:
+		return t.Format(time.RFC3339), false
+	default:
+		bytes, _ := json.Marshal(t)
+		return string(bytes), false
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i], _ = formatValue(v)
+	}
+	return c.writer.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+	columns []Column
+}
+
+func (n *ndjsonWriter) WriteRow(values []any) error {
+	// 列の順序を保つためにキーと値を順に書き出す
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, c := range n.columns {
+		if 0 < i {
+			sb.WriteString(",")
+		}
+		key, _ := json.Marshal(c.Key)
+		sb.Write(key)
+		sb.WriteString(":")
+		var v any
+		if i < len(values) {
+			v = values[i]
+		}
+		if t, ok := v.(time.Time); ok {
+			v = t.Format(time.RFC3339)
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		sb.Write(value)
+	}
+	sb.WriteString("}")
+	return n.encoder.Encode(json.RawMessage(sb.String()))
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
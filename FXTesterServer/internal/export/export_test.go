@@ -0,0 +1,164 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testColumns = []Column{
+	{Key: "time", Header: "日時"},
+	{Key: "kind", Header: "種類"},
+	{Key: "delta", Header: "値幅"},
+	{Key: "pips", Header: "pips"},
+}
+
+func testRows() [][]any {
+	return [][]any{
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "peak", 1.25, nil},
+		{time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC), "a,\"b\"", float32(0.5), 12},
+	}
+}
+
+func writeAll(t *testing.T, format Format) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf, testColumns, "zigzag")
+	if err != nil {
+		t.Fatalf("NewWriter()=%v", err)
+	}
+	if err := WriteRows(w, func(write func(values []any) error) error {
+		for _, row := range testRows() {
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("WriteRows()=%v", err)
+	}
+	return buf.Bytes()
+}
+
+func Test_NegotiateFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		accept string
+		want   Format
+		wantOk bool
+	}{
+		{name: "formatを優先", format: "xlsx", accept: "text/csv", want: FormatXLSX, wantOk: true},
+		{name: "不正なformat", format: "pdf", accept: "text/csv", want: "pdf", wantOk: false},
+		{name: "Acceptが未指定", want: FormatCSV, wantOk: true},
+		{name: "Acceptでndjson", accept: "application/x-ndjson", want: FormatNDJSON, wantOk: true},
+		{name: "品質値の高い形式", accept: "text/csv;q=0.5, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", want: FormatXLSX, wantOk: true},
+		{name: "ワイルドカード", accept: "application/json, */*;q=0.1", want: FormatCSV, wantOk: true},
+		{name: "対応する形式が無い", accept: "application/pdf", want: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NegotiateFormat(tt.format, tt.accept)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("NegotiateFormat()=(%v, %v) want=(%v, %v)", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_Writer_CSV(t *testing.T) {
+	got := string(writeAll(t, FormatCSV))
+	want := "日時,種類,値幅,pips\n" +
+		"2024-01-01T00:00:00Z,peak,1.25,\n" +
+		"2024-01-01T01:00:00Z,\"a,\"\"b\"\"\",0.5,12\n"
+	if got != want {
+		t.Errorf("CSV=%q want=%q", got, want)
+	}
+}
+
+func Test_Writer_NDJSON(t *testing.T) {
+	got := string(writeAll(t, FormatNDJSON))
+	want := `{"time":"2024-01-01T00:00:00Z","kind":"peak","delta":1.25,"pips":null}` + "\n" +
+		`{"time":"2024-01-01T01:00:00Z","kind":"a,\"b\"","delta":0.5,"pips":12}` + "\n"
+	if got != want {
+		t.Errorf("NDJSON=%q want=%q", got, want)
+	}
+}
+
+// 行は生成した時点で出力し、生成が失敗した場合はそのエラーを返却すること
+func Test_WriteRows(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatNDJSON, &buf, testColumns, "zigzag")
+	if err != nil {
+		t.Fatalf("NewWriter()=%v", err)
+	}
+
+	errGenerate := errors.New("generate error")
+	err = WriteRows(w, func(write func(values []any) error) error {
+		if err := write(testRows()[0]); err != nil {
+			return err
+		}
+		if got := strings.Count(buf.String(), "\n"); got != 1 {
+			t.Errorf("lines=%v want=1", got)
+		}
+		return errGenerate
+	})
+	if !errors.Is(err, errGenerate) {
+		t.Errorf("WriteRows()=%v want=%v", err, errGenerate)
+	}
+}
+
+func Test_Writer_XLSX(t *testing.T) {
+	data := writeAll(t, FormatXLSX)
+
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader()=%v", err)
+	}
+	files := map[string]string{}
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Open(%v)=%v", f.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("missing part %v", name)
+		}
+	}
+	if !strings.Contains(files["xl/workbook.xml"], `name="zigzag"`) {
+		t.Errorf("workbook.xml=%v", files["xl/workbook.xml"])
+	}
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">日時</t></is></c>`,
+		`<c r="C2"><v>1.25</v></c>`,
+		`<c r="B3" t="inlineStr"><is><t xml:space="preserve">a,&#34;b&#34;</t></is></c>`,
+		`<c r="D3"><v>12</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1.xml does not contain %v", want)
+		}
+	}
+	if strings.Contains(sheet, `r="D2"`) {
+		t.Errorf("sheet1.xml contains empty cell D2")
+	}
+}
+
+func Test_columnName(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(index); got != want {
+			t.Errorf("columnName(%d)=%v want=%v", index, got, want)
+		}
+	}
+}
@@ -22,12 +22,543 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+	CookieAuthScopes = "cookieAuth.Scopes"
+)
+
+// Defines values for ApiTokenScope.
+const (
+	Analysis       ApiTokenScope = "analysis"
+	BacktestsRead  ApiTokenScope = "backtests:read"
+	BacktestsWrite ApiTokenScope = "backtests:write"
+)
+
+// Defines values for AuditEventOutcome.
+const (
+	Failure AuditEventOutcome = "failure"
+	Success AuditEventOutcome = "success"
+)
+
+// Defines values for AuditEventType.
+const (
+	Access      AuditEventType = "access"
+	DataAnalyze AuditEventType = "data.analyze"
+	DataDelete  AuditEventType = "data.delete"
+	DataExport  AuditEventType = "data.export"
+	Login       AuditEventType = "login"
+	Logout      AuditEventType = "logout"
+)
+
+// Defines values for BacktestDatasetType.
+const (
+	BacktestDatasetTypeCandles BacktestDatasetType = "candles"
+	BacktestDatasetTypeCsv     BacktestDatasetType = "csv"
+)
+
+// Defines values for BacktestIntrabarPath.
+const (
+	Ohlc        BacktestIntrabarPath = "ohlc"
+	Olhc        BacktestIntrabarPath = "olhc"
+	Pessimistic BacktestIntrabarPath = "pessimistic"
+)
+
+// Defines values for BacktestOrderSide.
+const (
+	BacktestOrderSideBuy  BacktestOrderSide = "buy"
+	BacktestOrderSideSell BacktestOrderSide = "sell"
+)
+
+// Defines values for BacktestRunKind.
+const (
+	Portfolio BacktestRunKind = "portfolio"
+	Single    BacktestRunKind = "single"
+)
+
+// Defines values for BacktestSizingType.
+const (
+	FixedFractional BacktestSizingType = "fixedFractional"
+	FixedLot        BacktestSizingType = "fixedLot"
+	FixedRatio      BacktestSizingType = "fixedRatio"
+	Kelly           BacktestSizingType = "kelly"
+	Volatility      BacktestSizingType = "volatility"
+)
+
+// Defines values for BacktestTradeExitReason.
+const (
+	EndOfData  BacktestTradeExitReason = "endOfData"
+	StopLoss   BacktestTradeExitReason = "stopLoss"
+	StopOut    BacktestTradeExitReason = "stopOut"
+	TakeProfit BacktestTradeExitReason = "takeProfit"
+)
+
+// Defines values for BacktestTradeSide.
+const (
+	BacktestTradeSideBuy  BacktestTradeSide = "buy"
+	BacktestTradeSideSell BacktestTradeSide = "sell"
+)
+
+// Defines values for DisplayPreferencesTheme.
+const (
+	Dark   DisplayPreferencesTheme = "dark"
+	Light  DisplayPreferencesTheme = "light"
+	System DisplayPreferencesTheme = "system"
+)
+
+// Defines values for HealthStatus.
+const (
+	Degraded HealthStatus = "degraded"
+	Ok       HealthStatus = "ok"
+)
+
+// Defines values for IndicatorKind.
+const (
+	Ema IndicatorKind = "ema"
+	Sma IndicatorKind = "sma"
+)
+
+// Defines values for PostBacktestPortfolioRequestType.
+const (
+	PostBacktestPortfolioRequestTypeCandles PostBacktestPortfolioRequestType = "candles"
+	PostBacktestPortfolioRequestTypeCsv     PostBacktestPortfolioRequestType = "csv"
+)
+
+// Defines values for PostBacktestRequestTimeframe.
+const (
+	PostBacktestRequestTimeframeD1  PostBacktestRequestTimeframe = "D1"
+	PostBacktestRequestTimeframeH1  PostBacktestRequestTimeframe = "H1"
+	PostBacktestRequestTimeframeH4  PostBacktestRequestTimeframe = "H4"
+	PostBacktestRequestTimeframeM1  PostBacktestRequestTimeframe = "M1"
+	PostBacktestRequestTimeframeM15 PostBacktestRequestTimeframe = "M15"
+	PostBacktestRequestTimeframeM30 PostBacktestRequestTimeframe = "M30"
+	PostBacktestRequestTimeframeM5  PostBacktestRequestTimeframe = "M5"
+)
+
+// Defines values for PostBacktestRequestType.
+const (
+	PostBacktestRequestTypeCandles PostBacktestRequestType = "candles"
+	PostBacktestRequestTypeCsv     PostBacktestRequestType = "csv"
+)
+
+// Defines values for PostBacktestsCompareRequestTimeframe.
+const (
+	PostBacktestsCompareRequestTimeframeD1  PostBacktestsCompareRequestTimeframe = "D1"
+	PostBacktestsCompareRequestTimeframeH1  PostBacktestsCompareRequestTimeframe = "H1"
+	PostBacktestsCompareRequestTimeframeH4  PostBacktestsCompareRequestTimeframe = "H4"
+	PostBacktestsCompareRequestTimeframeM1  PostBacktestsCompareRequestTimeframe = "M1"
+	PostBacktestsCompareRequestTimeframeM15 PostBacktestsCompareRequestTimeframe = "M15"
+	PostBacktestsCompareRequestTimeframeM30 PostBacktestsCompareRequestTimeframe = "M30"
+	PostBacktestsCompareRequestTimeframeM5  PostBacktestsCompareRequestTimeframe = "M5"
+)
+
+// Defines values for PostChartsRequestFormat.
+const (
+	Png PostChartsRequestFormat = "png"
+	Svg PostChartsRequestFormat = "svg"
+)
+
+// Defines values for PostChartsRequestType.
+const (
+	PostChartsRequestTypeCandles PostChartsRequestType = "candles"
+	PostChartsRequestTypeCsv     PostChartsRequestType = "csv"
+)
+
+// Defines values for PostIndicatorsExportRequestType.
+const (
+	PostIndicatorsExportRequestTypeCandles PostIndicatorsExportRequestType = "candles"
+	PostIndicatorsExportRequestTypeCsv     PostIndicatorsExportRequestType = "csv"
+)
+
 // Defines values for PostZigzagRequestType.
 const (
 	PostZigzagRequestTypeCandles PostZigzagRequestType = "candles"
 	PostZigzagRequestTypeCsv     PostZigzagRequestType = "csv"
 )
 
+// Defines values for UserPreferencesLocale.
+const (
+	En UserPreferencesLocale = "en"
+	Ja UserPreferencesLocale = "ja"
+)
+
+// Defines values for ExportFormat.
+const (
+	ExportFormatCsv    ExportFormat = "csv"
+	ExportFormatNdjson ExportFormat = "ndjson"
+	ExportFormatXlsx   ExportFormat = "xlsx"
+)
+
+// Defines values for GetBacktestsIdExportParamsFormat.
+const (
+	GetBacktestsIdExportParamsFormatCsv    GetBacktestsIdExportParamsFormat = "csv"
+	GetBacktestsIdExportParamsFormatNdjson GetBacktestsIdExportParamsFormat = "ndjson"
+	GetBacktestsIdExportParamsFormatXlsx   GetBacktestsIdExportParamsFormat = "xlsx"
+)
+
+// Defines values for PostIndicatorsExportParamsFormat.
+const (
+	PostIndicatorsExportParamsFormatCsv    PostIndicatorsExportParamsFormat = "csv"
+	PostIndicatorsExportParamsFormatNdjson PostIndicatorsExportParamsFormat = "ndjson"
+	PostIndicatorsExportParamsFormatXlsx   PostIndicatorsExportParamsFormat = "xlsx"
+)
+
+// Defines values for PostZigzagExportParamsFormat.
+const (
+	PostZigzagExportParamsFormatCsv    PostZigzagExportParamsFormat = "csv"
+	PostZigzagExportParamsFormatNdjson PostZigzagExportParamsFormat = "ndjson"
+	PostZigzagExportParamsFormatXlsx   PostZigzagExportParamsFormat = "xlsx"
+)
+
+// AdminUser 登録済みのユーザ
+type AdminUser struct {
+	// DisabledAt idPでアカウントが無効化されたためプロビジョニングを解除した日時 (有効なユーザの場合は省略)
+	DisabledAt *string `json:"disabledAt,omitempty"`
+	Email      string  `json:"email"`
+	Id         int64   `json:"id"`
+
+	// Profile SAMLアサーションから同期した属性 (ログインの度に更新する。idPから送られない属性は空)
+	Profile UserProfile `json:"profile"`
+
+	// Roles SAMLアサーションから同期したロール
+	Roles []string `json:"roles"`
+}
+
+// ApiToken 個人用APIトークン (トークン自体は発行時のみ返却する)
+type ApiToken struct {
+	// CreatedAt 発行日時
+	CreatedAt string `json:"createdAt"`
+
+	// ExpiresAt 有効期限
+	ExpiresAt string `json:"expiresAt"`
+
+	// Id トークンID
+	Id string `json:"id"`
+
+	// LastUsedAt 最終使用日時 (未使用の場合は省略)
+	LastUsedAt *string `json:"lastUsedAt,omitempty"`
+
+	// Name トークン名
+	Name string `json:"name"`
+
+	// Prefix トークンを見分けるための先頭の数文字
+	Prefix string          `json:"prefix"`
+	Scopes []ApiTokenScope `json:"scopes"`
+}
+
+// ApiTokenScope 個人用APIトークンで呼び出せるエンドポイントの範囲
+// - analysis: 解析・バックテストの実行 (/zigzag, /charts, /backtest 等)
+// - backtests:read: 保存したバックテストの参照・比較・エクスポート
+// - backtests:write: 保存したバックテストの削除
+type ApiTokenScope string
+
+// AuditEvent 監査イベント
+type AuditEvent struct {
+	// Detail イベント毎の補足情報 (e.g. ログインしたidP、アップロードしたCSVのファイル名)
+	Detail string `json:"detail"`
+
+	// Email ユーザのEmail (ユーザを特定できない場合は空)
+	Email string `json:"email"`
+
+	// ErrorCode 失敗の原因のエラーコード (成功の場合や、エラーコードの無い失敗の場合は省略)
+	ErrorCode *uint32 `json:"errorCode,omitempty"`
+	Id        int64   `json:"id"`
+	IpAddress string  `json:"ipAddress"`
+
+	// OccurredAt 発生日時
+	OccurredAt string `json:"occurredAt"`
+
+	// Outcome 監査イベントの結果
+	Outcome AuditEventOutcome `json:"outcome"`
+
+	// Route リクエストのメソッドとルート
+	Route string `json:"route"`
+
+	// Type 監査イベントの種類
+	// - login: idPでのログイン (SAMLのACS・OpenID Connectのコールバック)
+	// - logout: idPとのシングルログアウト
+	// - access: 認証・認可に失敗したAPIの呼び出し
+	// - data.analyze: データのアップロード・解析 (/zigzag, /charts, /backtest 等)
+	// - data.export: データのエクスポート
+	// - data.delete: 保存したバックテストの削除
+	Type      AuditEventType `json:"type"`
+	UserAgent string         `json:"userAgent"`
+
+	// UserId ユーザのID (ユーザを特定できない場合は省略)
+	UserId *int64 `json:"userId,omitempty"`
+}
+
+// AuditEventOutcome 監査イベントの結果
+type AuditEventOutcome string
+
+// AuditEventType 監査イベントの種類
+// - login: idPでのログイン (SAMLのACS・OpenID Connectのコールバック)
+// - logout: idPとのシングルログアウト
+// - access: 認証・認可に失敗したAPIの呼び出し
+// - data.analyze: データのアップロード・解析 (/zigzag, /charts, /backtest 等)
+// - data.export: データのエクスポート
+// - data.delete: 保存したバックテストの削除
+type AuditEventType string
+
+// BacktestAccount バックテストの口座設定 (省略した項目はサーバの設定値を使用する)
+type BacktestAccount struct {
+	// InitialBalance 初期の口座残高(口座通貨建て)
+	InitialBalance *float64 `json:"initialBalance,omitempty"`
+
+	// Leverage レバレッジ
+	Leverage *float64 `json:"leverage,omitempty"`
+
+	// MarginCallLevel マージンコールとなる証拠金維持率[%]
+	MarginCallLevel *float64 `json:"marginCallLevel,omitempty"`
+
+	// StopOutLevel ロスカットとなる証拠金維持率[%] (marginCallLevel以下)
+	StopOutLevel *float64 `json:"stopOutLevel,omitempty"`
+}
+
+// BacktestCorrelation シンボル間の日次損益(UTC基準)の相関係数行列
+type BacktestCorrelation struct {
+	// Matrix matrix[i][j]はsymbols[i]とsymbols[j]の相関係数[-1.0~1.0] (損益の分散が0の場合は0)
+	Matrix  [][]float64 `json:"matrix"`
+	Symbols []string    `json:"symbols"`
+}
+
+// BacktestDataset バックテストに使用した入力データ
+type BacktestDataset struct {
+	// CandleCount ローソク足の本数
+	CandleCount int `json:"candleCount"`
+
+	// EndTime 最後のローソク足の日時
+	EndTime *string `json:"endTime,omitempty"`
+
+	// FileName アップロードしたCSVのファイル名 (typeがcsvの場合のみ)
+	FileName *string `json:"fileName,omitempty"`
+
+	// StartTime 最初のローソク足の日時
+	StartTime *string `json:"startTime,omitempty"`
+	Symbol    string  `json:"symbol"`
+
+	// Type 入力データのタイプ
+	Type BacktestDatasetType `json:"type"`
+}
+
+// BacktestDatasetType 入力データのタイプ
+type BacktestDatasetType string
+
+// BacktestEquityCurves 共通の時間軸に揃えた資産曲線 (各時刻までに決済した取引の損益を初期の口座残高に加えた値。評価損益は含まない)
+type BacktestEquityCurves struct {
+	Times []string `json:"times"`
+
+	// Values values[i][j]はruns[i]のtimes[j]時点の資産
+	Values [][]float64 `json:"values"`
+}
+
+// BacktestIntrabarPath 下位足が無い場合に想定するローソク足の中での値動き (省略時はpessimistic)
+// - pessimistic: 損切りと利確が同じローソク足で発生した場合は損切りを優先する
+// - ohlc: 始値→高値→安値→終値の順に値動きしたと想定する
+// - olhc: 始値→安値→高値→終値の順に値動きしたと想定する
+type BacktestIntrabarPath string
+
+// BacktestMetricRow バックテスト毎の指標の値
+type BacktestMetricRow struct {
+	// Name 指標名 (BacktestSummaryのプロパティ名)
+	Name string `json:"name"`
+
+	// Values values[i]はruns[i]の値
+	Values []float64 `json:"values"`
+}
+
+// BacktestOrder バックテストで発注する成行注文
+type BacktestOrder struct {
+	// Lots 取引数量(ロット)。省略時はsizingの資金管理モデルで算出する
+	Lots *float64 `json:"lots,omitempty"`
+
+	// Side 売買の方向
+	Side BacktestOrderSide `json:"side"`
+
+	// StopLoss 損切り価格 (省略時は損切りしない)
+	StopLoss *float64 `json:"stopLoss,omitempty"`
+
+	// Symbol 発注するシンボル (ポートフォリオのバックテストの場合は必須)
+	Symbol *string `json:"symbol,omitempty"`
+
+	// TakeProfit 利確価格 (省略時は利確しない)
+	TakeProfit *float64 `json:"takeProfit,omitempty"`
+
+	// Time 発注日時 (この日時以降で最初のローソク足の始値で約定する)
+	Time string `json:"time"`
+}
+
+// BacktestOrderSide 売買の方向
+type BacktestOrderSide string
+
+// BacktestOrders 注文配列
+type BacktestOrders = []BacktestOrder
+
+// BacktestRun 保存したバックテストの実行履歴
+type BacktestRun struct {
+	// CreatedAt 実行日時
+	CreatedAt string            `json:"createdAt"`
+	Datasets  []BacktestDataset `json:"datasets"`
+	Id        int64             `json:"id"`
+
+	// Kind バックテストの種類
+	// - single: 単一シンボルのバックテスト (POST /backtest)
+	// - portfolio: ポートフォリオのバックテスト (POST /backtest/portfolio)
+	Kind BacktestRunKind `json:"kind"`
+
+	// Parameters 保存したバックテストの実行パラメータ (口座設定・intrabarPathは省略時の設定値を補った値)
+	Parameters BacktestRunParameters `json:"parameters"`
+
+	// Summary バックテスト結果の集計 (金額は全て口座通貨建て。単一シンボルの場合は決済通貨を口座通貨とする)
+	Summary BacktestSummary `json:"summary"`
+}
+
+// BacktestRunKind バックテストの種類
+// - single: 単一シンボルのバックテスト (POST /backtest)
+// - portfolio: ポートフォリオのバックテスト (POST /backtest/portfolio)
+type BacktestRunKind string
+
+// BacktestRunParameters 保存したバックテストの実行パラメータ (口座設定・intrabarPathは省略時の設定値を補った値)
+type BacktestRunParameters struct {
+	// Account バックテストの口座設定 (省略した項目はサーバの設定値を使用する)
+	Account BacktestAccount `json:"account"`
+
+	// AccountCurrency 口座通貨 (ポートフォリオのバックテストの場合のみ)
+	AccountCurrency *string `json:"accountCurrency,omitempty"`
+
+	// IntrabarPath 下位足が無い場合に想定するローソク足の中での値動き (省略時はpessimistic)
+	// - pessimistic: 損切りと利確が同じローソク足で発生した場合は損切りを優先する
+	// - ohlc: 始値→高値→安値→終値の順に値動きしたと想定する
+	// - olhc: 始値→安値→高値→終値の順に値動きしたと想定する
+	IntrabarPath BacktestIntrabarPath `json:"intrabarPath"`
+
+	// Orders 注文配列
+	Orders BacktestOrders `json:"orders"`
+
+	// Sizing 取引数量が未指定の注文に使用する資金管理モデル
+	// - fixedLot: 一定の取引数量 (lots)
+	// - fixedFractional: 損切り価格までの損失が有効証拠金のriskPercent[%]となる取引数量 (riskPercent)
+	// - fixedRatio: 確定利益がdelta増える毎にlotsずつ取引数量を増やす (lots, delta)
+	// - kelly: ケリー基準の割合にkellyFractionを掛けた損失を許容する取引数量 (winRate, payoffRatio, kellyFraction)
+	// - volatility: ATRのatrMultiple倍の値動きでの損失が有効証拠金のriskPercent[%]となる取引数量 (riskPercent, atrPeriod, atrMultiple)
+	Sizing  *BacktestSizing `json:"sizing,omitempty"`
+	Symbols []string        `json:"symbols"`
+
+	// Timeframe バックテストを行った時間足 (指定した場合のみ)
+	Timeframe *string `json:"timeframe,omitempty"`
+}
+
+// BacktestSizing 取引数量が未指定の注文に使用する資金管理モデル
+// - fixedLot: 一定の取引数量 (lots)
+// - fixedFractional: 損切り価格までの損失が有効証拠金のriskPercent[%]となる取引数量 (riskPercent)
+// - fixedRatio: 確定利益がdelta増える毎にlotsずつ取引数量を増やす (lots, delta)
+// - kelly: ケリー基準の割合にkellyFractionを掛けた損失を許容する取引数量 (winRate, payoffRatio, kellyFraction)
+// - volatility: ATRのatrMultiple倍の値動きでの損失が有効証拠金のriskPercent[%]となる取引数量 (riskPercent, atrPeriod, atrMultiple)
+type BacktestSizing struct {
+	AtrMultiple   *float64           `json:"atrMultiple,omitempty"`
+	AtrPeriod     *int               `json:"atrPeriod,omitempty"`
+	Delta         *float64           `json:"delta,omitempty"`
+	KellyFraction *float64           `json:"kellyFraction,omitempty"`
+	Lots          *float64           `json:"lots,omitempty"`
+	PayoffRatio   *float64           `json:"payoffRatio,omitempty"`
+	RiskPercent   *float64           `json:"riskPercent,omitempty"`
+	Type          BacktestSizingType `json:"type"`
+	WinRate       *float64           `json:"winRate,omitempty"`
+}
+
+// BacktestSizingType defines model for BacktestSizing.Type.
+type BacktestSizingType string
+
+// BacktestSummary バックテスト結果の集計 (金額は全て口座通貨建て。単一シンボルの場合は決済通貨を口座通貨とする)
+type BacktestSummary struct {
+	Commission float64 `json:"commission"`
+
+	// FinalBalance 最終的な口座残高
+	FinalBalance float64 `json:"finalBalance"`
+
+	// GrossProfit コスト控除前の損益合計
+	GrossProfit float64 `json:"grossProfit"`
+
+	// InitialBalance 初期の口座残高
+	InitialBalance float64 `json:"initialBalance"`
+
+	// MarginCallCount マージンコールの発生回数
+	MarginCallCount int `json:"marginCallCount"`
+
+	// NetProfit コスト控除後の損益合計
+	NetProfit float64 `json:"netProfit"`
+
+	// RejectedOrderCount 取引数量が最小取引数量に満たない、または証拠金不足のため発注されなかった注文の数
+	RejectedOrderCount int     `json:"rejectedOrderCount"`
+	SlippageCost       float64 `json:"slippageCost"`
+	SpreadCost         float64 `json:"spreadCost"`
+
+	// StopOutCount ロスカットで強制決済されたポジションの数
+	StopOutCount int     `json:"stopOutCount"`
+	Swap         float64 `json:"swap"`
+	TradeCount   int     `json:"tradeCount"`
+}
+
+// BacktestSymbolResult シンボル毎のバックテスト結果 (金額は口座通貨建て)
+type BacktestSymbolResult struct {
+	// Summary バックテスト結果の集計 (金額は全て口座通貨建て。単一シンボルの場合は決済通貨を口座通貨とする)
+	Summary BacktestSummary `json:"summary"`
+	Symbol  string          `json:"symbol"`
+	Trades  []BacktestTrade `json:"trades"`
+}
+
+// BacktestTrade バックテストで約定した取引 (価格は決済通貨建て、金額は口座通貨建て。単一シンボルの場合は決済通貨を口座通貨とする)
+type BacktestTrade struct {
+	Commission float64 `json:"commission"`
+
+	// EntryPrice コスト込みの約定価格
+	EntryPrice float64 `json:"entryPrice"`
+	EntryTime  string  `json:"entryTime"`
+
+	// ExitPrice コスト込みの決済価格
+	ExitPrice float64 `json:"exitPrice"`
+
+	// ExitReason 決済理由 (stopOutは証拠金維持率の低下による強制決済)
+	ExitReason BacktestTradeExitReason `json:"exitReason"`
+	ExitTime   string                  `json:"exitTime"`
+
+	// GrossProfit コスト控除前の損益
+	GrossProfit float64 `json:"grossProfit"`
+	Lots        float64 `json:"lots"`
+
+	// NetProfit コスト控除後の損益
+	NetProfit float64 `json:"netProfit"`
+
+	// Pips 約定価格から決済価格までの損益(pips)
+	Pips         *float64          `json:"pips,omitempty"`
+	Side         BacktestTradeSide `json:"side"`
+	SlippageCost float64           `json:"slippageCost"`
+	SpreadCost   float64           `json:"spreadCost"`
+
+	// Swap スワップ損益 (受取りは正、支払いは負)
+	Swap float64 `json:"swap"`
+
+	// Symbol シンボル (ポートフォリオのバックテスト、または保存したバックテストの場合のみ)
+	Symbol *string `json:"symbol,omitempty"`
+}
+
+// BacktestTradeExitReason 決済理由 (stopOutは証拠金維持率の低下による強制決済)
+type BacktestTradeExitReason string
+
+// BacktestTradeSide defines model for BacktestTrade.Side.
+type BacktestTradeSide string
+
+// BacktestTradeDiff 他のバックテストに存在しない取引 (シンボル・売買の方向・約定日時が同じ取引を同じ取引とみなす)
+type BacktestTradeDiff struct {
+	// MissingIn 取引が存在しないバックテストのID
+	MissingIn []int64 `json:"missingIn"`
+
+	// RunId 取引が存在するバックテストのID
+	RunId int64 `json:"runId"`
+
+	// Trade バックテストで約定した取引 (価格は決済通貨建て、金額は口座通貨建て。単一シンボルの場合は決済通貨を口座通貨とする)
+	Trade BacktestTrade `json:"trade"`
+}
+
 // Candle ローソク足
 type Candle struct {
 	// Close 終値
@@ -42,6 +573,9 @@ type Candle struct {
 	// Open 始値
 	Open float32 `json:"open"`
 
+	// Spread スプレッド(価格単位)。省略時はシンボルの固定スプレッドを使用する
+	Spread *float32 `json:"spread,omitempty"`
+
 	// Time 日時
 	Time string `json:"time"`
 }
@@ -49,6 +583,22 @@ type Candle struct {
 // Candles ローソク足配列
 type Candles = []Candle
 
+// ChartLine ローソク足に重ねて描画する線 (インジケーター等)
+type ChartLine struct {
+	// Color 線の色 (#rrggbb形式。省略時は既定の色)
+	Color *string `json:"color,omitempty"`
+
+	// Name 凡例に表示する名前
+	Name   string       `json:"name"`
+	Points []ChartPoint `json:"points"`
+}
+
+// ChartPoint defines model for ChartPoint.
+type ChartPoint struct {
+	Time  string  `json:"time"`
+	Value float64 `json:"value"`
+}
+
 // CsvInfo defines model for CsvInfo.
 type CsvInfo struct {
 	// CloseColumnIndex 終値カラムのインデックス番号(0始まり)
@@ -69,10 +619,36 @@ type CsvInfo struct {
 	// OpenColumnIndex 始値カラムのインデックス番号(0始まり)
 	OpenColumnIndex int `json:"openColumnIndex"`
 
+	// SpreadColumnIndex スプレッドカラムのインデックス番号(0始まり)。ローソク足毎のスプレッドが存在する場合のみ指定する
+	SpreadColumnIndex *int `json:"spreadColumnIndex,omitempty"`
+
 	// TimeColumnIndex 時間カラムのインデックス番号(0始まり)
 	TimeColumnIndex int `json:"timeColumnIndex"`
 }
 
+// CsvInfoTemplate 名前を付けて保存したCSV情報
+type CsvInfoTemplate struct {
+	CsvInfo CsvInfo `json:"csvInfo"`
+
+	// Name テンプレート名
+	Name string `json:"name"`
+}
+
+// DisplayPreferences 表示設定
+type DisplayPreferences struct {
+	// PageSize 一覧の1ページの表示件数
+	PageSize *int `json:"pageSize,omitempty"`
+
+	// Theme 画面のテーマ
+	Theme *DisplayPreferencesTheme `json:"theme,omitempty"`
+
+	// Timeframe チャートに表示する既定の時間足 (M1, M5, M15, M30, H1, H4, D1)
+	Timeframe *string `json:"timeframe,omitempty"`
+}
+
+// DisplayPreferencesTheme 画面のテーマ
+type DisplayPreferencesTheme string
+
 // Error defines model for Error.
 type Error struct {
 	// Code サーバー内部で使用しているエラーコード
@@ -91,1091 +667,5899 @@ type ErrorWithTime struct {
 // File ファイルのテキストまたはバイナリデータ
 type File = openapi_types.File
 
-// PostZigzagRequest defines model for PostZigzagRequest.
-type PostZigzagRequest struct {
-	// Candles ローソク足配列
-	Candles *Candles `json:"candles,omitempty"`
+// GetAdminAuditResult defines model for GetAdminAuditResult.
+type GetAdminAuditResult struct {
+	// Count itemsの件数
+	Count int `json:"count"`
 
-	// Csv ファイルのテキストまたはバイナリデータ
-	Csv     *File    `json:"csv,omitempty"`
-	CsvInfo *CsvInfo `json:"csvInfo,omitempty"`
+	// Items 発生日時の新しい順
+	Items []AuditEvent `json:"items"`
 
-	// Type 入力データのタイプ
-	Type PostZigzagRequestType `json:"type"`
+	// Total ページングする前の条件に一致した件数
+	Total int64 `json:"total"`
 }
 
-// PostZigzagRequestType 入力データのタイプ
-type PostZigzagRequestType string
+// GetAdminUsersResult defines model for GetAdminUsersResult.
+type GetAdminUsersResult struct {
+	Count int `json:"count"`
 
-// PostZigzagResult defines model for PostZigzagResult.
-type PostZigzagResult struct {
-	Count int      `json:"count"`
-	Items []Zigzag `json:"items"`
+	// Items IDの昇順
+	Items []AdminUser `json:"items"`
 }
 
-// SAMLForm defines model for SAMLForm.
-type SAMLForm = string
+// GetBacktestResult defines model for GetBacktestResult.
+type GetBacktestResult struct {
+	// Run 保存したバックテストの実行履歴
+	Run     BacktestRun            `json:"run"`
+	Symbols []BacktestSymbolResult `json:"symbols"`
 
-// SAMLRequest defines model for SAMLRequest.
-type SAMLRequest struct {
-	RelayState  *string `json:"RelayState,omitempty"`
-	SAMLReqeust *string `json:"SAMLReqeust,omitempty"`
+	// Trades 全シンボルの取引 (決済日時順)
+	Trades []BacktestTrade `json:"trades"`
 }
 
-// SAMLResponse defines model for SAMLResponse.
-type SAMLResponse struct {
-	RelayState   *string `json:"RelayState,omitempty"`
-	SAMLResponse *string `json:"SAMLResponse,omitempty"`
+// GetBacktestsResult defines model for GetBacktestsResult.
+type GetBacktestsResult struct {
+	Count int `json:"count"`
+
+	// Items 実行日時の新しい順
+	Items []BacktestRun `json:"items"`
 }
 
-// Zigzag defines model for Zigzag.
-type Zigzag struct {
-	BottomIndex int         `json:"bottomIndex"`
-	Delta       float32     `json:"delta"`
-	Kind        interface{} `json:"kind"`
-	PeakIndex   int         `json:"peakIndex"`
-	StartTime   string      `json:"startTime"`
-	Velocity    float32     `json:"velocity"`
+// GetSessionsResult defines model for GetSessionsResult.
+type GetSessionsResult struct {
+	Count int `json:"count"`
+
+	// Items 最終アクセス日時の新しい順
+	Items []UserSession `json:"items"`
 }
 
-// GetSamlLoginParams defines parameters for GetSamlLogin.
-type GetSamlLoginParams struct {
-	// XRedirectURL シングルサインオン完了時にリダイレクトさせたいURLを指定する
-	XRedirectURL string `json:"X-Redirect-URL"`
+// GetSymbolsResult defines model for GetSymbolsResult.
+type GetSymbolsResult struct {
+	Count int          `json:"count"`
+	Items []SymbolInfo `json:"items"`
+}
 
-	// XRedirectURLOnError シングルサインオンエラー時にリダイレクトさせたいURLを指定する
-	XRedirectURLOnError string `json:"X-Redirect-URL-On-Error"`
+// GetTokensResult defines model for GetTokensResult.
+type GetTokensResult struct {
+	Count int `json:"count"`
+
+	// Items 発行日時の新しい順 (期限切れのトークンを含む)
+	Items []ApiToken `json:"items"`
 }
 
-// GetSamlLogoutParams defines parameters for GetSamlLogout.
-type GetSamlLogoutParams struct {
-	// XRedirectURL シングルログアウト完了時にリダイレクトさせたいURLを指定する
-	XRedirectURL string `json:"X-Redirect-URL"`
+// Health defines model for Health.
+type Health struct {
+	// AuthProvider ログインに使用するidPのプロトコル (saml | oidc)
+	AuthProvider string `json:"authProvider"`
 
-	// XRedirectURLOnError シングルログアウトのエラー時にリダイレクトさせたいURLを指定する
-	XRedirectURLOnError string `json:"X-Redirect-URL-On-Error"`
+	// SamlIdps SAMLのidP毎のメタデータの状態 (authProviderがsamlの場合のみ)
+	SamlIdps []SamlIdpHealth `json:"samlIdps"`
+
+	// Status ok、またはidPのメタデータの再取得に失敗しているか有効期限切れの場合はdegraded
+	Status HealthStatus `json:"status"`
 }
 
-// PostSamlSloFormdataBody defines parameters for PostSamlSlo.
-type PostSamlSloFormdataBody struct {
-	union json.RawMessage
+// HealthStatus ok、またはidPのメタデータの再取得に失敗しているか有効期限切れの場合はdegraded
+type HealthStatus string
+
+// Indicator 出力するインジケーター
+type Indicator struct {
+	// Kind インジケーターの種類
+	// - sma: 終値の単純移動平均
+	// - ema: 終値の指数移動平均
+	Kind IndicatorKind `json:"kind"`
+
+	// Period 期間 (ローソク足の本数。期間に満たない先頭のローソク足は空欄とする)
+	Period int `json:"period"`
 }
 
-// PostSamlAcsFormdataRequestBody defines body for PostSamlAcs for application/x-www-form-urlencoded ContentType.
-type PostSamlAcsFormdataRequestBody = SAMLResponse
+// IndicatorKind インジケーターの種類
+// - sma: 終値の単純移動平均
+// - ema: 終値の指数移動平均
+type IndicatorKind string
 
-// PostSamlSloFormdataRequestBody defines body for PostSamlSlo for application/x-www-form-urlencoded ContentType.
-type PostSamlSloFormdataRequestBody PostSamlSloFormdataBody
+// Me ログインユーザ
+type Me struct {
+	Email string `json:"email"`
+	Id    int64  `json:"id"`
 
-// PostZigzagMultipartRequestBody defines body for PostZigzag for multipart/form-data ContentType.
-type PostZigzagMultipartRequestBody = PostZigzagRequest
+	// Preferences ユーザの設定 (未設定の項目は省略する)
+	Preferences UserPreferences `json:"preferences"`
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(ctx context.Context, req *http.Request) error
+	// Profile SAMLアサーションから同期した属性 (ログインの度に更新する。idPから送られない属性は空)
+	Profile UserProfile `json:"profile"`
 
-// Doer performs HTTP requests.
-//
-// The standard http.Client implements this interface.
-type HttpRequestDoer interface {
-	Do(req *http.Request) (*http.Response, error)
+	// Roles SAMLアサーションから同期したロール
+	Roles []string `json:"roles"`
 }
 
-// Client which conforms to the OpenAPI3 specification for this service.
-type Client struct {
-	// The endpoint of the server conforming to this interface, with scheme,
-	// https://api.deepmap.com for example. This can contain a path relative
-	// to the server, such as https://api.deepmap.com/dev-test, and all the
-	// paths in the swagger spec will be appended to the server.
-	Server string
+// PostBacktestPortfolioRequest ポートフォリオのバックテストのリクエスト。
+// type・symbolは同じ個数を指定し、i番目のtypeがcsvの場合はcsvInfo・csvを、candlesの場合はcandlesを出現順に対応させる
+type PostBacktestPortfolioRequest struct {
+	// Account バックテストの口座設定 (省略した項目はサーバの設定値を使用する)
+	Account *BacktestAccount `json:"account,omitempty"`
+
+	// AccountCurrency 口座通貨。損益はアップロードしたローソク足から求めたクロスレートで口座通貨に換算する
+	AccountCurrency string     `json:"accountCurrency"`
+	Candles         *[]Candles `json:"candles,omitempty"`
+	Csv             *[]File    `json:"csv,omitempty"`
+	CsvInfo         *[]CsvInfo `json:"csvInfo,omitempty"`
+
+	// IntrabarPath 下位足が無い場合に想定するローソク足の中での値動き (省略時はpessimistic)
+	// - pessimistic: 損切りと利確が同じローソク足で発生した場合は損切りを優先する
+	// - ohlc: 始値→高値→安値→終値の順に値動きしたと想定する
+	// - olhc: 始値→安値→高値→終値の順に値動きしたと想定する
+	IntrabarPath *BacktestIntrabarPath `json:"intrabarPath,omitempty"`
+
+	// Orders 注文配列
+	Orders BacktestOrders `json:"orders"`
+
+	// Sizing 取引数量が未指定の注文に使用する資金管理モデル
+	// - fixedLot: 一定の取引数量 (lots)
+	// - fixedFractional: 損切り価格までの損失が有効証拠金のriskPercent[%]となる取引数量 (riskPercent)
+	// - fixedRatio: 確定利益がdelta増える毎にlotsずつ取引数量を増やす (lots, delta)
+	// - kelly: ケリー基準の割合にkellyFractionを掛けた損失を許容する取引数量 (winRate, payoffRatio, kellyFraction)
+	// - volatility: ATRのatrMultiple倍の値動きでの損失が有効証拠金のriskPercent[%]となる取引数量 (riskPercent, atrPeriod, atrMultiple)
+	Sizing *BacktestSizing `json:"sizing,omitempty"`
+
+	// Symbol 入力データ毎のシンボル名 (重複不可)
+	Symbol []string `json:"symbol"`
+
+	// Type 入力データのタイプ (シンボル毎に指定する)
+	Type []PostBacktestPortfolioRequestType `json:"type"`
+}
 
-	// Doer for performing requests, typically a *http.Client with any
-	// customized settings, such as certificate chains.
-	Client HttpRequestDoer
+// PostBacktestPortfolioRequestType defines model for PostBacktestPortfolioRequest.Type.
+type PostBacktestPortfolioRequestType string
 
-	// A list of callbacks for modifying requests which are generated before sending over
-	// the network.
-	RequestEditors []RequestEditorFn
-}
+// PostBacktestPortfolioResult defines model for PostBacktestPortfolioResult.
+type PostBacktestPortfolioResult struct {
+	AccountCurrency string `json:"accountCurrency"`
 
-// ClientOption allows setting custom parameters during construction
-type ClientOption func(*Client) error
+	// Correlation シンボル間の日次損益(UTC基準)の相関係数行列
+	Correlation BacktestCorrelation `json:"correlation"`
 
-// Creates a new Client, with reasonable defaults
-func NewClient(server string, opts ...ClientOption) (*Client, error) {
-	// create a client with sane default values
-	client := Client{
-		Server: server,
-	}
-	// mutate client and add all optional params
-	for _, o := range opts {
-		if err := o(&client); err != nil {
-			return nil, err
-		}
-	}
-	// ensure the server URL always has a trailing slash
-	if !strings.HasSuffix(client.Server, "/") {
-		client.Server += "/"
-	}
-	// create httpClient, if not already present
-	if client.Client == nil {
-		client.Client = &http.Client{}
-	}
-	return &client, nil
-}
+	// Id 保存したバックテストのID (ログイン中の場合のみ)
+	Id *int64 `json:"id,omitempty"`
 
-// WithHTTPClient allows overriding the default Doer, which is
-// automatically created using http.Client. This is useful for tests.
-func WithHTTPClient(doer HttpRequestDoer) ClientOption {
-	return func(c *Client) error {
-		c.Client = doer
-		return nil
-	}
-}
+	// Summary バックテスト結果の集計 (金額は全て口座通貨建て。単一シンボルの場合は決済通貨を口座通貨とする)
+	Summary BacktestSummary        `json:"summary"`
+	Symbols []BacktestSymbolResult `json:"symbols"`
 
-// WithRequestEditorFn allows setting up a callback function, which will be
-// called right before sending the request. This can be used to mutate the request.
-func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
-	return func(c *Client) error {
-		c.RequestEditors = append(c.RequestEditors, fn)
-		return nil
-	}
+	// Trades 全シンボルの取引 (決済日時順)
+	Trades []BacktestTrade `json:"trades"`
 }
 
-// The interface specification for the client above.
-type ClientInterface interface {
-	// PostSamlAcsWithBody request with any body
-	PostSamlAcsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+// PostBacktestRequest defines model for PostBacktestRequest.
+type PostBacktestRequest struct {
+	// Account バックテストの口座設定 (省略した項目はサーバの設定値を使用する)
+	Account *BacktestAccount `json:"account,omitempty"`
 
-	PostSamlAcsWithFormdataBody(ctx context.Context, body PostSamlAcsFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// Candles ローソク足配列
+	Candles *Candles `json:"candles,omitempty"`
 
-	// GetSamlError request
-	GetSamlError(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// Csv ファイルのテキストまたはバイナリデータ
+	Csv     *File    `json:"csv,omitempty"`
+	CsvInfo *CsvInfo `json:"csvInfo,omitempty"`
 
-	// GetSamlLogin request
-	GetSamlLogin(ctx context.Context, params *GetSamlLoginParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// IntrabarPath 下位足が無い場合に想定するローソク足の中での値動き (省略時はpessimistic)
+	// - pessimistic: 損切りと利確が同じローソク足で発生した場合は損切りを優先する
+	// - ohlc: 始値→高値→安値→終値の順に値動きしたと想定する
+	// - olhc: 始値→安値→高値→終値の順に値動きしたと想定する
+	IntrabarPath *BacktestIntrabarPath `json:"intrabarPath,omitempty"`
 
-	// GetSamlLogout request
-	GetSamlLogout(ctx context.Context, params *GetSamlLogoutParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// Orders 注文配列
+	Orders BacktestOrders `json:"orders"`
 
-	// PostSamlSloWithBody request with any body
-	PostSamlSloWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// Sizing 取引数量が未指定の注文に使用する資金管理モデル
+	// - fixedLot: 一定の取引数量 (lots)
+	// - fixedFractional: 損切り価格までの損失が有効証拠金のriskPercent[%]となる取引数量 (riskPercent)
+	// - fixedRatio: 確定利益がdelta増える毎にlotsずつ取引数量を増やす (lots, delta)
+	// - kelly: ケリー基準の割合にkellyFractionを掛けた損失を許容する取引数量 (winRate, payoffRatio, kellyFraction)
+	// - volatility: ATRのatrMultiple倍の値動きでの損失が有効証拠金のriskPercent[%]となる取引数量 (riskPercent, atrPeriod, atrMultiple)
+	Sizing *BacktestSizing `json:"sizing,omitempty"`
 
-	PostSamlSloWithFormdataBody(ctx context.Context, body PostSamlSloFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// Symbol シンボル名 (取引コストの設定に使用する)
+	Symbol string `json:"symbol"`
 
-	// GetWsUuid request
-	GetWsUuid(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// Timeframe バックテストを行う時間足。指定した場合はアップロードしたローソク足を下位足として扱い、
+	// 指定した時間足に集約したローソク足でバックテストを行う。損切り・利確の約定順序は下位足の値動きで判定する
+	Timeframe *PostBacktestRequestTimeframe `json:"timeframe,omitempty"`
 
-	// PostZigzagWithBody request with any body
-	PostZigzagWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// Type 入力データのタイプ
+	Type PostBacktestRequestType `json:"type"`
 }
 
-func (c *Client) PostSamlAcsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSamlAcsRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
+// PostBacktestRequestTimeframe バックテストを行う時間足。指定した場合はアップロードしたローソク足を下位足として扱い、
+// 指定した時間足に集約したローソク足でバックテストを行う。損切り・利確の約定順序は下位足の値動きで判定する
+type PostBacktestRequestTimeframe string
 
-func (c *Client) PostSamlAcsWithFormdataBody(ctx context.Context, body PostSamlAcsFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSamlAcsRequestWithFormdataBody(c.Server, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
+// PostBacktestRequestType 入力データのタイプ
+type PostBacktestRequestType string
 
-func (c *Client) GetSamlError(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSamlErrorRequest(c.Server)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
+// PostBacktestResult defines model for PostBacktestResult.
+type PostBacktestResult struct {
+	// Id 保存したバックテストのID (ログイン中の場合のみ)
+	Id *int64 `json:"id,omitempty"`
 
-func (c *Client) GetSamlLogin(ctx context.Context, params *GetSamlLoginParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSamlLoginRequest(c.Server, params)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	// Summary バックテスト結果の集計 (金額は全て口座通貨建て。単一シンボルの場合は決済通貨を口座通貨とする)
+	Summary BacktestSummary `json:"summary"`
+	Symbol  string          `json:"symbol"`
+	Trades  []BacktestTrade `json:"trades"`
 }
 
-func (c *Client) GetSamlLogout(ctx context.Context, params *GetSamlLogoutParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSamlLogoutRequest(c.Server, params)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
+// PostBacktestsCompareRequest defines model for PostBacktestsCompareRequest.
+type PostBacktestsCompareRequest struct {
+	// Ids 比較するバックテストのID (2件以上、重複不可)
+	Ids []int64 `json:"ids"`
 
-func (c *Client) PostSamlSloWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSamlSloRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	// Timeframe 資産曲線を揃える時間軸の間隔 (省略時はD1)
+	Timeframe *PostBacktestsCompareRequestTimeframe `json:"timeframe,omitempty"`
 }
 
-func (c *Client) PostSamlSloWithFormdataBody(ctx context.Context, body PostSamlSloFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSamlSloRequestWithFormdataBody(c.Server, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
+// PostBacktestsCompareRequestTimeframe 資産曲線を揃える時間軸の間隔 (省略時はD1)
+type PostBacktestsCompareRequestTimeframe string
 
-func (c *Client) GetWsUuid(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetWsUuidRequest(c.Server)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
+// PostBacktestsCompareResult defines model for PostBacktestsCompareResult.
+type PostBacktestsCompareResult struct {
+	// Equity 共通の時間軸に揃えた資産曲線 (各時刻までに決済した取引の損益を初期の口座残高に加えた値。評価損益は含まない)
+	Equity  BacktestEquityCurves `json:"equity"`
+	Metrics []BacktestMetricRow  `json:"metrics"`
 
-func (c *Client) PostZigzagWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostZigzagRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	// Runs 比較したバックテスト (リクエストのidsの順)
+	Runs       []BacktestRun       `json:"runs"`
+	TradeDiffs []BacktestTradeDiff `json:"tradeDiffs"`
 }
 
-// NewPostSamlAcsRequestWithFormdataBody calls the generic PostSamlAcs builder with application/x-www-form-urlencoded body
-func NewPostSamlAcsRequestWithFormdataBody(server string, body PostSamlAcsFormdataRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	bodyStr, err := runtime.MarshalForm(body, nil)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = strings.NewReader(bodyStr.Encode())
-	return NewPostSamlAcsRequestWithBody(server, "application/x-www-form-urlencoded", bodyReader)
-}
+// PostChartsRequest defines model for PostChartsRequest.
+type PostChartsRequest struct {
+	// Candles ローソク足配列
+	Candles *Candles `json:"candles,omitempty"`
 
-// NewPostSamlAcsRequestWithBody generates requests for PostSamlAcs with any type of body
-func NewPostSamlAcsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+	// Csv ファイルのテキストまたはバイナリデータ
+	Csv     *File    `json:"csv,omitempty"`
+	CsvInfo *CsvInfo `json:"csvInfo,omitempty"`
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+	// Format 画像の形式
+	Format *PostChartsRequestFormat `json:"format,omitempty"`
 
-	operationPath := fmt.Sprintf("/saml/acs")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	// From 描画する期間の開始日時 (省略時は先頭のローソク足から)
+	From *string `json:"from,omitempty"`
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+	// Height 画像の高さ[px] (省略時は設定の既定値)
+	Height *int `json:"height,omitempty"`
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
-	}
+	// Lines ローソク足に重ねて描画する線 (JSON配列の文字列で指定する)
+	Lines *[]ChartLine `json:"lines,omitempty"`
 
-	req.Header.Add("Content-Type", contentType)
+	// To 描画する期間の終了日時 (省略時は末尾のローソク足まで)
+	To *string `json:"to,omitempty"`
 
-	return req, nil
+	// Type 入力データのタイプ
+	Type PostChartsRequestType `json:"type"`
+
+	// Width 画像の幅[px] (省略時は設定の既定値)
+	Width *int `json:"width,omitempty"`
+
+	// Zigzag ジグザグと天井・底を重ねて描画するか
+	Zigzag *bool `json:"zigzag,omitempty"`
 }
 
-// NewGetSamlErrorRequest generates requests for GetSamlError
-func NewGetSamlErrorRequest(server string) (*http.Request, error) {
-	var err error
+// PostChartsRequestFormat 画像の形式
+type PostChartsRequestFormat string
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+// PostChartsRequestType 入力データのタイプ
+type PostChartsRequestType string
 
-	operationPath := fmt.Sprintf("/saml/error")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+// PostIndicatorsExportRequest defines model for PostIndicatorsExportRequest.
+type PostIndicatorsExportRequest struct {
+	// Candles ローソク足配列
+	Candles *Candles `json:"candles,omitempty"`
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+	// Csv ファイルのテキストまたはバイナリデータ
+	Csv     *File    `json:"csv,omitempty"`
+	CsvInfo *CsvInfo `json:"csvInfo,omitempty"`
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	// Indicators 出力するインジケーター (JSON配列の文字列で指定する。指定順に列を出力する)
+	Indicators []Indicator `json:"indicators"`
 
-	return req, nil
+	// Type 入力データのタイプ
+	Type PostIndicatorsExportRequestType `json:"type"`
 }
 
-// NewGetSamlLoginRequest generates requests for GetSamlLogin
-func NewGetSamlLoginRequest(server string, params *GetSamlLoginParams) (*http.Request, error) {
-	var err error
+// PostIndicatorsExportRequestType 入力データのタイプ
+type PostIndicatorsExportRequestType string
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+// PostTokensRequest defines model for PostTokensRequest.
+type PostTokensRequest struct {
+	// ExpiresInDays 有効日数 (省略時はsettings/config.yamlのapiToken.defaultExpiresDays)
+	ExpiresInDays *int `json:"expiresInDays,omitempty"`
 
-	operationPath := fmt.Sprintf("/saml/login")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	// Name トークン名 (ユーザ毎に重複不可)
+	Name string `json:"name"`
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+	// Scopes トークンに許可するスコープ (1件以上)
+	Scopes []ApiTokenScope `json:"scopes"`
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+// PostTokensResult defines model for PostTokensResult.
+type PostTokensResult struct {
+	// Item 個人用APIトークン (トークン自体は発行時のみ返却する)
+	Item ApiToken `json:"item"`
 
-	if params != nil {
+	// Token Authorization ヘッダに "Bearer <token>" の形式で指定するトークン (この応答でのみ返却する)
+	Token string `json:"token"`
+}
 
-		var headerParam0 string
+// PostZigzagRequest defines model for PostZigzagRequest.
+type PostZigzagRequest struct {
+	// Candles ローソク足配列
+	Candles *Candles `json:"candles,omitempty"`
 
-		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL", runtime.ParamLocationHeader, params.XRedirectURL)
-		if err != nil {
-			return nil, err
-		}
+	// Csv ファイルのテキストまたはバイナリデータ
+	Csv     *File    `json:"csv,omitempty"`
+	CsvInfo *CsvInfo `json:"csvInfo,omitempty"`
 
-		req.Header.Set("X-Redirect-URL", headerParam0)
+	// Symbol シンボル名 (指定した場合は値幅をpipsでも返却する)
+	Symbol *string `json:"symbol,omitempty"`
 
-		var headerParam1 string
+	// Type 入力データのタイプ
+	Type PostZigzagRequestType `json:"type"`
+}
 
-		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL-On-Error", runtime.ParamLocationHeader, params.XRedirectURLOnError)
-		if err != nil {
-			return nil, err
-		}
+// PostZigzagRequestType 入力データのタイプ
+type PostZigzagRequestType string
 
-		req.Header.Set("X-Redirect-URL-On-Error", headerParam1)
+// PostZigzagResult defines model for PostZigzagResult.
+type PostZigzagResult struct {
+	Count int      `json:"count"`
+	Items []Zigzag `json:"items"`
+}
 
-	}
+// SAMLForm defines model for SAMLForm.
+type SAMLForm = string
 
-	return req, nil
+// SAMLRequest defines model for SAMLRequest.
+type SAMLRequest struct {
+	RelayState  *string `json:"RelayState,omitempty"`
+	SAMLReqeust *string `json:"SAMLReqeust,omitempty"`
 }
 
-// NewGetSamlLogoutRequest generates requests for GetSamlLogout
-func NewGetSamlLogoutRequest(server string, params *GetSamlLogoutParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+// SAMLResponse defines model for SAMLResponse.
+type SAMLResponse struct {
+	RelayState   *string `json:"RelayState,omitempty"`
+	SAMLResponse *string `json:"SAMLResponse,omitempty"`
+}
 
-	operationPath := fmt.Sprintf("/saml/logout")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+// SamlIdpHealth defines model for SamlIdpHealth.
+type SamlIdpHealth struct {
+	// EntityId idPのEntityID
+	EntityId string `json:"entityId"`
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+	// FetchedAt 使用中のメタデータを取得した日時
+	FetchedAt string `json:"fetchedAt"`
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	// MetadataAgeSec 使用中のメタデータを取得してからの経過秒数
+	MetadataAgeSec int64 `json:"metadataAgeSec"`
 
-	if params != nil {
+	// Name idPの名前 (settings/config.yamlのsaml.idps。既定のidPはdefault)
+	Name string `json:"name"`
 
-		var headerParam0 string
+	// NextRefreshAt 次にメタデータを再取得する日時 (再取得しない場合は省略)
+	NextRefreshAt *string `json:"nextRefreshAt,omitempty"`
 
-		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL", runtime.ParamLocationHeader, params.XRedirectURL)
-		if err != nil {
-			return nil, err
-		}
+	// RefreshFailed 直近のメタデータの再取得に失敗した場合はtrue (最後に取得できたメタデータを使用し続ける)
+	RefreshFailed bool `json:"refreshFailed"`
 
-		req.Header.Set("X-Redirect-URL", headerParam0)
+	// ValidUntil メタデータの有効期限 (メタデータにvalidUntilが無い場合は省略)
+	ValidUntil *string `json:"validUntil,omitempty"`
+}
 
-		var headerParam1 string
+// SymbolInfo シンボルのメタデータ
+type SymbolInfo struct {
+	// Base 基軸通貨
+	Base string `json:"base"`
 
-		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL-On-Error", runtime.ParamLocationHeader, params.XRedirectURLOnError)
-		if err != nil {
-			return nil, err
-		}
+	// ContractSize 1ロットあたりの通貨量
+	ContractSize float64 `json:"contractSize"`
 
-		req.Header.Set("X-Redirect-URL-On-Error", headerParam1)
+	// Digits 価格の小数点以下の桁数
+	Digits int    `json:"digits"`
+	Name   string `json:"name"`
 
-	}
+	// PipSize 1pipの価格幅
+	PipSize float64 `json:"pipSize"`
 
-	return req, nil
+	// Quote 決済通貨
+	Quote    string          `json:"quote"`
+	Sessions []SymbolSession `json:"sessions"`
 }
 
-// NewPostSamlSloRequestWithFormdataBody calls the generic PostSamlSlo builder with application/x-www-form-urlencoded body
-func NewPostSamlSloRequestWithFormdataBody(server string, body PostSamlSloFormdataRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	bodyStr, err := runtime.MarshalForm(body, nil)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = strings.NewReader(bodyStr.Encode())
-	return NewPostSamlSloRequestWithBody(server, "application/x-www-form-urlencoded", bodyReader)
-}
+// SymbolSession 取引セッション
+type SymbolSession struct {
+	// Close 終了時刻 (UTCのHH:MM)
+	Close string `json:"close"`
+	Name  string `json:"name"`
 
-// NewPostSamlSloRequestWithBody generates requests for PostSamlSlo with any type of body
-func NewPostSamlSloRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+	// Open 開始時刻 (UTCのHH:MM)
+	Open string `json:"open"`
+}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+// UserPreferences ユーザの設定 (未設定の項目は省略する)
+type UserPreferences struct {
+	// CsvInfoTemplates 既定のCSV情報のテンプレート (名前の重複不可)
+	CsvInfoTemplates *[]CsvInfoTemplate `json:"csvInfoTemplates,omitempty"`
 
-	operationPath := fmt.Sprintf("/saml/slo")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	// Display 表示設定
+	Display *DisplayPreferences `json:"display,omitempty"`
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+	// Locale 既定のロケール (Accept-Languageより優先する)
+	Locale *UserPreferencesLocale `json:"locale,omitempty"`
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
-	}
+	// Timezone CSVのタイムゾーン指定の無い時間を解釈するタイムゾーン (IANAのタイムゾーン名)
+	Timezone *string `json:"timezone,omitempty"`
+}
 
-	req.Header.Add("Content-Type", contentType)
+// UserPreferencesLocale 既定のロケール (Accept-Languageより優先する)
+type UserPreferencesLocale string
 
-	return req, nil
+// UserProfile SAMLアサーションから同期した属性 (ログインの度に更新する。idPから送られない属性は空)
+type UserProfile struct {
+	DisplayName string   `json:"displayName"`
+	FamilyName  string   `json:"familyName"`
+	GivenName   string   `json:"givenName"`
+	Groups      []string `json:"groups"`
+	Locale      string   `json:"locale"`
 }
 
-// NewGetWsUuidRequest generates requests for GetWsUuid
-func NewGetWsUuidRequest(server string) (*http.Request, error) {
-	var err error
+// UserSession ログイン中の端末のセッション
+type UserSession struct {
+	// CreatedAt ログイン日時
+	CreatedAt string `json:"createdAt"`
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+	// Current リクエストしたセッションの場合はtrue
+	Current bool `json:"current"`
 
-	operationPath := fmt.Sprintf("/ws/:uuid")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	// Device User-Agentから判定した端末の説明
+	Device string `json:"device"`
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+	// Id セッションID
+	Id string `json:"id"`
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	// IpAddress 最後にアクセスしたIPアドレス
+	IpAddress string `json:"ipAddress"`
 
-	return req, nil
+	// LastSeenAt 最終アクセス日時
+	LastSeenAt string `json:"lastSeenAt"`
+
+	// UserAgent 最後にアクセスしたUser-Agent
+	UserAgent string `json:"userAgent"`
 }
 
-// NewPostZigzagRequestWithBody generates requests for PostZigzag with any type of body
-func NewPostZigzagRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+// Zigzag defines model for Zigzag.
+type Zigzag struct {
+	BottomIndex int     `json:"bottomIndex"`
+	Delta       float32 `json:"delta"`
+
+	// DeltaPips 値幅(pips)。symbolを指定した場合のみ
+	DeltaPips *float32    `json:"deltaPips,omitempty"`
+	Kind      interface{} `json:"kind"`
+	PeakIndex int         `json:"peakIndex"`
+	StartTime string      `json:"startTime"`
+	Velocity  float32     `json:"velocity"`
+
+	// VelocityPips 1ローソク足あたりの値幅(pips)。symbolを指定した場合のみ
+	VelocityPips *float32 `json:"velocityPips,omitempty"`
+}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+// ExportFormat defines model for ExportFormat.
+type ExportFormat string
 
-	operationPath := fmt.Sprintf("/zigzag")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+// GetAdminAuditParams defines parameters for GetAdminAudit.
+type GetAdminAuditParams struct {
+	Type    *AuditEventType    `form:"type,omitempty" json:"type,omitempty"`
+	Outcome *AuditEventOutcome `form:"outcome,omitempty" json:"outcome,omitempty"`
+	UserId  *int64             `form:"userId,omitempty" json:"userId,omitempty"`
+	Email   *string            `form:"email,omitempty" json:"email,omitempty"`
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+	// From 発生日時の開始 (この日時を含む。RFC3339)
+	From *string `form:"from,omitempty" json:"from,omitempty"`
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
-	}
+	// To 発生日時の終了 (この日時を含まない。RFC3339)
+	To *string `form:"to,omitempty" json:"to,omitempty"`
 
-	req.Header.Add("Content-Type", contentType)
+	// Limit 1ページの件数 (省略時は50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
-	return req, nil
+	// Offset 先頭から読み飛ばす件数 (省略時は0)
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	return nil
+// GetBacktestsIdExportParams defines parameters for GetBacktestsIdExport.
+type GetBacktestsIdExportParams struct {
+	// Format 出力形式 (省略時はAcceptヘッダで決定し、Acceptヘッダも未指定の場合はcsv)
+	// - csv: text/csv (見出しはAccept-Languageで多言語化)
+	// - ndjson: application/x-ndjson (1行1オブジェクト。キーは列名)
+	// - xlsx: application/vnd.openxmlformats-officedocument.spreadsheetml.sheet (見出しはAccept-Languageで多言語化)
+	Format *GetBacktestsIdExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// GetBacktestsIdExportParamsFormat defines parameters for GetBacktestsIdExport.
+type GetBacktestsIdExportParamsFormat string
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return &ClientWithResponses{client}, nil
+// PostIndicatorsExportParams defines parameters for PostIndicatorsExport.
+type PostIndicatorsExportParams struct {
+	// Format 出力形式 (省略時はAcceptヘッダで決定し、Acceptヘッダも未指定の場合はcsv)
+	// - csv: text/csv (見出しはAccept-Languageで多言語化)
+	// - ndjson: application/x-ndjson (1行1オブジェクト。キーは列名)
+	// - xlsx: application/vnd.openxmlformats-officedocument.spreadsheetml.sheet (見出しはAccept-Languageで多言語化)
+	Format *PostIndicatorsExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
-	}
-}
+// PostIndicatorsExportParamsFormat defines parameters for PostIndicatorsExport.
+type PostIndicatorsExportParamsFormat string
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// PostSamlAcsWithBodyWithResponse request with any body
-	PostSamlAcsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSamlAcsResponse, error)
+// GetOidcCallbackParams defines parameters for GetOidcCallback.
+type GetOidcCallbackParams struct {
+	// Code 認可コード
+	Code *string `form:"code,omitempty" json:"code,omitempty"`
 
-	PostSamlAcsWithFormdataBodyWithResponse(ctx context.Context, body PostSamlAcsFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostSamlAcsResponse, error)
+	// State 認可リクエストで指定したstate
+	State *string `form:"state,omitempty" json:"state,omitempty"`
 
-	// GetSamlErrorWithResponse request
-	GetSamlErrorWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSamlErrorResponse, error)
+	// Error 認可エラーのエラーコード
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
 
-	// GetSamlLoginWithResponse request
-	GetSamlLoginWithResponse(ctx context.Context, params *GetSamlLoginParams, reqEditors ...RequestEditorFn) (*GetSamlLoginResponse, error)
+	// ErrorDescription 認可エラーの詳細
+	ErrorDescription *string `form:"error_description,omitempty" json:"error_description,omitempty"`
+}
 
-	// GetSamlLogoutWithResponse request
-	GetSamlLogoutWithResponse(ctx context.Context, params *GetSamlLogoutParams, reqEditors ...RequestEditorFn) (*GetSamlLogoutResponse, error)
+// GetOidcLoginParams defines parameters for GetOidcLogin.
+type GetOidcLoginParams struct {
+	// XRedirectURL ログイン完了時にリダイレクトさせたいURLを指定する
+	XRedirectURL string `json:"X-Redirect-URL"`
 
-	// PostSamlSloWithBodyWithResponse request with any body
-	PostSamlSloWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSamlSloResponse, error)
+	// XRedirectURLOnError ログインエラー時にリダイレクトさせたいURLを指定する
+	XRedirectURLOnError string `json:"X-Redirect-URL-On-Error"`
+}
 
-	PostSamlSloWithFormdataBodyWithResponse(ctx context.Context, body PostSamlSloFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostSamlSloResponse, error)
+// GetOidcLogoutParams defines parameters for GetOidcLogout.
+type GetOidcLogoutParams struct {
+	// XRedirectURL ログアウト完了時にリダイレクトさせたいURLを指定する
+	XRedirectURL string `json:"X-Redirect-URL"`
+}
 
-	// GetWsUuidWithResponse request
-	GetWsUuidWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWsUuidResponse, error)
+// GetSamlLoginParams defines parameters for GetSamlLogin.
+type GetSamlLoginParams struct {
+	// Idp ログインに使用するidPの名前 (settings/config.yamlのsaml.idps)。未指定の場合はemailのドメインから選択し、一致しない場合は既定のidPを使用する
+	Idp *string `form:"idp,omitempty" json:"idp,omitempty"`
 
-	// PostZigzagWithBodyWithResponse request with any body
-	PostZigzagWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostZigzagResponse, error)
-}
+	// Email ログインするユーザのEmail。idPの選択にのみ使用する
+	Email *string `form:"email,omitempty" json:"email,omitempty"`
 
-type PostSamlAcsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-}
+	// XRedirectURL シングルサインオン完了時にリダイレクトさせたいURLを指定する
+	XRedirectURL string `json:"X-Redirect-URL"`
 
-// Status returns HTTPResponse.Status
-func (r PostSamlAcsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+	// XRedirectURLOnError シングルサインオンエラー時にリダイレクトさせたいURLを指定する
+	XRedirectURLOnError string `json:"X-Redirect-URL-On-Error"`
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostSamlAcsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+// GetSamlLogoutParams defines parameters for GetSamlLogout.
+type GetSamlLogoutParams struct {
+	// XRedirectURL シングルログアウト完了時にリダイレクトさせたいURLを指定する
+	XRedirectURL string `json:"X-Redirect-URL"`
+
+	// XRedirectURLOnError シングルログアウトのエラー時にリダイレクトさせたいURLを指定する
+	XRedirectURLOnError string `json:"X-Redirect-URL-On-Error"`
 }
 
-type GetSamlErrorResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ErrorWithTime
-	JSONDefault  *Error
+// GetSamlSloParams defines parameters for GetSamlSlo.
+type GetSamlSloParams struct {
+	// SAMLRequest DEFLATEで圧縮しbase64でエンコードしたLogoutRequest
+	SAMLRequest *string `form:"SAMLRequest,omitempty" json:"SAMLRequest,omitempty"`
+
+	// SAMLResponse DEFLATEで圧縮しbase64でエンコードしたLogoutResponse
+	SAMLResponse *string `form:"SAMLResponse,omitempty" json:"SAMLResponse,omitempty"`
+	RelayState   *string `form:"RelayState,omitempty" json:"RelayState,omitempty"`
+
+	// SigAlg 署名アルゴリズム (e.g. http://www.w3.org/2001/04/xmldsig-more#rsa-sha256)
+	SigAlg *string `form:"SigAlg,omitempty" json:"SigAlg,omitempty"`
+
+	// Signature クエリ文字列の署名
+	Signature *string `form:"Signature,omitempty" json:"Signature,omitempty"`
 }
 
-// Status returns HTTPResponse.Status
-func (r GetSamlErrorResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+// PostSamlSloFormdataBody defines parameters for PostSamlSlo.
+type PostSamlSloFormdataBody struct {
+	union json.RawMessage
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSamlErrorResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+// PostZigzagExportParams defines parameters for PostZigzagExport.
+type PostZigzagExportParams struct {
+	// Format 出力形式 (省略時はAcceptヘッダで決定し、Acceptヘッダも未指定の場合はcsv)
+	// - csv: text/csv (見出しはAccept-Languageで多言語化)
+	// - ndjson: application/x-ndjson (1行1オブジェクト。キーは列名)
+	// - xlsx: application/vnd.openxmlformats-officedocument.spreadsheetml.sheet (見出しはAccept-Languageで多言語化)
+	Format *PostZigzagExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
 }
 
-type GetSamlLoginResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *Error
-}
+// PostZigzagExportParamsFormat defines parameters for PostZigzagExport.
+type PostZigzagExportParamsFormat string
 
-// Status returns HTTPResponse.Status
-func (r GetSamlLoginResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+// PostBacktestMultipartRequestBody defines body for PostBacktest for multipart/form-data ContentType.
+type PostBacktestMultipartRequestBody = PostBacktestRequest
+
+// PostBacktestPortfolioMultipartRequestBody defines body for PostBacktestPortfolio for multipart/form-data ContentType.
+type PostBacktestPortfolioMultipartRequestBody = PostBacktestPortfolioRequest
+
+// PostBacktestsCompareJSONRequestBody defines body for PostBacktestsCompare for application/json ContentType.
+type PostBacktestsCompareJSONRequestBody = PostBacktestsCompareRequest
+
+// PostChartsMultipartRequestBody defines body for PostCharts for multipart/form-data ContentType.
+type PostChartsMultipartRequestBody = PostChartsRequest
+
+// PostIndicatorsExportMultipartRequestBody defines body for PostIndicatorsExport for multipart/form-data ContentType.
+type PostIndicatorsExportMultipartRequestBody = PostIndicatorsExportRequest
+
+// PatchMeJSONRequestBody defines body for PatchMe for application/json ContentType.
+type PatchMeJSONRequestBody = UserPreferences
+
+// PostSamlAcsFormdataRequestBody defines body for PostSamlAcs for application/x-www-form-urlencoded ContentType.
+type PostSamlAcsFormdataRequestBody = SAMLResponse
+
+// PostSamlSloFormdataRequestBody defines body for PostSamlSlo for application/x-www-form-urlencoded ContentType.
+type PostSamlSloFormdataRequestBody PostSamlSloFormdataBody
+
+// PostTokensJSONRequestBody defines body for PostTokens for application/json ContentType.
+type PostTokensJSONRequestBody = PostTokensRequest
+
+// PostZigzagMultipartRequestBody defines body for PostZigzag for multipart/form-data ContentType.
+type PostZigzagMultipartRequestBody = PostZigzagRequest
+
+// PostZigzagExportMultipartRequestBody defines body for PostZigzagExport for multipart/form-data ContentType.
+type PostZigzagExportMultipartRequestBody = PostZigzagRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSamlLoginResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
 }
 
-type GetSamlLogoutResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *Error
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetSamlLogoutResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
 	}
-	return http.StatusText(0)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSamlLogoutResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
 	}
-	return 0
 }
 
-type PostSamlSloResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAdminAudit request
+	GetAdminAudit(ctx context.Context, params *GetAdminAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminUsers request
+	GetAdminUsers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthRefresh request
+	PostAuthRefresh(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostBacktestWithBody request with any body
+	PostBacktestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostBacktestPortfolioWithBody request with any body
+	PostBacktestPortfolioWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBacktests request
+	GetBacktests(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostBacktestsCompareWithBody request with any body
+	PostBacktestsCompareWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostBacktestsCompare(ctx context.Context, body PostBacktestsCompareJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteBacktestsId request
+	DeleteBacktestsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBacktestsId request
+	GetBacktestsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBacktestsIdExport request
+	GetBacktestsIdExport(ctx context.Context, id int64, params *GetBacktestsIdExportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostChartsWithBody request with any body
+	PostChartsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealth request
+	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostIndicatorsExportWithBody request with any body
+	PostIndicatorsExportWithBody(ctx context.Context, params *PostIndicatorsExportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMe request
+	GetMe(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchMeWithBody request with any body
+	PatchMeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchMe(ctx context.Context, body PatchMeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOidcCallback request
+	GetOidcCallback(ctx context.Context, params *GetOidcCallbackParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOidcLogin request
+	GetOidcLogin(ctx context.Context, params *GetOidcLoginParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOidcLogout request
+	GetOidcLogout(ctx context.Context, params *GetOidcLogoutParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSamlAcsWithBody request with any body
+	PostSamlAcsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSamlAcsWithFormdataBody(ctx context.Context, body PostSamlAcsFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSamlError request
+	GetSamlError(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSamlLogin request
+	GetSamlLogin(ctx context.Context, params *GetSamlLoginParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSamlLogout request
+	GetSamlLogout(ctx context.Context, params *GetSamlLogoutParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSamlMetadata request
+	GetSamlMetadata(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSamlSlo request
+	GetSamlSlo(ctx context.Context, params *GetSamlSloParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSamlSloWithBody request with any body
+	PostSamlSloWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSamlSloWithFormdataBody(ctx context.Context, body PostSamlSloFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSessions request
+	GetSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteSessionsId request
+	DeleteSessionsId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSymbols request
+	GetSymbols(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTokens request
+	GetTokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTokensWithBody request with any body
+	PostTokensWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTokens(ctx context.Context, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteTokensId request
+	DeleteTokensId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWsUuid request
+	GetWsUuid(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostZigzagWithBody request with any body
+	PostZigzagWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostZigzagExportWithBody request with any body
+	PostZigzagExportWithBody(ctx context.Context, params *PostZigzagExportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-// Status returns HTTPResponse.Status
-func (r PostSamlSloResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) GetAdminAudit(ctx context.Context, params *GetAdminAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminAuditRequest(c.Server, params)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostSamlSloResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) GetAdminUsers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminUsersRequest(c.Server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GetWsUuidResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *Error
-	JSON401      *Error
-	JSON500      *Error
+func (c *Client) PostAuthRefresh(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthRefreshRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetWsUuidResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PostBacktestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBacktestRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetWsUuidResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) PostBacktestPortfolioWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBacktestPortfolioRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type PostZigzagResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *PostZigzagResult
-	JSON400      *Error
-	JSON401      *Error
-	JSON500      *Error
+func (c *Client) GetBacktests(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBacktestsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r PostZigzagResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PostBacktestsCompareWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBacktestsCompareRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostBacktestsCompare(ctx context.Context, body PostBacktestsCompareJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBacktestsCompareRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteBacktestsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteBacktestsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBacktestsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBacktestsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBacktestsIdExport(ctx context.Context, id int64, params *GetBacktestsIdExportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBacktestsIdExportRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostChartsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostChartsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostIndicatorsExportWithBody(ctx context.Context, params *PostIndicatorsExportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIndicatorsExportRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetMe(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMeRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchMeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchMeRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchMe(ctx context.Context, body PatchMeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchMeRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOidcCallback(ctx context.Context, params *GetOidcCallbackParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOidcCallbackRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOidcLogin(ctx context.Context, params *GetOidcLoginParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOidcLoginRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOidcLogout(ctx context.Context, params *GetOidcLogoutParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOidcLogoutRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSamlAcsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSamlAcsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSamlAcsWithFormdataBody(ctx context.Context, body PostSamlAcsFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSamlAcsRequestWithFormdataBody(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSamlError(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSamlErrorRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSamlLogin(ctx context.Context, params *GetSamlLoginParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSamlLoginRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSamlLogout(ctx context.Context, params *GetSamlLogoutParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSamlLogoutRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSamlMetadata(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSamlMetadataRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSamlSlo(ctx context.Context, params *GetSamlSloParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSamlSloRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSamlSloWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSamlSloRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSamlSloWithFormdataBody(ctx context.Context, body PostSamlSloFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSamlSloRequestWithFormdataBody(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSessionsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteSessionsId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSessionsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSymbols(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSymbolsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTokensRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTokensWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTokensRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTokens(ctx context.Context, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTokensRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteTokensId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTokensIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWsUuid(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWsUuidRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostZigzagWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostZigzagRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostZigzagExportWithBody(ctx context.Context, params *PostZigzagExportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostZigzagExportRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAdminAuditRequest generates requests for GetAdminAudit
+func NewGetAdminAuditRequest(server string, params *GetAdminAuditParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/audit")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Type != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Outcome != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "outcome", runtime.ParamLocationQuery, *params.Outcome); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.UserId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "userId", runtime.ParamLocationQuery, *params.UserId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Email != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "email", runtime.ParamLocationQuery, *params.Email); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminUsersRequest generates requests for GetAdminUsers
+func NewGetAdminUsersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAuthRefreshRequest generates requests for PostAuthRefresh
+func NewPostAuthRefreshRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/refresh")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostBacktestRequestWithBody generates requests for PostBacktest with any type of body
+func NewPostBacktestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/backtest")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostBacktestPortfolioRequestWithBody generates requests for PostBacktestPortfolio with any type of body
+func NewPostBacktestPortfolioRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/backtest/portfolio")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetBacktestsRequest generates requests for GetBacktests
+func NewGetBacktestsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/backtests")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostBacktestsCompareRequest calls the generic PostBacktestsCompare builder with application/json body
+func NewPostBacktestsCompareRequest(server string, body PostBacktestsCompareJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostBacktestsCompareRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostBacktestsCompareRequestWithBody generates requests for PostBacktestsCompare with any type of body
+func NewPostBacktestsCompareRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/backtests/compare")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteBacktestsIdRequest generates requests for DeleteBacktestsId
+func NewDeleteBacktestsIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/backtests/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBacktestsIdRequest generates requests for GetBacktestsId
+func NewGetBacktestsIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/backtests/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBacktestsIdExportRequest generates requests for GetBacktestsIdExport
+func NewGetBacktestsIdExportRequest(server string, id int64, params *GetBacktestsIdExportParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/backtests/%s/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostChartsRequestWithBody generates requests for PostCharts with any type of body
+func NewPostChartsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/charts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostIndicatorsExportRequestWithBody generates requests for PostIndicatorsExport with any type of body
+func NewPostIndicatorsExportRequestWithBody(server string, params *PostIndicatorsExportParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/indicators/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetMeRequest generates requests for GetMe
+func NewGetMeRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchMeRequest calls the generic PatchMe builder with application/json body
+func NewPatchMeRequest(server string, body PatchMeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchMeRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPatchMeRequestWithBody generates requests for PatchMe with any type of body
+func NewPatchMeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetOidcCallbackRequest generates requests for GetOidcCallback
+func NewGetOidcCallbackRequest(server string, params *GetOidcCallbackParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/oidc/callback")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Code != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "code", runtime.ParamLocationQuery, *params.Code); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.State != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "state", runtime.ParamLocationQuery, *params.State); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Error != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "error", runtime.ParamLocationQuery, *params.Error); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ErrorDescription != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "error_description", runtime.ParamLocationQuery, *params.ErrorDescription); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOidcLoginRequest generates requests for GetOidcLogin
+func NewGetOidcLoginRequest(server string, params *GetOidcLoginParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/oidc/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL", runtime.ParamLocationHeader, params.XRedirectURL)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Redirect-URL", headerParam0)
+
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL-On-Error", runtime.ParamLocationHeader, params.XRedirectURLOnError)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Redirect-URL-On-Error", headerParam1)
+
+	}
+
+	return req, nil
+}
+
+// NewGetOidcLogoutRequest generates requests for GetOidcLogout
+func NewGetOidcLogoutRequest(server string, params *GetOidcLogoutParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/oidc/logout")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL", runtime.ParamLocationHeader, params.XRedirectURL)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Redirect-URL", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewPostSamlAcsRequestWithFormdataBody calls the generic PostSamlAcs builder with application/x-www-form-urlencoded body
+func NewPostSamlAcsRequestWithFormdataBody(server string, body PostSamlAcsFormdataRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	bodyStr, err := runtime.MarshalForm(body, nil)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = strings.NewReader(bodyStr.Encode())
+	return NewPostSamlAcsRequestWithBody(server, "application/x-www-form-urlencoded", bodyReader)
+}
+
+// NewPostSamlAcsRequestWithBody generates requests for PostSamlAcs with any type of body
+func NewPostSamlAcsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/saml/acs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSamlErrorRequest generates requests for GetSamlError
+func NewGetSamlErrorRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/saml/error")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSamlLoginRequest generates requests for GetSamlLogin
+func NewGetSamlLoginRequest(server string, params *GetSamlLoginParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/saml/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Idp != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "idp", runtime.ParamLocationQuery, *params.Idp); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Email != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "email", runtime.ParamLocationQuery, *params.Email); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL", runtime.ParamLocationHeader, params.XRedirectURL)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Redirect-URL", headerParam0)
+
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL-On-Error", runtime.ParamLocationHeader, params.XRedirectURLOnError)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Redirect-URL-On-Error", headerParam1)
+
+	}
+
+	return req, nil
+}
+
+// NewGetSamlLogoutRequest generates requests for GetSamlLogout
+func NewGetSamlLogoutRequest(server string, params *GetSamlLogoutParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/saml/logout")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL", runtime.ParamLocationHeader, params.XRedirectURL)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Redirect-URL", headerParam0)
+
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Redirect-URL-On-Error", runtime.ParamLocationHeader, params.XRedirectURLOnError)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Redirect-URL-On-Error", headerParam1)
+
+	}
+
+	return req, nil
+}
+
+// NewGetSamlMetadataRequest generates requests for GetSamlMetadata
+func NewGetSamlMetadataRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/saml/metadata")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSamlSloRequest generates requests for GetSamlSlo
+func NewGetSamlSloRequest(server string, params *GetSamlSloParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/saml/slo")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.SAMLRequest != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "SAMLRequest", runtime.ParamLocationQuery, *params.SAMLRequest); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.SAMLResponse != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "SAMLResponse", runtime.ParamLocationQuery, *params.SAMLResponse); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.RelayState != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "RelayState", runtime.ParamLocationQuery, *params.RelayState); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.SigAlg != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "SigAlg", runtime.ParamLocationQuery, *params.SigAlg); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Signature != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "Signature", runtime.ParamLocationQuery, *params.Signature); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostSamlSloRequestWithFormdataBody calls the generic PostSamlSlo builder with application/x-www-form-urlencoded body
+func NewPostSamlSloRequestWithFormdataBody(server string, body PostSamlSloFormdataRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	bodyStr, err := runtime.MarshalForm(body, nil)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = strings.NewReader(bodyStr.Encode())
+	return NewPostSamlSloRequestWithBody(server, "application/x-www-form-urlencoded", bodyReader)
+}
+
+// NewPostSamlSloRequestWithBody generates requests for PostSamlSlo with any type of body
+func NewPostSamlSloRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/saml/slo")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSessionsRequest generates requests for GetSessions
+func NewGetSessionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteSessionsIdRequest generates requests for DeleteSessionsId
+func NewDeleteSessionsIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSymbolsRequest generates requests for GetSymbols
+func NewGetSymbolsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/symbols")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTokensRequest generates requests for GetTokens
+func NewGetTokensRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostTokensRequest calls the generic PostTokens builder with application/json body
+func NewPostTokensRequest(server string, body PostTokensJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTokensRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTokensRequestWithBody generates requests for PostTokens with any type of body
+func NewPostTokensRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteTokensIdRequest generates requests for DeleteTokensId
+func NewDeleteTokensIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWsUuidRequest generates requests for GetWsUuid
+func NewGetWsUuidRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ws/:uuid")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostZigzagRequestWithBody generates requests for PostZigzag with any type of body
+func NewPostZigzagRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/zigzag")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostZigzagExportRequestWithBody generates requests for PostZigzagExport with any type of body
+func NewPostZigzagExportRequestWithBody(server string, params *PostZigzagExportParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/zigzag/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAdminAuditWithResponse request
+	GetAdminAuditWithResponse(ctx context.Context, params *GetAdminAuditParams, reqEditors ...RequestEditorFn) (*GetAdminAuditResponse, error)
+
+	// GetAdminUsersWithResponse request
+	GetAdminUsersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminUsersResponse, error)
+
+	// PostAuthRefreshWithResponse request
+	PostAuthRefreshWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthRefreshResponse, error)
+
+	// PostBacktestWithBodyWithResponse request with any body
+	PostBacktestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBacktestResponse, error)
+
+	// PostBacktestPortfolioWithBodyWithResponse request with any body
+	PostBacktestPortfolioWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBacktestPortfolioResponse, error)
+
+	// GetBacktestsWithResponse request
+	GetBacktestsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetBacktestsResponse, error)
+
+	// PostBacktestsCompareWithBodyWithResponse request with any body
+	PostBacktestsCompareWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBacktestsCompareResponse, error)
+
+	PostBacktestsCompareWithResponse(ctx context.Context, body PostBacktestsCompareJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBacktestsCompareResponse, error)
+
+	// DeleteBacktestsIdWithResponse request
+	DeleteBacktestsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteBacktestsIdResponse, error)
+
+	// GetBacktestsIdWithResponse request
+	GetBacktestsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetBacktestsIdResponse, error)
+
+	// GetBacktestsIdExportWithResponse request
+	GetBacktestsIdExportWithResponse(ctx context.Context, id int64, params *GetBacktestsIdExportParams, reqEditors ...RequestEditorFn) (*GetBacktestsIdExportResponse, error)
+
+	// PostChartsWithBodyWithResponse request with any body
+	PostChartsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostChartsResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// PostIndicatorsExportWithBodyWithResponse request with any body
+	PostIndicatorsExportWithBodyWithResponse(ctx context.Context, params *PostIndicatorsExportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostIndicatorsExportResponse, error)
+
+	// GetMeWithResponse request
+	GetMeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMeResponse, error)
+
+	// PatchMeWithBodyWithResponse request with any body
+	PatchMeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchMeResponse, error)
+
+	PatchMeWithResponse(ctx context.Context, body PatchMeJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchMeResponse, error)
+
+	// GetOidcCallbackWithResponse request
+	GetOidcCallbackWithResponse(ctx context.Context, params *GetOidcCallbackParams, reqEditors ...RequestEditorFn) (*GetOidcCallbackResponse, error)
+
+	// GetOidcLoginWithResponse request
+	GetOidcLoginWithResponse(ctx context.Context, params *GetOidcLoginParams, reqEditors ...RequestEditorFn) (*GetOidcLoginResponse, error)
+
+	// GetOidcLogoutWithResponse request
+	GetOidcLogoutWithResponse(ctx context.Context, params *GetOidcLogoutParams, reqEditors ...RequestEditorFn) (*GetOidcLogoutResponse, error)
+
+	// PostSamlAcsWithBodyWithResponse request with any body
+	PostSamlAcsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSamlAcsResponse, error)
+
+	PostSamlAcsWithFormdataBodyWithResponse(ctx context.Context, body PostSamlAcsFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostSamlAcsResponse, error)
+
+	// GetSamlErrorWithResponse request
+	GetSamlErrorWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSamlErrorResponse, error)
+
+	// GetSamlLoginWithResponse request
+	GetSamlLoginWithResponse(ctx context.Context, params *GetSamlLoginParams, reqEditors ...RequestEditorFn) (*GetSamlLoginResponse, error)
+
+	// GetSamlLogoutWithResponse request
+	GetSamlLogoutWithResponse(ctx context.Context, params *GetSamlLogoutParams, reqEditors ...RequestEditorFn) (*GetSamlLogoutResponse, error)
+
+	// GetSamlMetadataWithResponse request
+	GetSamlMetadataWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSamlMetadataResponse, error)
+
+	// GetSamlSloWithResponse request
+	GetSamlSloWithResponse(ctx context.Context, params *GetSamlSloParams, reqEditors ...RequestEditorFn) (*GetSamlSloResponse, error)
+
+	// PostSamlSloWithBodyWithResponse request with any body
+	PostSamlSloWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSamlSloResponse, error)
+
+	PostSamlSloWithFormdataBodyWithResponse(ctx context.Context, body PostSamlSloFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostSamlSloResponse, error)
+
+	// GetSessionsWithResponse request
+	GetSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSessionsResponse, error)
+
+	// DeleteSessionsIdWithResponse request
+	DeleteSessionsIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteSessionsIdResponse, error)
+
+	// GetSymbolsWithResponse request
+	GetSymbolsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSymbolsResponse, error)
+
+	// GetTokensWithResponse request
+	GetTokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTokensResponse, error)
+
+	// PostTokensWithBodyWithResponse request with any body
+	PostTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTokensResponse, error)
+
+	PostTokensWithResponse(ctx context.Context, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTokensResponse, error)
+
+	// DeleteTokensIdWithResponse request
+	DeleteTokensIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteTokensIdResponse, error)
+
+	// GetWsUuidWithResponse request
+	GetWsUuidWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWsUuidResponse, error)
+
+	// PostZigzagWithBodyWithResponse request with any body
+	PostZigzagWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostZigzagResponse, error)
+
+	// PostZigzagExportWithBodyWithResponse request with any body
+	PostZigzagExportWithBodyWithResponse(ctx context.Context, params *PostZigzagExportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostZigzagExportResponse, error)
+}
+
+type GetAdminAuditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetAdminAuditResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminAuditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminAuditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetAdminUsersResult
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthRefreshResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthRefreshResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthRefreshResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostBacktestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *PostBacktestResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostBacktestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostBacktestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostBacktestPortfolioResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *PostBacktestPortfolioResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostBacktestPortfolioResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostBacktestPortfolioResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBacktestsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetBacktestsResult
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBacktestsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBacktestsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostBacktestsCompareResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PostBacktestsCompareResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostBacktestsCompareResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostBacktestsCompareResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteBacktestsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteBacktestsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteBacktestsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBacktestsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetBacktestResult
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBacktestsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBacktestsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBacktestsIdExportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBacktestsIdExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBacktestsIdExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostChartsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostChartsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostChartsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Health
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostIndicatorsExportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostIndicatorsExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostIndicatorsExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Me
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchMeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Me
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchMeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchMeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOidcCallbackResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOidcCallbackResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOidcCallbackResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOidcLoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOidcLoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOidcLoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOidcLogoutResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOidcLogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOidcLogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSamlAcsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSamlAcsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSamlAcsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSamlErrorResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ErrorWithTime
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSamlErrorResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSamlErrorResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSamlLoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSamlLoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSamlLoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSamlLogoutResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSamlLogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSamlLogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSamlMetadataResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSamlMetadataResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSamlMetadataResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSamlSloResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSamlSloResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSamlSloResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSamlSloResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSamlSloResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSamlSloResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSessionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetSessionsResult
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSessionsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSessionsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSessionsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSymbolsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetSymbolsResult
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSymbolsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSymbolsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetTokensResult
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *PostTokensResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTokensIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTokensIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTokensIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWsUuidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWsUuidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWsUuidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostZigzagResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *PostZigzagResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostZigzagResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostZigzagResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostZigzagExportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostZigzagExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostZigzagExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAdminAuditWithResponse request returning *GetAdminAuditResponse
+func (c *ClientWithResponses) GetAdminAuditWithResponse(ctx context.Context, params *GetAdminAuditParams, reqEditors ...RequestEditorFn) (*GetAdminAuditResponse, error) {
+	rsp, err := c.GetAdminAudit(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminAuditResponse(rsp)
+}
+
+// GetAdminUsersWithResponse request returning *GetAdminUsersResponse
+func (c *ClientWithResponses) GetAdminUsersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminUsersResponse, error) {
+	rsp, err := c.GetAdminUsers(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminUsersResponse(rsp)
+}
+
+// PostAuthRefreshWithResponse request returning *PostAuthRefreshResponse
+func (c *ClientWithResponses) PostAuthRefreshWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthRefreshResponse, error) {
+	rsp, err := c.PostAuthRefresh(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthRefreshResponse(rsp)
+}
+
+// PostBacktestWithBodyWithResponse request with arbitrary body returning *PostBacktestResponse
+func (c *ClientWithResponses) PostBacktestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBacktestResponse, error) {
+	rsp, err := c.PostBacktestWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBacktestResponse(rsp)
+}
+
+// PostBacktestPortfolioWithBodyWithResponse request with arbitrary body returning *PostBacktestPortfolioResponse
+func (c *ClientWithResponses) PostBacktestPortfolioWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBacktestPortfolioResponse, error) {
+	rsp, err := c.PostBacktestPortfolioWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBacktestPortfolioResponse(rsp)
+}
+
+// GetBacktestsWithResponse request returning *GetBacktestsResponse
+func (c *ClientWithResponses) GetBacktestsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetBacktestsResponse, error) {
+	rsp, err := c.GetBacktests(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBacktestsResponse(rsp)
+}
+
+// PostBacktestsCompareWithBodyWithResponse request with arbitrary body returning *PostBacktestsCompareResponse
+func (c *ClientWithResponses) PostBacktestsCompareWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBacktestsCompareResponse, error) {
+	rsp, err := c.PostBacktestsCompareWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBacktestsCompareResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostBacktestsCompareWithResponse(ctx context.Context, body PostBacktestsCompareJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBacktestsCompareResponse, error) {
+	rsp, err := c.PostBacktestsCompare(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBacktestsCompareResponse(rsp)
+}
+
+// DeleteBacktestsIdWithResponse request returning *DeleteBacktestsIdResponse
+func (c *ClientWithResponses) DeleteBacktestsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteBacktestsIdResponse, error) {
+	rsp, err := c.DeleteBacktestsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteBacktestsIdResponse(rsp)
+}
+
+// GetBacktestsIdWithResponse request returning *GetBacktestsIdResponse
+func (c *ClientWithResponses) GetBacktestsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetBacktestsIdResponse, error) {
+	rsp, err := c.GetBacktestsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBacktestsIdResponse(rsp)
+}
+
+// GetBacktestsIdExportWithResponse request returning *GetBacktestsIdExportResponse
+func (c *ClientWithResponses) GetBacktestsIdExportWithResponse(ctx context.Context, id int64, params *GetBacktestsIdExportParams, reqEditors ...RequestEditorFn) (*GetBacktestsIdExportResponse, error) {
+	rsp, err := c.GetBacktestsIdExport(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBacktestsIdExportResponse(rsp)
+}
+
+// PostChartsWithBodyWithResponse request with arbitrary body returning *PostChartsResponse
+func (c *ClientWithResponses) PostChartsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostChartsResponse, error) {
+	rsp, err := c.PostChartsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostChartsResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// PostIndicatorsExportWithBodyWithResponse request with arbitrary body returning *PostIndicatorsExportResponse
+func (c *ClientWithResponses) PostIndicatorsExportWithBodyWithResponse(ctx context.Context, params *PostIndicatorsExportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostIndicatorsExportResponse, error) {
+	rsp, err := c.PostIndicatorsExportWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIndicatorsExportResponse(rsp)
+}
+
+// GetMeWithResponse request returning *GetMeResponse
+func (c *ClientWithResponses) GetMeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMeResponse, error) {
+	rsp, err := c.GetMe(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMeResponse(rsp)
+}
+
+// PatchMeWithBodyWithResponse request with arbitrary body returning *PatchMeResponse
+func (c *ClientWithResponses) PatchMeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchMeResponse, error) {
+	rsp, err := c.PatchMeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchMeResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchMeWithResponse(ctx context.Context, body PatchMeJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchMeResponse, error) {
+	rsp, err := c.PatchMe(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchMeResponse(rsp)
+}
+
+// GetOidcCallbackWithResponse request returning *GetOidcCallbackResponse
+func (c *ClientWithResponses) GetOidcCallbackWithResponse(ctx context.Context, params *GetOidcCallbackParams, reqEditors ...RequestEditorFn) (*GetOidcCallbackResponse, error) {
+	rsp, err := c.GetOidcCallback(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOidcCallbackResponse(rsp)
+}
+
+// GetOidcLoginWithResponse request returning *GetOidcLoginResponse
+func (c *ClientWithResponses) GetOidcLoginWithResponse(ctx context.Context, params *GetOidcLoginParams, reqEditors ...RequestEditorFn) (*GetOidcLoginResponse, error) {
+	rsp, err := c.GetOidcLogin(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOidcLoginResponse(rsp)
+}
+
+// GetOidcLogoutWithResponse request returning *GetOidcLogoutResponse
+func (c *ClientWithResponses) GetOidcLogoutWithResponse(ctx context.Context, params *GetOidcLogoutParams, reqEditors ...RequestEditorFn) (*GetOidcLogoutResponse, error) {
+	rsp, err := c.GetOidcLogout(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOidcLogoutResponse(rsp)
+}
+
+// PostSamlAcsWithBodyWithResponse request with arbitrary body returning *PostSamlAcsResponse
+func (c *ClientWithResponses) PostSamlAcsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSamlAcsResponse, error) {
+	rsp, err := c.PostSamlAcsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSamlAcsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSamlAcsWithFormdataBodyWithResponse(ctx context.Context, body PostSamlAcsFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostSamlAcsResponse, error) {
+	rsp, err := c.PostSamlAcsWithFormdataBody(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSamlAcsResponse(rsp)
+}
+
+// GetSamlErrorWithResponse request returning *GetSamlErrorResponse
+func (c *ClientWithResponses) GetSamlErrorWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSamlErrorResponse, error) {
+	rsp, err := c.GetSamlError(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSamlErrorResponse(rsp)
+}
+
+// GetSamlLoginWithResponse request returning *GetSamlLoginResponse
+func (c *ClientWithResponses) GetSamlLoginWithResponse(ctx context.Context, params *GetSamlLoginParams, reqEditors ...RequestEditorFn) (*GetSamlLoginResponse, error) {
+	rsp, err := c.GetSamlLogin(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSamlLoginResponse(rsp)
+}
+
+// GetSamlLogoutWithResponse request returning *GetSamlLogoutResponse
+func (c *ClientWithResponses) GetSamlLogoutWithResponse(ctx context.Context, params *GetSamlLogoutParams, reqEditors ...RequestEditorFn) (*GetSamlLogoutResponse, error) {
+	rsp, err := c.GetSamlLogout(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSamlLogoutResponse(rsp)
+}
+
+// GetSamlMetadataWithResponse request returning *GetSamlMetadataResponse
+func (c *ClientWithResponses) GetSamlMetadataWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSamlMetadataResponse, error) {
+	rsp, err := c.GetSamlMetadata(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSamlMetadataResponse(rsp)
+}
+
+// GetSamlSloWithResponse request returning *GetSamlSloResponse
+func (c *ClientWithResponses) GetSamlSloWithResponse(ctx context.Context, params *GetSamlSloParams, reqEditors ...RequestEditorFn) (*GetSamlSloResponse, error) {
+	rsp, err := c.GetSamlSlo(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSamlSloResponse(rsp)
+}
+
+// PostSamlSloWithBodyWithResponse request with arbitrary body returning *PostSamlSloResponse
+func (c *ClientWithResponses) PostSamlSloWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSamlSloResponse, error) {
+	rsp, err := c.PostSamlSloWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSamlSloResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSamlSloWithFormdataBodyWithResponse(ctx context.Context, body PostSamlSloFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostSamlSloResponse, error) {
+	rsp, err := c.PostSamlSloWithFormdataBody(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSamlSloResponse(rsp)
+}
+
+// GetSessionsWithResponse request returning *GetSessionsResponse
+func (c *ClientWithResponses) GetSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSessionsResponse, error) {
+	rsp, err := c.GetSessions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSessionsResponse(rsp)
+}
+
+// DeleteSessionsIdWithResponse request returning *DeleteSessionsIdResponse
+func (c *ClientWithResponses) DeleteSessionsIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteSessionsIdResponse, error) {
+	rsp, err := c.DeleteSessionsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteSessionsIdResponse(rsp)
+}
+
+// GetSymbolsWithResponse request returning *GetSymbolsResponse
+func (c *ClientWithResponses) GetSymbolsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSymbolsResponse, error) {
+	rsp, err := c.GetSymbols(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSymbolsResponse(rsp)
+}
+
+// GetTokensWithResponse request returning *GetTokensResponse
+func (c *ClientWithResponses) GetTokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTokensResponse, error) {
+	rsp, err := c.GetTokens(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTokensResponse(rsp)
+}
+
+// PostTokensWithBodyWithResponse request with arbitrary body returning *PostTokensResponse
+func (c *ClientWithResponses) PostTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTokensResponse, error) {
+	rsp, err := c.PostTokensWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTokensResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTokensWithResponse(ctx context.Context, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTokensResponse, error) {
+	rsp, err := c.PostTokens(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTokensResponse(rsp)
+}
+
+// DeleteTokensIdWithResponse request returning *DeleteTokensIdResponse
+func (c *ClientWithResponses) DeleteTokensIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteTokensIdResponse, error) {
+	rsp, err := c.DeleteTokensId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTokensIdResponse(rsp)
+}
+
+// GetWsUuidWithResponse request returning *GetWsUuidResponse
+func (c *ClientWithResponses) GetWsUuidWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWsUuidResponse, error) {
+	rsp, err := c.GetWsUuid(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWsUuidResponse(rsp)
+}
+
+// PostZigzagWithBodyWithResponse request with arbitrary body returning *PostZigzagResponse
+func (c *ClientWithResponses) PostZigzagWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostZigzagResponse, error) {
+	rsp, err := c.PostZigzagWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostZigzagResponse(rsp)
+}
+
+// PostZigzagExportWithBodyWithResponse request with arbitrary body returning *PostZigzagExportResponse
+func (c *ClientWithResponses) PostZigzagExportWithBodyWithResponse(ctx context.Context, params *PostZigzagExportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostZigzagExportResponse, error) {
+	rsp, err := c.PostZigzagExportWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostZigzagExportResponse(rsp)
+}
+
+// ParseGetAdminAuditResponse parses an HTTP response from a GetAdminAuditWithResponse call
+func ParseGetAdminAuditResponse(rsp *http.Response) (*GetAdminAuditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminAuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetAdminAuditResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminUsersResponse parses an HTTP response from a GetAdminUsersWithResponse call
+func ParseGetAdminUsersResponse(rsp *http.Response) (*GetAdminUsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminUsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetAdminUsersResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthRefreshResponse parses an HTTP response from a PostAuthRefreshWithResponse call
+func ParsePostAuthRefreshResponse(rsp *http.Response) (*PostAuthRefreshResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthRefreshResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostBacktestResponse parses an HTTP response from a PostBacktestWithResponse call
+func ParsePostBacktestResponse(rsp *http.Response) (*PostBacktestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostBacktestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest PostBacktestResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostBacktestPortfolioResponse parses an HTTP response from a PostBacktestPortfolioWithResponse call
+func ParsePostBacktestPortfolioResponse(rsp *http.Response) (*PostBacktestPortfolioResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostBacktestPortfolioResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest PostBacktestPortfolioResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBacktestsResponse parses an HTTP response from a GetBacktestsWithResponse call
+func ParseGetBacktestsResponse(rsp *http.Response) (*GetBacktestsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBacktestsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetBacktestsResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostBacktestsCompareResponse parses an HTTP response from a PostBacktestsCompareWithResponse call
+func ParsePostBacktestsCompareResponse(rsp *http.Response) (*PostBacktestsCompareResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostBacktestsCompareResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PostBacktestsCompareResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteBacktestsIdResponse parses an HTTP response from a DeleteBacktestsIdWithResponse call
+func ParseDeleteBacktestsIdResponse(rsp *http.Response) (*DeleteBacktestsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteBacktestsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBacktestsIdResponse parses an HTTP response from a GetBacktestsIdWithResponse call
+func ParseGetBacktestsIdResponse(rsp *http.Response) (*GetBacktestsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBacktestsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetBacktestResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBacktestsIdExportResponse parses an HTTP response from a GetBacktestsIdExportWithResponse call
+func ParseGetBacktestsIdExportResponse(rsp *http.Response) (*GetBacktestsIdExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBacktestsIdExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostChartsResponse parses an HTTP response from a PostChartsWithResponse call
+func ParsePostChartsResponse(rsp *http.Response) (*PostChartsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostChartsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostIndicatorsExportResponse parses an HTTP response from a PostIndicatorsExportWithResponse call
+func ParsePostIndicatorsExportResponse(rsp *http.Response) (*PostIndicatorsExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostIndicatorsExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetMeResponse parses an HTTP response from a GetMeWithResponse call
+func ParseGetMeResponse(rsp *http.Response) (*GetMeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetMeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Me
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchMeResponse parses an HTTP response from a PatchMeWithResponse call
+func ParsePatchMeResponse(rsp *http.Response) (*PatchMeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchMeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Me
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOidcCallbackResponse parses an HTTP response from a GetOidcCallbackWithResponse call
+func ParseGetOidcCallbackResponse(rsp *http.Response) (*GetOidcCallbackResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOidcCallbackResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOidcLoginResponse parses an HTTP response from a GetOidcLoginWithResponse call
+func ParseGetOidcLoginResponse(rsp *http.Response) (*GetOidcLoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOidcLoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOidcLogoutResponse parses an HTTP response from a GetOidcLogoutWithResponse call
+func ParseGetOidcLogoutResponse(rsp *http.Response) (*GetOidcLogoutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOidcLogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSamlAcsResponse parses an HTTP response from a PostSamlAcsWithResponse call
+func ParsePostSamlAcsResponse(rsp *http.Response) (*PostSamlAcsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSamlAcsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetSamlErrorResponse parses an HTTP response from a GetSamlErrorWithResponse call
+func ParseGetSamlErrorResponse(rsp *http.Response) (*GetSamlErrorResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSamlErrorResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ErrorWithTime
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSamlLoginResponse parses an HTTP response from a GetSamlLoginWithResponse call
+func ParseGetSamlLoginResponse(rsp *http.Response) (*GetSamlLoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSamlLoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSamlLogoutResponse parses an HTTP response from a GetSamlLogoutWithResponse call
+func ParseGetSamlLogoutResponse(rsp *http.Response) (*GetSamlLogoutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSamlLogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSamlMetadataResponse parses an HTTP response from a GetSamlMetadataWithResponse call
+func ParseGetSamlMetadataResponse(rsp *http.Response) (*GetSamlMetadataResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSamlMetadataResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSamlSloResponse parses an HTTP response from a GetSamlSloWithResponse call
+func ParseGetSamlSloResponse(rsp *http.Response) (*GetSamlSloResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSamlSloResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePostSamlSloResponse parses an HTTP response from a PostSamlSloWithResponse call
+func ParsePostSamlSloResponse(rsp *http.Response) (*PostSamlSloResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSamlSloResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetSessionsResponse parses an HTTP response from a GetSessionsWithResponse call
+func ParseGetSessionsResponse(rsp *http.Response) (*GetSessionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSessionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetSessionsResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteSessionsIdResponse parses an HTTP response from a DeleteSessionsIdWithResponse call
+func ParseDeleteSessionsIdResponse(rsp *http.Response) (*DeleteSessionsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteSessionsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSymbolsResponse parses an HTTP response from a GetSymbolsWithResponse call
+func ParseGetSymbolsResponse(rsp *http.Response) (*GetSymbolsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSymbolsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetSymbolsResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTokensResponse parses an HTTP response from a GetTokensWithResponse call
+func ParseGetTokensResponse(rsp *http.Response) (*GetTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetTokensResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTokensResponse parses an HTTP response from a PostTokensWithResponse call
+func ParsePostTokensResponse(rsp *http.Response) (*PostTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest PostTokensResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteTokensIdResponse parses an HTTP response from a DeleteTokensIdWithResponse call
+func ParseDeleteTokensIdResponse(rsp *http.Response) (*DeleteTokensIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteTokensIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWsUuidResponse parses an HTTP response from a GetWsUuidWithResponse call
+func ParseGetWsUuidResponse(rsp *http.Response) (*GetWsUuidResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWsUuidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostZigzagResponse parses an HTTP response from a PostZigzagWithResponse call
+func ParsePostZigzagResponse(rsp *http.Response) (*PostZigzagResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostZigzagResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest PostZigzagResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostZigzagExportResponse parses an HTTP response from a PostZigzagExportWithResponse call
+func ParsePostZigzagExportResponse(rsp *http.Response) (*PostZigzagExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostZigzagExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// 監査イベント (ログイン・ログアウト、認証・認可の失敗、データのアップロード・解析等) を検索する (管理者のみ)
+	// (GET /admin/audit)
+	GetAdminAudit(ctx echo.Context, params GetAdminAuditParams) error
+	// 登録済みのユーザとロールの一覧を返却する (管理者のみ)
+	// (GET /admin/users)
+	GetAdminUsers(ctx echo.Context) error
+	// リフレッシュトークンを検証し、アクセストークンとリフレッシュトークンを再発行するエンドポイント。
+	// (POST /auth/refresh)
+	PostAuthRefresh(ctx echo.Context) error
+	// ローソク足と注文からバックテストを実行し、取引コスト控除前後の損益を返却する
+	// (POST /backtest)
+	PostBacktest(ctx echo.Context) error
+	// 複数シンボルのローソク足と注文から口座を共有したバックテストを実行し、シンボル毎と全体の損益を口座通貨建てで返却する
+	// (POST /backtest/portfolio)
+	PostBacktestPortfolio(ctx echo.Context) error
+	// ログイン中のユーザが保存したバックテストの実行履歴(パラメータ、入力データ、主要な指標)を返却する
+	// (GET /backtests)
+	GetBacktests(ctx echo.Context) error
+	// 保存した複数のバックテストの指標・資産曲線・取引の差分を並べて返却する
+	// (POST /backtests/compare)
+	PostBacktestsCompare(ctx echo.Context) error
+	// 保存したバックテストを削除する
+	// (DELETE /backtests/{id})
+	DeleteBacktestsId(ctx echo.Context, id int64) error
+	// 保存したバックテストの実行結果(シンボル毎の集計と取引)を返却する
+	// (GET /backtests/{id})
+	GetBacktestsId(ctx echo.Context, id int64) error
+	// 保存したバックテストの取引をCSV・JSON Lines・Excel形式で出力する
+	// (GET /backtests/{id}/export)
+	GetBacktestsIdExport(ctx echo.Context, id int64, params GetBacktestsIdExportParams) error
+	// ローソク足とジグザグ・インジケーターのチャートをSVGまたはPNGで描画する
+	// (POST /charts)
+	PostCharts(ctx echo.Context) error
+	// サーバの状態 (idPのメタデータの取得日時等) を返却する
+	// (GET /health)
+	GetHealth(ctx echo.Context) error
+	// ローソク足からインジケーターを算出し、CSV・JSON Lines・Excel形式で出力する
+	// (POST /indicators/export)
+	PostIndicatorsExport(ctx echo.Context, params PostIndicatorsExportParams) error
+	// ログインユーザの情報と設定を返却する
+	// (GET /me)
+	GetMe(ctx echo.Context) error
+	// ログインユーザの設定を更新する
+	// (PATCH /me)
+	PatchMe(ctx echo.Context) error
+	// OpenID Providerから認可コードを受け取り、IDトークンを検証してログインさせるエンドポイント。
+	// (GET /oidc/callback)
+	GetOidcCallback(ctx echo.Context, params GetOidcCallbackParams) error
+	// OpenID Connectの認可リクエスト (認可コードフロー + PKCE) を作成し、OpenID Providerにリダイレクトさせるエンドポイント。
+	// (GET /oidc/login)
+	GetOidcLogin(ctx echo.Context, params GetOidcLoginParams) error
+	// ログインセッションを失効させ、OpenID Providerのログアウトエンドポイントにリダイレクトさせるエンドポイント。
+	// (GET /oidc/logout)
+	GetOidcLogout(ctx echo.Context, params GetOidcLogoutParams) error
+	// IdPから受け取る認証レスポンス（SAMLアサーション）を処理するエンドポイント。
+	// (POST /saml/acs)
+	PostSamlAcs(ctx echo.Context) error
+	// SAML・OpenID Connectのログインのエラー詳細を返却するエンドポイント
+	// (GET /saml/error)
+	GetSamlError(ctx echo.Context) error
+	// ユーザをシングルサインオンさせるログインリクエストを作成し、FormのPOSTによってidPに送信するスクリプトタグを含んだHTMLを返却するエンドポイント。
+	// (GET /saml/login)
+	GetSamlLogin(ctx echo.Context, params GetSamlLoginParams) error
+	// ユーザをログアウトさせるログアウトリクエストを作成し、FormのPOSTによってidPに送信するスクリプトタグを含んだHTMLを返却するエンドポイント。
+	// (GET /saml/logout)
+	GetSamlLogout(ctx echo.Context, params GetSamlLogoutParams) error
+	// SPのメタデータ (EntityDescriptor) を返却するエンドポイント。
+	// (GET /saml/metadata)
+	GetSamlMetadata(ctx echo.Context) error
+	// IdPからHTTP-Redirectバインディングで受け取るログアウトリクエスト・レスポンスを処理するエンドポイント。
+	// (GET /saml/slo)
+	GetSamlSlo(ctx echo.Context, params GetSamlSloParams) error
+	// IdPから受け取るログアウトリクエストを処理し、ユーザーをログアウトさせるエンドポイント。
+	// (POST /saml/slo)
+	PostSamlSlo(ctx echo.Context) error
+	// ログインユーザのログイン中のセッション(端末)の一覧を返却する
+	// (GET /sessions)
+	GetSessions(ctx echo.Context) error
+	// 指定したセッションを失効させ、その端末をログアウトさせる
+	// (DELETE /sessions/{id})
+	DeleteSessionsId(ctx echo.Context, id string) error
+	// 登録済みのシンボルのメタデータ(pipサイズ、桁数、取引単位、通貨、取引セッション)を返却する
+	// (GET /symbols)
+	GetSymbols(ctx echo.Context) error
+	// ログインユーザの個人用APIトークンの一覧を返却する
+	// (GET /tokens)
+	GetTokens(ctx echo.Context) error
+	// 個人用APIトークンを発行する
+	// (POST /tokens)
+	PostTokens(ctx echo.Context) error
+	// 指定した個人用APIトークンを失効させる
+	// (DELETE /tokens/{id})
+	DeleteTokensId(ctx echo.Context, id string) error
+	// Websocketと接続を行うためのエンドポイント。
+	// (GET /ws/:uuid)
+	GetWsUuid(ctx echo.Context) error
+	// ローソク足をジグザグに変換し返却する
+	// (POST /zigzag)
+	PostZigzag(ctx echo.Context) error
+	// ローソク足をジグザグに変換し、CSV・JSON Lines・Excel形式で出力する
+	// (POST /zigzag/export)
+	PostZigzagExport(ctx echo.Context, params PostZigzagExportParams) error
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostZigzagResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
 }
 
-// PostSamlAcsWithBodyWithResponse request with arbitrary body returning *PostSamlAcsResponse
-func (c *ClientWithResponses) PostSamlAcsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSamlAcsResponse, error) {
-	rsp, err := c.PostSamlAcsWithBody(ctx, contentType, body, reqEditors...)
+// GetAdminAudit converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAdminAudit(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAuditParams
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", ctx.QueryParams(), &params.Type)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter type: %s", err))
 	}
-	return ParsePostSamlAcsResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostSamlAcsWithFormdataBodyWithResponse(ctx context.Context, body PostSamlAcsFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostSamlAcsResponse, error) {
-	rsp, err := c.PostSamlAcsWithFormdataBody(ctx, body, reqEditors...)
+	// ------------- Optional query parameter "outcome" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "outcome", ctx.QueryParams(), &params.Outcome)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter outcome: %s", err))
 	}
-	return ParsePostSamlAcsResponse(rsp)
-}
 
-// GetSamlErrorWithResponse request returning *GetSamlErrorResponse
-func (c *ClientWithResponses) GetSamlErrorWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSamlErrorResponse, error) {
-	rsp, err := c.GetSamlError(ctx, reqEditors...)
+	// ------------- Optional query parameter "userId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "userId", ctx.QueryParams(), &params.UserId)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
 	}
-	return ParseGetSamlErrorResponse(rsp)
-}
 
-// GetSamlLoginWithResponse request returning *GetSamlLoginResponse
-func (c *ClientWithResponses) GetSamlLoginWithResponse(ctx context.Context, params *GetSamlLoginParams, reqEditors ...RequestEditorFn) (*GetSamlLoginResponse, error) {
-	rsp, err := c.GetSamlLogin(ctx, params, reqEditors...)
+	// ------------- Optional query parameter "email" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "email", ctx.QueryParams(), &params.Email)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter email: %s", err))
 	}
-	return ParseGetSamlLoginResponse(rsp)
-}
 
-// GetSamlLogoutWithResponse request returning *GetSamlLogoutResponse
-func (c *ClientWithResponses) GetSamlLogoutWithResponse(ctx context.Context, params *GetSamlLogoutParams, reqEditors ...RequestEditorFn) (*GetSamlLogoutResponse, error) {
-	rsp, err := c.GetSamlLogout(ctx, params, reqEditors...)
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
 	}
-	return ParseGetSamlLogoutResponse(rsp)
-}
 
-// PostSamlSloWithBodyWithResponse request with arbitrary body returning *PostSamlSloResponse
-func (c *ClientWithResponses) PostSamlSloWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSamlSloResponse, error) {
-	rsp, err := c.PostSamlSloWithBody(ctx, contentType, body, reqEditors...)
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
 	}
-	return ParsePostSamlSloResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostSamlSloWithFormdataBodyWithResponse(ctx context.Context, body PostSamlSloFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostSamlSloResponse, error) {
-	rsp, err := c.PostSamlSloWithFormdataBody(ctx, body, reqEditors...)
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
 	}
-	return ParsePostSamlSloResponse(rsp)
-}
 
-// GetWsUuidWithResponse request returning *GetWsUuidResponse
-func (c *ClientWithResponses) GetWsUuidWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWsUuidResponse, error) {
-	rsp, err := c.GetWsUuid(ctx, reqEditors...)
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
 	}
-	return ParseGetWsUuidResponse(rsp)
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetAdminAudit(ctx, params)
+	return err
 }
 
-// PostZigzagWithBodyWithResponse request with arbitrary body returning *PostZigzagResponse
-func (c *ClientWithResponses) PostZigzagWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostZigzagResponse, error) {
-	rsp, err := c.PostZigzagWithBody(ctx, contentType, body, reqEditors...)
+// GetAdminUsers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAdminUsers(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetAdminUsers(ctx)
+	return err
+}
+
+// PostAuthRefresh converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthRefresh(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthRefresh(ctx)
+	return err
+}
+
+// PostBacktest converts echo context to params.
+func (w *ServerInterfaceWrapper) PostBacktest(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostBacktest(ctx)
+	return err
+}
+
+// PostBacktestPortfolio converts echo context to params.
+func (w *ServerInterfaceWrapper) PostBacktestPortfolio(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostBacktestPortfolio(ctx)
+	return err
+}
+
+// GetBacktests converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBacktests(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetBacktests(ctx)
+	return err
+}
+
+// PostBacktestsCompare converts echo context to params.
+func (w *ServerInterfaceWrapper) PostBacktestsCompare(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostBacktestsCompare(ctx)
+	return err
+}
+
+// DeleteBacktestsId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteBacktestsId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
-	return ParsePostZigzagResponse(rsp)
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteBacktestsId(ctx, id)
+	return err
 }
 
-// ParsePostSamlAcsResponse parses an HTTP response from a PostSamlAcsWithResponse call
-func ParsePostSamlAcsResponse(rsp *http.Response) (*PostSamlAcsResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// GetBacktestsId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBacktestsId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	response := &PostSamlAcsResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	ctx.Set(CookieAuthScopes, []string{})
 
-	return response, nil
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetBacktestsId(ctx, id)
+	return err
 }
 
-// ParseGetSamlErrorResponse parses an HTTP response from a GetSamlErrorWithResponse call
-func ParseGetSamlErrorResponse(rsp *http.Response) (*GetSamlErrorResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// GetBacktestsIdExport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBacktestsIdExport(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	response := &GetSamlErrorResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	ctx.Set(CookieAuthScopes, []string{})
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ErrorWithTime
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+	ctx.Set(BearerAuthScopes, []string{})
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSONDefault = &dest
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBacktestsIdExportParams
+	// ------------- Optional query parameter "format" -------------
 
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
 	}
 
-	return response, nil
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetBacktestsIdExport(ctx, id, params)
+	return err
 }
 
-// ParseGetSamlLoginResponse parses an HTTP response from a GetSamlLoginWithResponse call
-func ParseGetSamlLoginResponse(rsp *http.Response) (*GetSamlLoginResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+// PostCharts converts echo context to params.
+func (w *ServerInterfaceWrapper) PostCharts(ctx echo.Context) error {
+	var err error
 
-	response := &GetSamlLoginResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	ctx.Set(CookieAuthScopes, []string{})
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSONDefault = &dest
+	ctx.Set(BearerAuthScopes, []string{})
 
-	}
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostCharts(ctx)
+	return err
+}
 
-	return response, nil
+// GetHealth converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealth(ctx)
+	return err
 }
 
-// ParseGetSamlLogoutResponse parses an HTTP response from a GetSamlLogoutWithResponse call
-func ParseGetSamlLogoutResponse(rsp *http.Response) (*GetSamlLogoutResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// PostIndicatorsExport converts echo context to params.
+func (w *ServerInterfaceWrapper) PostIndicatorsExport(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostIndicatorsExportParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
 	}
 
-	response := &GetSamlLogoutResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostIndicatorsExport(ctx, params)
+	return err
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSONDefault = &dest
+// GetMe converts echo context to params.
+func (w *ServerInterfaceWrapper) GetMe(ctx echo.Context) error {
+	var err error
 
-	}
+	ctx.Set(CookieAuthScopes, []string{})
 
-	return response, nil
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetMe(ctx)
+	return err
 }
 
-// ParsePostSamlSloResponse parses an HTTP response from a PostSamlSloWithResponse call
-func ParsePostSamlSloResponse(rsp *http.Response) (*PostSamlSloResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+// PatchMe converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchMe(ctx echo.Context) error {
+	var err error
 
-	response := &PostSamlSloResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	ctx.Set(CookieAuthScopes, []string{})
 
-	return response, nil
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchMe(ctx)
+	return err
 }
 
-// ParseGetWsUuidResponse parses an HTTP response from a GetWsUuidWithResponse call
-func ParseGetWsUuidResponse(rsp *http.Response) (*GetWsUuidResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// GetOidcCallback converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOidcCallback(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOidcCallbackParams
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", ctx.QueryParams(), &params.Code)
 	if err != nil {
-		return nil, err
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter code: %s", err))
 	}
 
-	response := &GetWsUuidResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", ctx.QueryParams(), &params.State)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter state: %s", err))
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
+	// ------------- Optional query parameter "error" -------------
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON401 = &dest
+	err = runtime.BindQueryParameter("form", true, false, "error", ctx.QueryParams(), &params.Error)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter error: %s", err))
+	}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+	// ------------- Optional query parameter "error_description" -------------
 
+	err = runtime.BindQueryParameter("form", true, false, "error_description", ctx.QueryParams(), &params.ErrorDescription)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter error_description: %s", err))
 	}
 
-	return response, nil
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOidcCallback(ctx, params)
+	return err
 }
 
-// ParsePostZigzagResponse parses an HTTP response from a PostZigzagWithResponse call
-func ParsePostZigzagResponse(rsp *http.Response) (*PostZigzagResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+// GetOidcLogin converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOidcLogin(ctx echo.Context) error {
+	var err error
 
-	response := &PostZigzagResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOidcLoginParams
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest PostZigzagResult
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Redirect-URL" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Redirect-URL")]; found {
+		var XRedirectURL string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Redirect-URL, got %d", n))
 		}
-		response.JSON201 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Redirect-URL", runtime.ParamLocationHeader, valueList[0], &XRedirectURL)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Redirect-URL: %s", err))
 		}
-		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+		params.XRedirectURL = XRedirectURL
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Redirect-URL is required, but not found"))
+	}
+	// ------------- Required header parameter "X-Redirect-URL-On-Error" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Redirect-URL-On-Error")]; found {
+		var XRedirectURLOnError string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Redirect-URL-On-Error, got %d", n))
 		}
-		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Redirect-URL-On-Error", runtime.ParamLocationHeader, valueList[0], &XRedirectURLOnError)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Redirect-URL-On-Error: %s", err))
 		}
-		response.JSON500 = &dest
 
+		params.XRedirectURLOnError = XRedirectURLOnError
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Redirect-URL-On-Error is required, but not found"))
 	}
 
-	return response, nil
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOidcLogin(ctx, params)
+	return err
 }
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// IdPから受け取る認証レスポンス（SAMLアサーション）を処理するエンドポイント。
-	// (POST /saml/acs)
-	PostSamlAcs(ctx echo.Context) error
-	// SAMLログインのエラー詳細を返却するエンドポイント
-	// (GET /saml/error)
-	GetSamlError(ctx echo.Context) error
-	// ユーザをシングルサインオンさせるログインリクエストを作成し、FormのPOSTによってidPに送信するスクリプトタグを含んだHTMLを返却するエンドポイント。
-	// (GET /saml/login)
-	GetSamlLogin(ctx echo.Context, params GetSamlLoginParams) error
-	// ユーザをログアウトさせるログアウトリクエストを作成し、FormのPOSTによってidPに送信するスクリプトタグを含んだHTMLを返却するエンドポイント。
-	// (GET /saml/logout)
-	GetSamlLogout(ctx echo.Context, params GetSamlLogoutParams) error
-	// IdPから受け取るログアウトリクエストを処理し、ユーザーをログアウトさせるエンドポイント。
-	// (POST /saml/slo)
-	PostSamlSlo(ctx echo.Context) error
-	// Websocketと接続を行うためのエンドポイント。
-	// (GET /ws/:uuid)
-	GetWsUuid(ctx echo.Context) error
-	// ローソク足をジグザグに変換し返却する
-	// (POST /zigzag)
-	PostZigzag(ctx echo.Context) error
-}
+// GetOidcLogout converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOidcLogout(ctx echo.Context) error {
+	var err error
 
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOidcLogoutParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Redirect-URL" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Redirect-URL")]; found {
+		var XRedirectURL string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Redirect-URL, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Redirect-URL", runtime.ParamLocationHeader, valueList[0], &XRedirectURL)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Redirect-URL: %s", err))
+		}
+
+		params.XRedirectURL = XRedirectURL
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Redirect-URL is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOidcLogout(ctx, params)
+	return err
 }
 
 // PostSamlAcs converts echo context to params.
@@ -1202,6 +6586,19 @@ func (w *ServerInterfaceWrapper) GetSamlLogin(ctx echo.Context) error {
 
 	// Parameter object where we will unmarshal all parameters from the context
 	var params GetSamlLoginParams
+	// ------------- Optional query parameter "idp" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "idp", ctx.QueryParams(), &params.Idp)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter idp: %s", err))
+	}
+
+	// ------------- Optional query parameter "email" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "email", ctx.QueryParams(), &params.Email)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter email: %s", err))
+	}
 
 	headers := ctx.Request().Header
 	// ------------- Required header parameter "X-Redirect-URL" -------------
@@ -1292,6 +6689,61 @@ func (w *ServerInterfaceWrapper) GetSamlLogout(ctx echo.Context) error {
 	return err
 }
 
+// GetSamlMetadata converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSamlMetadata(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSamlMetadata(ctx)
+	return err
+}
+
+// GetSamlSlo converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSamlSlo(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSamlSloParams
+	// ------------- Optional query parameter "SAMLRequest" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "SAMLRequest", ctx.QueryParams(), &params.SAMLRequest)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter SAMLRequest: %s", err))
+	}
+
+	// ------------- Optional query parameter "SAMLResponse" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "SAMLResponse", ctx.QueryParams(), &params.SAMLResponse)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter SAMLResponse: %s", err))
+	}
+
+	// ------------- Optional query parameter "RelayState" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "RelayState", ctx.QueryParams(), &params.RelayState)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter RelayState: %s", err))
+	}
+
+	// ------------- Optional query parameter "SigAlg" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "SigAlg", ctx.QueryParams(), &params.SigAlg)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter SigAlg: %s", err))
+	}
+
+	// ------------- Optional query parameter "Signature" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "Signature", ctx.QueryParams(), &params.Signature)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Signature: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSamlSlo(ctx, params)
+	return err
+}
+
 // PostSamlSlo converts echo context to params.
 func (w *ServerInterfaceWrapper) PostSamlSlo(ctx echo.Context) error {
 	var err error
@@ -1301,10 +6753,106 @@ func (w *ServerInterfaceWrapper) PostSamlSlo(ctx echo.Context) error {
 	return err
 }
 
+// GetSessions converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSessions(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSessions(ctx)
+	return err
+}
+
+// DeleteSessionsId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteSessionsId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteSessionsId(ctx, id)
+	return err
+}
+
+// GetSymbols converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSymbols(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSymbols(ctx)
+	return err
+}
+
+// GetTokens converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTokens(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTokens(ctx)
+	return err
+}
+
+// PostTokens converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTokens(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTokens(ctx)
+	return err
+}
+
+// DeleteTokensId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteTokensId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteTokensId(ctx, id)
+	return err
+}
+
 // GetWsUuid converts echo context to params.
 func (w *ServerInterfaceWrapper) GetWsUuid(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetWsUuid(ctx)
 	return err
@@ -1314,11 +6862,37 @@ func (w *ServerInterfaceWrapper) GetWsUuid(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) PostZigzag(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.PostZigzag(ctx)
 	return err
 }
 
+// PostZigzagExport converts echo context to params.
+func (w *ServerInterfaceWrapper) PostZigzagExport(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(CookieAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostZigzagExportParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostZigzagExport(ctx, params)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -1347,70 +6921,281 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/admin/audit", wrapper.GetAdminAudit)
+	router.GET(baseURL+"/admin/users", wrapper.GetAdminUsers)
+	router.POST(baseURL+"/auth/refresh", wrapper.PostAuthRefresh)
+	router.POST(baseURL+"/backtest", wrapper.PostBacktest)
+	router.POST(baseURL+"/backtest/portfolio", wrapper.PostBacktestPortfolio)
+	router.GET(baseURL+"/backtests", wrapper.GetBacktests)
+	router.POST(baseURL+"/backtests/compare", wrapper.PostBacktestsCompare)
+	router.DELETE(baseURL+"/backtests/:id", wrapper.DeleteBacktestsId)
+	router.GET(baseURL+"/backtests/:id", wrapper.GetBacktestsId)
+	router.GET(baseURL+"/backtests/:id/export", wrapper.GetBacktestsIdExport)
+	router.POST(baseURL+"/charts", wrapper.PostCharts)
+	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.POST(baseURL+"/indicators/export", wrapper.PostIndicatorsExport)
+	router.GET(baseURL+"/me", wrapper.GetMe)
+	router.PATCH(baseURL+"/me", wrapper.PatchMe)
+	router.GET(baseURL+"/oidc/callback", wrapper.GetOidcCallback)
+	router.GET(baseURL+"/oidc/login", wrapper.GetOidcLogin)
+	router.GET(baseURL+"/oidc/logout", wrapper.GetOidcLogout)
 	router.POST(baseURL+"/saml/acs", wrapper.PostSamlAcs)
 	router.GET(baseURL+"/saml/error", wrapper.GetSamlError)
 	router.GET(baseURL+"/saml/login", wrapper.GetSamlLogin)
 	router.GET(baseURL+"/saml/logout", wrapper.GetSamlLogout)
+	router.GET(baseURL+"/saml/metadata", wrapper.GetSamlMetadata)
+	router.GET(baseURL+"/saml/slo", wrapper.GetSamlSlo)
 	router.POST(baseURL+"/saml/slo", wrapper.PostSamlSlo)
+	router.GET(baseURL+"/sessions", wrapper.GetSessions)
+	router.DELETE(baseURL+"/sessions/:id", wrapper.DeleteSessionsId)
+	router.GET(baseURL+"/symbols", wrapper.GetSymbols)
+	router.GET(baseURL+"/tokens", wrapper.GetTokens)
+	router.POST(baseURL+"/tokens", wrapper.PostTokens)
+	router.DELETE(baseURL+"/tokens/:id", wrapper.DeleteTokensId)
 	router.GET(baseURL+"/ws/:uuid", wrapper.GetWsUuid)
 	router.POST(baseURL+"/zigzag", wrapper.PostZigzag)
+	router.POST(baseURL+"/zigzag/export", wrapper.PostZigzagExport)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xa/VPbRvr/V/zdb3+AqY1lA7nGnUwmSZuGXlKYQCa9YF9HyAtWoxdXWvOSlhkkJQQC",
-	"FEobCEnuEvICFAqkR15IQsofswibn/ov3OyuZMu2sM2EtNe7ZjKMvFo978/neZ6VvgaCKqdVBSpIB7Gv",
-	"gS6koMzTy1O8kpQguUpCXdDENBJVBcQAtlaxtYXNN9hczz3fAEGQ1tQ01JAI6XOCpOo+j2WfmfbQIxAE",
-	"sJ+X04RwpJlraI42BUG3qsk8AjHQLak8AkEgi4ooZ2QQ44IADaQhiAElI3dBDQwGQUrsSZWT31u5VUY+",
-	"0tDc2HhQ8pLaV07dXhstpd50tCF6cOHVNFR8yC+O+dgmwkUOSh6Jso/pd2cf786ZXvIgykWaQhEuFOE6",
-	"Io2xZi7WxDU0H/nL+9zRGMcBD9u/x+PJr5sGQ3XHY1xnJHQ08U2kkwtFE/Welc5IKJro5MhlYycXiiTq",
-	"O+qOx+gVW412cqHGRD1ZamZLnsu647F4vIFevl9/vO547NI3ne+HEtUo1L8H8gbQkSYqPWBwMAg0+FVG",
-	"1GASxDqZNRybO3HD/Bt0gjSRp6B2fQkFREzIwl6vGvd71ybskVkQBCKCMt3+nga7QQz8f7iQUmEnn8JO",
-	"Mg3mGfKaxg9Qfnpvi9KtEgI+eXRKlTKy0qIkYf9+KYXNFWz9iK372FjD5iNsbWDrOrYsbK5j82X25rI9",
-	"+aKOsxfHsPELNm/Ue+OgyTeeRAXBHhZQSSiJsoigdirFa+USCHovtm5ic54yXsHGmj3+yh65js0buzPX",
-	"7dXZoqALFgVWZzAeR/G4niCOlPn+s1DpQSkQi1CZPL9KnEwoijrSz0A+CfeRyVjB1i1iA2soNz+OjXF7",
-	"9ZZ9dwkbc9gcw8aYV6xuXtJhnkuXqkqQV1ygqWh/hjlvY/9oNftLal9FERgwvY0IjdVEIOlTWQaKXm8j",
-	"Q6SaDCSTK8qwO2fuzXz/NjJwlWUogZaiECxNk3J5y61YHl5lzg6WQ4AfYH2saarmAx9q0q94m88IiFlT",
-	"2Nqyh6/tWUvYWNx5s539YQkbs9hYwMZVkiLmEjXkFjY36P7RokTm+j/gOI6LePM5IyqoMQr8vCdDXed7",
-	"fKVx2Vjz1E2vKcvNQJ396HZuaSi3/E97fMZe/yX383yRs4DjXXObPnyDYE+eljGenXuV/eEeVegX+vce",
-	"HjLjSl25WrGAq0t9XKlaUKhNC/rs642LIkp1OKW42CtQ06qVCuZPTzH3Vu1oU4j7IBRp6ohEYpGjsUg0",
-	"X7ErS074OhT9pD4t+nd6RdCOrWFsrmLzJbZGqGHvYWOdhBLZcANbyzTZtrC57Y2LLlHhtQHgA+Ntqo4u",
-	"iT1X+J7z8KsM1JFPEBeKcfXqqhOigt5bbTdVlm11K29F4s62fO0uw79rj+0bd/LKU+zZpkah5U8hiNJJ",
-	"BQvmFUp4g5ndqtLLkLt+rvNaUc9IfkZUMwpdroyx+T6mpoaGsSxvaMoyhvB2ifvJ337i3NnTqiYXR7pz",
-	"FQt8E1cCgXiG4xqF//uo9VTH39o+DqSQLNElWLhZvOaudqnJAe+qu06iMyBDlFKTx+KgrbW9Iw4CIrkm",
-	"4jjhSKSKg+LHXQKiks6ggMLLsPiZOAhg6zX778c4TDj73WABxe4kVSEjQwU19ED0sQTJ5cmBlmSdj3T1",
-	"DXqmSxZRXb1D30vHa4lwsSmcRa/V/FLUw688rs5DiR9oRzwqQan+/v4KtGCG0SrsbzvzWarrYn9fq/Sp",
-	"JDSe7O1SPpNazqRQ1yfNV1oVdq+t/dOIIDcd6YqevsJ/fu5Il3waXfr83JFk3tq+GeQbbeehnlYVHR6S",
-	"RgViv49KTiaWKdOlIqTK+X6pap+PeLKtbN4smzEvi0qSausAWxrylzvUk5QbCDpsO9Q2yF8GicEgvV+j",
-	"FDriNdRRS9m75OePXiipgogGatGjBKcKnL0CB4uM6GHgGsyxRjmuEWWgkNFENNBOENNFYvWyCE9kED3A",
-	"EOm0QpdAEBAsITAqCFDXv0DqZejJSD4t/hUSdCU47dQsSRSgE3jOs+daOljfgKjZTvJaO9R6RQFS0TWd",
-	"latIA9fAuY09nxZBDDTSpSBI8yhFBQ3rvCyFeYH+SKssY0lw8aTmtSRJiKs6audl6YSgA2ZLqKOTanKA",
-	"KaogyIoOn05LokCfC/eH+vr6QsQ1oYwmQYX0U8nCsVO1mlOUb4ODzIfsJxW0kYv6TAarD+3Nzewzc+fV",
-	"sFMUNBfy6vZpGu/Z95/aUyPYWD+DUDo/SmJj7azKVMHGyu79rezTCWzcxOY4+e/2zhfOn8XGCvlrfUeJ",
-	"z7O+oI7Y9AtIurtjkXpsjO+8vrWz+a1LYIw1oCk6UlB1XF5+jdkyEYe0GD+R2cYasa+NYGPtwvmzIOgx",
-	"ZiGFUgil9Vg43E/+hSW1R1SOe+XxhZp2iEKnWHwW+ahA1hutxwjpDwNtPEodC38YIJZrVaSBYECD3RrU",
-	"Uz67Olo/avXuLEjks5mGJL1ZeMRH7EFv7oFYZyII9Iwskx40BlqSbdgYw+aoPTmLje/syRlsjuWWJ3JL",
-	"W9SUL7H1DzpXvPx1a4SEGzYfOCOT+QJbi9ja+HVrFJvT9vWF7NSwc55AgmgDW6PkYWfqHMFDJhGO79EJ",
-	"vDAWJ9paQIKI59GF2LMH+uTXJ5CmF5sGSgI9ynEVsuxLncVMbUlVPLBQ6+2fQKxUdPNOn3l4Avgx3nk1",
-	"snuXJeQyTa1KuQoquZ160lrF5hPHP6RFd6jlftzIPn2Czenc9g/2xNMKLq3mT5pV1fx5lm4iUKvxMkQ0",
-	"1TvL5+IXNAqf0Ln2mTvsLmNrw14b33k1vDtn0jOuUhggYGLcoZPZVYJA5vTu+HV77TbTivThhHzKPbZw",
-	"6sbnofMwKWpQQCGGIIXKiLQMrAVR/PDjAFrlvfEuFQu1KqFCOh2ChomqaYlgP6JN9sGKHJ2HqqViUa2o",
-	"DtW6ru4HqrygV4HU3zDrK0WJMZ5b+tmeXHdqJoGFMWw8rA0EsLVAcfw5NqcrMqGRZo4VAYa1TM8Ql5zT",
-	"D3N6583d3ZEpgkBDBnEXNtbIGEmC1xyhMi2IpNis7A0ZO9vzLqy8pOG8jK1ZSmabcpi2p1aw+T027p/p",
-	"ICWnOhTVUF0ktUfNoBrgiOw6CB45ZnmAzQXSePyX4FGxVt4C8SckvTNIkvaFJF1S/9cgqTQCS2DIXf/j",
-	"IZEuqdXHyHZJPcwxUlVgazdFsuoDJTveIvhQ+/CZ8Bk/33mu7byeaW/LPXuRNV9SgKoAX+OVxl7SxfvP",
-	"ynd/OhQGf87VbzlX/z6DcFWocadeAjUF7CJ/94evg4NGnx6OZTJislLvclG/QHaUZGCEi5Q79SLs0lXh",
-	"MkT0FdakI435ApuLRCBzPR/M2Fhh7UzRYBkE/aE+l0aoJXyasNCg0HugwlN8NssL+wQgsd96/qzBnprw",
-	"vEFKa2qPBnXva5TC2WeaH5BUPlk79rW51AYTPsfKTvCwk96alSz5PMKpN+v2tSVsLGSvzucWZvJpWfbd",
-	"2IFsUkn/0py+TeNuNf8eu+qZupM7Ua6pnNyFCy0fkVo682Bv6CGri6QAP17Fxjj5aSwWR5EDXm4zEARN",
-	"h31241cpTrS1lKAkNlZ2Nidsc47KaXpblLgSCuxsTuyuPsTGMtNv3G1lWVPjbAxkV0dZ9WhiifZuldhd",
-	"+nFvbipfSgjf5t/CeAzlss9v00+HVuw7T/buENPYNyf36MdE5RWNmND7aUXuX/dzEy/sB7P2o0WnETNv",
-	"OOhpTnswl3WMV+nz5EnLQVwGUqwGE/Qdph+1rOSuP7W/e5O9fRUby9kHr3LLE3kXuc4Z9MK8B/uWdr99",
-	"nH1+m/R0RIlhMriYhjvoVEboPBkHoa8UXjnt29Q5r6Uq9XRyRkJimtcQfSsaSvLsFVRt7iv/esC3JTu8",
-	"OC170e47KWzSKvic/DU2sbFmPxrdnbxTpcT858JCyRFs6cNrDCdY+Ho+FvF8C8HI/wkdfzToKPnclR6a",
-	"eYN7xY3sWe+M6AEN73anuaM9hdbrHjJlNMnTQkuqwEspVUexDziOC5O+5N8BAAD//7bNKpqeLgAA",
+	"H4sIAAAAAAAC/+y9e1cUV74w/FXq7ZmzVrumgW4UJzJrVg5RMzKjkVfMyZkoJ6voLqBid1dPVTWCGZ9F",
+	"daugQCRERYTEa4RAbDQag2D0uzxF9eWv8xWe9du7LntX7aqu5mI0YdasCEXVvv7u1y8iSSmTk7JCVlUi",
+	"7V9EcrzMZwRVkNFvh4dykqx+KMkZXoXfU4KSlMWcKkrZSHvEGF0zrswZv9wzXl7lopV5rXL9u/JsQddW",
+	"OpJJIafqxZt6sagXR3RtofxkzSjd0rUZfURz/7VQKM8vlSdG0Qsl484zY2pM11aSyuCe09kmLqkMtnOq",
+	"MKS2JJVBLlp9OG6MrsFI1jxNR/lsf57vF3RtwXhwq7o4Ul361pi4gb7Opj5XpGw7x+dyaTHJw9Jbhprw",
+	"Uy6aqN6dSOiFJb14Qy+s6oUFvbCiF8f0kYJeeKQXX+raijE2Y0xNorGG0soQPdJgNtUs5YTsUCbdh05J",
+	"aZL6+sSkkJKS+YyQVZuVnCzwKWVAENRMuhn92+AeIrGICMf9r7wgD0dikSyfESLtETxfJBZRkgNChofr",
+	"EbL5TKT9VCSpDMJ7aI+RWASWHemJRdThHHyoqLKY7Y+cP3/e+hbddUcqI2Y/VgTZe9GV2fXaxI/l1TFd",
+	"e61rJb34EI6m8DwSi+RkKSfIqiigMVKiwvemhVQHA1rEVJeuLeiFe3phWS881ItP4aC1icqFu8aVF8bE",
+	"DV27rhcmdO02/L+g6cUZvfhIL34NF1Nc0Ivj8EnhsV6Yri7cr80+QMd3uzwDMMdFy/OXjSsvdG3JXh0J",
+	"Sxg490RiEWGIz+TScA6t8dZ9TfH3mhL7TiYS7fF4ezz+acRzSrGIkOHFNDpe+1NVUNT/7BuCfwS5OSll",
+	"WN+JKeqjRMy6svaImFX373O+EbOq0C/I8FFOlvpEeP2LyB9loS/SHvlDi4OhLeZ9tcA9dZmvno9FZCkt",
+	"KN4j7+44dhQd+E/oRH5Gx/hU18b1wmVjaqI8fxufITrnl3pxGUBNFTIKvVseIIO1Q/MBL8v8cATASRb+",
+	"lRdlIQVAKKYi1tlZC3S250Cj1Pu5kFRhtI6ceFI6I2QZhGZkfGNtrXJtsaOrE4AGNrOiF59yUfK36ujS",
+	"xi9fw2XPrlXvTiBKVNK119XX14zJZ7o2qxfG93hANikLvMqGWHMcBGCbg5yhnCgLCmtsDK7l+du12SnG",
+	"2AeaEnuDxxZT3kHJ0+g8RA27t6+1971kQmjaz+9LNe0TEr1NB5KtfU1/TrUJ7/EHeuPJRIo1TZpX1I8V",
+	"9vmU50cqPxU2fnldubbooOESftAI9rUG7BNTu6CdGlOT1MhJkTVOThb6xKHgkYC0PBw3xi7p2ld6Ydyk",
+	"Q1rJuDhWu/NI10rl64/LN0aNRzPUhH1D6mcdvQeZ56ckpRwGNBuxgvDawoFu+CwciqEDsvdnzxgjIJuE",
+	"xCDMw7OGRj9gVV+91LWniJfNwYkVFuF58bJe/EYvPLBofKmycsGY+xFYKJ/l08OKqLRz1YX75W+n9OK6",
+	"XpwCQQCGvKQXXuAvjNLt6t0JLtpyTuw/x/fHuJbkAC+rSoxr6eWTZ4DycpVHlxFbth4o7cBq27mN198Y",
+	"j25apI01+NVC5eKCXlwvr1yrvizCGgqL8A688A0ihWP0wGdlURXCjHz5Sm32AeLZFjO2dhyJReiFUg/Q",
+	"BJEeEqyIDz1g1ZFPierhQSHLIltz98u319Hxz+Ib8PJpQTV5Gv0p+VF55UtdK1Xvz1efPy0XLxp3nnBR",
+	"obm/mQNuUXhsXi86CmDtIxpwmmLRYtsvAQrQXw92/xcSGq7rhbvoq2WQpygMUvhMmhNTub+mhD4+n1YD",
+	"2bAbfx12fxhe4aLOo8J05fILJFYu6NokCAfaBYcqfb9GryIUVxdkWZIPSikWnjx4Ur4+A1Dw5W1j7g5s",
+	"GvDhe7SYp/hIuGh5bMq4ctshj4UL6OzcLwLWXLgL67VHDSCnrYn9+/YeSBzY+2dCysiLWXVvK1PM2JRs",
+	"IuY6UilZUFziQeJAa3Ni/3vN8eYE68CkZDIvy74ctnLt9hY4rJRXk1KmrrjkoMtx8wMkNOVVJmtZQqRg",
+	"0UZqvXhXL/yCYPuyri3qxWVMIqgVdx3vPsm1ACC38EnFX1oKu9CTw5gB5BVB7ug3Ed2Z7ph0Tkyn+Za2",
+	"5jhrLviqMxWMLZ2HwqOKF+LCgAyLXRHgYH7g3KIjLzqgRp6AdWcxi4AxmZnnrkNQSMC2n6bK384ThFvJ",
+	"J5N4BX28mM7LLvJsPQykzieHc6HnXyzV7n4LbCct9YvZds5UmLQSSXC5KBLqtVLHwW69uH48J2Q7D3EH",
+	"pWxWSKqI4mD6sWyzpz3mkFJeNcdcRO/9bKpTxWVr/HtILUOcj0dbb+eqS5PVxZd6cb26NGlcXdG1ZYsc",
+	"AWUHeUArETLADHyb4lW+GTGvc0I7pxdHEYQhxdHLIYrrWA4Ix+nR0AIySrhHZvFv9HpKSAub5d3oJiKx",
+	"CD6+SCzCWyBBbtL6FS/M+g3PS8OMNZ4HYj4wd9qRTEp5FlP3kWPuG2sL1cVHRumWZYDBG6zduViZK+na",
+	"iqn7FaeAl6MXjZEHemHaEtLZSpGYFVWRT3/Ap/lsksXpxr5ByqO5gnJpvLZ8M4p/qY3cqv64aKyv6dpD",
+	"mmLE0f/gSTKdV8RB4ZiYFTNw0qqcFwh6kpLyvWk41oz1Qtw+s2w+04vZUVoYFGS+n0nCf0Dn9QM6slWK",
+	"UbZt2/wZXu4Xswf5dPqoMCgwRZNvEYCuIkyz8BLQb0kvjFcXX5bH79RGv6o8f1Se0Cpfjp76jx7XeTW8",
+	"JkWVcsfzqu+CHgHsFJYRFo4FL4WLuja4sf7dxuo4daVtja7wPINgW7B/UJJlIc3jtXolU0SvivN6cbl2",
+	"42vQxWa+K/9wtzw1WZm7Ev345EHj9lp57cYeoKRzq7Ub9zZeF8rXH1fvThhjMx4Az/CqzNIG8fNTYs+p",
+	"z3t0bUUZzvRKaeWU2KNri9Yvn/e45jjVlGiO/59Ec7yHi+L1AGaMXSpfv69rE3FSdItTx3fqVCIWb25t",
+	"64mdgn9iiZ4ewgBj/+A9Yc+902qi93dz7ZQMcSrycfehv3f9MxKLHP74xMfdhyLk7I0Ze6zxY9bJ9gRc",
+	"9CFe5RUhJJFbtigVkDXj4nfGlTmb8HuNOXw2lRYO+pFQxHQKv+iFlerzpwBC8z+Urz+mcG7fvjgTgAkx",
+	"WMimToossaI8P2K8mjAZtmumQBG3dW972wE/ERfsZR+xjSCNKFxcFEbWtYmkMkhAJBjIaC0or6Q+zw1/",
+	"lkk0YzOyZ0GKysuq/wmMfbOJE0ASvt8JYOCi5V8bdH3FbBfHoiEHiQyv0eHMEPwe7xhDkUKzbuZhMLHA",
+	"kWtJcAxCiMP/yovq8MG8PMiy4hoXn9RGwDNSni3UbnxdXV/VteXy1aKujena7erT0cq1++W5Hys/3+Ki",
+	"xtSF8mzBGFvXtVdIeFwuP1lDhnuEPldvGC+vw0iYShWmmYwcpLwrd/DwIC2MFKrfX994ddeibSvG1DIa",
+	"H3QEr/CgihnBTWl8rtp+3EY8boAKxSKDfDrPOjT83Cbkcj6LqXgJre7U5z3l2UKl8AKkInSALrJsiiqJ",
+	"eKI1Ho/3xIgn8ffa4vEdItQueMInaW8yCIQ6s6rM9/JyF68OeE9jY3V845dJhIgTplXBxP/lcvEp0vpA",
+	"EPRi7cbqI6yDGCMPjPHrujZJO/pygqKIGVFRxSQS0Inf27ny1KQxNqoXrujaojH2feXemq5NGFMTunbT",
+	"M9MCNgWYcGqxS2eEwrRxYcm4OIYXClNJA+lkO2csjBsjD/7vpena8k38g1G6jH+o/FQA6NVKtTuXAKSt",
+	"HeA5dG2R3DoaMT1AjmgPZA/d4IgEYSHOJRKLwNLhn/RAkiYy9Gu+WsIxQZXF5AnpbBgWiu145YnR8uIs",
+	"vkgPxrJt7PgTxDqsibvzmQwvDyMCj7nOV2ie+x6LXlZQkX+Kacqri7QUxuIVO9iJUDKGsDC2Bdxz4Zpp",
+	"Rg+BasfllCCHOXkM1eWnpppVHpsCd9LTxfKNUc8VpCWVRfoRwS5ff1wbvRpF5w1y+x59pEAioSKeE7P9",
+	"mJSBEF+6W5m6pBfvIW63DKsozSD1HFbhsuFsky6kiEyD6P0fqz/+DNB344Ux9RWBD7354UgsogjpNI0A",
+	"+DlD5JByRyWFcUI2hQD+dOclTZ0IAjTjcCtC2DvQ3Nb4Vm1pxGPLtK+a1Fa4qG2TQCLZ98jAuIRwiKXT",
+	"W7TPeH2xdmeMxqoAqYc/I5gIx9DWgfayDsiiyszTaUts4nRUplyIz8byEera17ZAuLH+XW12EgJE/EVH",
+	"TJEBjp9dsMlrXV/+n+IgUkeIHfzP6dOpL/adb4q+3x4/lWg60PPvxKl4U2vPHuLJqURTa8+pOPy491S8",
+	"KdGz52T0/Xb0E37aeiretLdnDzxqw4+IH6Pvt58+3Yx+/NOe96Pvt3/671N/auqpN8KeP9YVLdG5mnhW",
+	"lzix8ASRndrFSawNh3JGUmOyhBfrhRN5hsZe396GXHzGk+/Kj5414pbH323BaZDC+md4r6xbcWUcxaYc",
+	"KmfEbCrs3Cfy2X/A6+djrhitkB93OR8BEcOMPOz3Jt9nW/TRLmh3M7FC4ridaYMg2NpoOBOoYzVXxGx/",
+	"WmjnjMmbG6sjJAVmklouij02lo0Zi6+SrPZJaVFq58ITbfdILfYoeygZEC8QTsf6O8377L/7yn30NW4W",
+	"45DQ9j04tZAqzEVJM7JeXBcJVcL2/eBoGtKCXL0/r2v3sYroVQJ5x5AdBsAsu/f5mPXlQfASZZPDLKHI",
+	"MTNvkrl6rR4+bFV0qVVh9kKpYuCjtElyaGKLcRSJdaFRFL9dz9bXkHINTKdP9gnA8RxvYRqAC4GEaal4",
+	"/pSLWnGdpF7nPf4jiZDGFSXiuhQbYCL2SQdRl277UP0lbV2bIANSMeskLJEgfjBFbSAhfeKQkDoqqe3c",
+	"xuoIHoAcm4uCpL/HfvNDmU/CEvg0oS9jUc0y4YC1xnjwBK0KgsZsa72ulWRROdMlyEkhq576jx7boE/P",
+	"SLzkTHwCDO3tXOUexOWCHAiWnYmUkFZ5494MGH8K40h1XIYF69otXXtAHVJhGt4rXNC1WbypGIe+RlOc",
+	"EdLp4XZOLzwBhCy+xMZ55GT7Edsd0BvW7vXCdPnLOYi60m6buy1MVxefGKUX+LjpDZ0Vsyd4VYhxOX5Y",
+	"6utDW4lx1IhoFYMSuBPSojrcznWcPKFrJV6Vj+XTqphLC8bIJGnX2PajjnG8KncJsiilYhwxL2YKLmrp",
+	"/JlC29ZtU9DstdBiyj7iswRLREFXSn3Ttq1OPOrSqHnizY256vghexuBM1qaNjHR9unBBEDSB928fX5H",
+	"AsZ2CFgsE7oluVg0LRLDPzpEy3qCd2xeJ5hRbMxzBU14PvcwIRO53bDQtoVLd+tS8NdAJuFIx/W4Ho4a",
+	"AZPg3KXq4hgXrY1+Vbs7Cbr1xUVde+j1iesjBaaI6hg9kcUefwJUlhgBUR2fOGYpkxEVxcSiENawPjEb",
+	"4OHHsb2VWxd0bYn0DURiYcbulyVF8TNHgCscWya/XKjNPjAuT9oeCWNqrLo4Fm6OTcUohBvacXz7uhKZ",
+	"nv0SNmIbc99it2KwH9ExkdY9olcTmzoiWQDIFlJIpvTZikfwGTEeX6UfLpfXRpB9G2xEEKwIgsltXVux",
+	"2ePGKnYulHDItGUKw/kcS5BwgMVCU5IqhTkeJS3mcny/cFBS1JAgjXNtGvkAh0sEOIypcIkF4+XPxthz",
+	"y6VmpatArPMqkV4Rbntn+VzIVaoyn3Kc2kHDugmd8yGNk9RRuY46RpISc50xyp7vwjwXKfGij+ucmXAZ",
+	"SI6R5H9CUPJp1i0RZBT7OvyoNEmcmaFKNEXdrI2kYYc13FLjxqiT8FnIwAzC9GLPF3TieOyQLo5nFxwF",
+	"D9ENLmopMBQvs9ifFnALbwtzFLKqPNwli0khgDhXX73ECXH4CPCmI7HQ41sxFJtJLBLV0KvDR9bQ6oZE",
+	"9YTAK6xALDxaZepS5doTLmoiNskL7BgycB//8uXG6riuLesF0CdJ8rmHtIxZ7h3KjwF/Tx3vA6OrQ0IY",
+	"eYx4vQGH2RZ8mJsVVsKdpaVuhHh1cwJBuGXkxBzDYkgCLk4LJIGFNEBAkB2MsSfcdJY/kO3v8/r33gSv",
+	"Nxmu+2Bf6MUVHEaFtwn20Bnj6g3kNlwpP7qvj2jlayvly9dB+tFWqj/eCXsIPp7CTXsHCdGrvq3X19bm",
+	"x4ncnAOu0IRfkmBRxJHAPZIqUSRk+0SPuizrkNjXx7CLr9/wMQgvG49uGvOLtgPU4l8U9ymuu/zYenEd",
+	"Iw72QdlBLfhr4Erkr8CVXqPhZ72MCW0229+Z9RPMYXB6jczbprM9T7UyIyP8HVFuw6+cz3amQiwJBw3V",
+	"XU84b5hqCR0NSD8umMXrtoaKEcfLAp2DKDyvbqCoV5pISwrLyY2ig2gXery5rXUfsfu+tIRy9gPNLwNi",
+	"PyOGC0cheTz0e/c2OnyaFTeEg53c0RGtjS9eyjEzuBfG3cO3xZsT8USjw2Pi4UPHZ8yQ/+JlUwIFWfKX",
+	"SXe8jFu2nEOlKegBXCkS5MrjzfF4w4fOjotgOrET+5oS8aZE/GRib3tbvH1fvLlt/59/k9EMCFZMeMdw",
+	"GTORyx9dlbr42liQAx6VRQQPQhrSUTErhAglX66NTuraI117WL56tXJtHUMNCsk1E2XBQvDEDD4uvoSE",
+	"JoaWkpZY1Td+BidS9fKPXPQPstzf39uLS664oLo8cw+7m6qXf6QZ/h9a2/bvFXphPl5VBRkG/Z8/wF3x",
+	"TX0dTR/2fLH//B/D594bo3c3XoFQX727WHlghpUZU5PGZToPv/tYRyszOTEnidkGoi/QPXTBN2GD+MwJ",
+	"mEDkDNb+BSNwOUgfSxxoT7R+6hvQGEoOZSMC/p65XmWwM9sneReL8OSglM5nsp3ZlDDkx4+Q+ep7vYjT",
+	"kXFK/qjJsAsvKteXjKs/R+PGwjiIloUrFOTsq2fESglpMSOqggzH6l0BpBoQWQhAayfWsIuTUUYhRlG3",
+	"U7HTp9XTp5WeP2I7/1Eh268OWJZ+4jeWNqioyhGBTwk+a9KW7eJDyGVNyzMaRe37+LQi2LP0SlJa4LMW",
+	"lw48f8ywt3L+rfXOPy2dDVwC5upbWcLeeksAGh68hoXxLa4hUW8NlkYRsAoPe29sOVAQiqb5pqnRNSwN",
+	"SqQOZoUTuKWJtnqbAxIRuDUcbLGVA66T8uSiWRR+uWmAd71eEPHijgeSY176FkAdTwqZXJpnJfNjtgTC",
+	"3PpNFF7wkFScD3b/F65s4WXFDtkNZE3mawF1ai6hm8AgAlq+u1rNsZP7aAK3f18dCsfmedaKWcd0SFRy",
+	"aX64Sxb6BAiqYglRmJnjAC/PcYCG3i2eE1iZJSPVh2AjSujFW6ZPTCvhwTbWn7vS61rjpM82Hq8XfaAO",
+	"CMxw4mvrtW/uIZ3+EjrVb0lj4rCiChkAKbF/AGdjy2doLzR6wrLEB8U7aXrxPr5Cl+hjC15E1NOxRIw7",
+	"1hbjjiXgP3vjMe5IIsYd2RfjDiVCRT557vCwLGPh0C0zMg31dtZ38aVx6WKtuKhrC0Qi5UOwIxTGvVVH",
+	"qKXFh96DWI9EJFxNkYygKOx0bHsaiDks6oV1DChclKx0Z6y8qj65Sx+OJTu/Rh9fARnCHkubIFKGXpmW",
+	"sJHC6WzUu612ztoLjr4Jxih0ps5+evxu4xNRHbDMzvStCLJcj3Tg+yQ0w0Cp01b/glcO85ojslb9ocg2",
+	"d1AiGjLjPLIsOaa5EUAJXriCwrmc9FsbLnrFrOldciPV3wQVlRZERTEcJ54bjJkeWKQfgB/BoiTBrNJW",
+	"J/yrzCAj3mMELRdqdy6F1RKJekusOElJ5Znp9reIKIHHpkyAPQjf3N1Yfw4xhasj1dFnGHrtXXqMZQ1w",
+	"ZysWEm/LWhsLGqyLgVqCSt2L2dTJdx6Cvd4cbeSkrSXVVfbonfrs0A6d9tmfnM/WWxGZ5UCH1zbkr6W8",
+	"2Cwgst3A7vTfRbfByrRSY18NhuvanUt7Gs3rCOdBhhOKEdG3Ae5j4rx3CKDI3I9NorLrOrcOYt0Ccljs",
+	"0I5xIBYqzrMCnLPwYku7B8wyF7xNu8eAsV2bD7UJPKcleW99D6j44Q7dH1lE1HVnXBTX/0QWEVw3gqpF",
+	"CWn2hZHQaG3XT936mRwR+LQ64D0KPq8OdMnSoOiTAUsWBqTC5VHdKTtpeAwJZstcFNX9+zcniamktyAg",
+	"MxGUz6Q7Uzm/WrdaSUx1WUFAd5Hc6BR8qFx5Xr44zkXJTejaBAzJ8JeGA0S8HPO4WHVXVF7NM1YrnSGd",
+	"udbpuFdsXJoEWv9qhq67ZUnv2jhZRdaGIjtWJiX0A61OEcqRdAZp7OZzSimSztR3CePdxGg4IG6FBUud",
+	"2RRUy5ZkvwLiVsosw0zuUUTPsDPDWN/SyWEZvp2z0/eNyZuVZ9cqC+vG+HXjxVPjm1F4R6Degez364/p",
+	"d0gtM8NHULk6+hDxY6/F2w69dxP327UbX3NRv9I0+kgBv+IKwrQL0no+hKqW5R8uULFQtPodoHC7rttM",
+	"7DMXz7rbY0IdKuBbKfwNF9WmDB/1C2s7r/9+SnLTx8S67S5JseW7LiuH8YTwr7ygMKNnG0jMcxfdBD0e",
+	"5teL61jyRFVnUFTFyDggRmHaSSwb0cTK9SVU767ELHK0YhrH9OI6/KEwrY9oZpEf6i3zUWHaGF2rXH1l",
+	"VvlYeWW8nodoX1TimJW982ZyHYEYWBV4/Eo/eegBjukqoDrWt9GhY9X+B8uUtUBNAYWF5iqlGa+d2i9D",
+	"Mul4ZRvwtyosZgkllsIO86HIdtoShttwy3EsuO6h3t3cz7rlryznhaNWojIvtdHJ6oPRjVUo9UkXqttK",
+	"qbhNlOSiI7BwGiLpQKEENN/iXY0RR/THmBMw7cbNwPRSH9LIVigYWF8fzejiiGGggqyn6NMwoG4UIa4R",
+	"7DB0VA/KP7YwHDveamD978f24oVBJ4zfY5GhgaQelBJ8e7u4GcEKQnIAk+KHIfSNe+R+S/TbQ6qtWNOn",
+	"Nqqa9RtovTt0+aDNZPpfsh1eIJow8vxDiymFaaJCnemiKl9+gtPNTmfJsZ05teXa3KXKsws+ks9CwMKx",
+	"KGXWhiquW2WQzESO2p1LxtpVCLJ2FkUlihtjD9hl3o6Br+xYG/wngf67Nx6JYRffEaCFhxK0ungk8ZZV",
+	"sHQzwZAsz4/T/XZ4zjuYzEVekHJQyuR4WfCl+mKKZXvG7VGCwry5aOvG+nNUGvoKSu3ykyATsa0GpQfQ",
+	"KLIIKqiGuDhqYZyomFqq3fi6dusaXf/MdMZvI/Z6NO5G7oaNRAKqDhsWkqhassgrD7UiG4dIp8YkOz8g",
+	"AFzYmM1F3Uo+WGsVXE1zz7Z4b0x0gCyQTaIgfBrGI4YqXZsnG7NuiJre79ZRAKrii4dvmQzVR7R+xJ15",
+	"2iPKYH8k5rr6yrV1o3gVyDQKTSYNpejtXLafqZH1yVKGAUhEDLVl/yzVbowbC+NWDUGqiqGvNRSsH+xC",
+	"gfFEcM3pAQFFD7V/4bdRVCf5+qncUA+9GEsMK+GwILM4lr2A/fWDntJiNkSUe2DM+d+7j3+Ew+CRywlC",
+	"bdHPC75KdN0AbBQIz4x9CHl/0Cpu7RLr/srzy8bjV6z7g/xD9v3tTZhV09sOfPpry1BQsySlDgQAi/Hi",
+	"YsOQkmitDyq4ZQqFnrjgi1t7WEXS1HP4r7ZoPPh+Y+06yL1rkK3GBCMcBe2Oeg5bSAUIne33UXAX2XeF",
+	"5In2ujfhsAqJera+ZJqYx2aw0dkePDRu2qe8LXa3bVckiMP0gxPL+e4DHWbnws7sIX5Y8e2jOfNd+fpj",
+	"Gr0UQVXFbL/SkpSyfWJ/8zD28/Kml7zZxJjDeHwYnQ58r4t8oTpTEi22sBnTV0LGfSsbCQQmu0v6LwKi",
+	"VRefoOZNGGxfWGGRM1w0YQvvDYcZhGtRaUYnmwutBwM+WqQqZBoKfmB3ke3IqwOSLJ5D9jGOaE69zJ2O",
+	"fCDwsiBzp/Px+N4kGgD9KJyOcLZU40ZiuhMtKmpsvJ6vPLpmtu3ytp719gw93Pe3gc7P/5E+lj2e+//l",
+	"bvXjwU+G/nkunmjdu69t/5/fO9BkvlUf4dCm8R36nvOniF+8K5Q4tDGMaXsCg82Li3phOifmFLiRQsH/",
+	"Ot7KdiKB7NW6yjcYdoWn3IbwInCCQ1N52qhi/tTO/ft0ljMx8f87dPzgyX92HeYG1EzaxEj7j/Qz62mv",
+	"lBomn1rPQZXhMoI6IKX+ehq1azwd4UT4GZZj4gSs6nSE/twaQMzm8ioHBI3+BkhEcR3/nzVxC8zM+gMG",
+	"KPwXu198v6AeTgvw4wfDnakoY3V7mpV8b0ZUo3vM8clxyJNooY/CfEieGgvgifm8cHVCSPPD3aq7Pl9k",
+	"aGgoYCwhr7jaV3Yd+Wig95Ohs8fTf08n934w2Jv9KN15ZEDt/VvbueNZ/Leu7r8nkpl9+3tbPzzH//ex",
+	"/b2ZD9VP//vY/pR92uEyJ/AilJyUVYRt2pEz2K+0JSruzLMnIauK6jCrggOONDuM/053CB9Q1Vx7S8sZ",
+	"YTiZlvgz7e/F34u3yAKfzigtmeEm9BOzP5WgJgfYldqxJwLbb93RbYVpK7TN6eK/qWruGUHlocR4R7/Q",
+	"LSS3sIiH2F6AVNWJmvZlZWHa3R2sNR7K2MyWDPHZ44w0LuojnkIoXbOYyimgIliJRejLFVNgpRlXQMPk",
+	"rDCknhD6ZEEZYHZv/+EuSoT1nIgTdIizm7DCTjyeqd8dNnR7dxkv8ENeTAspVqfUZ9XXXzUSHOkIAKAJ",
+	"c1GrKduy9TJub3vbu3E7P6ry/BZuAL8nVCLwIJ8WUx9nVXZ3aveyyZBNLur5+7IzmqdXU/BBB1a5Ykvl",
+	"Np0gsdiDUe5LYnJ0JyI7UFzz3qQnmqqXZxVXMW6vVddXcZSSW3Jjx0uAJzepsrMWE3YvHV0roMAoaNmI",
+	"R6+NXvV2LQ1V7ykl9ousLj5WNa2S8fgqxJQWXuBWmgANdzUXjdkbRFDCCKw5Meez6ZyYg1wqtBrjxUVX",
+	"TZNEqD3+Ky+x8mzJsnxhosYUM2eiwZD/sJkLJnwjULLWbN+Pc0QuMCGW5Q/j1hL8iiNBcmPRDuxsoHYQ",
+	"GEdR6z4OupnqWunIkfZjx2hk98kCZAGIKp0Zllivsgv0mNb1uguIh0lDNI8fzRRU0cUdbBvYrtxqc1ye",
+	"X7Itp3abY6v3sU/5RzpNnGVGslitnRJuZRdTydtcFLNvmNplx2kk2tBaCMtml8K52vVGYqR0o3oQST4t",
+	"BGwP+dWf4MhiLtqRTAo5tekon+3P8/0CKtJ4heyzR3pHP0cB71lajf2c94smOSexCueYrUlNXfmOXniF",
+	"1vLUbraAOZ5V0mC6unC/NjpmWa7cX3HRzo6POpgDehrSdSgi33KSjRTnfWHTjvzeXBS38eTb8sgCHc4A",
+	"fGDtIQQ0zj1D2UCmQRgJeTBAbUQDMdQsnXwBj4Ej+72gbYLLRx78P8nLEvdPPsOnmFfUx2fENOMz/y/6",
+	"xUEhy57Hp6pmPkfTd+cj5CeVFdZ3bnxwQDoY6lwkiDwXcu3Uzu1V2tP4USlfqu8NVKksg0MLAWUdVuDf",
+	"4oocdQuqURLFDTLHd3nikcueXi8ZGQ+idIQl+6aEQWYVWjizpo5+IauaSGFGTME89gFVl34o3/yS2tnB",
+	"AVnKCJyU5T4RsynprOKfA+IWMqnFu3TbvX2tve8lE0LTfn5fqmmfkOhtOpBs7Wv6c6pNeI8/0BtPJlLM",
+	"mXIdqZQsKIp/s+dlMjUTb7CzC54VLwPbKLygFpI40Nocb25tZsZ9pXlF7RaEbIcaPg10U0pXXhFkdDkN",
+	"bcu50xBhLxEbNshTJKemO4YRe3fgloWNn9ouT5faIKmqlLFL5NStW4W7mniK+HmFeni1i1k9F1uYcVlc",
+	"faRgJqqQmSl0y6NILMR8Voab0zmWP3NS+gDtDkRa9MNJqUvgz0R6UGoZfybkrqnG3ZsociakpaQZiVR/",
+	"H9bb7KNLeFz9jhq2A8fqzWOUraK1zvnFKBgi9mvBi3k5XqhECk0yL4vqcDfIZyZEIpcSeJ3gNyS4IeqJ",
+	"HjtrBLsb1lilM6JgvS7CKeFHEUu8h9BsQVE+s1w9FqvMif8QQAdCzmusgafFpGBaKM1vj3WexKKZii79",
+	"A17uFmQTQQcFWTHvpTneHLe0BD4nAu1Ej1DtwAG0rxaUfNbCQ0kM+L0ft/IHZESuNbA70lU/6I6A7afM",
+	"7f0rL6AYRnOFVuwpknDDF+Q4CZ+dPx9jDyrl1aSU2cy4x80vfYcGUoZsJ87IDQWl+o1rJegxhg2TI3k+",
+	"5kY2V/0TrOrRbVjtbHN9pHDiw4N79+49gJQaxvJQyBZ7deGCq+ouECvDzAVaXefrL1OVAhd5oOFFUgWu",
+	"cKkWOuCgLe63FlQgjb2ctoYqYnlXZcW+gZBVXXqka69r9+d0DdQK1hp9lyj19SmCzxrrlYfrAeqKXSKI",
+	"QLTG49gHmVVNGYPP5dIQByJK2ZbPzbYC4fCRVT0IUTqX2PLovrG66rXyYj4BBG3fNi7KrN3kXYartaau",
+	"TWysTkIJeW2JXEli51dSXvy+NjtlV8LC8+7d+XlxR8TqyEUnnA+Kdk2XJzQne90+irY3cSnG6ENoV/H8",
+	"Fsr9WDbmHtfm0I1cv1q7O0HXETMBBsoBkDXUqj/eqU7+bNybMR4sWN0sruBxkQ9pRte+Qi0DTJ0dfW9H",
+	"QMMlPEW5LyjlElSuS6hQ43J19Jnx1S+4y1bl3lp1adLuKsRVHl0+jUPu7NyFSGXufvn2OtILZ9GYYy7b",
+	"QnHd+vWeXniIewZUlyariy/14np1aRKFAZVMd8mIRgcweHJ1iuvVhfvlb6egWjEHl/hgvvLsHrZYcFHq",
+	"qnFShsr3A383oaCjqxOEpaEmMxf+lJm23gObMqUIYKJKXSkClaiKvAEyQ9bC2iSZ2UXuXeTeHHLPrtcm",
+	"fkSdxRA+OrbvReewtZJZcbMwTUYzbR0b8+pAi+nnQ8q1xKrpYL6ANRCwliMFRS9MH/oAEg/NzC67LxoZ",
+	"hLhYubiAVDagO3YVJFKj0YvrvuNbFn/TVno6SxSzvI1qEl43i/CCBeg7euqVyoW7xpUXcLfQshXylKgn",
+	"qDcd/ckEeJfHvkdTmNtxkhq1b7zWvSi2au0BiHnwBI1tFoxAy43EXJQNQrhA1zMd9F7atq9OVCdy1OPi",
+	"UiRwRyB1gbeSVrsFtQmfIY1qRCER4gL+OjQ09BcOUl//2vIX7oiq5o5n08N/4bpBtxX+wnXzGaFbVIW/",
+	"fiRlGX3Kz59/U/Sv3oVPWNe75GC8CUredipEHSZvOSX4Et+ng5f0xcMb9aCpZEMTF3V97oUXqFC6Sz3D",
+	"Uk/T7BJpP9VD0tI6EIJEGRCKEDWiTJ000ao7DoGEs1ZFXbSh4jeWSAbVbAhqjMUxRI0R5e01889Iquul",
+	"FVaWWgRbsgRF/UBKDbsgJIN6SPOyikIOmyCGIzyQsIoDYJx2UabtQ3BGCjET272ZpxOOJFaa2Fi7RJHA",
+	"N6XtdXR1ehS+ZfAIF2Z1bQKZNO9TeLOxNma5BnEnJ9fHJWzihFfL80tYHnDVaaFeoir3EqI8XoOFJr+e",
+	"ULpLwxqTAD1G8UWrYy4KTGTVM8AFUTEdc9WEsDsWkt0CXXIjQZe8w9sSo5VmcirCZ/n0sCIqLtrVkrNq",
+	"3oSjYnaJnDdDzjzFyt4gXXNXA9olcA0QOJ/aZFjrRjPsksbfB2msPhhFZf9coaRB9BIDD6zy4pPy/GXf",
+	"iiM0EfUUHls0Li5u/PI1SUEZfYu1hR0gq4GGMbt8xA7bxdwVvd8ps9gumjUugdDhTIQZaqJ+01mESMaT",
+	"78qPnkXd1H9Ec2fNjWgbq+vVh5quLUG53cXZPVsUT2zEaUddIl3YhC6Vl4VwMopVliVQRNkeGcFdnYcp",
+	"nsR3eOq6yG2Vd/l1XGsNyyKo9wT2gWI/nFVRArVPXr1Sm52qPr9Y0760X8YhvfArWTeoUnhhDeF88+sL",
+	"EPvi+97AvETIDToipqjqbqKMroCLWm2XnRBuNvM1q9zvUuuGqTVJj00Byae+Miav4NAjK2UV162OziXj",
+	"55IxdgkV4nuoay907eEO0uEvxNR5bN5OC6xskkA/0wMColbCwVjBuHylNvvAJF1+JvlDaDU2YexMecOW",
+	"6uttuPM1irCAgCknwEJMmYwEB6HhMi0OKNePFuoJ4yJwJDFyx2+Hg/Jtp1c21G2sf2c8uBEaunYp2PZQ",
+	"MPbZmnC8NSp0VhZVAYXL1tWm3n603xHt7t2MedgVgXYJSCiVtPLTVPnbeW9V+VJt7lJ1cQysPEgS2mEF",
+	"FASfFgGVQwtl2ulM4dppbxdJirHBzllhC171h3ikBknYYDbVLOWE7FAmjZeiNEl9fWLSKZGC2z0rA4Kg",
+	"ZtLN6F8aKep2pTwfo6YcasqmvKjl/UYVhtQWs+SR/3sBFNSs8EZRUC56EB9EE+R0SooIX6HKDI4F25ia",
+	"dAXB7KEDPRhD+EZ8qCqfHICD/AsH2Y1mQRsLSD9LNCeVwdMR3/iOt1HXt3q0WM2xMBFGp22V7jJ9A7uK",
+	"+y7Xehe4lunKnYaE6eI6FJXkoAKrohfXDw8lhbRdkY6sGrkt3CqJChQH20hxEeOddd7ShZJDmUTFDN8v",
+	"tORw44XG2AH+VBns/9NQJr1Z+m4VUH1HLKSNeGvJDgxWRzqP+81NUHYdsb+HGBWqunBx3a8ZI9VBvzDd",
+	"/V9/s63xXR/9DWprEuWHKUrmfFffdTpgl0TzE67Nomk7qFabM2xKl7brOO80SLqRn91X315ZUKylDaVO",
+	"j1XfjqZo3zilz8oq8dG3KnP3qw9vOHGSTilhQoNih6m7YNTsH4YmBZv33Fx5/gfU5XORpGt+gAtvFqYT",
+	"CHXN3jQuputlkO7i114FrnENasc4rV+l7obdkLuq26+iujmY8ZtW3TBHZOOoXT8IT7UrePwOBA8UE8uE",
+	"h8J0pTRjjK7hiK5Nq1CssetLIBkhSPo4Juyk5HFM2A3P+r2EZ5H2Gatk3aLJZ3yFKjL5BJX0SDLalpBC",
+	"kVVhD3IKrYJlKIPPeWcJUFG7T7y8Urn6CumBJXs9leePkPd+1j8jDhZzbKfCrTx9vXc2xKoOIlon6ZIm",
+	"8GMcrs++avpi97wx24JdxsBb4cCCBCpNcpeovPtExcZdslBhQCKbJKaSLUk+nQazIsED/UvbASaMTRlX",
+	"brsqN3N4rLTUL2ah/cZ/N50QUqIsJNWmj08chUBSVr1n+q2m49kmdHu6tnw6C5/RkMvBFJ8J8MZfE6CG",
+	"bqzf3Fj90hREIe1vBC3yB2RDHQuiXH8T1ONiKnnQ2nodZ51ZhsFswnLZpyBLUkrRVYvqFqgxx3WX9lsg",
+	"ybmCCt2zZ7T+1viUju2g5PxcZ3/o5Lc6W/X7p5Vnj4Nm+IwcImg2t5Nyb7w1GHitvBzThmUvC9cwAoDT",
+	"lv3gaHtytRnJ2j6q3xuzKh3PCdnOQ9xBKZsVkqo3W9rP6RRoYDIH7ZKlQTElyFaxIwqLaIoJKf6dh3xT",
+	"frWH5EU6mfqNJ+86dMqX4Pn1ocqrA805e0sTMJKrza1V44CMWqQX7qAbxgREO6GFQAsCfg6UG6KWfyD5",
+	"Oop2UTfQwI0ACNyZgI5OFXDjAiCDU7/PZCMIZTH8OzhL0/DAOAW6c4XS3tICKDHUEqaWF3WKFOLu0E5s",
+	"brRdWwpFrjyIUyJIqAfQt5tcIQbrJVaUmPD2UC4asSaslmnXLfFrnDRchSFXDg1kMmYu6qZgxevYxMH9",
+	"iev6x8HDyDa+8ct8eWwKq1ye2/SH1S3RMimv+hIzzxomhGzqM7Ns/mdCNpWTxKyKcu5+qN0Yd5v12KKa",
+	"ri1X5p6Vv/xuU2LXUbzikITLLIv17tCuTSK6a78+4OCUPvHcSB1a8OuhJgOi6pQCIWl9YPGVEW2TB7nd",
+	"qIhYOJ+sE4UBXaA6kkpoi8lQ09mzZ5uQkygvp4UsqBip8PdDtb5i2E+YkIlNHriip9mfTba6j0WDnY66",
+	"tgLMgWjUWDoq4a2A4njnZeWZpdkWJkygKIxj6PXqe1E4U0vf2wPpXKa+hwdAZXcoBmfNxa6eTt81aphQ",
+	"wlhfH8uRvPg+uR6mm2tbFIIYR9XUot46efzQcfJNZ0WMlx2psg7PDkDFTqvFASGqj1vlCX9AfPEbhJYv",
+	"/vflGLvHwv++RKI+tpJstuSOs5cgUz2glyOz7ZChEE3wiagOoHLUvjZDjEDvaIAAusniulc3DNZlaFsn",
+	"85rr3fGmNDPUgq0XnKrZfl2bkE2+aCtnZnTDhNtzafFGswAfB0aBEe3IyWNHUSLqfb1wFRU4X6YNbrS5",
+	"pjBt/lpcIppHL6NYS03XHqKpA7mNn6wE4BxOx8NB8hDMswzYZ3LOpXdc5fPf1bupAcbqWFYt6wEssbHW",
+	"g1B1vzy/ZPvUbXaMKpMjxL2MQnse2E1vatpq+cq3WE+BApWjz7xtAql2hoVpcn0+FjwxlfNhqBDukhXk",
+	"xo8FN252jNyH0ZbM7jslaxvLtAEmYIlBxdrziiD/p7nSZvOxT832+ikSKIAFerc2JrChNrv12EqDir2i",
+	"SH4CAp9U6qr0TDnxyMmTXTa+gJfDpI6jeuG+ibfaQjDhrI1oG6/v+lssggS6oHFxnDsGkGARr1771Jac",
+	"LKlSUkqjw3qf6LSLThLk4tb93WJ/R7qffpDl1bwsoGebFBW3eGdvTtcLINNbssqQPtTASSw+GggTpF0G",
+	"UEzXStBR2nTjafdtTk0CJXxdQHYgKLI9hpqWPbYA7Gtdu4NkhRBiTwjpto4d59cWfSxt+k1LP2EsRSRw",
+	"vKtWo1gDuyJF73faBv52M820LwFW0tIbYJq+KLd1vuk79G+DdW7t5n5jrNNNO1zs0hcU3nqOaXW99uWZ",
+	"HQe79eJ699HjtnvfuHJH18CMHsROlZyuLdQmf9KLt9Dx2LHglKWz8suPECleXC/fmjGu/mxM3ECaR6m6",
+	"+LJ888vy3CrZoel0Fh9VN+hRVhVN2Hvt1pxL8wtkiMesLTdk4IJdWYe16Wy5d9ugxUhu4aKHUTf1Q+ac",
+	"kuxJbtksZCppyRcoMQ7ZKvoidJ9duIkSFggT6UiBJVeVMNBxUUw3AbgteonW7pfEgz9zIFNbIKup47/a",
+	"rXzh82IROV5w76wVbPzdWL+pa1/Z3bz8gbQ7LdUT2Q4d/vBox8nDEO0wv1BZLenaDLT+3r8P2B8+dNvL",
+	"iu4YS4JWngtbsydYTGNhSptejelaCVqO/UrgeljfnxDS/HB34yFe5nVCc6BlvfAMEfY1vXiHiwrN/c2c",
+	"ybvPnj3bfHZvsyT3t7TG44mW+L6WoUw6pYj9TRlJFv4gK3yTMsC3tu33a0CGYbCxtQUAtf8sGMAbigXb",
+	"ccFyY/1Gd1f1p58rhRdmPKevrD5B0k8uivA/rORHuVhcxAlTkD1uH13ET/Ysz/+wqSWTTucGtl2qu5lQ",
+	"wrB3xx6nJLWxhV0X5dZclG/Opxjq/knHYx2RtbjuhbEt+B1jdRz5mM1tmyNfygrH+3wTTWmXvpntGWvE",
+	"/d8TKoHiLSGTO07UdiMb3lmy0QBFcPAf9060tOLiyyDFeBNCPw6nC6z+3m29s7PlAa1pdmu//37zgBg1",
+	"4X163/k0JwwB6nVLAnvSWnDFBmod1dGl6tqyT6d4cEiE7DZYIIuOBtUKtrAjRM1QeqWbLM1XX08JLAlM",
+	"hjr+jut3hstIYZZB84aP7tZA2yy5oUuv1InLxT03MZ0JYLZBlGY40yulg3mq+coOs1Q8yy5H/f119HV3",
+	"L6IMq9GcmDPdNoU1yLC/q0FBf6utmzF5c+OXSX1EM7thWc9dqBNUwJaYvn4ZC8QUA9HlJH5jZ7EFT7KL",
+	"LL9f8dMYGd9YW6tcW0Slhai2uo1ImzGfWmFkC2V6+BXj9Xzl0TUEUhDWYk2Cu4RZBwixgit68ardIBYV",
+	"FoP3rSKfs07uJpWPykH/Z0kWzyGIaOc+EHhZgJzNZTK2QtcA1y1Ehb+6DgFZprA/YcZxEjK1zsK08dVL",
+	"XXuKSuME96YmkHtneh9ZiP3GGjKGpiQWRPw6VTwbrbSBamhNTejaTRzeixgL3crcFG8BmuBtan+Fcfrt",
+	"Eu5yhFrJLNe0a2SM1269rHeJnvrSzcK0BQF1lHMsAjBUc5YujNErTPcMZym7avCvWRCcSSRClAKn+5/v",
+	"KsDboQAHICsFr4H4elZpac/nxVSQ0P6J8nHeRC8CYxIY9Omj/kToVaTkGQGnal01tw17XkBKykqdLsWg",
+	"Xpy1xmjqbPkQppCF5GBDUJKTYQ+qiBfKJ30cF2YLf1MLwgEAQjafgXPKyVK/LCgKaDwu2gHEajgt8anw",
+	"PrMua7Tz6NTNAaXez4WkJccoQjbV0CbpzVgRcCvGxUVde1i5cLf68IbtzonEtnImQft3fQ7xag+s9muX",
+	"mY4c7+bhKZP+fvwxkJ3l2o17tZH7mPVASOB3j1BZ0FkkjpBQRMWVv8U11C1xDe+PLaPtFht912gzQfsW",
+	"y19+V3mOairCJi4hc6VmBc0He/bsYeoYW86J/ef4/uBs/0/xOztaCRrP8QaVMmtCP6WMqi2vQTtW48Hl",
+	"8tW53Qb5u23ufw9FjgvTNAYsW+A/42vkdV6vb+TFdIdRT9+P/LwD1exD0LDdGvZvXQ17DIm/+fr1DdD/",
+	"XdL+eyXtW6pZH5r808FxcJEQ/QI+CYiVg2jQXuSQsJ9A/JwgD1okPy+nifi/tJTk0wOSora/F4/HW0A5",
+	"/n8DAD7MDzVbNgEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -2,6 +2,7 @@ package keycloak
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,12 +24,65 @@ const (
 )
 
 const (
-	AttributeSamlClientSignature         = "saml.client.signature"
-	AttributeValidPostLogoutRedirectURIs = "post.logout.redirect.uris"
-	AttributeLogoutServicePostBindingURL = "saml_single_logout_service_url_post"
-	AttributeNameIdFormat                = "saml_name_id_format"
+	AttributeSamlClientSignature             = "saml.client.signature"
+	AttributeValidPostLogoutRedirectURIs     = "post.logout.redirect.uris"
+	AttributeLogoutServicePostBindingURL     = "saml_single_logout_service_url_post"
+	AttributeLogoutServiceRedirectBindingURL = "saml_single_logout_service_url_redirect"
+	AttributeSamlForcePostBinding            = "saml.force.post.binding"
+	AttributeNameIdFormat                    = "saml_name_id_format"
+	AttributeSamlSigningCertificate          = "saml.signing.certificate"
+	AttributeSamlEncrypt                     = "saml.encrypt"
+	AttributeSamlEncryptionCertificate       = "saml.encryption.certificate"
 )
 
+// ErrNotFound 指定したリソースがkeycloakに存在しない
+var ErrNotFound = errors.New("not found")
+
+// IClient realmの宣言ファイルの適用に使用するkeycloakの管理APIのクライアント
+type IClient interface {
+	GetRealm(realm string) (*RealmRepresentation, error)
+	CreateRealm(body RealmRepresentation) error
+	UpdateRealm(realm string, body RealmRepresentation) error
+
+	ListRealmRoles(realm string) ([]RoleRepresentation, error)
+	GetRealmRole(realm string, roleName string) (*RoleRepresentation, error)
+	CreateRealmRole(realm string, role RoleRepresentation) error
+	UpdateRealmRole(realm string, role RoleRepresentation) error
+
+	ListGroups(realm string) ([]GroupRepresentation, error)
+	CreateGroup(realm string, group GroupRepresentation) error
+	UpdateGroup(realm string, group GroupRepresentation) error
+	GetGroupRealmRoles(realm string, groupId string) ([]RoleRepresentation, error)
+	AddGroupRealmRoles(realm string, groupId string, roles []RoleRepresentation) error
+
+	ListClientScopes(realm string) ([]ClientScopeRepresentation, error)
+	GetClientScope(realm string, scopeName string) (*ClientScopeRepresentation, error)
+	CreateClientScope(realm string, scope ClientScopeRepresentation) error
+	UpdateClientScope(realm string, scope ClientScopeRepresentation) error
+	CreateProtocolMapper(realm string, clientScopeId string, protocolMapper ProtocolMapperRepresentation) error
+	UpdateProtocolMapper(realm string, clientScopeId string, protocolMapper ProtocolMapperRepresentation) error
+
+	GetClientByClientId(realm string, clientId string) (*ClientRepresentation, error)
+	CreateClient(realmName string, body ClientRepresentation) error
+	UpdateClient(realm string, body ClientRepresentation) error
+	GetClientDefaultScopes(realm string, id string) ([]ClientScopeRepresentation, error)
+	AddClientDefaultScope(realm string, id string, clientScopeId string) error
+
+	ListIdentityProviders(realm string) ([]IdentityProviderRepresentation, error)
+	CreateIdentityProvider(realm string, idp IdentityProviderRepresentation) error
+	UpdateIdentityProvider(realm string, idp IdentityProviderRepresentation) error
+
+	GetUser(realm string, username string) (*UserRepresentation, error)
+	CreateUser(realm string, user UserRepresentation, password string) error
+	UpdateUser(realm string, user UserRepresentation) error
+	GetUserRealmRoles(realm string, userId string) ([]RoleRepresentation, error)
+	AddUserRealmRoles(realm string, userId string, roles []RoleRepresentation) error
+	RemoveUserRealmRoles(realm string, userId string, roles []RoleRepresentation) error
+	GetUserGroups(realm string, userId string) ([]GroupRepresentation, error)
+	AddUserToGroup(realm string, userId string, groupId string) error
+	RemoveUserFromGroup(realm string, userId string, groupId string) error
+}
+
 type client struct {
 	param ClientParam
 
@@ -86,243 +140,121 @@ func (c *client) Login() error {
 	return nil
 }
 
-func (c *client) CreateRealm(realmName string) error {
-	// リクエストボディの作成
-	reqBody, err := func() (string, error) {
-		var req CreateRealmRequest
-		req.RealmName = realmName
-		req.Enabled = true
-		bytes, err := json.Marshal(req)
-		if err != nil {
-			return "", err
-		}
-		return string(bytes), nil
-	}()
-	if err != nil {
-		return err
-	}
-
-	// リクエストURLの作成
-	res, err := c.doHttpRequest(struct {
-		requestURL      string
-		method          string
-		body            string
-		header          map[string]string
-		withAccessToken bool
-	}{
-		requestURL: fmt.Sprintf("%s/admin/realms/", c.param[KeyBaseURL]),
-		method:     "POST",
-		body:       reqBody,
-		header: map[string]string{
-			"Content-Type": "application/json",
-		},
-		withAccessToken: true,
-	})
-	if err != nil {
-		return err
-	}
-
-	if res.status != 201 {
-		return fmt.Errorf("invalid response: %d", res.status)
-	}
-
-	return nil
+func (c *client) CreateRealm(body RealmRepresentation) error {
+	return c.requestJSON("POST", fmt.Sprintf("%s/admin/realms/", c.param[KeyBaseURL]), body, 201, nil)
 }
 
 func (c *client) DeleteRealm(realmName string) error {
-	// リクエストURLの作成
-	deleteRes, err := c.doHttpRequest(struct {
-		requestURL      string
-		method          string
-		body            string
-		header          map[string]string
-		withAccessToken bool
-	}{
-		requestURL:      fmt.Sprintf("%s/admin/realms/%s", c.param[KeyBaseURL], realmName),
-		method:          "DELETE",
-		withAccessToken: true,
-	})
-	if err != nil {
-		return err
-	}
-	if deleteRes.status != 204 {
-		return fmt.Errorf("invalid response: %d", deleteRes.status)
-	}
-	return nil
+	return c.requestJSON("DELETE", c.realmURL(realmName, ""), nil, 204, nil)
 }
 
 func (c *client) CreateClient(realmName string, body ClientRepresentation) error {
-	// リクエストボディの作成
-	reqBody, err := func() (string, error) {
-		bytes, err := json.Marshal(body)
-		if err != nil {
-			return "", err
-		}
-		return string(bytes), nil
-	}()
-	if err != nil {
-		return err
-	}
-
-	// リクエストURLの作成
-	postRes, err := c.doHttpRequest(struct {
-		requestURL      string
-		method          string
-		body            string
-		header          map[string]string
-		withAccessToken bool
-	}{
-		requestURL: fmt.Sprintf("%s/admin/realms/%s/clients", c.param[KeyBaseURL], realmName),
-		method:     "POST",
-		body:       reqBody,
-		header: map[string]string{
-			"Content-Type": "application/json",
-		},
-		withAccessToken: true,
-	})
-	if err != nil {
-		return err
-	}
-
-	if postRes.status != 201 {
-		return fmt.Errorf("invalid response: %d", postRes.status)
-	}
-
-	return nil
+	return c.requestJSON("POST", c.realmURL(realmName, "/clients"), body, 201, nil)
 }
 
 func (c *client) GetClient(realmName string) error {
-	postRes, err := c.doHttpRequest(struct {
-		requestURL      string
-		method          string
-		body            string
-		header          map[string]string
-		withAccessToken bool
-	}{
-		requestURL: fmt.Sprintf("%s/admin/realms/%s/clients", c.param[KeyBaseURL], realmName),
-		method:     "GET",
-		header: map[string]string{
-			"Content-Type": "application/json",
-		},
-		withAccessToken: true,
-	})
-	if err != nil {
+	var res json.RawMessage
+	if err := c.requestJSON("GET", c.realmURL(realmName, "/clients"), nil, 200, &res); err != nil {
 		return err
 	}
 
-	fmt.Println(string(postRes.resBody))
+	fmt.Println(string(res))
 
 	return nil
 }
 
 func (c *client) DeleteClient(realmName string, id string) error {
-	// リクエストURLの作成
-	deleteRes, err := c.doHttpRequest(struct {
-		requestURL      string
-		method          string
-		body            string
-		header          map[string]string
-		withAccessToken bool
-	}{
-		requestURL:      fmt.Sprintf("%s/admin/realms/%s/clients/%s", c.param[KeyBaseURL], realmName, id),
-		method:          "DELETE",
-		withAccessToken: true,
-	})
-	if err != nil {
-		return err
-	}
-
-	if deleteRes.status != 204 {
-		return fmt.Errorf("invalid response: %d", deleteRes.status)
-	}
-	return nil
+	return c.requestJSON("DELETE", c.realmURL(realmName, "/clients/"+id), nil, 204, nil)
 }
 
 func (c *client) GetClientScope(realm string, scopeName string) (*ClientScopeRepresentation, error) {
-	res, err := c.doHttpRequest(struct {
-		requestURL      string
-		method          string
-		body            string
-		header          map[string]string
-		withAccessToken bool
-	}{
-		requestURL:      fmt.Sprintf("%s/admin/realms/%s/client-scopes", c.param[KeyBaseURL], realm),
-		method:          "GET",
-		withAccessToken: true,
-	})
+	scopes, err := c.ListClientScopes(realm)
 	if err != nil {
 		return nil, err
 	}
-
-	if res.status != 200 {
-		return nil, fmt.Errorf("invalid response: %d", res.status)
-	}
-
-	var m []ClientScopeRepresentation
-	json.Unmarshal(res.resBody, &m)
-	for _, v := range m {
+	for _, v := range scopes {
 		if v.Name == scopeName {
 			return &v, nil
 		}
 	}
-	return nil, fmt.Errorf("no found: %s:%s", realm, scopeName)
+	return nil, fmt.Errorf("%w: %s:%s", ErrNotFound, realm, scopeName)
 }
 
 func (c *client) UpdateProtocolMapper(realm string, clientScopeId string, protocolMapper ProtocolMapperRepresentation) error {
-	// リクエストボディの作成
-	reqBody, err := func() (string, error) {
-		bytes, err := json.Marshal(protocolMapper)
-		if err != nil {
-			return "", err
-		}
-		return string(bytes), nil
-	}()
-	if err != nil {
-		return err
-	}
-
-	res, err := c.doHttpRequest(struct {
-		requestURL      string
-		method          string
-		body            string
-		header          map[string]string
-		withAccessToken bool
-	}{
-		requestURL:      fmt.Sprintf("%s/admin/realms/%s/client-scopes/%s/protocol-mappers/models/%s", c.param[KeyBaseURL], realm, clientScopeId, protocolMapper.Id),
-		method:          "PUT",
-		body:            reqBody,
-		withAccessToken: true,
-	})
-	if err != nil {
-		return err
-	}
-
-	if res.status != 204 {
-		return fmt.Errorf("invalid response: %d", res.status)
-	}
-
-	return nil
+	return c.requestJSON("PUT", c.realmURL(realm, "/client-scopes/"+clientScopeId+"/protocol-mappers/models/"+protocolMapper.Id), protocolMapper, 204, nil)
 }
 
-func (c *client) CreateUser(realm string, username string, email string, password string) error {
-	user := UserRepresentation{
-		Email:         email,
-		Username:      username,
-		Enabled:       true,
-		EmailVerified: false,
-		Credentials: []CredentialRepresentation{
+// CreateUser ユーザを作成する (ユーザは有効とし、passwordが空の場合はパスワードを設定しない)
+func (c *client) CreateUser(realm string, user UserRepresentation, password string) error {
+	user.Enabled = true
+	if password != "" {
+		user.Credentials = []CredentialRepresentation{
 			{
 				UserLabel: "MyPassword",
 				Type:      "password",
 				Value:     password,
 				Temporary: false,
 			},
-		},
+		}
 	}
+	return c.requestJSON("POST", c.realmURL(realm, "/users"), user, 201, nil)
+}
 
+// CreateRealmRole realmのロールを作成する
+func (c *client) CreateRealmRole(realm string, role RoleRepresentation) error {
+	return c.requestJSON("POST", c.realmURL(realm, "/roles"), role, 201, nil)
+}
+
+// GetRealmRole 名前を指定してrealmのロールを取得する
+func (c *client) GetRealmRole(realm string, roleName string) (*RoleRepresentation, error) {
+	var role RoleRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/roles/"+url.PathEscape(roleName)), nil, 200, &role); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetUser ユーザ名と完全一致するユーザを取得する
+func (c *client) GetUser(realm string, username string) (*UserRepresentation, error) {
+	var users []UserRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/users?exact=true&username="+url.QueryEscape(username)), nil, 200, &users); err != nil {
+		return nil, err
+	}
+	if len(users) <= 0 {
+		return nil, fmt.Errorf("%w: %s:%s", ErrNotFound, realm, username)
+	}
+	return &users[0], nil
+}
+
+// GetUserId ユーザ名と完全一致するユーザのIDを取得する
+func (c *client) GetUserId(realm string, username string) (string, error) {
+	user, err := c.GetUser(realm, username)
+	if err != nil {
+		return "", err
+	}
+	return user.Id, nil
+}
+
+// AddUserRealmRoles ユーザにrealmのロールを割り当てる
+func (c *client) AddUserRealmRoles(realm string, userId string, roles []RoleRepresentation) error {
+	return c.requestJSON("POST", c.realmURL(realm, "/users/"+userId+"/role-mappings/realm"), roles, 204, nil)
+}
+
+// realmURL realmの管理APIのURLを作成する (pathは先頭の/を含む)
+func (c *client) realmURL(realm string, path string) string {
+	return fmt.Sprintf("%s/admin/realms/%s%s", c.param[KeyBaseURL], url.PathEscape(realm), path)
+}
+
+// requestJSON bodyをJSONで送信し、レスポンスのステータスがstatusの場合はレスポンスボディをresultに読み込む。
+// bodyがnilの場合はリクエストボディを送信せず、resultがnilの場合はレスポンスボディを読み込まない。
+// 404の場合はErrNotFoundを返却する
+func (c *client) requestJSON(method string, requestURL string, body any, status int, result any) error {
 	// リクエストボディの作成
 	reqBody, err := func() (string, error) {
-		bytes, err := json.Marshal(user)
+		if body == nil {
+			return "", nil
+		}
+		bytes, err := json.Marshal(body)
 		if err != nil {
 			return "", err
 		}
@@ -339,20 +271,28 @@ func (c *client) CreateUser(realm string, username string, email string, passwor
 		header          map[string]string
 		withAccessToken bool
 	}{
-		requestURL:      fmt.Sprintf("%s/admin/realms/%s/users", c.param[KeyBaseURL], realm),
-		method:          "POST",
-		body:            reqBody,
+		requestURL: requestURL,
+		method:     method,
+		body:       reqBody,
+		header: map[string]string{
+			"Content-Type": "application/json",
+		},
 		withAccessToken: true,
 	})
 	if err != nil {
 		return err
 	}
 
-	if res.status != 201 {
+	if res.status == 404 {
+		return fmt.Errorf("%w: %s %s", ErrNotFound, method, requestURL)
+	} else if res.status != status {
 		return fmt.Errorf("invalid response: %d", res.status)
 	}
 
-	return nil
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(res.resBody, result)
 }
 
 func (c *client) doHttpRequest(param struct {
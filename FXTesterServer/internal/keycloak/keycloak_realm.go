@@ -0,0 +1,179 @@
+package keycloak
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// GetRealm realmを取得する
+func (c *client) GetRealm(realm string) (*RealmRepresentation, error) {
+	var res RealmRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, ""), nil, 200, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpdateRealm realmの設定を更新する (未指定の項目は変更しない)
+func (c *client) UpdateRealm(realm string, body RealmRepresentation) error {
+	return c.requestJSON("PUT", c.realmURL(realm, ""), body, 204, nil)
+}
+
+// ListRealmRoles realmのロールの一覧を取得する
+func (c *client) ListRealmRoles(realm string) ([]RoleRepresentation, error) {
+	var res []RoleRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/roles"), nil, 200, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateRealmRole 名前が一致するrealmのロールを更新する
+func (c *client) UpdateRealmRole(realm string, role RoleRepresentation) error {
+	return c.requestJSON("PUT", c.realmURL(realm, "/roles/"+url.PathEscape(role.Name)), role, 204, nil)
+}
+
+// ListGroups realmの最上位のグループの一覧を属性を含めて取得する
+func (c *client) ListGroups(realm string) ([]GroupRepresentation, error) {
+	var res []GroupRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/groups?briefRepresentation=false"), nil, 200, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateGroup realmの最上位にグループを作成する
+func (c *client) CreateGroup(realm string, group GroupRepresentation) error {
+	return c.requestJSON("POST", c.realmURL(realm, "/groups"), group, 201, nil)
+}
+
+// UpdateGroup IDが一致するグループを更新する
+func (c *client) UpdateGroup(realm string, group GroupRepresentation) error {
+	return c.requestJSON("PUT", c.realmURL(realm, "/groups/"+group.Id), group, 204, nil)
+}
+
+// GetGroupRealmRoles グループに割り当てたrealmのロールを取得する
+func (c *client) GetGroupRealmRoles(realm string, groupId string) ([]RoleRepresentation, error) {
+	var res []RoleRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/groups/"+groupId+"/role-mappings/realm"), nil, 200, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AddGroupRealmRoles グループにrealmのロールを割り当てる
+func (c *client) AddGroupRealmRoles(realm string, groupId string, roles []RoleRepresentation) error {
+	return c.requestJSON("POST", c.realmURL(realm, "/groups/"+groupId+"/role-mappings/realm"), roles, 204, nil)
+}
+
+// ListClientScopes realmのクライアントスコープの一覧をプロトコルマッパーを含めて取得する
+func (c *client) ListClientScopes(realm string) ([]ClientScopeRepresentation, error) {
+	var res []ClientScopeRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/client-scopes"), nil, 200, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateClientScope クライアントスコープを作成する
+func (c *client) CreateClientScope(realm string, scope ClientScopeRepresentation) error {
+	return c.requestJSON("POST", c.realmURL(realm, "/client-scopes"), scope, 201, nil)
+}
+
+// UpdateClientScope IDが一致するクライアントスコープを更新する (プロトコルマッパーは更新されない)
+func (c *client) UpdateClientScope(realm string, scope ClientScopeRepresentation) error {
+	return c.requestJSON("PUT", c.realmURL(realm, "/client-scopes/"+scope.Id), scope, 204, nil)
+}
+
+// CreateProtocolMapper クライアントスコープにプロトコルマッパーを追加する
+func (c *client) CreateProtocolMapper(realm string, clientScopeId string, protocolMapper ProtocolMapperRepresentation) error {
+	return c.requestJSON("POST", c.realmURL(realm, "/client-scopes/"+clientScopeId+"/protocol-mappers/models"), protocolMapper, 201, nil)
+}
+
+// GetClientByClientId クライアントID (SAMLの場合はエンティティID) が一致するクライアントを取得する
+func (c *client) GetClientByClientId(realm string, clientId string) (*ClientRepresentation, error) {
+	var res []ClientRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/clients?clientId="+url.QueryEscape(clientId)), nil, 200, &res); err != nil {
+		return nil, err
+	}
+	if len(res) <= 0 {
+		return nil, fmt.Errorf("%w: %s:%s", ErrNotFound, realm, clientId)
+	}
+	return &res[0], nil
+}
+
+// UpdateClient IDが一致するクライアントを更新する
+func (c *client) UpdateClient(realm string, body ClientRepresentation) error {
+	return c.requestJSON("PUT", c.realmURL(realm, "/clients/"+body.Id), body, 204, nil)
+}
+
+// GetClientDefaultScopes クライアントの既定のクライアントスコープを取得する
+func (c *client) GetClientDefaultScopes(realm string, id string) ([]ClientScopeRepresentation, error) {
+	var res []ClientScopeRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/clients/"+id+"/default-client-scopes"), nil, 200, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AddClientDefaultScope クライアントの既定のクライアントスコープを追加する
+func (c *client) AddClientDefaultScope(realm string, id string, clientScopeId string) error {
+	return c.requestJSON("PUT", c.realmURL(realm, "/clients/"+id+"/default-client-scopes/"+clientScopeId), nil, 204, nil)
+}
+
+// ListIdentityProviders realmのidentity providerの一覧を取得する
+func (c *client) ListIdentityProviders(realm string) ([]IdentityProviderRepresentation, error) {
+	var res []IdentityProviderRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/identity-provider/instances"), nil, 200, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateIdentityProvider identity providerを作成する
+func (c *client) CreateIdentityProvider(realm string, idp IdentityProviderRepresentation) error {
+	return c.requestJSON("POST", c.realmURL(realm, "/identity-provider/instances"), idp, 201, nil)
+}
+
+// UpdateIdentityProvider エイリアスが一致するidentity providerを更新する
+func (c *client) UpdateIdentityProvider(realm string, idp IdentityProviderRepresentation) error {
+	return c.requestJSON("PUT", c.realmURL(realm, "/identity-provider/instances/"+url.PathEscape(idp.Alias)), idp, 204, nil)
+}
+
+// UpdateUser IDが一致するユーザを更新する
+func (c *client) UpdateUser(realm string, user UserRepresentation) error {
+	return c.requestJSON("PUT", c.realmURL(realm, "/users/"+user.Id), user, 204, nil)
+}
+
+// GetUserRealmRoles ユーザに直接割り当てたrealmのロールを取得する
+func (c *client) GetUserRealmRoles(realm string, userId string) ([]RoleRepresentation, error) {
+	var res []RoleRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/users/"+userId+"/role-mappings/realm"), nil, 200, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RemoveUserRealmRoles ユーザからrealmのロールの割り当てを解除する
+func (c *client) RemoveUserRealmRoles(realm string, userId string, roles []RoleRepresentation) error {
+	return c.requestJSON("DELETE", c.realmURL(realm, "/users/"+userId+"/role-mappings/realm"), roles, 204, nil)
+}
+
+// GetUserGroups ユーザが所属するグループを取得する
+func (c *client) GetUserGroups(realm string, userId string) ([]GroupRepresentation, error) {
+	var res []GroupRepresentation
+	if err := c.requestJSON("GET", c.realmURL(realm, "/users/"+userId+"/groups"), nil, 200, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AddUserToGroup ユーザをグループに所属させる
+func (c *client) AddUserToGroup(realm string, userId string, groupId string) error {
+	return c.requestJSON("PUT", c.realmURL(realm, "/users/"+userId+"/groups/"+groupId), nil, 204, nil)
+}
+
+// RemoveUserFromGroup ユーザをグループから脱退させる
+func (c *client) RemoveUserFromGroup(realm string, userId string, groupId string) error {
+	return c.requestJSON("DELETE", c.realmURL(realm, "/users/"+userId+"/groups/"+groupId), nil, 204, nil)
+}
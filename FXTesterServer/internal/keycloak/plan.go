@@ -0,0 +1,780 @@
+package keycloak
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type ActionKind string
+
+const (
+	ActionCreate ActionKind = "create"
+	ActionUpdate ActionKind = "update"
+)
+
+// 計画の対象のリソースの種類
+const (
+	ResourceRealm            = "realm"
+	ResourceRole             = "role"
+	ResourceGroup            = "group"
+	ResourceClientScope      = "clientScope"
+	ResourceClient           = "client"
+	ResourceIdentityProvider = "identityProvider"
+	ResourceUser             = "user"
+)
+
+// maskedValue keycloakが秘密情報の代わりに返却する値 (現在の値が不明なため比較せずに常に変更する)
+const maskedValue = "**********"
+
+// Action realmを宣言ファイルに一致させるための1つのリソースの変更
+type Action struct {
+	Kind     ActionKind
+	Resource string
+	Name     string
+	// 変更内容 (e.g. displayName: "a" -> "b")
+	Changes []string
+
+	apply func(c IClient) error
+}
+
+// Plan realmの現在の状態と宣言ファイルの差分から作成した変更の計画
+type Plan struct {
+	Realm string
+	// realmが存在しない場合はtrue (keycloakが既定で作成するリソースも作成として計画する)
+	NewRealm bool
+	Actions  []Action
+
+	spec *RealmSpec
+}
+
+// NewPlan realmの現在の状態を取得し、宣言ファイルに一致させるための変更を計画する。
+// 宣言したリソースの作成・更新のみ計画し、宣言していないリソースの削除は計画しない
+func NewPlan(c IClient, spec *RealmSpec) (*Plan, error) {
+	p := &Plan{
+		Realm: spec.Realm,
+		spec:  spec,
+	}
+
+	current, err := c.GetRealm(spec.Realm)
+	if errors.Is(err, ErrNotFound) {
+		p.NewRealm = true
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get realm %s: %w", spec.Realm, err)
+	}
+	p.planRealm(current)
+
+	for _, f := range []func(c IClient) error{
+		p.planRoles,
+		p.planGroups,
+		p.planClientScopes,
+		p.planClients,
+		p.planIdentityProviders,
+		p.planUsers,
+	} {
+		if err := f(c); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Write 計画を出力する
+func (p *Plan) Write(w io.Writer) {
+	fmt.Fprintf(w, "realm %s\n", p.Realm)
+	if p.NewRealm {
+		fmt.Fprintln(w, "  (realmが存在しないため、keycloakが既定で作成するリソースも作成として表示します)")
+	}
+	for _, action := range p.Actions {
+		mark := "+"
+		if action.Kind == ActionUpdate {
+			mark = "~"
+		}
+		fmt.Fprintf(w, "  %s %s %s\n", mark, action.Resource, action.Name)
+		for _, change := range action.Changes {
+			fmt.Fprintf(w, "      %s\n", change)
+		}
+	}
+	if len(p.Actions) <= 0 {
+		fmt.Fprintln(w, "変更はありません")
+	} else {
+		fmt.Fprintf(w, "%d件の変更があります\n", len(p.Actions))
+	}
+}
+
+// Apply 計画した変更を順に適用する。
+// realmを作成した場合は、keycloakが既定で作成したリソースを踏まえて残りの変更を計画し直す
+func (p *Plan) Apply(c IClient) error {
+	for _, action := range p.Actions {
+		if err := action.apply(c); err != nil {
+			return fmt.Errorf("failed to %s %s %s: %w", action.Kind, action.Resource, action.Name, err)
+		}
+		if action.Resource == ResourceRealm && action.Kind == ActionCreate {
+			next, err := NewPlan(c, p.spec)
+			if err != nil {
+				return err
+			}
+			return next.Apply(c)
+		}
+	}
+	return nil
+}
+
+func (p *Plan) add(kind ActionKind, resource string, name string, changes []string, apply func(c IClient) error) {
+	p.Actions = append(p.Actions, Action{
+		Kind:     kind,
+		Resource: resource,
+		Name:     name,
+		Changes:  changes,
+		apply:    apply,
+	})
+}
+
+func (p *Plan) planRealm(current *RealmRepresentation) {
+	enabled := p.spec.Enabled == nil || *p.spec.Enabled
+	if current == nil {
+		p.add(ActionCreate, ResourceRealm, p.Realm, nil, func(c IClient) error {
+			return c.CreateRealm(RealmRepresentation{Realm: p.Realm, DisplayName: p.spec.DisplayName, Enabled: &enabled})
+		})
+		return
+	}
+
+	changes := diffValue(nil, "displayName", current.DisplayName, p.spec.DisplayName)
+	if currentEnabled := current.Enabled != nil && *current.Enabled; currentEnabled != enabled {
+		changes = append(changes, fmt.Sprintf("enabled: %v -> %v", currentEnabled, enabled))
+	}
+	if len(changes) <= 0 {
+		return
+	}
+	p.add(ActionUpdate, ResourceRealm, p.Realm, changes, func(c IClient) error {
+		return c.UpdateRealm(p.Realm, RealmRepresentation{DisplayName: p.spec.DisplayName, Enabled: &enabled})
+	})
+}
+
+func (p *Plan) planRoles(c IClient) error {
+	current := map[string]RoleRepresentation{}
+	if !p.NewRealm {
+		roles, err := c.ListRealmRoles(p.Realm)
+		if err != nil {
+			return fmt.Errorf("failed to list roles: %w", err)
+		}
+		for _, role := range roles {
+			current[role.Name] = role
+		}
+	}
+
+	for _, spec := range p.spec.Roles {
+		role, ok := current[spec.Name]
+		if !ok {
+			p.add(ActionCreate, ResourceRole, spec.Name, nil, func(c IClient) error {
+				return c.CreateRealmRole(p.Realm, RoleRepresentation{Name: spec.Name, Description: spec.Description})
+			})
+			continue
+		}
+
+		changes := diffValue(nil, "description", role.Description, spec.Description)
+		if len(changes) <= 0 {
+			continue
+		}
+		role.Description = spec.Description
+		p.add(ActionUpdate, ResourceRole, spec.Name, changes, func(c IClient) error {
+			return c.UpdateRealmRole(p.Realm, role)
+		})
+	}
+	return nil
+}
+
+func (p *Plan) planGroups(c IClient) error {
+	current := map[string]GroupRepresentation{}
+	if !p.NewRealm {
+		groups, err := c.ListGroups(p.Realm)
+		if err != nil {
+			return fmt.Errorf("failed to list groups: %w", err)
+		}
+		for _, group := range groups {
+			current[group.Name] = group
+		}
+	}
+
+	for _, spec := range p.spec.Groups {
+		group, ok := current[spec.Name]
+		if !ok {
+			changes := diffMap(nil, "attributes", nil, spec.Attributes)
+			changes = diffList(changes, "realmRoles", spec.RealmRoles, nil)
+			p.add(ActionCreate, ResourceGroup, spec.Name, changes, func(c IClient) error {
+				if err := c.CreateGroup(p.Realm, GroupRepresentation{Name: spec.Name, Attributes: toMultiValued(nil, spec.Attributes)}); err != nil {
+					return err
+				}
+				created, err := findGroup(c, p.Realm, spec.Name)
+				if err != nil {
+					return err
+				}
+				return addGroupRealmRoles(c, p.Realm, created.Id, spec.RealmRoles)
+			})
+			continue
+		}
+
+		changes := diffMap(nil, "attributes", firstValues(group.Attributes), spec.Attributes)
+		attributesChanged := len(changes) > 0
+		roles, err := c.GetGroupRealmRoles(p.Realm, group.Id)
+		if err != nil {
+			return fmt.Errorf("failed to get realm roles of group %s: %w", spec.Name, err)
+		}
+		added := missingValues(roleNames(roles), spec.RealmRoles)
+		changes = diffList(changes, "realmRoles", added, nil)
+		if len(changes) <= 0 {
+			continue
+		}
+		p.add(ActionUpdate, ResourceGroup, spec.Name, changes, func(c IClient) error {
+			if attributesChanged {
+				group.Attributes = toMultiValued(group.Attributes, spec.Attributes)
+				if err := c.UpdateGroup(p.Realm, group); err != nil {
+					return err
+				}
+			}
+			return addGroupRealmRoles(c, p.Realm, group.Id, added)
+		})
+	}
+	return nil
+}
+
+func (p *Plan) planClientScopes(c IClient) error {
+	current := map[string]ClientScopeRepresentation{}
+	if !p.NewRealm {
+		scopes, err := c.ListClientScopes(p.Realm)
+		if err != nil {
+			return fmt.Errorf("failed to list client scopes: %w", err)
+		}
+		for _, scope := range scopes {
+			current[scope.Name] = scope
+		}
+	}
+
+	for _, spec := range p.spec.ClientScopes {
+		scope, ok := current[spec.Name]
+		if !ok {
+			mappers := []ProtocolMapperRepresentation{}
+			names := []string{}
+			for _, m := range spec.ProtocolMappers {
+				mappers = append(mappers, newProtocolMapper(m, spec.Protocol))
+				names = append(names, m.Name)
+			}
+			changes := diffMap(nil, "attributes", nil, spec.Attributes)
+			changes = diffList(changes, "protocolMappers", names, nil)
+			p.add(ActionCreate, ResourceClientScope, spec.Name, changes, func(c IClient) error {
+				return c.CreateClientScope(p.Realm, ClientScopeRepresentation{
+					Name:            spec.Name,
+					Description:     spec.Description,
+					Protocol:        spec.Protocol,
+					Attributes:      toAnyMap(nil, spec.Attributes),
+					ProtocolMappers: mappers,
+				})
+			})
+			continue
+		}
+
+		changes := diffValue(nil, "description", scope.Description, spec.Description)
+		changes = diffMap(changes, "attributes", toStringMap(scope.Attributes), spec.Attributes)
+		scopeChanged := len(changes) > 0
+
+		// プロトコルマッパーは名前で対応付け、configに指定したキーのみ更新する
+		created := []ProtocolMapperRepresentation{}
+		updated := []ProtocolMapperRepresentation{}
+		for _, m := range spec.ProtocolMappers {
+			i := slices.IndexFunc(scope.ProtocolMappers, func(v ProtocolMapperRepresentation) bool { return v.Name == m.Name })
+			if i < 0 {
+				created = append(created, newProtocolMapper(m, scope.Protocol))
+				changes = diffList(changes, "protocolMappers", []string{m.Name}, nil)
+				continue
+			}
+			mapper := scope.ProtocolMappers[i]
+			mapperChanges := diffMap(nil, "protocolMappers."+m.Name+".config", toStringMap(mapper.Config), m.Config)
+			if len(mapperChanges) <= 0 {
+				continue
+			}
+			mapper.Config = toAnyMap(mapper.Config, m.Config)
+			updated = append(updated, mapper)
+			changes = append(changes, mapperChanges...)
+		}
+		if len(changes) <= 0 {
+			continue
+		}
+		p.add(ActionUpdate, ResourceClientScope, spec.Name, changes, func(c IClient) error {
+			if scopeChanged {
+				body := scope
+				if spec.Description != "" {
+					body.Description = spec.Description
+				}
+				body.Attributes = toAnyMap(scope.Attributes, spec.Attributes)
+				body.ProtocolMappers = nil
+				if err := c.UpdateClientScope(p.Realm, body); err != nil {
+					return err
+				}
+			}
+			for _, mapper := range created {
+				if err := c.CreateProtocolMapper(p.Realm, scope.Id, mapper); err != nil {
+					return err
+				}
+			}
+			for _, mapper := range updated {
+				if err := c.UpdateProtocolMapper(p.Realm, scope.Id, mapper); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+func (p *Plan) planClients(c IClient) error {
+	for _, spec := range p.spec.Clients {
+		var current *ClientRepresentation
+		if !p.NewRealm {
+			client, err := c.GetClientByClientId(p.Realm, spec.ClientId)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("failed to get client %s: %w", spec.ClientId, err)
+			}
+			current = client
+		}
+
+		if current == nil {
+			changes := diffMap(nil, "attributes", nil, spec.Attributes)
+			changes = diffList(changes, "defaultClientScopes", spec.DefaultClientScopes, nil)
+			p.add(ActionCreate, ResourceClient, spec.ClientId, changes, func(c IClient) error {
+				if err := c.CreateClient(p.Realm, ClientRepresentation{
+					Id:           spec.Id,
+					ClientId:     spec.ClientId,
+					Name:         spec.Name,
+					Protocol:     spec.Protocol,
+					RedirectUris: spec.RedirectUris,
+					Attributes:   spec.Attributes,
+				}); err != nil {
+					return err
+				}
+				created, err := c.GetClientByClientId(p.Realm, spec.ClientId)
+				if err != nil {
+					return err
+				}
+				return addClientDefaultScopes(c, p.Realm, created.Id, spec.DefaultClientScopes)
+			})
+			continue
+		}
+
+		changes := diffValue(nil, "name", current.Name, spec.Name)
+		changes = diffValue(changes, "protocol", current.Protocol, spec.Protocol)
+		if len(spec.RedirectUris) > 0 && !slices.Equal(current.RedirectUris, spec.RedirectUris) {
+			changes = append(changes, fmt.Sprintf("redirectUris: %q -> %q", current.RedirectUris, spec.RedirectUris))
+		}
+		changes = diffMap(changes, "attributes", current.Attributes, spec.Attributes)
+		clientChanged := len(changes) > 0
+
+		scopes, err := c.GetClientDefaultScopes(p.Realm, current.Id)
+		if err != nil {
+			return fmt.Errorf("failed to get default client scopes of client %s: %w", spec.ClientId, err)
+		}
+		scopeNames := []string{}
+		for _, scope := range scopes {
+			scopeNames = append(scopeNames, scope.Name)
+		}
+		added := missingValues(scopeNames, spec.DefaultClientScopes)
+		changes = diffList(changes, "defaultClientScopes", added, nil)
+		if len(changes) <= 0 {
+			continue
+		}
+		p.add(ActionUpdate, ResourceClient, spec.ClientId, changes, func(c IClient) error {
+			if clientChanged {
+				body := *current
+				if spec.Name != "" {
+					body.Name = spec.Name
+				}
+				if spec.Protocol != "" {
+					body.Protocol = spec.Protocol
+				}
+				if len(spec.RedirectUris) > 0 {
+					body.RedirectUris = spec.RedirectUris
+				}
+				body.Attributes = mergeMap(current.Attributes, spec.Attributes)
+				if err := c.UpdateClient(p.Realm, body); err != nil {
+					return err
+				}
+			}
+			return addClientDefaultScopes(c, p.Realm, current.Id, added)
+		})
+	}
+	return nil
+}
+
+func (p *Plan) planIdentityProviders(c IClient) error {
+	current := map[string]IdentityProviderRepresentation{}
+	if !p.NewRealm {
+		idps, err := c.ListIdentityProviders(p.Realm)
+		if err != nil {
+			return fmt.Errorf("failed to list identity providers: %w", err)
+		}
+		for _, idp := range idps {
+			current[idp.Alias] = idp
+		}
+	}
+
+	for _, spec := range p.spec.IdentityProviders {
+		enabled := spec.Enabled == nil || *spec.Enabled
+		idp, ok := current[spec.Alias]
+		if !ok {
+			p.add(ActionCreate, ResourceIdentityProvider, spec.Alias, diffMap(nil, "config", nil, spec.Config), func(c IClient) error {
+				return c.CreateIdentityProvider(p.Realm, IdentityProviderRepresentation{
+					Alias:       spec.Alias,
+					DisplayName: spec.DisplayName,
+					ProviderId:  spec.ProviderId,
+					Enabled:     enabled,
+					TrustEmail:  spec.TrustEmail,
+					Config:      spec.Config,
+				})
+			})
+			continue
+		}
+
+		// idPの種類は作成後に変更できないため比較しない
+		changes := diffValue(nil, "displayName", idp.DisplayName, spec.DisplayName)
+		if idp.Enabled != enabled {
+			changes = append(changes, fmt.Sprintf("enabled: %v -> %v", idp.Enabled, enabled))
+		}
+		if idp.TrustEmail != spec.TrustEmail {
+			changes = append(changes, fmt.Sprintf("trustEmail: %v -> %v", idp.TrustEmail, spec.TrustEmail))
+		}
+		changes = diffMap(changes, "config", idp.Config, spec.Config)
+		if len(changes) <= 0 {
+			continue
+		}
+		p.add(ActionUpdate, ResourceIdentityProvider, spec.Alias, changes, func(c IClient) error {
+			body := idp
+			if spec.DisplayName != "" {
+				body.DisplayName = spec.DisplayName
+			}
+			body.Enabled = enabled
+			body.TrustEmail = spec.TrustEmail
+			body.Config = mergeMap(idp.Config, spec.Config)
+			return c.UpdateIdentityProvider(p.Realm, body)
+		})
+	}
+	return nil
+}
+
+func (p *Plan) planUsers(c IClient) error {
+	// ユーザのロール・グループは宣言ファイルで管理するもののみ割り当て・解除する
+	managedRoles := []string{}
+	for _, role := range p.spec.Roles {
+		managedRoles = append(managedRoles, role.Name)
+	}
+	managedGroups := []string{}
+	for _, group := range p.spec.Groups {
+		managedGroups = append(managedGroups, group.Name)
+	}
+
+	for _, spec := range p.spec.Users {
+		var current *UserRepresentation
+		if !p.NewRealm {
+			user, err := c.GetUser(p.Realm, spec.Username)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("failed to get user %s: %w", spec.Username, err)
+			}
+			current = user
+		}
+
+		if current == nil {
+			changes := diffList(nil, "realmRoles", spec.RealmRoles, nil)
+			changes = diffList(changes, "groups", spec.Groups, nil)
+			p.add(ActionCreate, ResourceUser, spec.Username, changes, func(c IClient) error {
+				if err := c.CreateUser(p.Realm, UserRepresentation{
+					Username:  spec.Username,
+					Email:     spec.Email,
+					FirstName: spec.FirstName,
+					LastName:  spec.LastName,
+				}, spec.Password); err != nil {
+					return err
+				}
+				created, err := c.GetUser(p.Realm, spec.Username)
+				if err != nil {
+					return err
+				}
+				return updateUserMemberships(c, p.Realm, created.Id, spec.RealmRoles, nil, spec.Groups, nil)
+			})
+			continue
+		}
+
+		changes := diffValue(nil, "email", current.Email, spec.Email)
+		changes = diffValue(changes, "firstName", current.FirstName, spec.FirstName)
+		changes = diffValue(changes, "lastName", current.LastName, spec.LastName)
+		userChanged := len(changes) > 0
+
+		roles, err := c.GetUserRealmRoles(p.Realm, current.Id)
+		if err != nil {
+			return fmt.Errorf("failed to get realm roles of user %s: %w", spec.Username, err)
+		}
+		currentRoles := roleNames(roles)
+		addedRoles := missingValues(currentRoles, spec.RealmRoles)
+		removedRoles := missingValues(spec.RealmRoles, intersect(currentRoles, managedRoles))
+		changes = diffList(changes, "realmRoles", addedRoles, removedRoles)
+
+		groups, err := c.GetUserGroups(p.Realm, current.Id)
+		if err != nil {
+			return fmt.Errorf("failed to get groups of user %s: %w", spec.Username, err)
+		}
+		currentGroups := []string{}
+		for _, group := range groups {
+			currentGroups = append(currentGroups, group.Name)
+		}
+		addedGroups := missingValues(currentGroups, spec.Groups)
+		removedGroups := missingValues(spec.Groups, intersect(currentGroups, managedGroups))
+		changes = diffList(changes, "groups", addedGroups, removedGroups)
+
+		if len(changes) <= 0 {
+			continue
+		}
+		p.add(ActionUpdate, ResourceUser, spec.Username, changes, func(c IClient) error {
+			if userChanged {
+				body := *current
+				if spec.Email != "" {
+					body.Email = spec.Email
+				}
+				if spec.FirstName != "" {
+					body.FirstName = spec.FirstName
+				}
+				if spec.LastName != "" {
+					body.LastName = spec.LastName
+				}
+				if err := c.UpdateUser(p.Realm, body); err != nil {
+					return err
+				}
+			}
+			return updateUserMemberships(c, p.Realm, current.Id, addedRoles, removedRoles, addedGroups, removedGroups)
+		})
+	}
+	return nil
+}
+
+// updateUserMemberships ユーザのrealmのロールの割り当て・グループへの所属を変更する
+func updateUserMemberships(c IClient, realm string, userId string, addedRoles []string, removedRoles []string, addedGroups []string, removedGroups []string) error {
+	if len(addedRoles) > 0 {
+		roles, err := resolveRealmRoles(c, realm, addedRoles)
+		if err != nil {
+			return err
+		}
+		if err := c.AddUserRealmRoles(realm, userId, roles); err != nil {
+			return err
+		}
+	}
+	if len(removedRoles) > 0 {
+		roles, err := resolveRealmRoles(c, realm, removedRoles)
+		if err != nil {
+			return err
+		}
+		if err := c.RemoveUserRealmRoles(realm, userId, roles); err != nil {
+			return err
+		}
+	}
+	for _, name := range addedGroups {
+		group, err := findGroup(c, realm, name)
+		if err != nil {
+			return err
+		}
+		if err := c.AddUserToGroup(realm, userId, group.Id); err != nil {
+			return err
+		}
+	}
+	for _, name := range removedGroups {
+		group, err := findGroup(c, realm, name)
+		if err != nil {
+			return err
+		}
+		if err := c.RemoveUserFromGroup(realm, userId, group.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addGroupRealmRoles(c IClient, realm string, groupId string, names []string) error {
+	if len(names) <= 0 {
+		return nil
+	}
+	roles, err := resolveRealmRoles(c, realm, names)
+	if err != nil {
+		return err
+	}
+	return c.AddGroupRealmRoles(realm, groupId, roles)
+}
+
+func addClientDefaultScopes(c IClient, realm string, id string, names []string) error {
+	for _, name := range names {
+		scope, err := c.GetClientScope(realm, name)
+		if err != nil {
+			return err
+		}
+		if err := c.AddClientDefaultScope(realm, id, scope.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveRealmRoles ロールの割り当てに必要なIDを含むrealmのロールを取得する
+func resolveRealmRoles(c IClient, realm string, names []string) ([]RoleRepresentation, error) {
+	roles := []RoleRepresentation{}
+	for _, name := range names {
+		role, err := c.GetRealmRole(realm, name)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, *role)
+	}
+	return roles, nil
+}
+
+func findGroup(c IClient, realm string, name string) (*GroupRepresentation, error) {
+	groups, err := c.ListGroups(realm)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.Name == name {
+			return &group, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s:%s", ErrNotFound, realm, name)
+}
+
+func newProtocolMapper(spec ProtocolMapperSpec, protocol string) ProtocolMapperRepresentation {
+	if spec.Protocol != "" {
+		protocol = spec.Protocol
+	}
+	return ProtocolMapperRepresentation{
+		Name:           spec.Name,
+		Protocol:       protocol,
+		ProtocolMapper: spec.ProtocolMapper,
+		Config:         toAnyMap(nil, spec.Config),
+	}
+}
+
+// diffValue 宣言した値が現在の値と異なる場合に変更内容を追加する (宣言していない空文字の値は比較しない)
+func diffValue(changes []string, field string, current string, want string) []string {
+	if want == "" || current == want {
+		return changes
+	}
+	return append(changes, fmt.Sprintf("%s: %q -> %q", field, current, want))
+}
+
+// diffMap 宣言したキーの値が現在の値と異なる場合に、キーの順に変更内容を追加する。
+// 秘密情報 (キーにsecretを含む、またはkeycloakがマスクした値) は現在の値と比較できないため、宣言した場合は常に値を表示せずに変更する
+func diffMap(changes []string, field string, current map[string]string, want map[string]string) []string {
+	keys := []string{}
+	for key := range want {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, ok := current[key]
+		if value == maskedValue || strings.Contains(strings.ToLower(key), "secret") {
+			changes = append(changes, fmt.Sprintf("%s.%s: (changed)", field, key))
+			continue
+		}
+		if ok && value == want[key] {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%s.%s: %q -> %q", field, key, value, want[key]))
+	}
+	return changes
+}
+
+// diffList 追加・削除する値の変更内容を追加する
+func diffList(changes []string, field string, added []string, removed []string) []string {
+	for _, v := range added {
+		changes = append(changes, fmt.Sprintf("%s: + %s", field, v))
+	}
+	for _, v := range removed {
+		changes = append(changes, fmt.Sprintf("%s: - %s", field, v))
+	}
+	return changes
+}
+
+// missingValues wantのうちcurrentに含まれない値を返却する
+func missingValues(current []string, want []string) []string {
+	values := []string{}
+	for _, v := range want {
+		if !slices.Contains(current, v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// intersect valuesのうちfilterに含まれる値を返却する
+func intersect(values []string, filter []string) []string {
+	result := []string{}
+	for _, v := range values {
+		if slices.Contains(filter, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func roleNames(roles []RoleRepresentation) []string {
+	names := []string{}
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	return names
+}
+
+// mergeMap currentの値をwantの値で上書きした新しいmapを返却する
+func mergeMap(current map[string]string, want map[string]string) map[string]string {
+	merged := map[string]string{}
+	maps.Copy(merged, current)
+	maps.Copy(merged, want)
+	return merged
+}
+
+// toAnyMap currentの値をwantの値で上書きした、クライアントスコープ・プロトコルマッパーの形式の新しいmapを返却する
+func toAnyMap(current map[string]interface{}, want map[string]string) map[string]interface{} {
+	merged := map[string]interface{}{}
+	maps.Copy(merged, current)
+	for k, v := range want {
+		merged[k] = v
+	}
+	return merged
+}
+
+// toStringMap クライアントスコープ・プロトコルマッパーの値を比較用に文字列に変換する
+func toStringMap(m map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	for k, v := range m {
+		result[k] = fmt.Sprint(v)
+	}
+	return result
+}
+
+// toMultiValued currentの値をwantの値で上書きした、グループの属性の形式の新しいmapを返却する
+func toMultiValued(current map[string][]string, want map[string]string) map[string][]string {
+	merged := map[string][]string{}
+	maps.Copy(merged, current)
+	for k, v := range want {
+		merged[k] = []string{v}
+	}
+	return merged
+}
+
+// firstValues グループの属性を比較用に先頭の値のみに変換する
+func firstValues(m map[string][]string) map[string]string {
+	result := map[string]string{}
+	for k, v := range m {
+		if len(v) > 0 {
+			result[k] = v[0]
+		}
+	}
+	return result
+}
@@ -0,0 +1,455 @@
+package keycloak
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeClient keycloakの管理APIを模したメモリ上のrealm
+type fakeClient struct {
+	realm        *RealmRepresentation
+	roles        []RoleRepresentation
+	groups       []GroupRepresentation
+	groupRoles   map[string][]string
+	scopes       []ClientScopeRepresentation
+	clients      []ClientRepresentation
+	clientScopes map[string][]string
+	idps         []IdentityProviderRepresentation
+	users        []UserRepresentation
+	userRoles    map[string][]string
+	userGroups   map[string][]string
+	seq          int
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		groupRoles:   map[string][]string{},
+		clientScopes: map[string][]string{},
+		userRoles:    map[string][]string{},
+		userGroups:   map[string][]string{},
+	}
+}
+
+func (f *fakeClient) nextId() string {
+	f.seq++
+	return fmt.Sprintf("id-%d", f.seq)
+}
+
+func (f *fakeClient) GetRealm(realm string) (*RealmRepresentation, error) {
+	if f.realm == nil {
+		return nil, ErrNotFound
+	}
+	return f.realm, nil
+}
+
+// CreateRealm keycloakと同様に既定のクライアントスコープ (role_list) も作成する
+func (f *fakeClient) CreateRealm(body RealmRepresentation) error {
+	f.realm = &body
+	f.scopes = append(f.scopes, ClientScopeRepresentation{
+		Id:       f.nextId(),
+		Name:     "role_list",
+		Protocol: ProtocolSAML,
+		ProtocolMappers: []ProtocolMapperRepresentation{
+			{Id: f.nextId(), Name: "role list", Config: map[string]interface{}{"single": "false", "attribute.name": "Role"}},
+		},
+	})
+	return nil
+}
+
+func (f *fakeClient) UpdateRealm(realm string, body RealmRepresentation) error {
+	if body.DisplayName != "" {
+		f.realm.DisplayName = body.DisplayName
+	}
+	if body.Enabled != nil {
+		f.realm.Enabled = body.Enabled
+	}
+	return nil
+}
+
+func (f *fakeClient) ListRealmRoles(realm string) ([]RoleRepresentation, error) {
+	return f.roles, nil
+}
+
+func (f *fakeClient) GetRealmRole(realm string, roleName string) (*RoleRepresentation, error) {
+	for _, role := range f.roles {
+		if role.Name == roleName {
+			return &role, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (f *fakeClient) CreateRealmRole(realm string, role RoleRepresentation) error {
+	role.Id = f.nextId()
+	f.roles = append(f.roles, role)
+	return nil
+}
+
+func (f *fakeClient) UpdateRealmRole(realm string, role RoleRepresentation) error {
+	i := slices.IndexFunc(f.roles, func(v RoleRepresentation) bool { return v.Name == role.Name })
+	f.roles[i] = role
+	return nil
+}
+
+func (f *fakeClient) ListGroups(realm string) ([]GroupRepresentation, error) {
+	return f.groups, nil
+}
+
+func (f *fakeClient) CreateGroup(realm string, group GroupRepresentation) error {
+	group.Id = f.nextId()
+	f.groups = append(f.groups, group)
+	return nil
+}
+
+func (f *fakeClient) UpdateGroup(realm string, group GroupRepresentation) error {
+	i := slices.IndexFunc(f.groups, func(v GroupRepresentation) bool { return v.Id == group.Id })
+	f.groups[i] = group
+	return nil
+}
+
+func (f *fakeClient) GetGroupRealmRoles(realm string, groupId string) ([]RoleRepresentation, error) {
+	return f.rolesByName(f.groupRoles[groupId]), nil
+}
+
+func (f *fakeClient) AddGroupRealmRoles(realm string, groupId string, roles []RoleRepresentation) error {
+	f.groupRoles[groupId] = append(f.groupRoles[groupId], roleNames(roles)...)
+	return nil
+}
+
+func (f *fakeClient) ListClientScopes(realm string) ([]ClientScopeRepresentation, error) {
+	return f.scopes, nil
+}
+
+func (f *fakeClient) GetClientScope(realm string, scopeName string) (*ClientScopeRepresentation, error) {
+	for _, scope := range f.scopes {
+		if scope.Name == scopeName {
+			return &scope, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (f *fakeClient) CreateClientScope(realm string, scope ClientScopeRepresentation) error {
+	scope.Id = f.nextId()
+	f.scopes = append(f.scopes, scope)
+	return nil
+}
+
+func (f *fakeClient) UpdateClientScope(realm string, scope ClientScopeRepresentation) error {
+	i := slices.IndexFunc(f.scopes, func(v ClientScopeRepresentation) bool { return v.Id == scope.Id })
+	scope.ProtocolMappers = f.scopes[i].ProtocolMappers
+	f.scopes[i] = scope
+	return nil
+}
+
+func (f *fakeClient) CreateProtocolMapper(realm string, clientScopeId string, protocolMapper ProtocolMapperRepresentation) error {
+	i := slices.IndexFunc(f.scopes, func(v ClientScopeRepresentation) bool { return v.Id == clientScopeId })
+	protocolMapper.Id = f.nextId()
+	f.scopes[i].ProtocolMappers = append(f.scopes[i].ProtocolMappers, protocolMapper)
+	return nil
+}
+
+func (f *fakeClient) UpdateProtocolMapper(realm string, clientScopeId string, protocolMapper ProtocolMapperRepresentation) error {
+	i := slices.IndexFunc(f.scopes, func(v ClientScopeRepresentation) bool { return v.Id == clientScopeId })
+	j := slices.IndexFunc(f.scopes[i].ProtocolMappers, func(v ProtocolMapperRepresentation) bool { return v.Id == protocolMapper.Id })
+	f.scopes[i].ProtocolMappers[j] = protocolMapper
+	return nil
+}
+
+func (f *fakeClient) GetClientByClientId(realm string, clientId string) (*ClientRepresentation, error) {
+	for _, client := range f.clients {
+		if client.ClientId == clientId {
+			return &client, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (f *fakeClient) CreateClient(realmName string, body ClientRepresentation) error {
+	if body.Id == "" {
+		body.Id = f.nextId()
+	}
+	f.clients = append(f.clients, body)
+	return nil
+}
+
+func (f *fakeClient) UpdateClient(realm string, body ClientRepresentation) error {
+	i := slices.IndexFunc(f.clients, func(v ClientRepresentation) bool { return v.Id == body.Id })
+	f.clients[i] = body
+	return nil
+}
+
+func (f *fakeClient) GetClientDefaultScopes(realm string, id string) ([]ClientScopeRepresentation, error) {
+	scopes := []ClientScopeRepresentation{}
+	for _, scope := range f.scopes {
+		if slices.Contains(f.clientScopes[id], scope.Id) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+func (f *fakeClient) AddClientDefaultScope(realm string, id string, clientScopeId string) error {
+	f.clientScopes[id] = append(f.clientScopes[id], clientScopeId)
+	return nil
+}
+
+func (f *fakeClient) ListIdentityProviders(realm string) ([]IdentityProviderRepresentation, error) {
+	return f.idps, nil
+}
+
+func (f *fakeClient) CreateIdentityProvider(realm string, idp IdentityProviderRepresentation) error {
+	idp.Config = maps.Clone(idp.Config)
+	f.idps = append(f.idps, idp)
+	return nil
+}
+
+func (f *fakeClient) UpdateIdentityProvider(realm string, idp IdentityProviderRepresentation) error {
+	i := slices.IndexFunc(f.idps, func(v IdentityProviderRepresentation) bool { return v.Alias == idp.Alias })
+	f.idps[i] = idp
+	return nil
+}
+
+func (f *fakeClient) GetUser(realm string, username string) (*UserRepresentation, error) {
+	for _, user := range f.users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (f *fakeClient) CreateUser(realm string, user UserRepresentation, password string) error {
+	user.Id = f.nextId()
+	f.users = append(f.users, user)
+	return nil
+}
+
+func (f *fakeClient) UpdateUser(realm string, user UserRepresentation) error {
+	i := slices.IndexFunc(f.users, func(v UserRepresentation) bool { return v.Id == user.Id })
+	f.users[i] = user
+	return nil
+}
+
+func (f *fakeClient) GetUserRealmRoles(realm string, userId string) ([]RoleRepresentation, error) {
+	return f.rolesByName(f.userRoles[userId]), nil
+}
+
+func (f *fakeClient) AddUserRealmRoles(realm string, userId string, roles []RoleRepresentation) error {
+	f.userRoles[userId] = append(f.userRoles[userId], roleNames(roles)...)
+	return nil
+}
+
+func (f *fakeClient) RemoveUserRealmRoles(realm string, userId string, roles []RoleRepresentation) error {
+	names := roleNames(roles)
+	f.userRoles[userId] = slices.DeleteFunc(f.userRoles[userId], func(v string) bool { return slices.Contains(names, v) })
+	return nil
+}
+
+func (f *fakeClient) GetUserGroups(realm string, userId string) ([]GroupRepresentation, error) {
+	groups := []GroupRepresentation{}
+	for _, group := range f.groups {
+		if slices.Contains(f.userGroups[userId], group.Id) {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+func (f *fakeClient) AddUserToGroup(realm string, userId string, groupId string) error {
+	f.userGroups[userId] = append(f.userGroups[userId], groupId)
+	return nil
+}
+
+func (f *fakeClient) RemoveUserFromGroup(realm string, userId string, groupId string) error {
+	f.userGroups[userId] = slices.DeleteFunc(f.userGroups[userId], func(v string) bool { return v == groupId })
+	return nil
+}
+
+func (f *fakeClient) rolesByName(names []string) []RoleRepresentation {
+	roles := []RoleRepresentation{}
+	for _, name := range names {
+		if role, err := f.GetRealmRole("", name); err == nil {
+			roles = append(roles, *role)
+		}
+	}
+	return roles
+}
+
+func newTestRealmSpec() *RealmSpec {
+	return &RealmSpec{
+		Realm:       "my-realm",
+		DisplayName: "FX Tester",
+		Roles:       []RoleSpec{{Name: "admin", Description: "管理者"}, {Name: "viewer"}},
+		Groups:      []GroupSpec{{Name: "traders", Attributes: map[string]string{"desk": "fx"}, RealmRoles: []string{"viewer"}}},
+		ClientScopes: []ClientScopeSpec{
+			{Name: "role_list", ProtocolMappers: []ProtocolMapperSpec{{Name: "role list", Config: map[string]string{"single": "true"}}}},
+			{Name: "groups", Protocol: ProtocolSAML, ProtocolMappers: []ProtocolMapperSpec{{Name: "group list", ProtocolMapper: "saml-group-membership-mapper", Config: map[string]string{"attribute.name": "groups"}}}},
+		},
+		Clients: []ClientSpec{
+			{ClientId: "https://fx-tester-fe:3000/", Id: "fx-tester-client", Protocol: ProtocolSAML, RedirectUris: []string{"https://fx-tester-be:8000/*"}, Attributes: map[string]string{AttributeSamlEncrypt: "false"}, DefaultClientScopes: []string{"groups"}},
+		},
+		IdentityProviders: []IdentityProviderSpec{
+			{Alias: "partner", ProviderId: "saml", Config: map[string]string{"singleSignOnServiceUrl": "https://partner/sso", "clientSecret": "secret"}},
+		},
+		Users: []UserSpec{
+			{Username: "admin", Email: "admin@fxtester.com", Password: "admin", RealmRoles: []string{"admin"}, Groups: []string{"traders"}},
+		},
+	}
+}
+
+func Test_Plan_NewRealm(t *testing.T) {
+	c := newFakeClient()
+	spec := newTestRealmSpec()
+
+	plan, err := NewPlan(c, spec)
+	if err != nil {
+		t.Fatalf("NewPlan()=%v", err)
+	}
+	if !plan.NewRealm {
+		t.Errorf("NewRealm=%v, want true", plan.NewRealm)
+	}
+	for _, action := range plan.Actions {
+		if action.Kind != ActionCreate {
+			t.Errorf("Kind=%v, want %v (%s %s)", action.Kind, ActionCreate, action.Resource, action.Name)
+		}
+	}
+
+	// dry-runの場合と同様に、計画の作成ではrealmを変更しない
+	if c.realm != nil || len(c.roles) > 0 {
+		t.Fatalf("NewPlan() changed the realm")
+	}
+
+	if err := plan.Apply(c); err != nil {
+		t.Fatalf("Apply()=%v", err)
+	}
+
+	// 既定のrole_listは作成せずにプロトコルマッパーを更新する
+	scope, _ := c.GetClientScope("", "role_list")
+	if len(scope.ProtocolMappers) != 1 || scope.ProtocolMappers[0].Config["single"] != "true" || scope.ProtocolMappers[0].Config["attribute.name"] != "Role" {
+		t.Errorf("role_list=%+v", scope.ProtocolMappers)
+	}
+	user, _ := c.GetUser("", "admin")
+	if got := c.userRoles[user.Id]; !reflect.DeepEqual(got, []string{"admin"}) {
+		t.Errorf("userRoles=%v", got)
+	}
+	if got := c.userGroups[user.Id]; len(got) != 1 {
+		t.Errorf("userGroups=%v", got)
+	}
+	if got := c.clientScopes["fx-tester-client"]; len(got) != 1 {
+		t.Errorf("clientScopes=%v", got)
+	}
+
+	// 適用後は宣言した秘密情報の再設定以外の変更が無い (冪等)
+	again, err := NewPlan(c, spec)
+	if err != nil {
+		t.Fatalf("NewPlan()=%v", err)
+	}
+	if len(again.Actions) != 1 || !reflect.DeepEqual(again.Actions[0].Changes, []string{"config.clientSecret: (changed)"}) {
+		buf := bytes.NewBufferString("")
+		again.Write(buf)
+		t.Errorf("Actions after Apply()=%s", buf.String())
+	}
+}
+
+func Test_Plan_Update(t *testing.T) {
+	c := newFakeClient()
+	spec := newTestRealmSpec()
+	plan, err := NewPlan(c, spec)
+	if err != nil {
+		t.Fatalf("NewPlan()=%v", err)
+	}
+	if err := plan.Apply(c); err != nil {
+		t.Fatalf("Apply()=%v", err)
+	}
+
+	// keycloakの管理コンソール等で変更された状態
+	c.roles[0].Description = "old"
+	c.clients[0].Attributes = map[string]string{AttributeSamlEncrypt: "true", "other": "keep"}
+	c.idps[0].Config["clientSecret"] = maskedValue
+	user, _ := c.GetUser("", "admin")
+	c.AddUserRealmRoles("", user.Id, []RoleRepresentation{{Name: "viewer"}})
+	c.userGroups[user.Id] = nil
+
+	plan, err = NewPlan(c, spec)
+	if err != nil {
+		t.Fatalf("NewPlan()=%v", err)
+	}
+	buf := bytes.NewBufferString("")
+	plan.Write(buf)
+	want := strings.Join([]string{
+		"realm my-realm",
+		`  ~ role admin`,
+		`      description: "old" -> "管理者"`,
+		`  ~ client https://fx-tester-fe:3000/`,
+		`      attributes.saml.encrypt: "true" -> "false"`,
+		`  ~ identityProvider partner`,
+		`      config.clientSecret: (changed)`,
+		`  ~ user admin`,
+		`      realmRoles: - viewer`,
+		`      groups: + traders`,
+		"4件の変更があります",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("Write()=\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	if err := plan.Apply(c); err != nil {
+		t.Fatalf("Apply()=%v", err)
+	}
+	// 宣言していない属性は変更しない
+	if got := c.clients[0].Attributes; got["other"] != "keep" || got[AttributeSamlEncrypt] != "false" {
+		t.Errorf("Attributes=%v", got)
+	}
+	if got := c.userRoles[user.Id]; !reflect.DeepEqual(got, []string{"admin"}) {
+		t.Errorf("userRoles=%v", got)
+	}
+}
+
+func Test_RealmSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *RealmSpec)
+		wantErr bool
+	}{
+		{
+			name:   "test1_正常",
+			modify: func(s *RealmSpec) {},
+		},
+		{
+			name:    "test2_realm未指定",
+			modify:  func(s *RealmSpec) { s.Realm = "" },
+			wantErr: true,
+		},
+		{
+			name:    "test3_ロールの重複",
+			modify:  func(s *RealmSpec) { s.Roles = append(s.Roles, RoleSpec{Name: "admin"}) },
+			wantErr: true,
+		},
+		{
+			name:    "test4_宣言していないロールの割り当て",
+			modify:  func(s *RealmSpec) { s.Users[0].RealmRoles = []string{"unknown"} },
+			wantErr: true,
+		},
+		{
+			name:    "test5_宣言していないグループへの所属",
+			modify:  func(s *RealmSpec) { s.Users[0].Groups = []string{"unknown"} },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := newTestRealmSpec()
+			tt.modify(spec)
+			if err := spec.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate()=%v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package keycloak
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RealmSpec realmの宣言ファイル。
+// 宣言したリソースのみ作成・更新し、宣言していないリソース・属性は変更しない
+type RealmSpec struct {
+	Realm       string `yaml:"realm"`
+	DisplayName string `yaml:"displayName"`
+	// 未指定の場合は有効とする
+	Enabled *bool `yaml:"enabled"`
+
+	Roles             []RoleSpec             `yaml:"roles"`
+	Groups            []GroupSpec            `yaml:"groups"`
+	ClientScopes      []ClientScopeSpec      `yaml:"clientScopes"`
+	Clients           []ClientSpec           `yaml:"clients"`
+	IdentityProviders []IdentityProviderSpec `yaml:"identityProviders"`
+	Users             []UserSpec             `yaml:"users"`
+}
+
+// RoleSpec realmのロール
+type RoleSpec struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// GroupSpec realmの最上位のグループ
+type GroupSpec struct {
+	Name       string            `yaml:"name"`
+	Attributes map[string]string `yaml:"attributes"`
+	// グループに割り当てるrealmのロール (割り当ての解除はしない)
+	RealmRoles []string `yaml:"realmRoles"`
+}
+
+// ClientScopeSpec クライアントスコープ (keycloakが既定で作成するスコープのプロトコルマッパーの変更にも使用する)
+type ClientScopeSpec struct {
+	Name            string               `yaml:"name"`
+	Description     string               `yaml:"description"`
+	Protocol        string               `yaml:"protocol"`
+	Attributes      map[string]string    `yaml:"attributes"`
+	ProtocolMappers []ProtocolMapperSpec `yaml:"protocolMappers"`
+}
+
+// ProtocolMapperSpec クライアントスコープのプロトコルマッパー (既存のマッパーはconfigに指定したキーのみ更新する)
+type ProtocolMapperSpec struct {
+	Name           string            `yaml:"name"`
+	Protocol       string            `yaml:"protocol"`
+	ProtocolMapper string            `yaml:"protocolMapper"`
+	Config         map[string]string `yaml:"config"`
+}
+
+// ClientSpec クライアント (clientIdで既存のクライアントと対応付ける)
+type ClientSpec struct {
+	ClientId string `yaml:"clientId"`
+	// keycloak内部のID (作成時のみ使用する)
+	Id           string            `yaml:"id"`
+	Name         string            `yaml:"name"`
+	Protocol     string            `yaml:"protocol"`
+	RedirectUris []string          `yaml:"redirectUris"`
+	Attributes   map[string]string `yaml:"attributes"`
+	// 既定のクライアントスコープに追加するスコープの名前 (削除はしない)
+	DefaultClientScopes []string `yaml:"defaultClientScopes"`
+}
+
+// IdentityProviderSpec identity provider (aliasで既存のidPと対応付ける)
+type IdentityProviderSpec struct {
+	Alias       string `yaml:"alias"`
+	DisplayName string `yaml:"displayName"`
+	// idPの種類 (e.g. saml, oidc)
+	ProviderId string `yaml:"providerId"`
+	// 未指定の場合は有効とする
+	Enabled    *bool             `yaml:"enabled"`
+	TrustEmail bool              `yaml:"trustEmail"`
+	Config     map[string]string `yaml:"config"`
+}
+
+// UserSpec ユーザ (usernameで既存のユーザと対応付ける)
+type UserSpec struct {
+	Username  string `yaml:"username"`
+	Email     string `yaml:"email"`
+	FirstName string `yaml:"firstName"`
+	LastName  string `yaml:"lastName"`
+	// 作成時のみ設定する (既存のユーザのパスワードは変更しない)
+	Password string `yaml:"password"`
+	// 宣言ファイルのrolesのうち、ユーザに割り当てるロール (宣言ファイルのその他のロールは割り当てを解除する)
+	RealmRoles []string `yaml:"realmRoles"`
+	// 宣言ファイルのgroupsのうち、ユーザを所属させるグループ (宣言ファイルのその他のグループからは脱退させる)
+	Groups []string `yaml:"groups"`
+}
+
+// LoadRealmSpec realmの宣言ファイルを読み込む。
+// 宣言ファイルはtext/templateとしてdataを適用してから解析する (設定ファイルの値の埋め込みに使用する)
+func LoadRealmSpec(path string, data any) (*RealmSpec, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := template.New("realm").Option("missingkey=error").Parse(string(fileBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %v", err)
+	}
+	buf := bytes.NewBufferString("")
+	if err := tmp.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %v", err)
+	}
+
+	spec := &RealmSpec{}
+	if err := yaml.Unmarshal(buf.Bytes(), spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal: %v", err)
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// validate 対応付けに使用する名前が未指定・重複していないかチェックする
+func (s *RealmSpec) validate() error {
+	if s.Realm == "" {
+		return fmt.Errorf("realm is required")
+	}
+	check := func(kind string, names []string) error {
+		seen := map[string]bool{}
+		for _, name := range names {
+			if name == "" {
+				return fmt.Errorf("%s: name is required", kind)
+			}
+			if seen[name] {
+				return fmt.Errorf("%s: duplicate name %s", kind, name)
+			}
+			seen[name] = true
+		}
+		return nil
+	}
+
+	roles := []string{}
+	for _, v := range s.Roles {
+		roles = append(roles, v.Name)
+	}
+	groups := []string{}
+	for _, v := range s.Groups {
+		groups = append(groups, v.Name)
+	}
+	scopes := []string{}
+	for _, v := range s.ClientScopes {
+		scopes = append(scopes, v.Name)
+	}
+	clients := []string{}
+	for _, v := range s.Clients {
+		clients = append(clients, v.ClientId)
+	}
+	idps := []string{}
+	for _, v := range s.IdentityProviders {
+		idps = append(idps, v.Alias)
+	}
+	users := []string{}
+	for _, v := range s.Users {
+		users = append(users, v.Username)
+	}
+	for _, v := range []struct {
+		kind  string
+		names []string
+	}{
+		{"roles", roles},
+		{"groups", groups},
+		{"clientScopes", scopes},
+		{"clients", clients},
+		{"identityProviders", idps},
+		{"users", users},
+	} {
+		if err := check(v.kind, v.names); err != nil {
+			return err
+		}
+	}
+
+	// ユーザのロール・グループは宣言したものに限る (宣言外のものは割り当ての解除の対象を判断できないため)
+	for _, user := range s.Users {
+		for _, role := range user.RealmRoles {
+			if !slices.Contains(roles, role) {
+				return fmt.Errorf("users: %s: undeclared role %s", user.Username, role)
+			}
+		}
+		for _, group := range user.Groups {
+			if !slices.Contains(groups, group) {
+				return fmt.Errorf("users: %s: undeclared group %s", user.Username, group)
+			}
+		}
+	}
+	return nil
+}
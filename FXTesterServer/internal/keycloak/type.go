@@ -4,9 +4,12 @@ type PolicyEnforcementMode string
 type DecisionStrategy string
 type Logic string
 
-type CreateRealmRequest struct {
-	RealmName string `json:"realm"`
-	Enabled   bool   `json:"enabled"`
+type RealmRepresentation struct {
+	Id          string `json:"id,omitempty"`
+	Realm       string `json:"realm,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	// falseを送信できるようにポインタとする
+	Enabled *bool `json:"enabled,omitempty"`
 }
 
 type ClientRepresentation struct {
@@ -222,3 +225,32 @@ type SocialLinkRepresentation struct {
 	SocialUserId   string `json:"socialUserId,omitempty"`
 	SocialUsername string `json:"socialUsername,omitempty"`
 }
+
+type RoleRepresentation struct {
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Composite   bool   `json:"composite,omitempty"`
+	ClientRole  bool   `json:"clientRole,omitempty"`
+	ContainerId string `json:"containerId,omitempty"`
+}
+
+type GroupRepresentation struct {
+	Id         string                `json:"id,omitempty"`
+	Name       string                `json:"name,omitempty"`
+	Path       string                `json:"path,omitempty"`
+	Attributes map[string][]string   `json:"attributes,omitempty"`
+	RealmRoles []string              `json:"realmRoles,omitempty"`
+	SubGroups  []GroupRepresentation `json:"subGroups,omitempty"`
+}
+
+type IdentityProviderRepresentation struct {
+	Alias                     string            `json:"alias,omitempty"`
+	DisplayName               string            `json:"displayName,omitempty"`
+	InternalId                string            `json:"internalId,omitempty"`
+	ProviderId                string            `json:"providerId,omitempty"`
+	Enabled                   bool              `json:"enabled"`
+	TrustEmail                bool              `json:"trustEmail"`
+	FirstBrokerLoginFlowAlias string            `json:"firstBrokerLoginFlowAlias,omitempty"`
+	Config                    map[string]string `json:"config,omitempty"`
+}
@@ -28,6 +28,8 @@ type ErrorCode uint32
   - b: エラーメッセージのタイプ (2桁目)
     - 00: インターナルエラー
 	- 01: バッドリクエストエラー
+	- 02: 認証エラー
+	- 03: リソース未検出エラー
     - その他の値は、随時追加されるタイプ
 
   - c: エラーの詳細番号 (3桁目以降)
@@ -67,6 +69,16 @@ const (
 	ErrEmptyLogoutRequestId       ErrorCode = 0x80000030 // LogoutRequest.IDが未指定
 	ErrOperationNotAllow          ErrorCode = 0x80000031 // 許可されていない操作
 	ErrInvalidAuthnRequestId      ErrorCode = 0x80000032 // AuthnRequestIdが不一致
+	ErrOidcDiscovery              ErrorCode = 0x80000033 // OpenID Providerのディスカバリ・JWKSの取得に失敗した場合
+	ErrOidcAuthorization          ErrorCode = 0x80000034 // 認可レスポンスがエラー、またはstateが不一致の場合
+	ErrOidcTokenExchange          ErrorCode = 0x80000035 // 認可コードとトークンの交換に失敗した場合
+	ErrOidcInvalidIdToken         ErrorCode = 0x80000036 // IDトークンの検証に失敗した場合
+	ErrUnknownSamlIssuer          ErrorCode = 0x80000037 // SAMLメッセージのIssuerに一致するidPが無い場合
+	ErrInvalidSpKeyPair           ErrorCode = 0x80000038 // SPの署名・暗号化用の鍵ペアが不正な場合
+	ErrSamlMetadataCreation       ErrorCode = 0x80000039 // SPのメタデータの作成に失敗した場合
+	ErrSamlRedirectSignature      ErrorCode = 0x8000003A // HTTP-Redirectバインディングのクエリ文字列の署名が無い、または検証に失敗した場合
+	ErrInflateSamlMessage         ErrorCode = 0x8000003B // HTTP-RedirectバインディングのSAMLメッセージの展開に失敗した場合
+	ErrSamlPostSignature          ErrorCode = 0x8000003C // HTTP-PostバインディングのSAMLメッセージのXML署名が無い、または検証に失敗した場合
 
 	// ユーザ起因のエラー
 	ErrCodeForbiddenCharacterError ErrorCode = 0x81010001 // 禁止文字エラー
@@ -74,6 +86,12 @@ const (
 	ErrInvalidParameterError       ErrorCode = 0x81010003 // パラメータに予期しない値が設定された場合のエラー
 	ErrTooLargeMessageError        ErrorCode = 0x81010004 // multipart/formで巨大なサイズのデータがアップロードされた場合のエラー
 	ErrInvalidRequestProtocol      ErrorCode = 0x81010005 // リクエスト形式に不備があった場合のエラー
+	ErrUnauthorized                ErrorCode = 0x81020001 // ログインしていない、またはセッションが無効な場合のエラー
+	ErrForbidden                   ErrorCode = 0x81020002 // ログインユーザのロールに操作の権限が無い場合のエラー
+	ErrSamlAssertionReplayed       ErrorCode = 0x81020003 // 使用済みのSAMLアサーションが再送された場合のエラー
+	ErrAccountDisabled             ErrorCode = 0x81020004 // idPでアカウントが無効化されている場合のエラー
+	ErrResourceNotFound            ErrorCode = 0x81030001 // 指定したリソースが存在しない場合のエラー
+	ErrResourceLimitExceeded       ErrorCode = 0x81030002 // 作成できるリソースの上限数に達している場合のエラー
 )
 
 type ErrorTypeDetail struct {
@@ -120,6 +138,42 @@ var errorTypeDetails = []ErrorTypeDetail{
 		dictKey:          "InvalidRequestProtocolError",
 		displayErrorCode: true,
 	},
+	{
+		errorCodePattern: regexp.MustCompile(fmt.Sprintf("0x%x", ErrUnauthorized)),
+		statusCode:       http.StatusUnauthorized,
+		dictKey:          "UnauthorizedError",
+		displayErrorCode: true,
+	},
+	{
+		errorCodePattern: regexp.MustCompile(fmt.Sprintf("0x%x", ErrForbidden)),
+		statusCode:       http.StatusForbidden,
+		dictKey:          "ForbiddenError",
+		displayErrorCode: true,
+	},
+	{
+		errorCodePattern: regexp.MustCompile(fmt.Sprintf("0x%x", ErrSamlAssertionReplayed)),
+		statusCode:       http.StatusUnauthorized,
+		dictKey:          "SamlAssertionReplayedError",
+		displayErrorCode: true,
+	},
+	{
+		errorCodePattern: regexp.MustCompile(fmt.Sprintf("0x%x", ErrAccountDisabled)),
+		statusCode:       http.StatusForbidden,
+		dictKey:          "AccountDisabledError",
+		displayErrorCode: true,
+	},
+	{
+		errorCodePattern: regexp.MustCompile(fmt.Sprintf("0x%x", ErrResourceNotFound)),
+		statusCode:       http.StatusNotFound,
+		dictKey:          "ResourceNotFoundError",
+		displayErrorCode: true,
+	},
+	{
+		errorCodePattern: regexp.MustCompile(fmt.Sprintf("0x%x", ErrResourceLimitExceeded)),
+		statusCode:       http.StatusBadRequest,
+		dictKey:          "ResourceLimitExceededError",
+		displayErrorCode: true,
+	},
 }
 
 type FxtError struct {
@@ -166,6 +166,74 @@ func Test_ErrorHandler(t *testing.T) {
 			wantErrorCode:    ErrCodePanic,
 			wantErrorMessage: "インターナルサーバーエラーが発生しました。\n(エラーコード: 0x80000001)",
 		},
+		{
+			name: "test9",
+			args: args{
+				ctx: func(w http.ResponseWriter) echo.Context {
+					req := httptest.NewRequest("GET", "http://localhost", nil)
+					req.Header.Set("Accept-Language", "ja")
+					return newNoLoggerEcho().NewContext(req, w)
+				},
+				next: func(c echo.Context) error {
+					return NewFxtError(ErrResourceNotFound, "words.backtest")
+				},
+			},
+			wantErr:          false,
+			wantBody:         true,
+			wantErrorCode:    ErrResourceNotFound,
+			wantErrorMessage: "指定されたバックテストが見つかりません。\n(エラーコード: 0x81030001)",
+		},
+		{
+			name: "test10",
+			args: args{
+				ctx: func(w http.ResponseWriter) echo.Context {
+					req := httptest.NewRequest("GET", "http://localhost", nil)
+					req.Header.Set("Accept-Language", "en")
+					return newNoLoggerEcho().NewContext(req, w)
+				},
+				next: func(c echo.Context) error {
+					return NewFxtError(ErrResourceLimitExceeded, "words.apiToken", 10)
+				},
+			},
+			wantErr:          false,
+			wantBody:         true,
+			wantErrorCode:    ErrResourceLimitExceeded,
+			wantErrorMessage: "The API token limit (10) has been reached.\n(Error code: 0x81030002)",
+		},
+		{
+			name: "test11",
+			args: args{
+				ctx: func(w http.ResponseWriter) echo.Context {
+					req := httptest.NewRequest("GET", "http://localhost", nil)
+					req.Header.Set("Accept-Language", "ja")
+					return newNoLoggerEcho().NewContext(req, w)
+				},
+				next: func(c echo.Context) error {
+					return NewFxtError(ErrSamlAssertionReplayed)
+				},
+			},
+			wantErr:          false,
+			wantBody:         true,
+			wantErrorCode:    ErrSamlAssertionReplayed,
+			wantErrorMessage: "このログイン応答は既に使用されています。もう一度ログインしてください。\n(エラーコード: 0x81020003)",
+		},
+		{
+			name: "test12",
+			args: args{
+				ctx: func(w http.ResponseWriter) echo.Context {
+					req := httptest.NewRequest("GET", "http://localhost", nil)
+					req.Header.Set("Accept-Language", "en")
+					return newNoLoggerEcho().NewContext(req, w)
+				},
+				next: func(c echo.Context) error {
+					return NewFxtError(ErrAccountDisabled)
+				},
+			},
+			wantErr:          false,
+			wantBody:         true,
+			wantErrorCode:    ErrAccountDisabled,
+			wantErrorMessage: "This account has been disabled. Please contact your administrator.\n(Error code: 0x81020004)",
+		},
 	}
 
 	for _, tt := range tests {
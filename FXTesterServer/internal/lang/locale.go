@@ -16,9 +16,18 @@ var locale = struct {
 	re: regexp.MustCompile(`((?:[a-zA-Z]+|\*)(?:-[a-zA-Z]+)?)(?:\s*;\s*q\s*=\s*([0-9]\.[0-9]))?`),
 }
 
+// ContextKeyLocale ログインユーザの設定で指定された既定のロケールを設定するコンテキストのキー
+const ContextKeyLocale = "locale"
+
+// GetLocales 優先度順のロケールを返却する。
+// ログインユーザの設定でロケールが指定されている場合はAccept-Languageより優先する
 func GetLocales(ctx echo.Context) []string {
 	defaultLocales := []string{"ja"}
 
+	if v, ok := ctx.Get(ContextKeyLocale).(string); ok && v != "" {
+		return []string{v}
+	}
+
 	if v := ctx.Request().Header.Get("Accept-Language"); v != "" {
 		acceptLanguages := common.ArrayMapSkip(func(input string) (*struct {
 			lang string
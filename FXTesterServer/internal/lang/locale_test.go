@@ -102,6 +102,23 @@ func Test_GetLocales(t *testing.T) {
 				"ja",
 			},
 		},
+		{
+			name: "test7_user_locale",
+			args: args{
+				ctx: func() echo.Context {
+					const acceptLanguage = "ja, en;q=0.5"
+					req := httptest.NewRequest("GET", "http://test.co.jp", nil)
+					req.Header.Set("Accept-Language", acceptLanguage)
+					ctx := echo.New().NewContext(req, nil)
+					// ログインユーザの設定のロケールはAccept-Languageより優先する
+					ctx.Set(ContextKeyLocale, "en")
+					return ctx
+				}(),
+			},
+			want: []string{
+				"en",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,88 @@
+package net
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fxtester/internal/db"
+	"fxtester/internal/lang"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ApiTokenPrefix 個人用APIトークンの接頭辞 (シークレットスキャン等でトークンを判別できるようにする)
+const ApiTokenPrefix = "fxt_"
+
+// apiTokenDisplayLength 一覧でトークンを見分けるために保存する先頭の文字数 (接頭辞を含む)
+const apiTokenDisplayLength = len(ApiTokenPrefix) + 4
+
+// ExtensionScopes 個人用APIトークンで呼び出すために必要なスコープを指定するOpenAPI定義の拡張プロパティ。
+// いずれかのスコープを持つトークンのみ呼び出せる (未指定の操作はトークンで呼び出せない)
+const ExtensionScopes = "x-scopes"
+
+// GenerateApiToken 個人用APIトークンを生成し、トークンと保存用のハッシュ値・先頭の数文字を返却する
+func GenerateApiToken() (token, tokenHash, tokenPrefix string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	token = ApiTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, HashApiToken(token), token[:apiTokenDisplayLength], nil
+}
+
+// HashApiToken 個人用APIトークンのSHA-256を16進数で返却する
+func HashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// bearerToken AuthorizationヘッダのBearerトークンを返却する。指定されていない場合はfalseを返却する
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get(echo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// authenticateApiToken 個人用APIトークンを検証し、所有ユーザのログインユーザとして返却する
+func authenticateApiToken(token string, dao db.IUserEntityDao) (*AuthSessionPayload, error) {
+	if !strings.HasPrefix(token, ApiTokenPrefix) {
+		return nil, lang.NewFxtError(lang.ErrUnauthorized)
+	}
+
+	apiToken, user, err := dao.FindApiToken(HashApiToken(token))
+	if errors.Is(err, db.ErrNoData) {
+		// 存在しない・失効済み・有効期限切れのトークンの場合
+		return nil, lang.NewFxtError(lang.ErrUnauthorized).SetCause(err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &AuthSessionPayload{
+		UserId:     user.UserId,
+		Email:      user.Email,
+		Roles:      user.Roles,
+		ApiTokenId: apiToken.TokenId,
+		Scopes:     apiToken.Scopes,
+	}, nil
+}
+
+// hasAnyScope ログインユーザが操作に必要ないずれかのスコープを持つか返却する。
+// Cookieで認証したユーザはスコープによる制限を受けない
+func (p *AuthSessionPayload) hasAnyScope(scopes []string) bool {
+	if p.ApiTokenId == "" {
+		return true
+	}
+	for _, scope := range scopes {
+		if slices.Contains(p.Scopes, scope) {
+			return true
+		}
+	}
+	return false
+}
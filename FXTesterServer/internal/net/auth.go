@@ -0,0 +1,149 @@
+package net
+
+import (
+	"errors"
+	"fxtester/internal/audit"
+	"fxtester/internal/db"
+	"fxtester/internal/lang"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// ContextKeyAuthSession 認証ミドルウェアがログインユーザを設定するコンテキストのキー
+const ContextKeyAuthSession = "authSession"
+
+// NewAuthMiddleware access_tokenのCookie、またはAuthorizationヘッダの個人用APIトークンを検証し、
+// ログインユーザとその設定をコンテキストに設定するミドルウェアを作成する。
+// OpenAPI定義で security: [] が指定されたエンドポイント (および未定義のルート) は検証しない。
+// x-rolesが指定されたエンドポイントは、いずれかのロールを持たないユーザの場合は権限エラーとする。
+// 個人用APIトークンの場合は、x-scopesのいずれかのスコープを持たないトークンの場合も権限エラーとする。
+// 資格情報(access_tokenのCookie・Bearerトークン)を伴うリクエストの認証・認可の失敗はrecorderに監査イベントとして記録する
+func NewAuthMiddleware(spec *openapi3.T, newUserDao func() db.IUserEntityDao, recorder *audit.Recorder) echo.MiddlewareFunc {
+	secured := securedRoutes(spec)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			route, ok := secured[routeKey(ctx.Request().Method, ctx.Path())]
+			if !ok {
+				return next(ctx)
+			}
+
+			// Bearerトークンが指定されている場合はCookieより優先する
+			dao := newUserDao()
+			var session *AuthSessionPayload
+			var err error
+			token, hasCredential := bearerToken(ctx.Request())
+			if hasCredential {
+				session, err = authenticateApiToken(token, dao)
+			} else {
+				_, cookieErr := ctx.Request().Cookie(NameAccessToken)
+				hasCredential = cookieErr == nil
+				session, err = authenticate(ctx.Request(), dao)
+			}
+			if err != nil {
+				// 資格情報の無いリクエスト (未ログインのアクセス・クローラ等) は大量に発生し得るため記録しない
+				if hasCredential {
+					recorder.Record(ctx, audit.Event{Type: audit.EventAccess, Err: err})
+				}
+				return err
+			}
+			// 権限エラーのメッセージにも設定のロケールを使用するため、ロールの検証より先に読み込む
+			if err := loadUserPreferences(ctx, dao, session.UserId); err != nil {
+				return err
+			}
+			if !session.hasAnyRole(route.roles) || !session.hasAnyScope(route.scopes) {
+				err := lang.NewFxtError(lang.ErrForbidden)
+				recorder.Record(ctx, audit.Event{Type: audit.EventAccess, UserId: session.UserId, Email: session.Email, Err: err})
+				return err
+			}
+			ctx.Set(ContextKeyAuthSession, session)
+			return next(ctx)
+		}
+	}
+}
+
+// GetAuthSession 認証ミドルウェアが設定したログインユーザを返却する
+func GetAuthSession(ctx echo.Context) (*AuthSessionPayload, error) {
+	session, ok := ctx.Get(ContextKeyAuthSession).(*AuthSessionPayload)
+	if !ok || session == nil {
+		return nil, lang.NewFxtError(lang.ErrUnauthorized)
+	}
+	return session, nil
+}
+
+// authenticate access_tokenの署名と有効期限を検証し、ログインセッションのアクセストークンと一致するかチェックする
+func authenticate(r *http.Request, dao db.IUserEntityDao) (*AuthSessionPayload, error) {
+	session, err := GetAuthSessionAccessToken(r)
+	if err != nil {
+		// レスポンスには原因となったFxtErrorが使用されるため、FxtErrorの原因のみを設定する
+		return nil, lang.NewFxtError(lang.ErrUnauthorized).SetCause(errors.Unwrap(err))
+	}
+
+	// 署名済みのトークンでもログアウト・セッションの失効で無効化されている場合がある
+	cookie, err := r.Cookie(NameAccessToken)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrUnauthorized).SetCause(err)
+	}
+	if err := dao.CheckAccessToken(session.SessionId, session.UserId, cookie.Value); errors.Is(err, db.ErrTokenMismatch) {
+		return nil, lang.NewFxtError(lang.ErrUnauthorized).SetCause(err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// routeRequirement ルート毎に必要なロールとスコープ
+type routeRequirement struct {
+	roles  []string
+	scopes []string
+}
+
+// securedRoutes OpenAPI定義から認証が必要なルートと、ルート毎に必要なロール・スコープの一覧を作成する。
+// 操作毎のsecurityを優先し、未指定の場合は全体のsecurityに従う
+func securedRoutes(spec *openapi3.T) map[string]routeRequirement {
+	routes := map[string]routeRequirement{}
+	for path, item := range spec.Paths.Map() {
+		for method, op := range item.Operations() {
+			requirements := spec.Security
+			if op.Security != nil {
+				requirements = *op.Security
+			}
+			if len(requirements) <= 0 {
+				continue
+			}
+			routes[routeKey(method, toEchoPath(path))] = routeRequirement{
+				roles:  extensionValues(op, ExtensionRoles),
+				scopes: extensionValues(op, ExtensionScopes),
+			}
+		}
+	}
+	return routes
+}
+
+// extensionValues 操作の拡張プロパティ (x-roles, x-scopes) に指定された文字列の一覧を返却する (未指定の場合はnil)
+func extensionValues(op *openapi3.Operation, name string) []string {
+	values, ok := op.Extensions[name].([]any)
+	if !ok {
+		return nil
+	}
+	strs := []string{}
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// toEchoPath OpenAPIのパス (e.g. /backtests/{id}) をEchoのルートのパス (e.g. /backtests/:id) に変換する
+func toEchoPath(path string) string {
+	return strings.NewReplacer("{", ":", "}", "").Replace(path)
+}
@@ -0,0 +1,385 @@
+package net
+
+import (
+	"bytes"
+	"database/sql"
+	"fxtester/internal/audit"
+	"fxtester/internal/db"
+	"fxtester/internal/lang"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type MockDB struct {
+	db *sql.DB
+}
+
+func (m *MockDB) Init() error {
+	return nil
+}
+
+func (m *MockDB) GetDB() *sql.DB {
+	return m.db
+}
+
+const testSpec = `
+openapi: 3.0.0
+info:
+  title: test
+  version: 1.0.0
+security:
+  - cookieAuth: []
+  - bearerAuth: []
+components:
+  securitySchemes:
+    cookieAuth:
+      type: apiKey
+      in: cookie
+      name: access_token
+    bearerAuth:
+      type: http
+      scheme: bearer
+paths:
+  /zigzag:
+    post:
+      x-scopes:
+        - analysis
+      responses:
+        '200':
+          description: ok
+  /backtests/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: ok
+  /admin/users:
+    get:
+      x-roles:
+        - admin
+      responses:
+        '200':
+          description: ok
+  /saml/login:
+    get:
+      security: []
+      responses:
+        '200':
+          description: ok
+`
+
+func Test_NewAuthMiddleware(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+
+	validToken, err := GenerateToken(AuthSessionPayload{UserId: 1, Email: "test@fxtester.com", SessionId: "session1"}, time.Now().Add(time.Hour), AccessTokenKeys)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	adminToken, err := GenerateToken(AuthSessionPayload{UserId: 2, Email: "admin@fxtester.com", SessionId: "session2", Roles: []string{RoleAdmin}}, time.Now().Add(time.Hour), AccessTokenKeys)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	expiredToken, err := GenerateToken(AuthSessionPayload{UserId: 1, Email: "test@fxtester.com", SessionId: "session1"}, time.Now().Add(-time.Hour), AccessTokenKeys)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	checkQuery := regexp.QuoteMeta("select fxtester_schema.check_session_access_token($1, $2, $3)")
+	preferencesQuery := regexp.QuoteMeta("select fxtester_schema.select_user_preferences($1)")
+	preferencesRows := func(preferences any) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"select_user_preferences"}).AddRow(preferences)
+	}
+	apiToken, apiTokenHash, _, err := GenerateApiToken()
+	if err != nil {
+		t.Fatalf("failed to generate api token: %v", err)
+	}
+	findApiTokenQuery := regexp.QuoteMeta("from fxtester_schema.find_api_token($1)")
+	apiTokenRows := func(scopes string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "user_id", "email", "roles", "scopes"}).
+			AddRow("token1", 3, "ci@fxtester.com", "{}", scopes)
+	}
+
+	tests := []struct {
+		name string
+		// リクエストのメソッドとEchoのルートのパス
+		method, path string
+		// access_tokenのCookie (空文字の場合は付与しない)
+		token string
+		// AuthorizationヘッダのBearerトークン (空文字の場合は付与しない)
+		bearer string
+		// DBのモックの設定 (nilの場合はクエリを実行しない想定)
+		expect   func(mock sqlmock.Sqlmock)
+		wantCode lang.ErrorCode
+		wantUser int64
+		// コンテキストに設定される設定のロケール (空文字の場合は設定されない想定)
+		wantLocale string
+		// 監査イベントを記録するか (資格情報を伴う認証・認可の失敗のみ記録する)
+		wantAudit bool
+	}{
+		{
+			name:   "認証不要なエンドポイント",
+			method: http.MethodGet,
+			path:   "/saml/login",
+		},
+		{
+			name:   "OpenAPI定義に無いルート",
+			method: http.MethodGet,
+			path:   "/unknown",
+		},
+		{
+			name:     "Cookieなし",
+			method:   http.MethodPost,
+			path:     "/zigzag",
+			wantCode: lang.ErrUnauthorized,
+		},
+		{
+			name:      "不正なトークン",
+			method:    http.MethodPost,
+			path:      "/zigzag",
+			token:     "invalid",
+			wantCode:  lang.ErrUnauthorized,
+			wantAudit: true,
+		},
+		{
+			name:      "有効期限切れのトークン",
+			method:    http.MethodPost,
+			path:      "/zigzag",
+			token:     expiredToken,
+			wantCode:  lang.ErrUnauthorized,
+			wantAudit: true,
+		},
+		{
+			name:   "DBのトークンと不一致",
+			method: http.MethodPost,
+			path:   "/zigzag",
+			token:  validToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, validToken).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_access_token"}).AddRow(false))
+			},
+			wantCode:  lang.ErrUnauthorized,
+			wantAudit: true,
+		},
+		{
+			name:   "DBエラー",
+			method: http.MethodPost,
+			path:   "/zigzag",
+			token:  validToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, validToken).WillReturnError(sql.ErrConnDone)
+			},
+			wantCode:  lang.ErrDBQuery,
+			wantAudit: true,
+		},
+		{
+			name:   "パスパラメータを含むルート",
+			method: http.MethodGet,
+			path:   "/backtests/:id",
+			token:  validToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, validToken).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_access_token"}).AddRow(true))
+				mock.ExpectQuery(preferencesQuery).WithArgs(1).WillReturnRows(preferencesRows([]byte("{}")))
+			},
+			wantUser: 1,
+		},
+		{
+			name:   "ロールを持たないユーザ",
+			method: http.MethodGet,
+			path:   "/admin/users",
+			token:  validToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, validToken).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_access_token"}).AddRow(true))
+				mock.ExpectQuery(preferencesQuery).WithArgs(1).WillReturnRows(preferencesRows([]byte("{}")))
+			},
+			wantCode:  lang.ErrForbidden,
+			wantAudit: true,
+		},
+		{
+			name:   "ロールを持つユーザ",
+			method: http.MethodGet,
+			path:   "/admin/users",
+			token:  adminToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkQuery).WithArgs("session2", 2, adminToken).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_access_token"}).AddRow(true))
+				mock.ExpectQuery(preferencesQuery).WithArgs(2).WillReturnRows(preferencesRows([]byte("{}")))
+			},
+			wantUser: 2,
+		},
+		{
+			name:   "ロケールを設定したユーザ",
+			method: http.MethodPost,
+			path:   "/zigzag",
+			token:  validToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, validToken).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_access_token"}).AddRow(true))
+				mock.ExpectQuery(preferencesQuery).WithArgs(1).WillReturnRows(preferencesRows([]byte(`{"locale": "en", "timezone": "UTC"}`)))
+			},
+			wantUser:   1,
+			wantLocale: "en",
+		},
+		{
+			name:   "削除済みのユーザ",
+			method: http.MethodPost,
+			path:   "/zigzag",
+			token:  validToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, validToken).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_access_token"}).AddRow(true))
+				mock.ExpectQuery(preferencesQuery).WithArgs(1).WillReturnRows(preferencesRows(nil))
+			},
+			wantCode: lang.ErrUnauthorized,
+		},
+		{
+			name:   "スコープを持つAPIトークン",
+			method: http.MethodPost,
+			path:   "/zigzag",
+			bearer: apiToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(findApiTokenQuery).WithArgs(apiTokenHash).WillReturnRows(apiTokenRows("{analysis}"))
+				mock.ExpectQuery(preferencesQuery).WithArgs(3).WillReturnRows(preferencesRows([]byte("{}")))
+			},
+			wantUser: 3,
+		},
+		{
+			name:   "スコープを持たないAPIトークン",
+			method: http.MethodPost,
+			path:   "/zigzag",
+			bearer: apiToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(findApiTokenQuery).WithArgs(apiTokenHash).WillReturnRows(apiTokenRows("{backtests:read}"))
+				mock.ExpectQuery(preferencesQuery).WithArgs(3).WillReturnRows(preferencesRows([]byte("{}")))
+			},
+			wantCode:  lang.ErrForbidden,
+			wantAudit: true,
+		},
+		{
+			name:   "x-scopesが無いルートへのAPIトークン",
+			method: http.MethodGet,
+			path:   "/backtests/:id",
+			bearer: apiToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(findApiTokenQuery).WithArgs(apiTokenHash).WillReturnRows(apiTokenRows("{analysis}"))
+				mock.ExpectQuery(preferencesQuery).WithArgs(3).WillReturnRows(preferencesRows([]byte("{}")))
+			},
+			wantCode:  lang.ErrForbidden,
+			wantAudit: true,
+		},
+		{
+			name:   "失効・期限切れのAPIトークン",
+			method: http.MethodPost,
+			path:   "/zigzag",
+			bearer: apiToken,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(findApiTokenQuery).WithArgs(apiTokenHash).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "email", "roles", "scopes"}))
+			},
+			wantCode:  lang.ErrUnauthorized,
+			wantAudit: true,
+		},
+		{
+			name:      "接頭辞の無いBearerトークン",
+			method:    http.MethodPost,
+			path:      "/zigzag",
+			bearer:    validToken,
+			wantCode:  lang.ErrUnauthorized,
+			wantAudit: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed sqlmock.New(): %v", err)
+			}
+			defer mockDB.Close()
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+			if tt.wantAudit {
+				mock.ExpectQuery(regexp.QuoteMeta("call fxtester_schema.insert_audit_event($1, $2, $3, $4, $5, $6, $7, $8, $9)")).
+					WithArgs(audit.EventAccess, audit.OutcomeFailure, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(tt.wantCode), "").
+					WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			idb := &MockDB{db: mockDB}
+
+			req := httptest.NewRequest(tt.method, "https://localhost:8100", nil)
+			if tt.token != "" {
+				req.AddCookie(&http.Cookie{Name: NameAccessToken, Value: tt.token})
+			}
+			if tt.bearer != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+tt.bearer)
+			}
+			e := echo.New()
+			// 想定外の監査イベントの記録はDBのモックのエラーとしてログに出力される
+			var logs bytes.Buffer
+			e.Logger.SetOutput(&logs)
+			e.Logger.SetLevel(log.WARN)
+			ctx := e.NewContext(req, httptest.NewRecorder())
+			ctx.SetPath(tt.path)
+
+			called := false
+			middleware := NewAuthMiddleware(spec, func() db.IUserEntityDao {
+				return db.NewUserEntityDao(idb)
+			}, audit.NewRecorder(idb))
+			err = middleware(func(ctx echo.Context) error {
+				called = true
+				return nil
+			})(ctx)
+
+			if tt.wantCode != 0 {
+				fxtErr := lang.FindFxtError(err)
+				if fxtErr == nil || fxtErr.ErrCode != tt.wantCode {
+					t.Errorf("middleware()=%v want=0x%x", err, tt.wantCode)
+				}
+				if called {
+					t.Errorf("next handler is called")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("middleware()=%v", err)
+				}
+				if !called {
+					t.Errorf("next handler is not called")
+				}
+			}
+
+			if tt.wantUser != 0 {
+				session, err := GetAuthSession(ctx)
+				if err != nil || session.UserId != tt.wantUser {
+					t.Errorf("GetAuthSession()=(%v, %v) want UserId=%v", session, err, tt.wantUser)
+				}
+			}
+			if locales := lang.GetLocales(ctx); tt.wantLocale != "" && !slices.Equal(locales, []string{tt.wantLocale}) {
+				t.Errorf("GetLocales()=%v want=%v", locales, tt.wantLocale)
+			}
+			if strings.Contains(logs.String(), "failed to record the audit event") {
+				t.Errorf("unexpected audit event: %s", logs.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("ExpectationsWereMet()=%v", err)
+			}
+		})
+	}
+}
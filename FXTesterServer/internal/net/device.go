@@ -0,0 +1,47 @@
+package net
+
+import "strings"
+
+// ブラウザ・OSの判定に使用するUser-Agentのトークン (判定の優先順)
+var (
+	userAgentBrowsers = []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"CriOS/", "Chrome"},
+		{"Safari/", "Safari"},
+	}
+	userAgentPlatforms = []struct{ token, name string }{
+		{"Windows", "Windows"},
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Android", "Android"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// DescribeDevice User-Agentからセッション一覧に表示する端末の説明 (e.g. Chrome on Windows) を作成する。
+// 判定できない場合は空文字を返却する
+func DescribeDevice(userAgent string) string {
+	browser := findUserAgentToken(userAgent, userAgentBrowsers)
+	platform := findUserAgentToken(userAgent, userAgentPlatforms)
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	default:
+		return platform
+	}
+}
+
+func findUserAgentToken(userAgent string, tokens []struct{ token, name string }) string {
+	for _, t := range tokens {
+		if strings.Contains(userAgent, t.token) {
+			return t.name
+		}
+	}
+	return ""
+}
@@ -2,15 +2,49 @@ package net
 
 import (
 	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fxtester/internal/lang"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 )
 
+var (
+	ErrNoSigningKey  = errors.New("jwt: no signing key")
+	ErrUnknownKeyId  = errors.New("jwt: unknown kid")
+	ErrSigningMethod = errors.New("jwt: unexpected signing method")
+)
+
+// 署名鍵の用途
+const (
+	PurposeAccessToken  = "access"
+	PurposeRefreshToken = "refresh"
+	PurposeSSOSession   = "sso"
+	PurposeSLOSession   = "slo"
+	PurposeSAMLError    = "saml_error"
+	PurposeOIDCSession  = "oidc"
+)
+
+// 用途毎の署名鍵。起動直後はプロセス内のみで有効な鍵を持ち、KeyStoreがDBの鍵に置き換える
+var (
+	AccessTokenKeys      = NewKeyRing(PurposeAccessToken)
+	RefreshTokenKeys     = NewKeyRing(PurposeRefreshToken)
+	SSOSessionKeys       = NewKeyRing(PurposeSSOSession)
+	SLOSessionKeys       = NewKeyRing(PurposeSLOSession)
+	SAMLErrorSessionKeys = NewKeyRing(PurposeSAMLError)
+	OIDCSessionKeys      = NewKeyRing(PurposeOIDCSession)
+	keyRings             = []*KeyRing{AccessTokenKeys, RefreshTokenKeys, SSOSessionKeys, SLOSessionKeys, SAMLErrorSessionKeys, OIDCSessionKeys}
+)
+
+// 署名鍵の長さ[byte] (HS256のハッシュ長以上とする)
+const signingKeySecretBytes = 32
+
+// makeSecret ランダムな署名鍵を作成する
 func makeSecret() []byte {
-	rv := make([]byte, 20)
+	rv := make([]byte, signingKeySecretBytes)
 
 	if _, err := io.ReadFull(rand.Reader, rv); err != nil {
 		panic(err)
@@ -18,11 +52,128 @@ func makeSecret() []byte {
 	return rv
 }
 
-var AccessTokenSecret = makeSecret()      // TODO: スケーラブルに問題が出ないように別コマンド化、ファイルに保存？
-var RefreshTokenSecret = makeSecret()     // TODO: スケーラブルに問題が出ないように別コマンド化、ファイルに保存？
-var SSOSessionSecret = makeSecret()       // TODO: スケーラブルに問題が出ないように別コマンド化、ファイルに保存？
-var SLOSessionSecret = makeSecret()       // TODO: スケーラブルに問題が出ないように別コマンド化、ファイルに保存？
-var SAMLErrorSessionSecret = makeSecret() // TODO: スケーラブルに問題が出ないように別コマンド化、ファイルに保存？
+// makeKeyId ランダムな鍵ID(kid)を作成する
+func makeKeyId() string {
+	rv := make([]byte, 8)
+
+	if _, err := io.ReadFull(rand.Reader, rv); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(rv)
+}
+
+// makeTokenId トークンを一意に識別するID(jti)を生成する
+func makeTokenId() (string, error) {
+	rv := make([]byte, 16)
+
+	if _, err := io.ReadFull(rand.Reader, rv); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(rv), nil
+}
+
+// SigningKey JWTの署名鍵
+type SigningKey struct {
+	Kid    string
+	Secret []byte
+	// 退役日時 (nilの場合は署名に使用する現在の鍵)
+	RetiredAt *time.Time
+}
+
+// KeyRing 用途毎の署名鍵の集合。署名には現在の鍵を使用し、検証はJWTヘッダのkidで鍵を選択する。
+// 退役した鍵は猶予期間中のみ検証に使用する
+type KeyRing struct {
+	purpose     string
+	mu          sync.RWMutex
+	keys        []SigningKey
+	gracePeriod time.Duration
+
+	// 未知のkidの場合に鍵を再読み込みする関数 (nilの場合は再読み込みしない)
+	reload         func() error
+	reloadMu       sync.Mutex
+	reloadInterval time.Duration
+	lastReload     time.Time
+}
+
+// NewKeyRing プロセス内のみで有効な鍵を1つ持つKeyRingを作成する
+func NewKeyRing(purpose string) *KeyRing {
+	return &KeyRing{
+		purpose: purpose,
+		keys:    []SigningKey{{Kid: makeKeyId(), Secret: makeSecret()}},
+	}
+}
+
+// Purpose 署名鍵の用途を返却する
+func (k *KeyRing) Purpose() string {
+	return k.purpose
+}
+
+// SetKeys 署名鍵を置き換える。keysは作成日時の昇順とし、現在の鍵は退役していない最後の鍵とする
+func (k *KeyRing) SetKeys(keys []SigningKey, gracePeriod time.Duration) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys
+	k.gracePeriod = gracePeriod
+}
+
+// SetReloader 未知のkidで検証する場合に鍵を再読み込みする関数を設定する。
+// 他のサーバがローテーションした鍵を定期的な再読み込みの前に検証できるようにするため、再読み込みはinterval毎に最大1回とする
+func (k *KeyRing) SetReloader(reload func() error, interval time.Duration) {
+	k.reloadMu.Lock()
+	defer k.reloadMu.Unlock()
+	k.reload = reload
+	k.reloadInterval = interval
+}
+
+// signingKey 署名に使用する現在の鍵を返却する
+func (k *KeyRing) signingKey() (SigningKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for i := len(k.keys) - 1; 0 <= i; i-- {
+		if k.keys[i].RetiredAt == nil {
+			return k.keys[i], nil
+		}
+	}
+	return SigningKey{}, ErrNoSigningKey
+}
+
+// verificationKey kidに対応する検証用の鍵を返却する。猶予期間を過ぎた退役済みの鍵は返却しない。
+// 未知のkidの場合は鍵を再読み込みしてから再度検索する
+func (k *KeyRing) verificationKey(kid string, now time.Time) ([]byte, error) {
+	secret, found, err := k.findKey(kid, now)
+	if found || !k.reloadUnknownKey(now) {
+		return secret, err
+	}
+	secret, _, err = k.findKey(kid, now)
+	return secret, err
+}
+
+// findKey kidに対応する検証用の鍵を返却する。kidの鍵を持つ場合はfoundをtrueとする
+func (k *KeyRing) findKey(kid string, now time.Time) (secret []byte, found bool, err error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for _, key := range k.keys {
+		if key.Kid != kid {
+			continue
+		}
+		if key.RetiredAt != nil && key.RetiredAt.Add(k.gracePeriod).Before(now) {
+			return nil, true, ErrUnknownKeyId
+		}
+		return key.Secret, true, nil
+	}
+	return nil, false, ErrUnknownKeyId
+}
+
+// reloadUnknownKey 前回の再読み込みから間隔を空けている場合のみ鍵を再読み込みする。再読み込みした場合はtrueを返却する
+func (k *KeyRing) reloadUnknownKey(now time.Time) bool {
+	k.reloadMu.Lock()
+	defer k.reloadMu.Unlock()
+	if k.reload == nil || now.Before(k.lastReload.Add(k.reloadInterval)) {
+		return false
+	}
+	k.lastReload = now
+	return k.reload() == nil
+}
 
 // Claims クレーム (JWTのペイロード部分)
 type Claims[T any] struct {
@@ -30,16 +181,29 @@ type Claims[T any] struct {
 	jwt.StandardClaims
 }
 
-func GenerateToken[T any](value T, expires time.Time, jwtKey []byte) (string, error) {
+// GenerateToken 署名したトークンを発行する。
+// 同じ値を同じ秒に発行した場合もトークンが一致しないよう、ランダムなjtiを設定する (リフレッシュトークンの再利用の検知に必要)
+func GenerateToken[T any](value T, expires time.Time, keys *KeyRing) (string, error) {
+	key, err := keys.signingKey()
+	if err != nil {
+		return "", lang.NewFxtError(lang.ErrJWTSign).SetCause(err)
+	}
+	tokenId, err := makeTokenId()
+	if err != nil {
+		return "", lang.NewFxtError(lang.ErrJWTSign).SetCause(err)
+	}
+
 	claims := &Claims[T]{
 		Value: value,
 		StandardClaims: jwt.StandardClaims{
+			Id:        tokenId,
 			ExpiresAt: expires.UTC().Unix(),
 			IssuedAt:  time.Now().UTC().Unix(),
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
+	token.Header["kid"] = key.Kid
+	tokenString, err := token.SignedString(key.Secret)
 	if err != nil {
 		return "", lang.NewFxtError(lang.ErrJWTSign).SetCause(err)
 	}
@@ -47,10 +211,14 @@ func GenerateToken[T any](value T, expires time.Time, jwtKey []byte) (string, er
 	return tokenString, nil
 }
 
-func VerifyToken[T any](tokenStr string, secret []byte) (*Claims[T], error) {
+func VerifyToken[T any](tokenStr string, keys *KeyRing) (*Claims[T], error) {
 	claims := &Claims[T]{}
 	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-		return secret, nil
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrSigningMethod
+		}
+		kid, _ := token.Header["kid"].(string)
+		return keys.verificationKey(kid, time.Now())
 	})
 
 	if err != nil {
@@ -0,0 +1,196 @@
+package net
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang-jwt/jwt"
+)
+
+func Test_KeyRing(t *testing.T) {
+	now := time.Now()
+	retiredRecently := now.Add(-time.Hour)
+	retiredLongAgo := now.Add(-48 * time.Hour)
+
+	oldKeys := NewKeyRing(PurposeAccessToken)
+	oldToken, err := GenerateToken("old", now.Add(time.Hour), oldKeys)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	oldKey, _ := oldKeys.signingKey()
+
+	tests := []struct {
+		name string
+		// 旧鍵の退役日時 (nilの場合は旧鍵を持たない)
+		retiredAt *time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "猶予期間中の退役済みの鍵",
+			retiredAt: &retiredRecently,
+		},
+		{
+			name:      "猶予期間を過ぎた退役済みの鍵",
+			retiredAt: &retiredLongAgo,
+			wantErr:   true,
+		},
+		{
+			name:    "未知のkid",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := SigningKey{Kid: makeKeyId(), Secret: makeSecret()}
+			keys := []SigningKey{current}
+			if tt.retiredAt != nil {
+				keys = []SigningKey{{Kid: oldKey.Kid, Secret: oldKey.Secret, RetiredAt: tt.retiredAt}, current}
+			}
+			ring := NewKeyRing(PurposeAccessToken)
+			ring.SetKeys(keys, 24*time.Hour)
+
+			// 新しいトークンは現在の鍵で署名される
+			token, err := GenerateToken("new", now.Add(time.Hour), ring)
+			if err != nil {
+				t.Fatalf("failed to generate token: %v", err)
+			}
+			parsed, _, err := new(jwt.Parser).ParseUnverified(token, &Claims[string]{})
+			if err != nil || parsed.Header["kid"] != current.Kid {
+				t.Errorf("kid=%v want=%v", parsed.Header["kid"], current.Kid)
+			}
+			if _, err := VerifyToken[string](token, ring); err != nil {
+				t.Errorf("VerifyToken(new)=%v", err)
+			}
+
+			_, err = VerifyToken[string](oldToken, ring)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyToken(old)=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_KeyStore_Reload(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed sqlmock.New(): %v", err)
+	}
+	defer mockDB.Close()
+
+	retiredAt := time.Now().Add(-time.Hour)
+	createdAt := time.Now().Add(-2 * time.Hour)
+	columns := []string{"kid", "purpose", "secret", "created_at", "retired_at"}
+	mock.ExpectQuery(regexp.QuoteMeta("from fxtester_schema.select_jwt_signing_keys()")).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("old", PurposeAccessToken, []byte("old-secret"), createdAt, retiredAt).
+			AddRow("current", PurposeAccessToken, []byte("current-secret"), createdAt, nil))
+	mock.ExpectQuery(regexp.QuoteMeta("from fxtester_schema.select_jwt_signing_keys()")).
+		WillReturnError(errors.New("db error"))
+
+	access := NewKeyRing(PurposeAccessToken)
+	refresh := NewKeyRing(PurposeRefreshToken)
+	refreshKey, _ := refresh.signingKey()
+	store := NewKeyStore(&MockDB{db: mockDB})
+	store.rings = []*KeyRing{access, refresh}
+
+	if err := store.Reload(); err != nil {
+		t.Fatalf("Reload()=%v", err)
+	}
+	if key, err := access.signingKey(); err != nil || key.Kid != "current" {
+		t.Errorf("signingKey()=(%v, %v) want=current", key.Kid, err)
+	}
+	if _, err := access.verificationKey("old", time.Now()); err != nil {
+		t.Errorf("verificationKey(old)=%v", err)
+	}
+	// DBに鍵が無い用途はプロセス内の鍵を使用し続ける
+	if key, err := refresh.signingKey(); err != nil || key.Kid != refreshKey.Kid {
+		t.Errorf("signingKey()=(%v, %v) want=%v", key.Kid, err, refreshKey.Kid)
+	}
+
+	// 再読み込みに失敗した場合は読み込み済みの鍵を維持する
+	if err := store.Reload(); err == nil {
+		t.Errorf("Reload() want error")
+	}
+	if key, err := access.signingKey(); err != nil || key.Kid != "current" {
+		t.Errorf("signingKey()=(%v, %v) want=current", key.Kid, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("ExpectationsWereMet()=%v", err)
+	}
+}
+
+// 他のサーバがローテーションした鍵で署名したトークンは、定期的な再読み込みを待たずに検証できること
+func Test_KeyStore_ReloadUnknownKid(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed sqlmock.New(): %v", err)
+	}
+	defer mockDB.Close()
+
+	createdAt := time.Now().Add(-2 * time.Hour)
+	retiredAt := time.Now()
+	columns := []string{"kid", "purpose", "secret", "created_at", "retired_at"}
+	selectKeys := regexp.QuoteMeta("from fxtester_schema.select_jwt_signing_keys()")
+	beforeRotation := func() *sqlmock.Rows {
+		return sqlmock.NewRows(columns).
+			AddRow("old", PurposeAccessToken, []byte("old-secret"), createdAt, nil)
+	}
+	afterRotation := func() *sqlmock.Rows {
+		return sqlmock.NewRows(columns).
+			AddRow("old", PurposeAccessToken, []byte("old-secret"), createdAt, retiredAt).
+			AddRow("next", PurposeAccessToken, []byte("next-secret"), retiredAt, nil)
+	}
+	// サーバB・サーバAの順に読み込み、サーバAのみローテーション後の鍵を読み込む
+	mock.ExpectQuery(selectKeys).WillReturnRows(beforeRotation())
+	mock.ExpectQuery(selectKeys).WillReturnRows(afterRotation())
+	// サーバBが未知のkidで検証する際の再読み込み
+	mock.ExpectQuery(selectKeys).WillReturnRows(afterRotation())
+
+	newStore := func() (*KeyStore, *KeyRing) {
+		ring := NewKeyRing(PurposeAccessToken)
+		store := NewKeyStore(&MockDB{db: mockDB})
+		store.rings = []*KeyRing{ring}
+		store.unknownKidReloadInterval = time.Minute
+		return store, ring
+	}
+	storeB, ringB := newStore()
+	if err := storeB.Reload(); err != nil {
+		t.Fatalf("Reload()=%v", err)
+	}
+	ringB.SetReloader(storeB.Reload, storeB.unknownKidReloadInterval)
+	storeA, ringA := newStore()
+	if err := storeA.Reload(); err != nil {
+		t.Fatalf("Reload()=%v", err)
+	}
+
+	token, err := GenerateToken("value", time.Now().Add(time.Hour), ringA)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	claims, err := VerifyToken[string](token, ringB)
+	if err != nil || claims.Value != "value" {
+		t.Fatalf("VerifyToken()=(%v, %v)", claims, err)
+	}
+	if key, err := ringB.signingKey(); err != nil || key.Kid != "next" {
+		t.Errorf("signingKey()=(%v, %v) want=next", key.Kid, err)
+	}
+
+	// 間隔内の未知のkidは再読み込みせずに拒否する
+	unknown := NewKeyRing(PurposeAccessToken)
+	unknownToken, err := GenerateToken("value", time.Now().Add(time.Hour), unknown)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	if _, err := VerifyToken[string](unknownToken, ringB); err == nil {
+		t.Errorf("VerifyToken(unknown) want error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("ExpectationsWereMet()=%v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package net
+
+import (
+	"context"
+	"fxtester/internal/common"
+	"fxtester/internal/db"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// KeyStore DBに保存したJWTの署名鍵を管理する。
+// 署名鍵を複数のサーバで共有するため、ローテーションはDBで排他し、各サーバは定期的にDBから鍵を再読み込みする
+type KeyStore struct {
+	newDao func() db.IJwtSigningKeyEntityDao
+	rings  []*KeyRing
+	// 自動ローテーションの間隔 (0の場合は自動でローテーションしない)
+	rotationInterval time.Duration
+	// 退役した鍵で検証を許可する期間
+	gracePeriod time.Duration
+	// DBから鍵を再読み込みする間隔
+	reloadInterval time.Duration
+	// 未知のkidで検証する場合にDBから鍵を再読み込みする最短の間隔
+	unknownKidReloadInterval time.Duration
+}
+
+// NewKeyStore 設定ファイルのjwtの設定に従うKeyStoreを作成する
+func NewKeyStore(idb db.IDB) *KeyStore {
+	config := common.GetConfig().Jwt
+	return &KeyStore{
+		newDao: func() db.IJwtSigningKeyEntityDao {
+			return db.NewJwtSigningKeyEntityDao(idb)
+		},
+		rings:                    keyRings,
+		rotationInterval:         time.Duration(config.RotationIntervalHours) * time.Hour,
+		gracePeriod:              time.Duration(config.GracePeriodHours) * time.Hour,
+		reloadInterval:           time.Duration(config.ReloadIntervalSec) * time.Second,
+		unknownKidReloadInterval: time.Duration(config.UnknownKidReloadIntervalSec) * time.Second,
+	}
+}
+
+// Init 署名鍵が無い用途の鍵を作成し、DBの署名鍵を読み込む。
+// 以降は未知のkidで検証する場合にもDBから鍵を再読み込みする
+func (s *KeyStore) Init() error {
+	if _, err := s.Generate(); err != nil {
+		return err
+	}
+	if err := s.Reload(); err != nil {
+		return err
+	}
+	for _, ring := range s.rings {
+		ring.SetReloader(s.Reload, s.unknownKidReloadInterval)
+	}
+	return nil
+}
+
+// Start 署名鍵の自動ローテーションと再読み込みを定期的に行う。ctxがキャンセルされるまで処理を続ける
+func (s *KeyStore) Start(ctx context.Context, logger echo.Logger) {
+	if s.reloadInterval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(s.reloadInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.tick(logger); err != nil {
+					// 再読み込みに失敗した場合も読み込み済みの鍵で処理を続ける
+					logger.Errorf("failed to refresh jwt signing keys: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// tick 期限を迎えた署名鍵をローテーションし、猶予期間を過ぎた鍵を削除した上で再読み込みする
+func (s *KeyStore) tick(logger echo.Logger) error {
+	if 0 < s.rotationInterval {
+		for _, ring := range s.rings {
+			rotated, err := s.rotate(ring.Purpose(), &s.rotationInterval)
+			if err != nil {
+				return err
+			}
+			if rotated {
+				logger.Infof("jwt signing key rotated: purpose=%s", ring.Purpose())
+			}
+		}
+	}
+	if _, err := s.Prune(); err != nil {
+		return err
+	}
+	return s.Reload()
+}
+
+// Generate 署名鍵が無い用途の鍵を作成し、作成した用途を返却する
+func (s *KeyStore) Generate() ([]string, error) {
+	created := []string{}
+	for _, ring := range s.rings {
+		rotated, err := s.rotate(ring.Purpose(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if rotated {
+			created = append(created, ring.Purpose())
+		}
+	}
+	return created, nil
+}
+
+// Rotate 指定した用途の署名鍵を直ちにローテーションする。以前の鍵は猶予期間中のみ検証に使用される
+func (s *KeyStore) Rotate(purpose string) error {
+	force := time.Duration(0)
+	_, err := s.rotate(purpose, &force)
+	return err
+}
+
+func (s *KeyStore) rotate(purpose string, minAge *time.Duration) (bool, error) {
+	return s.newDao().RotateKey(purpose, makeKeyId(), makeSecret(), minAge)
+}
+
+// Prune 猶予期間を過ぎた退役済みの署名鍵を削除し、削除した件数を返却する
+func (s *KeyStore) Prune() (int64, error) {
+	return s.newDao().DeleteRetiredKeys(s.gracePeriod)
+}
+
+// Keys DBに保存されている全ての署名鍵を返却する
+func (s *KeyStore) Keys() ([]db.JwtSigningKeyEntity, error) {
+	return s.newDao().SelectKeys()
+}
+
+// Reload DBの署名鍵を読み込み、用途毎のKeyRingに設定する。DBに鍵が無い用途はプロセス内の鍵を使用し続ける
+func (s *KeyStore) Reload() error {
+	entities, err := s.Keys()
+	if err != nil {
+		return err
+	}
+
+	keys := map[string][]SigningKey{}
+	for _, e := range entities {
+		keys[e.Purpose] = append(keys[e.Purpose], SigningKey{
+			Kid:       e.Kid,
+			Secret:    e.Secret,
+			RetiredAt: e.RetiredAt,
+		})
+	}
+	for _, ring := range s.rings {
+		if k, ok := keys[ring.Purpose()]; ok {
+			ring.SetKeys(k, s.gracePeriod)
+		}
+	}
+	return nil
+}
+
+// Purposes 署名鍵の用途の一覧を返却する
+func Purposes() []string {
+	purposes := make([]string, len(keyRings))
+	for i, ring := range keyRings {
+		purposes[i] = ring.Purpose()
+	}
+	return purposes
+}
@@ -0,0 +1,110 @@
+package net
+
+import (
+	"errors"
+	"fxtester/internal/db"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LoginUser idPで認証したユーザをログインさせる (SAML・OpenID Connect共通)。
+// ユーザが存在しない場合は作成し、ロールと属性をidPに同期した上で、新しいログインセッションの認証セッションを作成する。
+// これらは1トランザクションで行う。attributes、idpとsamlSessionIndexはSAMLでログインした場合のみ指定する
+func LoginUser(ctx echo.Context, dao db.IUserEntityDao, email string, roles []string, attributes *db.UserAttributesEntity, idp string, samlSessionIndex string) (lastError error) {
+	// トランザクション開始
+	if err := dao.Begin(); err != nil {
+		return err
+	}
+
+	defer func() {
+		// エラーの有無に応じてRollbackまたはCommitを実行する
+		if lastError != nil {
+			err := dao.Rollback()
+			if err != nil {
+				// ロールバック失敗時は本来のエラーを書き換えないようにlastErrorはそのままにする
+				ctx.Logger().Errorf("failed Rollback: %v", err)
+			}
+		} else {
+			err := dao.Commit()
+			if err != nil {
+				lastError = err
+			}
+		}
+	}()
+
+	// ユーザが存在するか確認する
+	entity, err := dao.SelectWithEmail(email)
+	if errors.Is(err, db.ErrNoData) {
+		// ユーザが存在しない場合はユーザを作成する
+		entity, err = dao.CreateUser(email)
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	// ロールが変わった場合はidPのロールに同期する
+	if !slices.Equal(entity.Roles, roles) {
+		if err := dao.UpdateRoles(entity.UserId, roles); err != nil {
+			return err
+		}
+	}
+
+	// idPの属性をログインの度に同期する (プロビジョニングを解除したユーザは再び有効になる)
+	if attributes != nil {
+		if err := dao.UpdateAttributes(entity.UserId, attributes); err != nil {
+			return err
+		}
+	}
+
+	// 認証セッションを作成する
+	userAgent := ctx.Request().UserAgent()
+	return CreateAuthSession(ctx.Response().Writer, entity.UserId, entity.Email, roles, idp, func(sessionId, accessToken, refreshToken string) error {
+		// ログインセッションをDBに保存 (他の端末のセッションはそのまま残す)
+		return dao.CreateSession(&db.UserSessionEntity{
+			SessionId:        sessionId,
+			UserId:           entity.UserId,
+			AccessToken:      accessToken,
+			RefreshToken:     refreshToken,
+			Device:           DescribeDevice(userAgent),
+			IpAddress:        ctx.RealIP(),
+			UserAgent:        userAgent,
+			SamlSessionIndex: samlSessionIndex,
+		})
+	})
+}
+
+// DeprovisionUser idPで無効化されたユーザのプロビジョニングを解除する (全てのログインセッションと個人用APIトークンを削除する)。
+// ユーザが存在しない場合は何もしない
+func DeprovisionUser(ctx echo.Context, dao db.IUserEntityDao, email string) (lastError error) {
+	// トランザクション開始
+	if err := dao.Begin(); err != nil {
+		return err
+	}
+
+	defer func() {
+		// エラーの有無に応じてRollbackまたはCommitを実行する
+		if lastError != nil {
+			err := dao.Rollback()
+			if err != nil {
+				// ロールバック失敗時は本来のエラーを書き換えないようにlastErrorはそのままにする
+				ctx.Logger().Errorf("failed Rollback: %v", err)
+			}
+		} else {
+			err := dao.Commit()
+			if err != nil {
+				lastError = err
+			}
+		}
+	}()
+
+	entity, err := dao.SelectWithEmail(email)
+	if errors.Is(err, db.ErrNoData) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return dao.DeprovisionUser(entity.UserId)
+}
@@ -0,0 +1,59 @@
+package net
+
+import (
+	"encoding/json"
+	"errors"
+	"fxtester/internal/db"
+	"fxtester/internal/gen"
+	"fxtester/internal/lang"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ContextKeyUserPreferences 認証ミドルウェアがログインユーザの設定を設定するコンテキストのキー
+const ContextKeyUserPreferences = "userPreferences"
+
+// GetUserPreferences 認証ミドルウェアが設定したログインユーザの設定を返却する (未ログインの場合は空の設定)
+func GetUserPreferences(ctx echo.Context) gen.UserPreferences {
+	if preferences, ok := ctx.Get(ContextKeyUserPreferences).(*gen.UserPreferences); ok && preferences != nil {
+		return *preferences
+	}
+	return gen.UserPreferences{}
+}
+
+// GetUserLocation ログインユーザの設定のタイムゾーンを返却する。未設定の場合はサーバのタイムゾーンを返却する
+func GetUserLocation(ctx echo.Context) *time.Location {
+	preferences := GetUserPreferences(ctx)
+	if preferences.Timezone == nil {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(*preferences.Timezone)
+	if err != nil {
+		// 保存時にチェック済みのため、タイムゾーンのデータベースが異なる場合のみ発生する想定
+		return time.Local
+	}
+	return loc
+}
+
+// loadUserPreferences ログインユーザの設定を読み込み、コンテキストに設定する。
+// 設定でロケールが指定されている場合はAccept-Languageより優先させる
+func loadUserPreferences(ctx echo.Context, dao db.IUserEntityDao, userId int64) error {
+	data, err := dao.SelectPreferences(userId)
+	if errors.Is(err, db.ErrNoData) {
+		// トークンの発行後にユーザが削除されている場合
+		return lang.NewFxtError(lang.ErrUnauthorized).SetCause(err)
+	} else if err != nil {
+		return err
+	}
+
+	var preferences gen.UserPreferences
+	if err := json.Unmarshal(data, &preferences); err != nil {
+		return lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	ctx.Set(ContextKeyUserPreferences, &preferences)
+	if preferences.Locale != nil {
+		ctx.Set(lang.ContextKeyLocale, string(*preferences.Locale))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package net
+
+import (
+	"errors"
+	"fxtester/internal/db"
+	"fxtester/internal/lang"
+
+	"github.com/labstack/echo/v4"
+)
+
+var ErrRefreshTokenReused = errors.New("refresh token reused")
+
+// RefreshAuthSession リフレッシュトークンを検証し、アクセストークンとリフレッシュトークンを再発行する (ローテーション)。
+// ローテーション済みのリフレッシュトークンが再利用された場合は漏洩とみなし、そのセッションを失効させる
+func RefreshAuthSession(ctx echo.Context, dao db.IUserEntityDao) error {
+	session, err := GetAuthSessionRefreshToken(ctx.Request())
+	if err != nil {
+		// レスポンスには原因となったFxtErrorが使用されるため、FxtErrorの原因のみを設定する
+		return lang.NewFxtError(lang.ErrUnauthorized).SetCause(errors.Unwrap(err))
+	}
+	cookie, err := ctx.Request().Cookie(NameRefreshToken)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrUnauthorized).SetCause(err)
+	}
+
+	rotated, err := rotateAuthSession(ctx, dao, session, cookie.Value)
+	if fxtErr := lang.FindFxtError(err); fxtErr != nil && fxtErr.ErrCode == lang.ErrAccountDisabled {
+		// 無効化されたユーザはログイン画面に戻す
+		DeleteAuthSession(ctx.Response().Writer)
+		return err
+	} else if err != nil {
+		return err
+	}
+	if !rotated {
+		DeleteAuthSession(ctx.Response().Writer)
+		return lang.NewFxtError(lang.ErrUnauthorized).SetCause(ErrRefreshTokenReused)
+	}
+	return nil
+}
+
+// rotateAuthSession DBのセッションのリフレッシュトークンと一致する場合はトークンを再発行する。
+// 一致しない場合は再利用されたトークンとして扱い、セッションを失効させてfalseを返却する。
+// 検証と再発行は1トランザクションで行うため、同じトークンで同時にリフレッシュされた場合は後続が再利用として扱われる。
+// ロールはログイン後にidPで変更される場合があるため、再発行するトークンにはDBのユーザの最新のロールを設定する
+func rotateAuthSession(ctx echo.Context, dao db.IUserEntityDao, session *AuthSessionPayload, refreshToken string) (rotated bool, lastError error) {
+	// トランザクション開始
+	if err := dao.Begin(); err != nil {
+		return false, err
+	}
+
+	defer func() {
+		// エラーの有無に応じてRollbackまたはCommitを実行する (失効させた場合もCommitする)
+		if lastError != nil {
+			if err := dao.Rollback(); err != nil {
+				ctx.Logger().Errorf("failed Rollback: %v", err)
+			}
+		} else if err := dao.Commit(); err != nil {
+			lastError = err
+		}
+	}()
+
+	err := dao.CheckRefreshToken(session.SessionId, session.UserId, refreshToken)
+	if errors.Is(err, db.ErrNoData) {
+		// ログアウト・失効済みのセッション
+		return false, lang.NewFxtError(lang.ErrUnauthorized).SetCause(err)
+	} else if errors.Is(err, db.ErrTokenMismatch) {
+		ctx.Logger().Warnf("refresh token reuse detected, revoke the auth session: userId=%d", session.UserId)
+		if err := dao.DeleteSession(session.UserId, session.SessionId); err != nil && !errors.Is(err, db.ErrNoData) {
+			return false, err
+		}
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	user, err := dao.SelectWithUserId(session.UserId)
+	if errors.Is(err, db.ErrNoData) {
+		return false, lang.NewFxtError(lang.ErrUnauthorized).SetCause(err)
+	} else if err != nil {
+		return false, err
+	}
+	if user.DisabledAt != nil {
+		// idPで無効化されたユーザ (プロビジョニングの解除時にセッションは削除済み)
+		return false, lang.NewFxtError(lang.ErrAccountDisabled)
+	}
+	session.Roles = user.Roles
+
+	req := ctx.Request()
+	return true, issueAuthSession(ctx.Response().Writer, *session, func(accessToken, refreshToken string) error {
+		// トークンをDBに保存 (以前のリフレッシュトークンは無効になる)
+		return dao.UpdateSessionToken(session.SessionId, accessToken, refreshToken, ctx.RealIP(), req.UserAgent())
+	})
+}
@@ -0,0 +1,246 @@
+package net
+
+import (
+	"errors"
+	"fxtester/internal/db"
+	"fxtester/internal/lang"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/labstack/echo/v4"
+)
+
+func Test_RefreshAuthSession(t *testing.T) {
+	newToken := func(sessionId string) string {
+		token, err := GenerateToken(AuthSessionPayload{UserId: 1, Email: "test@fxtester.com", SessionId: sessionId, Roles: []string{"admin"}}, time.Now().Add(time.Hour), RefreshTokenKeys)
+		if err != nil {
+			t.Fatalf("failed to generate token: %v", err)
+		}
+		return token
+	}
+	token := newToken("session1")
+
+	checkQuery := regexp.QuoteMeta("select fxtester_schema.check_session_refresh_token($1, $2, $3)")
+	updateQuery := regexp.QuoteMeta("call fxtester_schema.update_user_session_token($1, $2, $3, $4, $5)")
+	deleteQuery := regexp.QuoteMeta("select fxtester_schema.delete_user_session($1, $2)")
+	selectUserQuery := regexp.QuoteMeta("from fxtester_schema.select_user_with_id($1)")
+	// DBのユーザ (ログイン後にidPでadminのロールを外されたユーザ)
+	userRows := func(disabledAt *time.Time) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "email", "roles", "preferences", "display_name", "given_name", "family_name", "groups", "locale", "disabled_at"}).
+			AddRow(1, "test@fxtester.com", "{user}", nil, "", "", "", "{}", "", disabledAt)
+	}
+	disabledAt := time.Now()
+
+	tests := []struct {
+		name string
+		// refresh_tokenのCookie (空文字の場合は付与しない)
+		token  string
+		expect func(mock sqlmock.Sqlmock)
+		// 期待するエラーコード (0の場合はエラーなし)
+		wantCode lang.ErrorCode
+		// トークンを再発行したか
+		wantIssued bool
+	}{
+		{
+			name:     "Cookieなし",
+			wantCode: lang.ErrUnauthorized,
+		},
+		{
+			name:     "署名が不正なトークン",
+			token:    "invalid",
+			wantCode: lang.ErrUnauthorized,
+		},
+		{
+			name:  "最新のトークンによるリフレッシュ",
+			token: token,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, token).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_refresh_token"}).AddRow(true))
+				mock.ExpectQuery(selectUserQuery).WithArgs(1).WillReturnRows(userRows(nil))
+				mock.ExpectQuery(updateQuery).WithArgs("session1", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{}))
+				mock.ExpectCommit()
+			},
+			wantIssued: true,
+		},
+		{
+			name:  "ローテーション済みのトークンの再利用",
+			token: token,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, token).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_refresh_token"}).AddRow(false))
+				// セッションを失効させる
+				mock.ExpectQuery(deleteQuery).WithArgs(1, "session1").
+					WillReturnRows(sqlmock.NewRows([]string{"delete_user_session"}).AddRow(true))
+				mock.ExpectCommit()
+			},
+			wantCode: lang.ErrUnauthorized,
+		},
+		{
+			name:  "無効化されたユーザ",
+			token: token,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, token).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_refresh_token"}).AddRow(true))
+				mock.ExpectQuery(selectUserQuery).WithArgs(1).WillReturnRows(userRows(&disabledAt))
+				mock.ExpectRollback()
+			},
+			wantCode: lang.ErrAccountDisabled,
+		},
+		{
+			name:  "削除されたユーザ",
+			token: token,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, token).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_refresh_token"}).AddRow(true))
+				mock.ExpectQuery(selectUserQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			wantCode: lang.ErrUnauthorized,
+		},
+		{
+			name:  "失効済みのセッション",
+			token: token,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, token).
+					WillReturnRows(sqlmock.NewRows([]string{"check_session_refresh_token"}).AddRow(nil))
+				mock.ExpectRollback()
+			},
+			wantCode: lang.ErrUnauthorized,
+		},
+		{
+			name:  "DBエラー",
+			token: token,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(checkQuery).WithArgs("session1", 1, token).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			wantCode: lang.ErrDBQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed sqlmock.New(): %v", err)
+			}
+			defer mockDB.Close()
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+
+			req := httptest.NewRequest(echo.POST, "https://localhost:8100/auth/refresh", nil)
+			if tt.token != "" {
+				req.AddCookie(&http.Cookie{Name: NameRefreshToken, Value: tt.token})
+			}
+			w := httptest.NewRecorder()
+			ctx := echo.New().NewContext(req, w)
+
+			err = RefreshAuthSession(ctx, db.NewUserEntityDao(&MockDB{db: mockDB}))
+
+			if tt.wantCode != 0 {
+				if fxtErr := lang.FindFxtError(err); fxtErr == nil || fxtErr.ErrCode != tt.wantCode {
+					t.Errorf("RefreshAuthSession()=%v want=0x%x", err, tt.wantCode)
+				}
+			} else if err != nil {
+				t.Errorf("RefreshAuthSession()=%v", err)
+			}
+
+			cookies := map[string]*http.Cookie{}
+			for _, c := range w.Result().Cookies() {
+				cookies[c.Name] = c
+			}
+			if tt.wantIssued {
+				refresh, ok := cookies[NameRefreshToken]
+				if !ok || refresh.Value == "" || refresh.Value == tt.token {
+					t.Fatalf("refresh_token is not issued: %v", refresh)
+				}
+				if _, ok := cookies[NameAccessToken]; !ok {
+					t.Errorf("access_token is not issued")
+				}
+				// 再発行したトークンは同じセッションIDを引き継ぎ、DBのユーザの最新のロールを持つ
+				claims, err := VerifyToken[AuthSessionPayload](refresh.Value, RefreshTokenKeys)
+				if err != nil || claims.Value.SessionId != "session1" || claims.Value.UserId != 1 {
+					t.Errorf("VerifyToken()=(%v, %v)", claims, err)
+				}
+				if err == nil && (len(claims.Value.Roles) != 1 || claims.Value.Roles[0] != "user") {
+					t.Errorf("Roles=%v want=[user]", claims.Value.Roles)
+				}
+			} else if refresh, ok := cookies[NameRefreshToken]; ok && refresh.Value != "" {
+				t.Errorf("refresh_token is issued: %v", refresh)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("ExpectationsWereMet()=%v", err)
+			}
+		})
+	}
+}
+
+// 同じ秒に2回ローテーションした場合も、異なるリフレッシュトークンを発行すること (一致すると再利用を検知できない)
+func Test_RefreshAuthSession_SameSecond(t *testing.T) {
+	token, err := GenerateToken(AuthSessionPayload{UserId: 1, Email: "test@fxtester.com", SessionId: "session1"}, time.Now().Add(time.Hour), RefreshTokenKeys)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed sqlmock.New(): %v", err)
+	}
+	defer mockDB.Close()
+	dao := db.NewUserEntityDao(&MockDB{db: mockDB})
+
+	tokens := []string{token}
+	for i := 0; i < 2; i++ {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("select fxtester_schema.check_session_refresh_token($1, $2, $3)")).WithArgs("session1", 1, tokens[i]).
+			WillReturnRows(sqlmock.NewRows([]string{"check_session_refresh_token"}).AddRow(true))
+		mock.ExpectQuery(regexp.QuoteMeta("from fxtester_schema.select_user_with_id($1)")).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles", "preferences", "display_name", "given_name", "family_name", "groups", "locale", "disabled_at"}).
+				AddRow(1, "test@fxtester.com", "{user}", nil, "", "", "", "{}", "", nil))
+		mock.ExpectQuery(regexp.QuoteMeta("call fxtester_schema.update_user_session_token($1, $2, $3, $4, $5)")).
+			WithArgs("session1", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{}))
+		mock.ExpectCommit()
+
+		req := httptest.NewRequest(echo.POST, "https://localhost:8100/auth/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: NameRefreshToken, Value: tokens[i]})
+		w := httptest.NewRecorder()
+		if err := RefreshAuthSession(echo.New().NewContext(req, w), dao); err != nil {
+			t.Fatalf("RefreshAuthSession()=%v", err)
+		}
+		for _, c := range w.Result().Cookies() {
+			if c.Name == NameRefreshToken {
+				tokens = append(tokens, c.Value)
+			}
+		}
+		if len(tokens) != i+2 {
+			t.Fatalf("refresh_token is not issued")
+		}
+	}
+
+	first, err1 := VerifyToken[AuthSessionPayload](tokens[1], RefreshTokenKeys)
+	second, err2 := VerifyToken[AuthSessionPayload](tokens[2], RefreshTokenKeys)
+	if err1 != nil || err2 != nil {
+		t.Fatalf("VerifyToken()=(%v, %v)", err1, err2)
+	}
+	if tokens[1] == tokens[2] || first.Id == "" || first.Id == second.Id {
+		t.Errorf("rotated tokens are not unique: jti=%q, %q", first.Id, second.Id)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("ExpectationsWereMet()=%v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package net
+
+import "slices"
+
+// ロール (SAMLアサーションのロールのうちsettings/config.yamlのsaml.rolesに含まれるもの)
+const (
+	RoleAdmin = "admin"
+)
+
+// ExtensionRoles 操作に必要なロールを指定するOpenAPI定義の拡張プロパティ。いずれかのロールを持つユーザのみ操作できる
+const ExtensionRoles = "x-roles"
+
+// HasRole ログインユーザがロールを持つか返却する
+func (p *AuthSessionPayload) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// hasAnyRole ログインユーザがいずれかのロールを持つか返却する。rolesが空の場合はtrueを返却する
+func (p *AuthSessionPayload) hasAnyRole(roles []string) bool {
+	if len(roles) <= 0 {
+		return true
+	}
+	for _, role := range roles {
+		if p.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,6 +5,8 @@ import (
 	"fxtester/internal/lang"
 	"net/http"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -13,29 +15,49 @@ const (
 	NameSSOToken       = "sso_token"
 	NameSLOToken       = "slo_token"
 	NameSAMLErrorToken = "saml_error_token"
+	NameOIDCToken      = "oidc_token"
 )
 
 type AuthSessionPayload struct {
 	UserId int64  `json:"user_id"`
 	Email  string `json:"email"`
+	// ログイン毎に発行するセッションID (リフレッシュで再発行したトークンは同じセッションIDを引き継ぐ)
+	SessionId string `json:"session_id,omitempty"`
+	// ログイン時にSAMLアサーションから取得したロール (リフレッシュで再発行したトークンも引き継ぐ)
+	Roles []string `json:"roles,omitempty"`
+	// SAMLでログインしたidPの名前 (ログアウト時に同じidPへLogoutRequestを送信する)
+	Idp string `json:"idp,omitempty"`
+	// 個人用APIトークンで認証した場合のトークンIDとスコープ (JWTには含めない)
+	ApiTokenId string   `json:"-"`
+	Scopes     []string `json:"-"`
+}
+
+// CreateAuthSession ログイン時に新しいセッションIDの認証セッションを作成する
+func CreateAuthSession(w http.ResponseWriter, userId int64, email string, roles []string, idp string, onNewToken func(sessionId, accessToken, refreshToken string) error) error {
+	sessionId := uuid.NewString()
+	return issueAuthSession(w, AuthSessionPayload{
+		UserId:    userId,
+		Email:     email,
+		SessionId: sessionId,
+		Roles:     roles,
+		Idp:       idp,
+	}, func(accessToken, refreshToken string) error {
+		return onNewToken(sessionId, accessToken, refreshToken)
+	})
 }
 
-func CreateAuthSession(w http.ResponseWriter, userId int64, email string, onNewToken func(accessToken, refreshToken string) error) error {
+// issueAuthSession アクセストークンとリフレッシュトークンを発行し、Cookieに設定する
+func issueAuthSession(w http.ResponseWriter, payload AuthSessionPayload, onNewToken func(accessToken, refreshToken string) error) error {
 	now := time.Now()
 	expiresAccessToken := now.Add(15 * time.Minute)
 	expiresRefreshToken := now.Add(7 * 24 * time.Hour)
 
-	payload := AuthSessionPayload{
-		UserId: userId,
-		Email:  email,
-	}
-
-	accessToken, err := GenerateToken(payload, expiresAccessToken, AccessTokenSecret)
+	accessToken, err := GenerateToken(payload, expiresAccessToken, AccessTokenKeys)
 	if err != nil {
 		return err
 	}
 
-	refreshToken, err := GenerateToken(payload, expiresRefreshToken, RefreshTokenSecret)
+	refreshToken, err := GenerateToken(payload, expiresRefreshToken, RefreshTokenKeys)
 	if err != nil {
 		return err
 	}
@@ -73,7 +95,20 @@ func GetAuthSessionAccessToken(r *http.Request) (*AuthSessionPayload, error) {
 		return nil, lang.NewFxtError(lang.ErrCookieNone).SetCause(err)
 	}
 	token := cookie.Value
-	claims, err := VerifyToken[AuthSessionPayload](token, AccessTokenSecret)
+	claims, err := VerifyToken[AuthSessionPayload](token, AccessTokenKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &claims.Value, nil
+}
+
+func GetAuthSessionRefreshToken(r *http.Request) (*AuthSessionPayload, error) {
+	cookie, err := r.Cookie(NameRefreshToken)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrCookieNone).SetCause(err)
+	}
+	token := cookie.Value
+	claims, err := VerifyToken[AuthSessionPayload](token, RefreshTokenKeys)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +154,7 @@ func CreateSSOSession(w http.ResponseWriter, authnRequestId string, redirectURL
 		RedirectURLOnError: redirectURLOnError,
 	}
 
-	token, err := GenerateToken(payload, expires, SSOSessionSecret)
+	token, err := GenerateToken(payload, expires, SSOSessionKeys)
 	if err != nil {
 		return err
 	}
@@ -143,7 +178,7 @@ func GetSSOSession(r *http.Request) (*SSOSessionPayload, error) {
 		return nil, lang.NewFxtError(lang.ErrCookieNone).SetCause(err)
 	}
 	token := cookie.Value
-	claims, err := VerifyToken[SSOSessionPayload](token, SSOSessionSecret)
+	claims, err := VerifyToken[SSOSessionPayload](token, SSOSessionKeys)
 	if err != nil {
 		return nil, err
 	}
@@ -163,24 +198,27 @@ func DeleteSSOSession(w http.ResponseWriter) {
 }
 
 type SLOSessionPayload struct {
-	UserId             int64  `json:"userId"`
+	UserId int64 `json:"userId"`
+	// ログアウトする認証セッションのID
+	SessionId          string `json:"sessionId"`
 	AuthnRequestId     string `json:"authnRequestId"`
 	RedirectURL        string `json:"redirectURL"`
 	RedirectURLOnError string `json:"redirectURLOnError"`
 }
 
-func CreateSLOSession(w http.ResponseWriter, userId int64, authnRequestId string, redirectURL string, redirectURLOnError string) error {
+func CreateSLOSession(w http.ResponseWriter, userId int64, sessionId string, authnRequestId string, redirectURL string, redirectURLOnError string) error {
 	now := time.Now()
 	expires := now.Add(60 * time.Minute)
 
 	payload := SLOSessionPayload{
 		UserId:             userId,
+		SessionId:          sessionId,
 		AuthnRequestId:     authnRequestId,
 		RedirectURL:        redirectURL,
 		RedirectURLOnError: redirectURLOnError,
 	}
 
-	token, err := GenerateToken(payload, expires, SLOSessionSecret)
+	token, err := GenerateToken(payload, expires, SLOSessionKeys)
 	if err != nil {
 		return err
 	}
@@ -204,7 +242,7 @@ func GetSLOSession(r *http.Request) (*SLOSessionPayload, error) {
 		return nil, lang.NewFxtError(lang.ErrCookieNone).SetCause(err)
 	}
 	token := cookie.Value
-	claims, err := VerifyToken[SLOSessionPayload](token, SLOSessionSecret)
+	claims, err := VerifyToken[SLOSessionPayload](token, SLOSessionKeys)
 	if err != nil {
 		return nil, err
 	}
@@ -223,6 +261,65 @@ func DeleteSLOSession(w http.ResponseWriter) {
 	})
 }
 
+// OIDCSessionPayload OpenID Connectの認可リクエストからコールバックまでの間保持する情報
+type OIDCSessionPayload struct {
+	// CSRF対策のstate
+	State string `json:"state"`
+	// IDトークンのリプレイ対策のnonce
+	Nonce string `json:"nonce"`
+	// PKCEのcode_verifier
+	CodeVerifier       string `json:"codeVerifier"`
+	RedirectURL        string `json:"redirectURL"`
+	RedirectURLOnError string `json:"redirectURLOnError"`
+}
+
+func CreateOIDCSession(w http.ResponseWriter, payload OIDCSessionPayload) error {
+	now := time.Now()
+	expires := now.Add(60 * time.Minute)
+
+	token, err := GenerateToken(payload, expires, OIDCSessionKeys)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     NameOIDCToken,
+		Value:    token,
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteNoneMode,
+		Path:     "/oidc/callback",
+	})
+
+	return nil
+}
+
+func GetOIDCSession(r *http.Request) (*OIDCSessionPayload, error) {
+	cookie, err := r.Cookie(NameOIDCToken)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrCookieNone).SetCause(err)
+	}
+	token := cookie.Value
+	claims, err := VerifyToken[OIDCSessionPayload](token, OIDCSessionKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &claims.Value, nil
+}
+
+func DeleteOIDCSession(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     NameOIDCToken,
+		Value:    "",
+		Expires:  time.Unix(0, 0), // 過去の日付を設定してCookieを削除する
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteNoneMode,
+		Path:     "/oidc/callback",
+	})
+}
+
 func CreateSamlErrorSession(w http.ResponseWriter, genErr gen.Error) error {
 	now := time.Now()
 	expires := now.Add(5 * time.Minute)
@@ -232,7 +329,7 @@ func CreateSamlErrorSession(w http.ResponseWriter, genErr gen.Error) error {
 		Time: time.Now().Format(time.RFC3339),
 	}
 
-	token, err := GenerateToken(payload, expires, SAMLErrorSessionSecret)
+	token, err := GenerateToken(payload, expires, SAMLErrorSessionKeys)
 	if err != nil {
 		return err
 	}
@@ -256,7 +353,7 @@ func GetSamlErrorSession(r *http.Request) (*gen.ErrorWithTime, error) {
 		return nil, lang.NewFxtError(lang.ErrCookieNone).SetCause(err)
 	}
 	token := cookie.Value
-	claims, err := VerifyToken[gen.ErrorWithTime](token, SAMLErrorSessionSecret)
+	claims, err := VerifyToken[gen.ErrorWithTime](token, SAMLErrorSessionKeys)
 	if err != nil {
 		return nil, err
 	}
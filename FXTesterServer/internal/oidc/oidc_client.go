@@ -0,0 +1,422 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fxtester/internal/audit"
+	"fxtester/internal/common"
+	"fxtester/internal/db"
+	"fxtester/internal/gen"
+	"fxtester/internal/lang"
+	"fxtester/internal/net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+)
+
+// URLParamOidcError コールバックでエラーが発生した場合にリダイレクト先へ付与するURLパラメータ
+const URLParamOidcError = "oidc_error"
+
+const codeChallengeMethodS256 = "S256"
+
+// requestTimeout OpenID Providerへのリクエストのタイムアウト
+const requestTimeout = 10 * time.Second
+
+var (
+	ErrStateMismatch   = errors.New("oidc: state mismatch")
+	ErrNonceMismatch   = errors.New("oidc: nonce mismatch")
+	ErrIssuerInvalid   = errors.New("oidc: invalid iss")
+	ErrAudienceInvalid = errors.New("oidc: invalid aud")
+	ErrEmailNotFound   = errors.New("oidc: email is not found or not verified")
+	ErrIdTokenNotFound = errors.New("oidc: id_token is not found")
+	ErrUnexpectedAlg   = errors.New("oidc: unexpected signing method")
+)
+
+type IDelegator interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type Delegator struct {
+}
+
+func (c *Delegator) Do(req *http.Request) (*http.Response, error) {
+	client := http.Client{Timeout: requestTimeout}
+	return client.Do(req)
+}
+
+type IOidcClient interface {
+	Init() error
+	ExecuteOidcLogin(ctx echo.Context, params gen.GetOidcLoginParams) error
+	ExecuteOidcCallback(ctx echo.Context, params gen.GetOidcCallbackParams) error
+	ExecuteOidcLogout(ctx echo.Context, params gen.GetOidcLogoutParams) error
+}
+
+type OidcClient struct {
+	delegate IDelegator
+	provider *ProviderMetadata
+	keys     *keySet
+	dao      db.IUserEntityDao
+	// ログインの監査イベントの記録先
+	auditor *audit.Recorder
+}
+
+// NewOidcClient OpenID Connectクライアントを生成します
+func NewOidcClient(delegate IDelegator, idb db.IDB) IOidcClient {
+	return &OidcClient{
+		delegate: delegate,
+		dao:      db.NewUserEntityDao(idb),
+		auditor:  audit.NewRecorder(idb),
+	}
+}
+
+// Init OpenID Providerのディスカバリドキュメントと署名鍵(JWKS)を取得し、OpenID Connectクライアントを初期化します
+func (c *OidcClient) Init() error {
+	config := common.GetConfig().Oidc
+	if config.Issuer == "" || config.ClientId == "" || config.RedirectURL == "" {
+		return lang.NewFxtError(lang.ErrCodeConfig)
+	}
+
+	provider, err := fetchProviderMetadata(c.delegate, config.Issuer)
+	if err != nil {
+		return err
+	}
+	keys := newKeySet(c.delegate, provider.JwksURI)
+	if err := keys.refresh(time.Now()); err != nil {
+		return err
+	}
+	c.provider = provider
+	c.keys = keys
+	return nil
+}
+
+// ExecuteOidcLogin 認可コードフロー(PKCE)を開始し、OpenID Providerの認可エンドポイントへリダイレクトします
+func (c *OidcClient) ExecuteOidcLogin(ctx echo.Context, params gen.GetOidcLoginParams) error {
+	config := common.GetConfig().Oidc
+
+	state, err := randomString()
+	if err != nil {
+		return err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return err
+	}
+	codeVerifier, err := randomString()
+	if err != nil {
+		return err
+	}
+
+	// コールバックまでの間、state・nonce・code_verifierをクッキーに保持する
+	// (クッキーの設定はヘッダ書き込みとBody書き込みより先に行う実装制約があるため)
+	err = net.CreateOIDCSession(ctx.Response().Writer, net.OIDCSessionPayload{
+		State:              state,
+		Nonce:              nonce,
+		CodeVerifier:       codeVerifier,
+		RedirectURL:        params.XRedirectURL,
+		RedirectURLOnError: params.XRedirectURLOnError,
+	})
+	if err != nil {
+		return err
+	}
+
+	scopes := []string{"openid"}
+	for _, scope := range config.Scopes {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", config.ClientId)
+	query.Set("redirect_uri", config.RedirectURL)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge(codeVerifier))
+	query.Set("code_challenge_method", codeChallengeMethodS256)
+	return ctx.Redirect(http.StatusFound, withQuery(c.provider.AuthorizationEndpoint, query))
+}
+
+// ExecuteOidcCallback 認可コードをIDトークンと交換して検証し、ユーザをログインさせます
+func (c *OidcClient) ExecuteOidcCallback(ctx echo.Context, params gen.GetOidcCallbackParams) (lastError error) {
+	// 認可リクエスト時のセッション情報を取得
+	session, err := net.GetOIDCSession(ctx.Request())
+	if err != nil {
+		return err
+	}
+
+	// 監査イベントに記録するユーザ (特定できた場合のみ)
+	var email string
+
+	// エラーの有無によってリダイレクト先やパラメータを変更するdefer
+	defer func() {
+		// 認可リクエストのセッションを破棄する
+		net.DeleteOIDCSession(ctx.Response().Writer)
+
+		// ログインの成否を監査イベントに記録する
+		c.auditor.Record(ctx, audit.Event{Type: audit.EventLogin, Email: email, Err: lastError, Detail: "oidc"})
+
+		if lastError != nil {
+			ctx.Logger().Errorf("failed ExecuteOidcCallback: %v", lastError)
+
+			// エラーレスポンスを作成
+			_, res := lang.ConvertToGenError(ctx, lastError)
+			// エラーの詳細をクッキーに保存 (/saml/errorで取得する)
+			net.CreateSamlErrorSession(ctx.Response().Writer, *res)
+			// リダイレクト先にURLパラメータでエラー内容を通知
+			query := url.Values{}
+			query.Add(URLParamOidcError, "1")
+			// 古いエラーは握り潰しリダイレクトに失敗した場合のみエラー処理を行う
+			lastError = ctx.Redirect(http.StatusFound, session.RedirectURLOnError+"?"+query.Encode())
+		} else {
+			// エラーを空にする
+			net.CreateSamlErrorSession(ctx.Response().Writer, gen.Error{})
+			lastError = ctx.Redirect(http.StatusFound, session.RedirectURL)
+		}
+	}()
+
+	// 認可レスポンスのチェック
+	if params.Error != nil {
+		description := ""
+		if params.ErrorDescription != nil {
+			description = *params.ErrorDescription
+		}
+		return lang.NewFxtError(lang.ErrOidcAuthorization).SetCause(fmt.Errorf("oidc: %s: %s", *params.Error, description))
+	}
+	if params.State == nil || *params.State != session.State {
+		return lang.NewFxtError(lang.ErrOidcAuthorization).SetCause(ErrStateMismatch)
+	}
+	if params.Code == nil || *params.Code == "" {
+		return lang.NewFxtError(lang.ErrOidcAuthorization).SetCause(ErrMissingRequiredItem)
+	}
+
+	// 認可コードをIDトークンと交換する
+	rawIdToken, err := c.exchangeCode(*params.Code, session.CodeVerifier)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrOidcTokenExchange).SetCause(err)
+	}
+
+	// IDトークンを検証する
+	claims, err := c.verifyIdToken(rawIdToken, session.Nonce, time.Now())
+	if err != nil {
+		return lang.NewFxtError(lang.ErrOidcInvalidIdToken).SetCause(err)
+	}
+	email, err = claimEmail(claims)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrOidcInvalidIdToken).SetCause(err)
+	}
+
+	// ユーザの作成・ロールの同期・ログインセッションの作成を行う
+	return net.LoginUser(ctx, c.dao, email, claimRoles(claims), nil, "", "")
+}
+
+// ExecuteOidcLogout ログインセッションを失効させ、OpenID Providerのログアウトエンドポイントへリダイレクトします
+func (c *OidcClient) ExecuteOidcLogout(ctx echo.Context, params gen.GetOidcLogoutParams) error {
+	// アクセストークンの検証
+	session, err := net.GetAuthSessionAccessToken(ctx.Request())
+	if err != nil {
+		return err
+	}
+
+	// ログアウトしたセッションを失効させる (失効済みの場合も成功とする)
+	if err := c.dao.DeleteSession(session.UserId, session.SessionId); err != nil && !errors.Is(err, db.ErrNoData) {
+		return err
+	}
+	net.DeleteAuthSession(ctx.Response().Writer)
+
+	// OpenID Providerがログアウトエンドポイントを公開していない場合はアプリケーションのログアウトのみ行う
+	if c.provider.EndSessionEndpoint == "" {
+		return ctx.Redirect(http.StatusFound, params.XRedirectURL)
+	}
+	query := url.Values{}
+	query.Set("client_id", common.GetConfig().Oidc.ClientId)
+	query.Set("post_logout_redirect_uri", params.XRedirectURL)
+	return ctx.Redirect(http.StatusFound, withQuery(c.provider.EndSessionEndpoint, query))
+}
+
+// exchangeCode トークンエンドポイントで認可コードをIDトークンと交換する
+func (c *OidcClient) exchangeCode(code string, codeVerifier string) (string, error) {
+	config := common.GetConfig().Oidc
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", config.RedirectURL)
+	form.Set("client_id", config.ClientId)
+	form.Set("code_verifier", codeVerifier)
+	req, err := http.NewRequest(http.MethodPost, c.provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.Header.Set("Accept", "application/json")
+	if config.ClientSecret != "" {
+		// コンフィデンシャルクライアントの場合はclient_secret_basicで認証する
+		req.SetBasicAuth(url.QueryEscape(config.ClientId), url.QueryEscape(config.ClientSecret))
+	}
+
+	res, err := c.delegate.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		IdToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("%w: POST %s: %d", ErrUnexpectedStatus, c.provider.TokenEndpoint, res.StatusCode)
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: POST %s: %d: %s: %s", ErrUnexpectedStatus, c.provider.TokenEndpoint, res.StatusCode, body.Error, body.ErrorDescription)
+	}
+	if body.IdToken == "" {
+		return "", ErrIdTokenNotFound
+	}
+	return body.IdToken, nil
+}
+
+// verifyIdToken IDトークンの署名(JWKS)・有効期限・iss・aud・nonceを検証し、クレームを返却する
+func (c *OidcClient) verifyIdToken(rawIdToken string, nonce string, now time.Time) (jwt.MapClaims, error) {
+	config := common.GetConfig().Oidc
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIdToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := c.keys.lookup(kid, now)
+		if err != nil {
+			return nil, err
+		}
+		// 鍵の種類と署名アルゴリズムの組み合わせをチェックする (alg=noneやHS256による偽装対策)
+		switch key.(type) {
+		case *rsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
+				return key, nil
+			}
+		case *ecdsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUnexpectedAlg, token.Header["alg"])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// expはjwtパッケージでは省略可能なため、ここで必須とする
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("%w: exp", ErrMissingRequiredItem)
+	}
+	if !claims.VerifyIssuer(c.provider.Issuer, true) {
+		return nil, ErrIssuerInvalid
+	}
+	if !verifyAudience(claims, config.ClientId) {
+		return nil, ErrAudienceInvalid
+	}
+	if v, _ := claims["nonce"].(string); v != nonce {
+		return nil, ErrNonceMismatch
+	}
+	return claims, nil
+}
+
+// verifyAudience audにクライアントIDが含まれるかチェックする。複数のaudを含む場合はazpがクライアントIDであることもチェックする
+func verifyAudience(claims jwt.MapClaims, clientId string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == clientId
+	case []interface{}:
+		found := false
+		for _, v := range aud {
+			if s, ok := v.(string); ok && s == clientId {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+		if len(aud) > 1 {
+			azp, _ := claims["azp"].(string)
+			return azp == clientId
+		}
+		return true
+	}
+	return false
+}
+
+// claimEmail IDトークンのemailクレームを返却する (email_verifiedがfalseの場合はエラーとする)
+func claimEmail(claims jwt.MapClaims) (string, error) {
+	email, _ := claims["email"].(string)
+	if email == "" {
+		return "", ErrEmailNotFound
+	}
+	if verified, ok := claims["email_verified"].(bool); ok && !verified {
+		return "", ErrEmailNotFound
+	}
+	return email, nil
+}
+
+// claimRoles IDトークンのロールのクレーム (settings/config.yamlのoidc.roleClaim) の値のうち、
+// アプリケーションで使用するロール (oidc.roles) を重複なく返却する
+func claimRoles(claims jwt.MapClaims) []string {
+	config := common.GetConfig().Oidc
+	roles := []string{}
+	if config.RoleClaim == "" {
+		return roles
+	}
+
+	// .で区切られたネストしたクレームを辿る (e.g. realm_access.roles)
+	var value interface{} = map[string]interface{}(claims)
+	for _, name := range strings.Split(config.RoleClaim, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return roles
+		}
+		value = m[name]
+	}
+
+	values, _ := value.([]interface{})
+	for _, v := range values {
+		if s, ok := v.(string); ok && slices.Contains(config.Roles, s) && !slices.Contains(roles, s) {
+			roles = append(roles, s)
+		}
+	}
+	slices.Sort(roles)
+	return roles
+}
+
+// randomString state・nonce・code_verifierに使用するランダムな文字列を生成する
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallenge code_verifierからPKCEのcode_challenge(S256)を生成する
+func codeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// withQuery エンドポイントのURLにクエリを追加する (エンドポイントが既にクエリを含む場合も考慮する)
+func withQuery(endpoint string, query url.Values) string {
+	if strings.Contains(endpoint, "?") {
+		return endpoint + "&" + query.Encode()
+	}
+	return endpoint + "?" + query.Encode()
+}
@@ -0,0 +1,416 @@
+package oidc
+
+import (
+	"database/sql"
+	"errors"
+	"fxtester/internal/common"
+	"fxtester/internal/db"
+	"fxtester/internal/gen"
+	"fxtester/internal/lang"
+	"fxtester/internal/net"
+	"fxtester/internal/oidc/oidctest"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+)
+
+type MockOidcClientDelegator struct {
+	client *http.Client
+}
+
+func (m *MockOidcClientDelegator) Do(req *http.Request) (*http.Response, error) {
+	return m.client.Do(req)
+}
+
+type MockDB struct {
+	db *sql.DB
+}
+
+func (m *MockDB) Init() error {
+	return nil
+}
+
+func (m *MockDB) GetDB() *sql.DB {
+	return m.db
+}
+
+type MockUserDao struct {
+	db.IUserEntityDao
+	delegateDeleteSession func(userId int64, sessionId string) error
+}
+
+func (m *MockUserDao) CreateSession(session *db.UserSessionEntity) error {
+	// セッションIDやトークンの値が動的となり、sqlmockでは対処が難しいためメソッドをオーバーライドして対処する
+	return nil
+}
+
+func (m *MockUserDao) DeleteSession(userId int64, sessionId string) error {
+	if m.delegateDeleteSession != nil {
+		return m.delegateDeleteSession(userId, sessionId)
+	}
+	return nil
+}
+
+// startStubIdP スタブのOpenID Providerを起動し、テスト用の設定に差し替える (戻り値の関数で停止と設定の復元を行う)
+func startStubIdP(t *testing.T, roles []string) (*oidctest.StubIdP, *httptest.Server, func()) {
+	idp, err := oidctest.NewStubIdP("test-client", "test@test.co.jp", roles)
+	if err != nil {
+		t.Fatalf("failed NewStubIdP: %v", err)
+	}
+	server := httptest.NewTLSServer(idp.Handler())
+	idp.Issuer = server.URL
+
+	config := common.GetConfig()
+	save := config.Oidc
+	config.Oidc.Issuer = server.URL
+	config.Oidc.ClientId = "test-client"
+	config.Oidc.ClientSecret = ""
+	config.Oidc.RedirectURL = "https://localhost/oidc/callback"
+	config.Oidc.Scopes = []string{"email"}
+	config.Oidc.RoleClaim = "realm_access.roles"
+	config.Oidc.Roles = []string{"admin"}
+	return idp, server, func() {
+		server.Close()
+		config.Oidc = save
+	}
+}
+
+// authorize ExecuteOidcLoginのリダイレクト先(認可エンドポイント)にアクセスし、コールバックのクエリとOIDCセッションを返却する
+func authorize(t *testing.T, client IOidcClient, server *httptest.Server) (url.Values, *net.OIDCSessionPayload) {
+	rec := httptest.NewRecorder()
+	ctx := echo.New().NewContext(httptest.NewRequest(echo.GET, "https://localhost/oidc/login", nil), rec)
+	err := client.ExecuteOidcLogin(ctx, gen.GetOidcLoginParams{
+		XRedirectURL:        "http://localhost/test-redirect",
+		XRedirectURLOnError: "http://localhost/test-redirect-error",
+	})
+	if err != nil {
+		t.Fatalf("failed ExecuteOidcLogin: %v", err)
+	}
+	if rec.Code != http.StatusFound {
+		t.Fatalf("ExecuteOidcLogin()=%v want=%v", rec.Code, http.StatusFound)
+	}
+	session, err := net.GetOIDCSession(&http.Request{Header: http.Header{"Cookie": rec.Header()["Set-Cookie"]}})
+	if err != nil {
+		t.Fatalf("invalid cookie: %v", err)
+	}
+
+	// 認可エンドポイントのリダイレクト(コールバック)は辿らずに取得する
+	httpClient := *server.Client()
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	res, err := httpClient.Get(rec.Header().Get(echo.HeaderLocation))
+	if err != nil {
+		t.Fatalf("failed authorize: %v", err)
+	}
+	res.Body.Close()
+	location, err := url.Parse(res.Header.Get(echo.HeaderLocation))
+	if err != nil {
+		t.Fatalf("invalid location: %v", err)
+	}
+	return location.Query(), session
+}
+
+// newCallbackContext OIDCセッションのクッキーを設定したコールバックのコンテキストを作成する
+func newCallbackContext(t *testing.T, session net.OIDCSessionPayload, w http.ResponseWriter) echo.Context {
+	token, err := net.GenerateToken(session, time.Now().Add(60*time.Minute), net.OIDCSessionKeys)
+	if err != nil {
+		t.Fatalf("failed net.GenerateToken: %v", err)
+	}
+	req := httptest.NewRequest(echo.GET, "https://localhost/oidc/callback", nil)
+	req.Header.Add("Cookie", (&http.Cookie{Name: net.NameOIDCToken, Value: token}).String())
+	return echo.New().NewContext(req, w)
+}
+
+func strptr(v string) *string {
+	return &v
+}
+
+func Test_OidcClient_Init(t *testing.T) {
+	tests := []struct {
+		name string
+		// 設定のIssuerを書き換える (nilの場合はスタブのIssuerのまま)
+		issuer   func(server *httptest.Server) string
+		wantCode lang.ErrorCode
+	}{
+		{
+			name: "test1_normal",
+		},
+		{
+			name: "test2_Issuerが不一致",
+			issuer: func(server *httptest.Server) string {
+				return server.URL + "/"
+			},
+			wantCode: lang.ErrOidcDiscovery,
+		},
+		{
+			name: "test3_ディスカバリの取得に失敗",
+			issuer: func(server *httptest.Server) string {
+				return server.URL + "/unknown"
+			},
+			wantCode: lang.ErrOidcDiscovery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, server, stop := startStubIdP(t, nil)
+			defer stop()
+			if tt.issuer != nil {
+				common.GetConfig().Oidc.Issuer = tt.issuer(server)
+			}
+
+			client := NewOidcClient(&MockOidcClientDelegator{client: server.Client()}, nil)
+			err := client.Init()
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("Init()=%v", err)
+				}
+				return
+			}
+			var fxtErr *lang.FxtError
+			if !errors.As(err, &fxtErr) || fxtErr.ErrCode != tt.wantCode {
+				t.Errorf("Init()=%v wantCode=%x", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_OidcClient_ExecuteOidcCallback(t *testing.T) {
+	const expectUserId = 1000
+	const expectEmail = "test@test.co.jp"
+
+	tests := []struct {
+		name string
+		// IDトークンに含めるロール
+		idpRoles []string
+		// IDトークンのクレームの書き換え
+		modifyClaims func(claims jwt.MapClaims)
+		// コールバックのクエリ・OIDCセッションの書き換え
+		modifyCallback func(params *gen.GetOidcCallbackParams, session *net.OIDCSessionPayload)
+		// DBへのクエリの期待値 (nilの場合はクエリを行わない)
+		expectQuery     func(mock sqlmock.Sqlmock)
+		wantRedirectURL string
+		wantOidcErr     *uint32
+		wantRoles       []string
+	}{
+		{
+			name:     "test1_normal",
+			idpRoles: []string{"default-roles-my-realm", "admin"},
+			expectQuery: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
+				mock.ExpectQuery(regexp.QuoteMeta(`call fxtester_schema.update_user_roles($1, $2)`)).WithArgs(expectUserId, "{\"admin\"}").WillReturnRows(sqlmock.NewRows([]string{}))
+				mock.ExpectCommit()
+			},
+			wantRedirectURL: "http://localhost/test-redirect",
+			wantOidcErr:     uint32ptr(0),
+			wantRoles:       []string{"admin"},
+		},
+		{
+			name: "test2_ロールなし",
+			expectQuery: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
+				mock.ExpectCommit()
+			},
+			wantRedirectURL: "http://localhost/test-redirect",
+			wantOidcErr:     uint32ptr(0),
+			wantRoles:       []string{},
+		},
+		{
+			name: "test3_stateが不一致",
+			modifyCallback: func(params *gen.GetOidcCallbackParams, session *net.OIDCSessionPayload) {
+				params.State = strptr("invalid-state")
+			},
+			wantRedirectURL: "http://localhost/test-redirect-error?oidc_error=1",
+			wantOidcErr:     uint32ptr(int(lang.ErrOidcAuthorization)),
+		},
+		{
+			name: "test4_認可エラー",
+			modifyCallback: func(params *gen.GetOidcCallbackParams, session *net.OIDCSessionPayload) {
+				params.Code = nil
+				params.Error = strptr("access_denied")
+			},
+			wantRedirectURL: "http://localhost/test-redirect-error?oidc_error=1",
+			wantOidcErr:     uint32ptr(int(lang.ErrOidcAuthorization)),
+		},
+		{
+			name: "test5_code_verifierが不一致",
+			modifyCallback: func(params *gen.GetOidcCallbackParams, session *net.OIDCSessionPayload) {
+				session.CodeVerifier = "invalid-code-verifier"
+			},
+			wantRedirectURL: "http://localhost/test-redirect-error?oidc_error=1",
+			wantOidcErr:     uint32ptr(int(lang.ErrOidcTokenExchange)),
+		},
+		{
+			name: "test6_nonceが不一致",
+			modifyClaims: func(claims jwt.MapClaims) {
+				claims["nonce"] = "invalid-nonce"
+			},
+			wantRedirectURL: "http://localhost/test-redirect-error?oidc_error=1",
+			wantOidcErr:     uint32ptr(int(lang.ErrOidcInvalidIdToken)),
+		},
+		{
+			name: "test7_audが不一致",
+			modifyClaims: func(claims jwt.MapClaims) {
+				claims["aud"] = "other-client"
+			},
+			wantRedirectURL: "http://localhost/test-redirect-error?oidc_error=1",
+			wantOidcErr:     uint32ptr(int(lang.ErrOidcInvalidIdToken)),
+		},
+		{
+			name: "test8_有効期限切れ",
+			modifyClaims: func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+			},
+			wantRedirectURL: "http://localhost/test-redirect-error?oidc_error=1",
+			wantOidcErr:     uint32ptr(int(lang.ErrOidcInvalidIdToken)),
+		},
+		{
+			name: "test9_emailが未検証",
+			modifyClaims: func(claims jwt.MapClaims) {
+				claims["email_verified"] = false
+			},
+			wantRedirectURL: "http://localhost/test-redirect-error?oidc_error=1",
+			wantOidcErr:     uint32ptr(int(lang.ErrOidcInvalidIdToken)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp, server, stop := startStubIdP(t, tt.idpRoles)
+			defer stop()
+			idp.ModifyClaims = tt.modifyClaims
+
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed sqlmock.New(): %v", err)
+			}
+			if tt.expectQuery != nil {
+				tt.expectQuery(mock)
+			}
+			client := &OidcClient{
+				delegate: &MockOidcClientDelegator{client: server.Client()},
+				dao: &MockUserDao{
+					IUserEntityDao: db.NewUserEntityDao(&MockDB{db: mockDB}),
+				},
+			}
+			if err := client.Init(); err != nil {
+				t.Fatalf("failed Init: %v", err)
+			}
+
+			query, session := authorize(t, client, server)
+			params := gen.GetOidcCallbackParams{
+				Code:  strptr(query.Get("code")),
+				State: strptr(query.Get("state")),
+			}
+			if tt.modifyCallback != nil {
+				tt.modifyCallback(&params, session)
+			}
+
+			rec := httptest.NewRecorder()
+			if err := client.ExecuteOidcCallback(newCallbackContext(t, *session, rec), params); err != nil {
+				t.Errorf("ExecuteOidcCallback()=%v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("ExpectationsWereMet()=%v", err)
+			}
+
+			redirectURL := rec.Header().Get(echo.HeaderLocation)
+			if redirectURL != tt.wantRedirectURL {
+				t.Errorf("ExecuteOidcCallback()=%v wantRedirectURL=%v", redirectURL, tt.wantRedirectURL)
+			}
+
+			parser := &http.Request{Header: http.Header{"Cookie": rec.Header()["Set-Cookie"]}}
+			if tt.wantRoles != nil {
+				// 成功時はアクセストークンのクッキーを発行する
+				c, err := parser.Cookie(net.NameAccessToken)
+				if err != nil {
+					t.Fatalf("invalid cookie: %v", err)
+				}
+				claims, err := net.VerifyToken[net.AuthSessionPayload](c.Value, net.AccessTokenKeys)
+				if err != nil {
+					t.Fatalf("invalid cookie: %v", err)
+				}
+				if claims.Value.UserId != expectUserId || claims.Value.Email != expectEmail {
+					t.Errorf("ExecuteOidcCallback()=%v,%v want=%v,%v", claims.Value.UserId, claims.Value.Email, expectUserId, expectEmail)
+				}
+				if !slices.Equal(claims.Value.Roles, tt.wantRoles) {
+					t.Errorf("ExecuteOidcCallback()=%v wantRoles=%v", claims.Value.Roles, tt.wantRoles)
+				}
+			} else if _, err := parser.Cookie(net.NameAccessToken); err == nil {
+				t.Errorf("ExecuteOidcCallback() issued access token on error")
+			}
+
+			// エラートークン
+			c, err := parser.Cookie(net.NameSAMLErrorToken)
+			if err != nil {
+				t.Fatalf("invalid cookie: %v", err)
+			}
+			errClaims, err := net.VerifyToken[gen.ErrorWithTime](c.Value, net.SAMLErrorSessionKeys)
+			if err != nil {
+				t.Fatalf("invalid cookie: %v", err)
+			}
+			if errClaims.Value.Err.Code != *tt.wantOidcErr {
+				t.Errorf("ExecuteOidcCallback()=%x, wantOidcErr=%x", errClaims.Value.Err.Code, *tt.wantOidcErr)
+			}
+		})
+	}
+}
+
+func Test_OidcClient_ExecuteOidcLogout(t *testing.T) {
+	_, server, stop := startStubIdP(t, nil)
+	defer stop()
+
+	var deleted []string
+	client := &OidcClient{
+		delegate: &MockOidcClientDelegator{client: server.Client()},
+		dao: &MockUserDao{
+			delegateDeleteSession: func(userId int64, sessionId string) error {
+				deleted = append(deleted, sessionId)
+				// 失効済みの場合も成功とする
+				return db.ErrNoData
+			},
+		},
+	}
+	if err := client.Init(); err != nil {
+		t.Fatalf("failed Init: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(echo.GET, "https://localhost/oidc/logout", nil)
+	err := net.CreateAuthSession(rec, 1000, "test@test.co.jp", nil, "", func(sessionId, accessToken, refreshToken string) error { return nil })
+	if err != nil {
+		t.Fatalf("failed CreateAuthSession: %v", err)
+	}
+	for _, c := range (&http.Response{Header: rec.Header()}).Cookies() {
+		req.AddCookie(c)
+	}
+
+	rec = httptest.NewRecorder()
+	if err := client.ExecuteOidcLogout(echo.New().NewContext(req, rec), gen.GetOidcLogoutParams{XRedirectURL: "http://localhost/test-redirect"}); err != nil {
+		t.Fatalf("ExecuteOidcLogout()=%v", err)
+	}
+	if len(deleted) != 1 {
+		t.Errorf("ExecuteOidcLogout() deleted=%v", deleted)
+	}
+	want := server.URL + "/logout?" + url.Values{
+		"client_id":                {"test-client"},
+		"post_logout_redirect_uri": {"http://localhost/test-redirect"},
+	}.Encode()
+	if location := rec.Header().Get(echo.HeaderLocation); location != want {
+		t.Errorf("ExecuteOidcLogout()=%v want=%v", location, want)
+	}
+}
+
+func uint32ptr(v int) *uint32 {
+	tmp := uint32(v)
+	return &tmp
+}
@@ -0,0 +1,246 @@
+// Package oidctest 動作確認・テスト用のスタブのOpenID Providerのパッケージ (サーバには組み込まない)。
+// oidcパッケージのテストから使用するため、oidcパッケージをimportしない
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// pathDiscovery ディスカバリドキュメントのパス
+const pathDiscovery = "/.well-known/openid-configuration"
+
+const codeChallengeMethodS256 = "S256"
+
+// StubIdP 動作確認・テスト用の最小限のOpenID Provider。
+// 認可エンドポイントは利用者の認証を行わず、Emailのユーザ (login_hintが指定された場合はそのユーザ) として即座に認可コードを発行する
+type StubIdP struct {
+	// Issuer IssuerのURL (サーバの起動後に設定する)
+	Issuer string
+	// ClientId 受け付けるクライアントID
+	ClientId string
+	// Email ログインさせるユーザのEmail
+	Email string
+	// Roles IDトークンに含めるロール (rolesとrealm_access.rolesの両方に設定する)
+	Roles []string
+	// ModifyClaims IDトークンの発行前にクレームを書き換える (テストで不正なIDトークンを発行する場合に使用する)
+	ModifyClaims func(claims jwt.MapClaims)
+
+	key   *rsa.PrivateKey
+	kid   string
+	mu    sync.Mutex
+	codes map[string]stubAuthorization
+}
+
+// stubAuthorization 認可コードに紐づく認可リクエストの内容
+type stubAuthorization struct {
+	email         string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// NewStubIdP 署名鍵を生成し、スタブのOpenID Providerを生成します
+func NewStubIdP(clientId string, email string, roles []string) (*StubIdP, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	kid, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	return &StubIdP{
+		ClientId: clientId,
+		Email:    email,
+		Roles:    roles,
+		key:      key,
+		kid:      kid,
+		codes:    map[string]stubAuthorization{},
+	}, nil
+}
+
+// Handler スタブのOpenID Providerのエンドポイントを返却します
+func (s *StubIdP) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pathDiscovery, s.handleDiscovery)
+	mux.HandleFunc("/authorize", s.handleAuthorize)
+	mux.HandleFunc("/token", s.handleToken)
+	mux.HandleFunc("/jwks", s.handleJwks)
+	mux.HandleFunc("/logout", s.handleLogout)
+	return mux
+}
+
+func (s *StubIdP) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                           s.Issuer,
+		"authorization_endpoint":           s.Issuer + "/authorize",
+		"token_endpoint":                   s.Issuer + "/token",
+		"jwks_uri":                         s.Issuer + "/jwks",
+		"end_session_endpoint":             s.Issuer + "/logout",
+		"code_challenge_methods_supported": []string{codeChallengeMethodS256},
+	})
+}
+
+func (s *StubIdP) handleJwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": s.kid,
+				"use": "sig",
+				"n":   encodeBigInt(s.key.N),
+				"e":   encodeBigInt(big.NewInt(int64(s.key.E))),
+			},
+		},
+	})
+}
+
+func (s *StubIdP) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI := query.Get("redirect_uri")
+	if query.Get("client_id") != s.ClientId || redirectURI == "" {
+		http.Error(w, "invalid client_id or redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	res := url.Values{}
+	res.Set("state", query.Get("state"))
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != codeChallengeMethodS256 {
+		res.Set("error", "invalid_request")
+		http.Redirect(w, r, withQuery(redirectURI, res), http.StatusFound)
+		return
+	}
+
+	email := s.Email
+	if hint := query.Get("login_hint"); hint != "" {
+		email = hint
+	}
+	code, err := randomString()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.mu.Lock()
+	s.codes[code] = stubAuthorization{
+		email:         email,
+		redirectURI:   redirectURI,
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	s.mu.Unlock()
+
+	res.Set("code", code)
+	http.Redirect(w, r, withQuery(redirectURI, res), http.StatusFound)
+}
+
+func (s *StubIdP) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	// 認可コードは1度のみ使用できる
+	code := r.PostForm.Get("code")
+	s.mu.Lock()
+	auth, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+
+	clientId := r.PostForm.Get("client_id")
+	if basicId, _, ok := r.BasicAuth(); ok {
+		clientId, _ = url.QueryUnescape(basicId)
+	}
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" || clientId != s.ClientId ||
+		r.PostForm.Get("redirect_uri") != auth.redirectURI ||
+		codeChallenge(r.PostForm.Get("code_verifier")) != auth.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	roles := make([]interface{}, len(s.Roles))
+	for i, role := range s.Roles {
+		roles[i] = role
+	}
+	claims := jwt.MapClaims{
+		"iss":            s.Issuer,
+		"sub":            auth.email,
+		"aud":            s.ClientId,
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          auth.nonce,
+		"email":          auth.email,
+		"email_verified": true,
+		"roles":          roles,
+		"realm_access":   map[string]interface{}{"roles": roles},
+	}
+	if s.ModifyClaims != nil {
+		s.ModifyClaims(claims)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.kid
+	idToken, err := token.SignedString(s.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": idToken,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (s *StubIdP) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if redirectURI := r.URL.Query().Get("post_logout_redirect_uri"); redirectURI != "" {
+		http.Redirect(w, r, redirectURI, http.StatusFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// encodeBigInt 公開鍵の値をJWKの形式(base64url)に変換する
+func encodeBigInt(v *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(v.Bytes())
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallenge code_verifierからPKCEのcode_challenge(S256)を生成する
+func codeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// withQuery リダイレクト先のURLにクエリを追加する
+func withQuery(endpoint string, query url.Values) string {
+	if strings.Contains(endpoint, "?") {
+		return endpoint + "&" + query.Encode()
+	}
+	return endpoint + "?" + query.Encode()
+}
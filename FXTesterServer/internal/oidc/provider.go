@@ -0,0 +1,214 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fxtester/internal/lang"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PathDiscovery Issuerからディスカバリドキュメントへの相対パス
+const PathDiscovery = "/.well-known/openid-configuration"
+
+// jwksRefreshInterval 未知のkidのIDトークンを受け取った場合に、JWKSを再取得する最短の間隔
+const jwksRefreshInterval = time.Minute
+
+var (
+	ErrUnknownKeyId        = errors.New("oidc: unknown kid")
+	ErrUnsupportedKeyType  = errors.New("oidc: unsupported key type")
+	ErrUnexpectedStatus    = errors.New("oidc: unexpected status")
+	ErrIssuerMismatch      = errors.New("oidc: issuer mismatch")
+	ErrPKCENotSupported    = errors.New("oidc: S256 code challenge is not supported")
+	ErrMissingRequiredItem = errors.New("oidc: missing required item")
+)
+
+// ProviderMetadata OpenID Providerのディスカバリドキュメント (使用する項目のみ)
+type ProviderMetadata struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	JwksURI                       string   `json:"jwks_uri"`
+	EndSessionEndpoint            string   `json:"end_session_endpoint,omitempty"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported,omitempty"`
+}
+
+// jsonWebKey JWKSの公開鍵 (RSA・ECのみ)
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// fetchJSON 指定したURLからJSONを取得する
+func fetchJSON(delegate IDelegator, url string, v any) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := delegate.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: GET %s: %d", ErrUnexpectedStatus, url, res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// fetchProviderMetadata Issuerのディスカバリドキュメントを取得し、必須項目とIssuerの一致・PKCE(S256)の対応をチェックする
+func fetchProviderMetadata(delegate IDelegator, issuer string) (*ProviderMetadata, error) {
+	var metadata ProviderMetadata
+	if err := fetchJSON(delegate, strings.TrimRight(issuer, "/")+PathDiscovery, &metadata); err != nil {
+		return nil, lang.NewFxtError(lang.ErrOidcDiscovery).SetCause(err)
+	}
+	if metadata.Issuer != issuer {
+		return nil, lang.NewFxtError(lang.ErrOidcDiscovery).SetCause(fmt.Errorf("%w: %s", ErrIssuerMismatch, metadata.Issuer))
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JwksURI == "" {
+		return nil, lang.NewFxtError(lang.ErrOidcDiscovery).SetCause(ErrMissingRequiredItem)
+	}
+	// 対応方式が公開されていない場合はS256に対応しているとみなす
+	if len(metadata.CodeChallengeMethodsSupported) > 0 && !slices.Contains(metadata.CodeChallengeMethodsSupported, codeChallengeMethodS256) {
+		return nil, lang.NewFxtError(lang.ErrOidcDiscovery).SetCause(ErrPKCENotSupported)
+	}
+	return &metadata, nil
+}
+
+// keySet IDトークンの検証に使用するJWKSの公開鍵。未知のkidの場合はJWKSを再取得する (鍵のローテーション対策)
+type keySet struct {
+	delegate  IDelegator
+	jwksURI   string
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func newKeySet(delegate IDelegator, jwksURI string) *keySet {
+	return &keySet{
+		delegate: delegate,
+		jwksURI:  jwksURI,
+		keys:     map[string]crypto.PublicKey{},
+	}
+}
+
+// lookup kidに対応する公開鍵を返却する。kidが空の場合は鍵が1つのみの場合にその鍵を返却する
+func (k *keySet) lookup(kid string, now time.Time) (crypto.PublicKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if key, ok := k.find(kid); ok {
+		return key, nil
+	}
+	if now.Sub(k.fetchedAt) < jwksRefreshInterval {
+		return nil, ErrUnknownKeyId
+	}
+	if err := k.refresh(now); err != nil {
+		return nil, err
+	}
+	if key, ok := k.find(kid); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKeyId
+}
+
+func (k *keySet) find(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// refresh JWKSを取得し、署名用の公開鍵に置き換える (未対応の種類の鍵は無視する)
+func (k *keySet) refresh(now time.Time) error {
+	var set jsonWebKeySet
+	if err := fetchJSON(k.delegate, k.jwksURI, &set); err != nil {
+		return lang.NewFxtError(lang.ErrOidcDiscovery).SetCause(err)
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	k.keys = keys
+	k.fetchedAt = now
+	return nil
+}
+
+// publicKey JWKを公開鍵に変換する
+func (j jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyType, j.Crv)
+		}
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyType, j.Kty)
+}
+
+func decodeBigInt(v string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -7,12 +7,14 @@ import (
 	"fxtester/internal/gen"
 	"io"
 	"strconv"
+	"time"
 
 	"golang.org/x/text/encoding/unicode"
 	"golang.org/x/text/transform"
 )
 
-func ReadCandleCsv(csvInfo gen.CsvInfo, r io.Reader) (res []common.Candle, lastError error) {
+// ReadCandleCsv CSVからローソク足を読み込む。タイムゾーンの指定が無い時間はlocのタイムゾーンとして解釈する
+func ReadCandleCsv(csvInfo gen.CsvInfo, loc *time.Location, r io.Reader) (res []common.Candle, lastError error) {
 
 	utf16bom := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
 	utf8Reader := transform.NewReader(r, utf16bom.NewDecoder())
@@ -65,7 +67,7 @@ func ReadCandleCsv(csvInfo gen.CsvInfo, r io.Reader) (res []common.Candle, lastE
 			return nil, err
 		}
 
-		time, err := common.ToTime(colTime)
+		time, err := common.ToTimeInLocation(colTime, loc)
 		if err != nil {
 			return nil, err
 		}
@@ -87,12 +89,26 @@ func ReadCandleCsv(csvInfo gen.CsvInfo, r io.Reader) (res []common.Candle, lastE
 			return nil, err
 		}
 
+		// スプレッドはカラムが指定されている場合のみ読み込む
+		spread := 0.0
+		if csvInfo.SpreadColumnIndex != nil {
+			colSpread, err := getColValue(row, *csvInfo.SpreadColumnIndex)
+			if err != nil {
+				return nil, err
+			}
+			spread, err = strconv.ParseFloat(colSpread, 32)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		candles = append(candles, common.Candle{
-			Time:  *time,
-			High:  high,
-			Open:  open,
-			Close: close,
-			Low:   low,
+			Time:   *time,
+			High:   high,
+			Open:   open,
+			Close:  close,
+			Low:    low,
+			Spread: spread,
 		})
 	}
 
@@ -0,0 +1,49 @@
+package reader
+
+import (
+	"fxtester/internal/common"
+	"sort"
+	"time"
+)
+
+// ResampleCandles ローソク足をperiodの期間毎に集約した上位足を作成する (e.g. M1→H1)
+//
+// 期間の区切りはUTCの0時を基準とする。スプレッドは上位足の始値で約定する際に使用されるため、期間内の最初のローソク足の値を引き継ぐ
+func ResampleCandles(candles []common.Candle, period time.Duration) []common.Candle {
+	// 時刻順に並び替える (元の配列は変更しない)
+	sorted := make([]common.Candle, len(candles))
+	copy(sorted, candles)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	results := []common.Candle{}
+	var cur *common.Candle
+	for _, c := range sorted {
+		start := c.Time.UTC().Truncate(period).In(c.Time.Location())
+		if cur != nil && cur.Time.Equal(start) {
+			// 同じ期間のローソク足を集約する
+			if cur.High < c.High {
+				cur.High = c.High
+			}
+			if c.Low < cur.Low {
+				cur.Low = c.Low
+			}
+			cur.Close = c.Close
+			continue
+		}
+
+		// 新しい期間のローソク足を作成する
+		results = append(results, common.Candle{
+			Time:   start,
+			High:   c.High,
+			Open:   c.Open,
+			Close:  c.Close,
+			Low:    c.Low,
+			Spread: c.Spread,
+		})
+		cur = &results[len(results)-1]
+	}
+
+	return results
+}
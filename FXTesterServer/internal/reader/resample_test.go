@@ -0,0 +1,69 @@
+package reader
+
+import (
+	"fxtester/internal/common"
+	"testing"
+	"time"
+)
+
+func Test_ResampleCandles(t *testing.T) {
+	base := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	at := func(min int) time.Time {
+		return base.Add(time.Duration(min) * time.Minute)
+	}
+
+	tests := []struct {
+		name    string
+		candles []common.Candle
+		period  time.Duration
+		want    []common.Candle
+	}{
+		{
+			name: "M15→H1",
+			candles: []common.Candle{
+				{Time: at(0), Open: 100, High: 101, Low: 99, Close: 100.5, Spread: 0.3},
+				{Time: at(15), Open: 100.5, High: 102, Low: 100, Close: 101, Spread: 0.2},
+				{Time: at(30), Open: 101, High: 101.5, Low: 98, Close: 99},
+				{Time: at(45), Open: 99, High: 100, Low: 98.5, Close: 99.5},
+				{Time: at(60), Open: 99.5, High: 99.8, Low: 99.1, Close: 99.2, Spread: 0.1},
+			},
+			period: time.Hour,
+			want: []common.Candle{
+				{Time: at(0), Open: 100, High: 102, Low: 98, Close: 99.5, Spread: 0.3},
+				{Time: at(60), Open: 99.5, High: 99.8, Low: 99.1, Close: 99.2, Spread: 0.1},
+			},
+		},
+		{
+			name: "時刻順に並んでいない場合",
+			candles: []common.Candle{
+				{Time: at(15), Open: 101, High: 103, Low: 100, Close: 102},
+				{Time: at(0), Open: 100, High: 101, Low: 99, Close: 101},
+			},
+			period: time.Hour,
+			want: []common.Candle{
+				{Time: at(0), Open: 100, High: 103, Low: 99, Close: 102},
+			},
+		},
+		{
+			name:    "空の配列",
+			candles: []common.Candle{},
+			period:  time.Hour,
+			want:    []common.Candle{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResampleCandles(tt.candles, tt.period)
+			if len(got) != len(tt.want) {
+				t.Fatalf("len(ResampleCandles())=%v want=%v", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if !got[i].Time.Equal(tt.want[i].Time) || got[i].Open != tt.want[i].Open || got[i].High != tt.want[i].High ||
+					got[i].Low != tt.want[i].Low || got[i].Close != tt.want[i].Close || got[i].Spread != tt.want[i].Spread {
+					t.Errorf("ResampleCandles()[%d]=%+v want=%+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package saml
+
+import (
+	"fxtester/internal/common"
+	"fxtester/internal/db"
+	"sync"
+	"time"
+
+	cs "github.com/crewjam/saml"
+)
+
+// IAssertionStore 使用済みのSAMLアサーションのIDを有効期限まで保持するストア (ACSへのアサーションの再送対策)
+type IAssertionStore interface {
+	// Consume アサーションのIDを有効期限まで使用済みとして記録する。初めて使用された場合はtrue、使用済みの場合はfalseを返却する
+	Consume(assertionId string, expiresAt time.Time) (bool, error)
+}
+
+// NewAssertionStore 設定 (saml.assertionStore) に従い、使用済みのSAMLアサーションのIDを保持するストアを生成します
+func NewAssertionStore(idb db.IDB) IAssertionStore {
+	if common.GetConfig().Saml.AssertionStore == common.SamlAssertionStorePostgres {
+		return NewDbAssertionStore(db.NewSamlAssertionEntityDao(idb))
+	}
+	return NewMemoryAssertionStore()
+}
+
+// MemoryAssertionStore 使用済みのSAMLアサーションのIDをメモリに保持するストア (サーバが1台の場合に使用する)
+type MemoryAssertionStore struct {
+	mu sync.Mutex
+	// アサーションのID毎の有効期限
+	expires map[string]time.Time
+	now     func() time.Time
+}
+
+// NewMemoryAssertionStore 使用済みのSAMLアサーションのIDをメモリに保持するストアを生成します
+func NewMemoryAssertionStore() *MemoryAssertionStore {
+	return &MemoryAssertionStore{
+		expires: map[string]time.Time{},
+		now:     time.Now,
+	}
+}
+
+// Consume アサーションのIDを有効期限まで使用済みとして記録します。初めて使用された場合はtrue、使用済みの場合はfalseを返却します
+func (m *MemoryAssertionStore) Consume(assertionId string, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 有効期限を過ぎたIDを削除する (有効期限を過ぎたアサーションはACSで受け付けないため)
+	now := m.now()
+	for id, expires := range m.expires {
+		if expires.Before(now) {
+			delete(m.expires, id)
+		}
+	}
+
+	if _, ok := m.expires[assertionId]; ok {
+		return false, nil
+	}
+	m.expires[assertionId] = expiresAt
+	return true, nil
+}
+
+// DbAssertionStore 使用済みのSAMLアサーションのIDをデータベースに保持するストア (複数のサーバでACSを処理する場合に使用する)
+type DbAssertionStore struct {
+	dao db.ISamlAssertionEntityDao
+}
+
+// NewDbAssertionStore 使用済みのSAMLアサーションのIDをデータベースに保持するストアを生成します
+func NewDbAssertionStore(dao db.ISamlAssertionEntityDao) *DbAssertionStore {
+	return &DbAssertionStore{
+		dao: dao,
+	}
+}
+
+// Consume アサーションのIDを有効期限まで使用済みとして記録します。初めて使用された場合はtrue、使用済みの場合はfalseを返却します
+func (d *DbAssertionStore) Consume(assertionId string, expiresAt time.Time) (bool, error) {
+	return d.dao.ConsumeAssertion(assertionId, expiresAt)
+}
+
+// assertionExpiry アサーションのIDを使用済みとして保持する期限を返却する。
+// crewjam/samlはIssueInstantからMaxIssueDelayを過ぎたアサーションを受け付けないため、
+// IssueInstantと現在日時の遅い方から、MaxIssueDelayに時刻のずれを加えた期間保持する
+func assertionExpiry(assertion *cs.Assertion, now time.Time) time.Time {
+	issued := assertion.IssueInstant
+	if issued.Before(now) {
+		issued = now
+	}
+	return issued.Add(cs.MaxIssueDelay + cs.MaxClockSkew)
+}
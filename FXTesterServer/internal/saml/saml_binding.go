@@ -0,0 +1,386 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rsa"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fxtester/internal/common"
+	"fxtester/internal/lang"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	cs "github.com/crewjam/saml"
+	"github.com/labstack/echo/v4"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+const RelayState = "RelayState"
+const SigAlg = "SigAlg"
+const Signature = "Signature"
+
+// cspPostFormScript AuthnRequest・LogoutRequestのPost()が出力する自動送信スクリプトのハッシュ
+const cspPostFormScript = "'sha256-AjPdJSbZmeWHnEc5ykvJFay8FTWeTeRbs9dutfZ0HqE='"
+
+// cspLogoutResponsePostFormScript LogoutResponseのPost()が出力する自動送信スクリプトのハッシュ
+const cspLogoutResponsePostFormScript = "'sha256-ae3F9sw3MnGNUqmT+7gdyojm/I6ukOUOr9mHRkJJvCU='"
+
+// maxInflatedSamlMessageSize HTTP-RedirectバインディングのSAMLメッセージを展開する最大サイズ (圧縮爆弾対策)
+const maxInflatedSamlMessageSize = 1 << 20
+
+// signatureHashes HTTP-Redirectバインディングの署名アルゴリズム (SigAlg) とハッシュ関数の対応
+var signatureHashes = map[string]crypto.Hash{
+	signatureMethods["rsa-sha1"]:   crypto.SHA1,
+	signatureMethods["rsa-sha256"]: crypto.SHA256,
+	signatureMethods["rsa-sha512"]: crypto.SHA512,
+}
+
+var ErrRedirectSignatureMissing = errors.New("saml: redirect binding message is not signed")
+var ErrRedirectSignatureMismatch = errors.New("saml: redirect binding signature mismatch")
+var ErrUnsupportedSigAlg = errors.New("saml: unsupported SigAlg")
+var ErrPostSignatureMissing = errors.New("saml: post binding message is not signed")
+var ErrNoIdpSigningCertificate = errors.New("saml: no idp signing certificate")
+var ErrSamlMessageTooLarge = errors.New("saml: inflated message too large")
+var ErrRedirectQueryAmbiguous = errors.New("saml: redirect binding parameter is duplicated or has an encoded key")
+
+// redirectBindingParams HTTP-Redirectバインディングのクエリ文字列のパラメータ
+var redirectBindingParams = []string{SAMLRequest, SAMLResponse, RelayState, SigAlg, Signature}
+
+// samlMessage idPに送信するSAMLメッセージ (AuthnRequest・LogoutRequest・LogoutResponse)
+type samlMessage interface {
+	Post(relayState string) []byte
+}
+
+// selectBinding 設定 (saml.binding) のバインディングを優先し、idPのメタデータにエンドポイントが存在するバインディングとそのURLを返却する
+func selectBinding(location func(binding string) string) (string, string) {
+	bindings := []string{cs.HTTPPostBinding, cs.HTTPRedirectBinding}
+	if common.GetConfig().Saml.Binding == common.SamlBindingRedirect {
+		bindings = []string{cs.HTTPRedirectBinding, cs.HTTPPostBinding}
+	}
+	for _, binding := range bindings {
+		if url := location(binding); url != "" {
+			return binding, url
+		}
+	}
+	// どちらも無い場合はPOSTとする (宛先が空のためidPへの送信に失敗する)
+	return cs.HTTPPostBinding, ""
+}
+
+// isRedirectBinding 受信したSAMLメッセージがHTTP-Redirectバインディング (クエリ文字列) か判定する
+func isRedirectBinding(request *http.Request) bool {
+	return request.Method == http.MethodGet
+}
+
+// sendSamlMessage SAMLメッセージをバインディングに従ってidPに送信する。
+// POSTの場合は自動送信するFormを含むHTMLを返却し、HTTP-Redirectの場合はクエリ文字列に含めてリダイレクトさせる
+func sendSamlMessage(ctx echo.Context, sp *cs.ServiceProvider, binding string, destination string, param string, message samlMessage, relayState string, scriptHash string) error {
+	if binding == cs.HTTPRedirectBinding {
+		redirectURL, err := redirectBindingURL(sp, destination, param, message, relayState)
+		if err != nil {
+			return err
+		}
+		return ctx.Redirect(http.StatusFound, redirectURL)
+	}
+
+	// ヘッダの設定
+	ctx.Response().Header().Add(echo.HeaderContentSecurityPolicy, "default-src; "+
+		"script-src "+scriptHash+"; "+
+		"reflected-xss block; referrer no-referrer;")
+	ctx.Response().Header().Add(echo.HeaderContentType, echo.MIMETextHTML)
+
+	// Bodyの内容組み立て
+	var buf bytes.Buffer
+	buf.WriteString(`<!DOCTYPE html><html><body>`)
+	buf.Write(message.Post(relayState))
+	buf.WriteString(`</body></html>`)
+
+	// text/htmlとしてレスポンスを返却する
+	if err := ctx.HTML(http.StatusOK, buf.String()); err != nil {
+		return lang.NewFxtError(lang.ErrSSOHtmlWriting).SetCause(err)
+	}
+	return nil
+}
+
+// redirectBindingURL SAMLメッセージをHTTP-Redirectバインディングで送信するURLを作成する。
+// SPの鍵ペアを設定した場合はXML署名の代わりにクエリ文字列に署名する (SAML Bindings 3.4.4.1)
+func redirectBindingURL(sp *cs.ServiceProvider, destination string, param string, message samlMessage, relayState string) (string, error) {
+	// クエリ文字列に署名するため、メッセージ内のXML署名は除く
+	switch m := message.(type) {
+	case *cs.AuthnRequest:
+		m.Signature = nil
+	case *cs.LogoutRequest:
+		m.Signature = nil
+	case *cs.LogoutResponse:
+		m.Signature = nil
+	}
+	messageXML, err := xml.Marshal(message)
+	if err != nil {
+		return "", lang.NewFxtError(lang.ErrSSOHtmlWriting).SetCause(err)
+	}
+
+	// DEFLATEで圧縮し、base64でエンコードする
+	var compressed bytes.Buffer
+	writer, _ := flate.NewWriter(&compressed, flate.BestCompression)
+	if _, err := writer.Write(messageXML); err != nil {
+		return "", lang.NewFxtError(lang.ErrSSOHtmlWriting).SetCause(err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", lang.NewFxtError(lang.ErrSSOHtmlWriting).SetCause(err)
+	}
+
+	// 署名対象はパラメータの順序が決まっているため、url.Valuesを使用せずに組み立てる
+	query := param + "=" + url.QueryEscape(base64.StdEncoding.EncodeToString(compressed.Bytes()))
+	if relayState != "" {
+		query += "&" + RelayState + "=" + url.QueryEscape(relayState)
+	}
+	if sp.SignatureMethod != "" {
+		query += "&" + SigAlg + "=" + url.QueryEscape(sp.SignatureMethod)
+		signingContext, err := cs.GetSigningContext(sp)
+		if err != nil {
+			return "", lang.NewFxtError(lang.ErrInvalidSpKeyPair).SetCause(err)
+		}
+		signature, err := signingContext.SignString(query)
+		if err != nil {
+			return "", lang.NewFxtError(lang.ErrInvalidSpKeyPair).SetCause(err)
+		}
+		query += "&" + Signature + "=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+	}
+
+	// idPのURLにクエリ文字列が含まれる場合は末尾に追加する
+	if strings.Contains(destination, "?") {
+		return destination + "&" + query, nil
+	}
+	return destination + "?" + query, nil
+}
+
+// decodeSamlMessage 受信したSAMLメッセージをデコードする。
+// HTTP-Redirectバインディングの場合は署名を検証する値と同じクエリ文字列の値をbase64でデコードした後にDEFLATEを展開する。
+// base64のデコードに失敗した場合はbase64ErrCodeのエラーを返却する
+func decodeSamlMessage(request *http.Request, param string, base64ErrCode lang.ErrorCode) ([]byte, error) {
+	encoded := request.Form.Get(param)
+	if isRedirectBinding(request) {
+		values, err := rawQueryValues(request.URL.RawQuery)
+		if err != nil {
+			return nil, lang.NewFxtError(lang.ErrSamlRedirectSignature).SetCause(err)
+		}
+		if encoded, err = url.QueryUnescape(values[param]); err != nil {
+			return nil, lang.NewFxtError(base64ErrCode).SetCause(err)
+		}
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, lang.NewFxtError(base64ErrCode).SetCause(err)
+	}
+	if !isRedirectBinding(request) {
+		return decoded, nil
+	}
+
+	reader := flate.NewReader(bytes.NewReader(decoded))
+	defer reader.Close()
+	inflated, err := io.ReadAll(io.LimitReader(reader, maxInflatedSamlMessageSize+1))
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrInflateSamlMessage).SetCause(err)
+	}
+	if len(inflated) > maxInflatedSamlMessageSize {
+		return nil, lang.NewFxtError(lang.ErrInflateSamlMessage).SetCause(ErrSamlMessageTooLarge)
+	}
+	return inflated, nil
+}
+
+// verifyRedirectSignature HTTP-Redirectバインディングのクエリ文字列の署名をidPの署名証明書で検証する。
+// 署名の無いメッセージはGETのリンクで偽造できるため受け付けない
+func verifyRedirectSignature(sp *cs.ServiceProvider, rawQuery string, param string) error {
+	// 署名の検証は受信したURLエンコードのままの値で行う (SAML Bindings 3.4.4.1)
+	values, err := rawQueryValues(rawQuery)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrSamlRedirectSignature).SetCause(err)
+	}
+	message := values[param]
+	sigAlg, hasSigAlg := values[SigAlg]
+	signatureEncoded, hasSignature := values[Signature]
+	if !hasSigAlg || !hasSignature {
+		return lang.NewFxtError(lang.ErrSamlRedirectSignature).SetCause(ErrRedirectSignatureMissing)
+	}
+	signed := param + "=" + message
+	if relayState, ok := values[RelayState]; ok {
+		signed += "&" + RelayState + "=" + relayState
+	}
+	signed += "&" + SigAlg + "=" + sigAlg
+
+	algorithm, err := url.QueryUnescape(sigAlg)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrSamlRedirectSignature).SetCause(err)
+	}
+	hash, ok := signatureHashes[algorithm]
+	if !ok {
+		return lang.NewFxtError(lang.ErrSamlRedirectSignature).SetCause(ErrUnsupportedSigAlg)
+	}
+	signatureBase64, err := url.QueryUnescape(signatureEncoded)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrSamlRedirectSignature).SetCause(err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(signatureBase64)
+	if err != nil {
+		return lang.NewFxtError(lang.ErrSamlRedirectSignature).SetCause(err)
+	}
+
+	certificates, err := idpSigningCertificates(sp)
+	if err != nil {
+		return err
+	}
+	hasher := hash.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+	// 証明書のローテーション中はメタデータに複数の署名証明書が含まれるため、いずれかで検証できれば良い
+	for _, certificate := range certificates {
+		if publicKey, ok := certificate.PublicKey.(*rsa.PublicKey); ok {
+			if rsa.VerifyPKCS1v15(publicKey, hash, digest, signature) == nil {
+				return nil
+			}
+		}
+	}
+	return lang.NewFxtError(lang.ErrSamlRedirectSignature).SetCause(ErrRedirectSignatureMismatch)
+}
+
+// verifyPostSignature HTTP-POSTバインディングで受信したSAMLメッセージのXML署名 (enveloped signature) をidPの署名証明書で検証し、
+// 署名で保護された要素のXMLを返却する (署名の範囲外の要素による改ざんを防ぐため、以降はこのXMLのみを使用する)。
+// 署名が無い場合はrequiredがtrueの場合のみエラーとし、受信したXMLをそのまま返却する
+func verifyPostSignature(sp *cs.ServiceProvider, messageXML []byte, required bool) ([]byte, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(messageXML); err != nil || doc.Root() == nil {
+		return nil, lang.NewFxtError(lang.ErrSamlPostSignature).SetCause(err)
+	}
+	root := doc.Root()
+	if root.FindElement("./Signature") == nil {
+		if required {
+			return nil, lang.NewFxtError(lang.ErrSamlPostSignature).SetCause(ErrPostSignatureMissing)
+		}
+		return messageXML, nil
+	}
+
+	certificates, err := idpSigningCertificates(sp)
+	if err != nil {
+		return nil, err
+	}
+	validationContext := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: certificates})
+	validationContext.IdAttribute = "ID"
+	// KeyInfoに証明書が無い場合はメタデータの署名証明書で検証する (crewjam/samlのアサーションの検証と同様)
+	if root.FindElement("./Signature/KeyInfo/X509Data/X509Certificate") == nil {
+		if keyInfo := root.FindElement("./Signature/KeyInfo"); keyInfo != nil {
+			keyInfo.Parent().RemoveChild(keyInfo)
+		}
+	}
+	validated, err := validationContext.Validate(root)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrSamlPostSignature).SetCause(err)
+	}
+
+	verified := etree.NewDocument()
+	verified.SetRoot(validated)
+	verifiedXML, err := verified.WriteToBytes()
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrSamlPostSignature).SetCause(err)
+	}
+	return verifiedXML, nil
+}
+
+// rawQueryValues HTTP-Redirectバインディングのパラメータの値をURLエンコードされたままで返却する。
+// 署名を検証したパラメータと異なるパラメータのSAMLメッセージを処理しないよう、
+// パラメータが重複する場合やキーがURLエンコードされている場合 (e.g. SAML%52equest) はエラーとする
+func rawQueryValues(rawQuery string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, pair := range strings.Split(rawQuery, "&") {
+		k, v, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(redirectBindingParams, key) {
+			continue
+		}
+		if _, ok := values[key]; ok || k != key {
+			return nil, ErrRedirectQueryAmbiguous
+		}
+		values[key] = v
+	}
+	return values, nil
+}
+
+// idpSigningCertificates idPのメタデータから署名用の証明書を取り出す
+func idpSigningCertificates(sp *cs.ServiceProvider) ([]*x509.Certificate, error) {
+	certificates := []*x509.Certificate{}
+	for _, descriptor := range sp.IDPMetadata.IDPSSODescriptors {
+		for _, key := range descriptor.KeyDescriptors {
+			if key.Use != "" && key.Use != "signing" {
+				continue
+			}
+			for _, data := range key.KeyInfo.X509Data.X509Certificates {
+				der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(data.Data), ""))
+				if err != nil {
+					return nil, lang.NewFxtError(lang.ErrInvalidIdpMetadata).SetCause(err)
+				}
+				certificate, err := x509.ParseCertificate(der)
+				if err != nil {
+					return nil, lang.NewFxtError(lang.ErrInvalidIdpMetadata).SetCause(err)
+				}
+				certificates = append(certificates, certificate)
+			}
+		}
+	}
+	if len(certificates) == 0 {
+		return nil, lang.NewFxtError(lang.ErrSamlRedirectSignature).SetCause(ErrNoIdpSigningCertificate)
+	}
+	return certificates, nil
+}
+
+// validateRedirectLogoutRequest HTTP-Redirectバインディングで受信したLogoutRequestを検証する。
+// 署名したURLを再送してログアウトやプロビジョニングの解除を繰り返させないよう、宛先と有効期間も検証する
+func validateRedirectLogoutRequest(sp *cs.ServiceProvider, request *http.Request, logoutRequest *cs.LogoutRequest) error {
+	if err := verifyRedirectSignature(sp, request.URL.RawQuery, SAMLRequest); err != nil {
+		return err
+	}
+	if logoutRequest.Destination != sp.SloURL.String() {
+		return lang.NewFxtError(lang.ErrSLOValidation).SetCause(errors.New("saml: destination does not match SloURL"))
+	}
+	now := time.Now()
+	if logoutRequest.IssueInstant.Add(cs.MaxIssueDelay).Before(now) || now.Add(cs.MaxClockSkew).Before(logoutRequest.IssueInstant) {
+		return lang.NewFxtError(lang.ErrSLOValidation).SetCause(errors.New("saml: issueInstant is out of range"))
+	}
+	if logoutRequest.NotOnOrAfter != nil && !now.Before(logoutRequest.NotOnOrAfter.Add(cs.MaxClockSkew)) {
+		return lang.NewFxtError(lang.ErrSLOValidation).SetCause(errors.New("saml: notOnOrAfter expired"))
+	}
+	return nil
+}
+
+// validateRedirectLogoutResponse HTTP-Redirectバインディングで受信したLogoutResponseを検証する。
+// (crewjam/samlのValidateLogoutResponseRequestはクエリ文字列の署名を検証しないため)
+func validateRedirectLogoutResponse(sp *cs.ServiceProvider, request *http.Request, logoutResponse *cs.LogoutResponse) error {
+	if err := verifyRedirectSignature(sp, request.URL.RawQuery, SAMLResponse); err != nil {
+		return err
+	}
+	if logoutResponse.Destination != sp.SloURL.String() {
+		return lang.NewFxtError(lang.ErrSLOValidation).SetCause(errors.New("saml: destination does not match SloURL"))
+	}
+	if logoutResponse.IssueInstant.Add(cs.MaxIssueDelay).Before(time.Now()) {
+		return lang.NewFxtError(lang.ErrSLOValidation).SetCause(errors.New("saml: issueInstant expired"))
+	}
+	if logoutResponse.Issuer == nil || logoutResponse.Issuer.Value != sp.IDPMetadata.EntityID {
+		return lang.NewFxtError(lang.ErrSLOValidation).SetCause(errors.New("saml: issuer does not match the idp metadata"))
+	}
+	if logoutResponse.Status.StatusCode.Value != cs.StatusSuccess {
+		return lang.NewFxtError(lang.ErrSLOValidation).SetCause(errors.New("saml: status code was not success"))
+	}
+	return nil
+}
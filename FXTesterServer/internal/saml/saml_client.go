@@ -1,11 +1,14 @@
 package saml
 
 import (
-	"bytes"
 	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/xml"
 	"errors"
+	"fxtester/internal/audit"
 	"fxtester/internal/common"
 	"fxtester/internal/db"
 	"fxtester/internal/gen"
@@ -15,6 +18,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -32,6 +36,16 @@ const SAMLResponse = "SAMLResponse"
 
 const URLParamSamlError = "saml_error"
 
+// MIMESamlMetadata SPのメタデータのContent-Type
+const MIMESamlMetadata = "application/samlmetadata+xml"
+
+// signatureMethods 設定の署名アルゴリズム名とXML署名のアルゴリズムの対応
+var signatureMethods = map[string]string{
+	"rsa-sha1":   "http://www.w3.org/2000/09/xmldsig#rsa-sha1",
+	"rsa-sha256": "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256",
+	"rsa-sha512": "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512",
+}
+
 type IDelegator interface {
 	OpenFile(path string) (io.ReadCloser, error)
 	FetchMetadata(ctx context.Context, url url.URL, timeout time.Duration) (*cs.EntityDescriptor, error)
@@ -65,19 +79,31 @@ type ISamlClient interface {
 	ExecuteSamlLogout(ctx echo.Context, params gen.GetSamlLogoutParams) error
 	ExecuteSamlSlo(ctx echo.Context) error
 	ExecuteSamlError(ctx echo.Context) error
+	ExecuteSamlMetadata(ctx echo.Context) error
+	StartMetadataRefresh(ctx context.Context, logger echo.Logger)
+	MetadataStatus() []IdpMetadataStatus
 }
 
 type SamlClient struct {
 	delegate IDelegator
-	sp       cs.ServiceProvider
-	dao      db.IUserEntityDao
+	// 設定したidP毎のサービスプロバイダ (先頭が既定のidP)
+	idps []*samlIdp
+	// idPのメタデータ以外を設定したサービスプロバイダ (idPのメタデータを設定して使用する)
+	spTemplate cs.ServiceProvider
+	dao        db.IUserEntityDao
+	// 使用済みのSAMLアサーションのID
+	assertions IAssertionStore
+	// ログイン・ログアウトの監査イベントの記録先
+	auditor *audit.Recorder
 }
 
 // NewSamlClient SAMLクライアントを生成します
 func NewSamlClient(delegate IDelegator, idb db.IDB) ISamlClient {
 	return &SamlClient{
-		delegate: delegate,
-		dao:      db.NewUserEntityDao(idb),
+		delegate:   delegate,
+		dao:        db.NewUserEntityDao(idb),
+		assertions: NewAssertionStore(idb),
+		auditor:    audit.NewRecorder(idb),
 	}
 }
 
@@ -89,30 +115,156 @@ func (c *SamlClient) Init() error {
 		return lang.NewFxtError(lang.ErrCodeConfig).SetCause(err)
 	}
 
-	// idPのmetadata.xmlをフェッチする
-	idpMetadata, err := c.FetchIdpMetadata()
+	configs := common.GetConfig().SamlIdps()
+	if len(configs) == 0 {
+		return lang.NewFxtError(lang.ErrCodeConfig)
+	}
+
+	// 使用済みのSAMLアサーションのIDを保持するストア
+	switch common.GetConfig().Saml.AssertionStore {
+	case "", common.SamlAssertionStoreMemory, common.SamlAssertionStorePostgres:
+	default:
+		return lang.NewFxtError(lang.ErrCodeConfig)
+	}
+	if c.assertions == nil {
+		c.assertions = NewMemoryAssertionStore()
+	}
+
+	// SPの署名・暗号化用の鍵ペアを読み込む (未設定の場合は署名しない)
+	key, certificate, err := c.loadSpKeyPair()
 	if err != nil {
 		return err
 	}
+	signatureMethod := ""
+	if key != nil {
+		name := common.GetConfig().Saml.Sp.SignatureMethod
+		if name == "" {
+			name = "rsa-sha256"
+		}
+		var ok bool
+		if signatureMethod, ok = signatureMethods[name]; !ok {
+			return lang.NewFxtError(lang.ErrCodeConfig)
+		}
+	}
+
+	// idPに送信するSAMLメッセージのバインディング
+	switch common.GetConfig().Saml.Binding {
+	case "", common.SamlBindingPost, common.SamlBindingRedirect:
+	default:
+		return lang.NewFxtError(lang.ErrCodeConfig)
+	}
 
 	opts := cssp.Options{
 		EntityID:    common.GetConfig().Saml.EntityId,
 		URL:         *backendURL, // acsやsloのURLを作成する際のベースとなるURL
-		IDPMetadata: idpMetadata,
 		SignRequest: false,
+		// SLOはidPからのPOSTとHTTP-Redirectの両方を受け付ける
+		LogoutBindings: []string{cs.HTTPPostBinding, cs.HTTPRedirectBinding},
 	}
 	sp := cssp.DefaultServiceProvider(opts)
 	sp.AuthnNameIDFormat = cs.UnspecifiedNameIDFormat
-	c.sp = sp
+	// 鍵を設定した場合、AuthnRequest・LogoutRequest・LogoutResponseに署名し、暗号化されたアサーションを復号する
+	sp.Key = key
+	sp.Certificate = certificate
+	sp.SignatureMethod = signatureMethod
+	c.spTemplate = sp
+
+	idps := make([]*samlIdp, 0, len(configs))
+	for _, config := range configs {
+		// idPの名前は/saml/login?idp=で指定するため、空や重複を許可しない
+		if config.Name == "" || slices.ContainsFunc(idps, func(idp *samlIdp) bool { return idp.name == config.Name }) {
+			return lang.NewFxtError(lang.ErrCodeConfig)
+		}
+
+		// idPのmetadata.xmlをフェッチする
+		idp := &samlIdp{
+			name:         config.Name,
+			metadataUrl:  config.IdpMetadataUrl,
+			emailDomains: config.EmailDomains,
+		}
+		if err := c.refreshIdpMetadata(idp, time.Now()); err != nil {
+			return err
+		}
+		idps = append(idps, idp)
+	}
+	c.idps = idps
 	return nil
 }
 
+// selectIdp ログインに使用するidPを選択する。idPの名前の指定、Emailのドメインに一致するidP、既定のidPの順に優先する
+func (c *SamlClient) selectIdp(params gen.GetSamlLoginParams) (*samlIdp, error) {
+	if params.Idp != nil && *params.Idp != "" {
+		for _, idp := range c.idps {
+			if idp.name == *params.Idp {
+				return idp, nil
+			}
+		}
+		return nil, lang.NewFxtError(lang.ErrInvalidParameterError, "idp")
+	}
+	if params.Email != nil {
+		if at := strings.LastIndex(*params.Email, "@"); at >= 0 {
+			domain := strings.ToLower((*params.Email)[at+1:])
+			for _, idp := range c.idps {
+				if slices.ContainsFunc(idp.emailDomains, func(v string) bool { return strings.ToLower(v) == domain }) {
+					return idp, nil
+				}
+			}
+		}
+	}
+	return c.idps[0], nil
+}
+
+// idpByName ログイン時に使用したidPを返却する (設定から削除された場合等、見つからない場合は既定のidP)
+func (c *SamlClient) idpByName(name string) *samlIdp {
+	for _, idp := range c.idps {
+		if idp.name == name {
+			return idp
+		}
+	}
+	return c.idps[0]
+}
+
+// idpByIssuer SAMLメッセージのIssuerに一致するEntityIDのidPを返却する。
+// idPが1つのみの場合はIssuerに関わらずそのidPとする (Issuerの検証はSAMLメッセージの検証で行われる)
+func (c *SamlClient) idpByIssuer(issuer *cs.Issuer) (*samlIdp, error) {
+	if len(c.idps) == 1 {
+		return c.idps[0], nil
+	}
+	if issuer != nil {
+		for _, idp := range c.idps {
+			if idp.serviceProvider().IDPMetadata.EntityID == issuer.Value {
+				return idp, nil
+			}
+		}
+	}
+	return nil, lang.NewFxtError(lang.ErrUnknownSamlIssuer)
+}
+
+// responseIssuer POSTされたSAMLResponseのIssuerを取り出す (解析できない場合はnil)
+func responseIssuer(form url.Values) *cs.Issuer {
+	samlResponseXML, err := base64.StdEncoding.DecodeString(form.Get(SAMLResponse))
+	if err != nil {
+		return nil
+	}
+	var response cs.Response
+	if err := xml.Unmarshal(samlResponseXML, &response); err != nil {
+		return nil
+	}
+	return response.Issuer
+}
+
 // ExecuteSamlLogin SAMLのSSOを開始します
 func (c *SamlClient) ExecuteSamlLogin(ctx echo.Context, params gen.GetSamlLoginParams) error {
-	// idpURLを取得
-	idpURL := c.sp.GetSSOBindingLocation(cs.HTTPPostBinding)
-	// AuthnRequestの作成
-	authnRequest, err := c.sp.MakeAuthenticationRequest(idpURL, cs.HTTPPostBinding, cs.HTTPPostBinding)
+	// ログインに使用するidPを選択
+	idp, err := c.selectIdp(params)
+	if err != nil {
+		return err
+	}
+	// 送信するバインディングとidpURLを取得
+	sp := idp.serviceProvider()
+	binding, idpURL := selectBinding(sp.GetSSOBindingLocation)
+	// AuthnRequestの作成 (SAMLResponseはバインディングに関わらずACSへのPOSTで受け取る)
+	authnRequest, err := sp.MakeAuthenticationRequest(idpURL, binding, cs.HTTPPostBinding)
 	if err != nil {
 		return lang.NewFxtError(lang.ErrSSOAuthnRequest)
 	}
@@ -123,26 +275,10 @@ func (c *SamlClient) ExecuteSamlLogin(ctx echo.Context, params gen.GetSamlLoginP
 		return err
 	}
 
-	// ヘッダの設定
-	ctx.Response().Header().Add(echo.HeaderContentSecurityPolicy, "default-src; "+
-		"script-src 'sha256-AjPdJSbZmeWHnEc5ykvJFay8FTWeTeRbs9dutfZ0HqE='; "+
-		"reflected-xss block; referrer no-referrer;")
-	ctx.Response().Header().Add(echo.HeaderContentType, echo.MIMETextHTML)
-
 	const emptyRelayState = ""
 
-	// Bodyの内容組み立て
-	var buf bytes.Buffer
-	buf.WriteString(`<!DOCTYPE html><html><body>`)
-	buf.Write(authnRequest.Post(emptyRelayState))
-	buf.WriteString(`</body></html>`)
-
-	// text/htmlとしてレスポンスを返却する
-	if err = ctx.HTML(http.StatusOK, buf.String()); err != nil {
-		return lang.NewFxtError(lang.ErrSSOHtmlWriting).SetCause(err)
-	}
-
-	return nil
+	// AuthnRequestをidPに送信する
+	return sendSamlMessage(ctx, sp, binding, idpURL, SAMLRequest, authnRequest, emptyRelayState, cspPostFormScript)
 }
 
 func (s *SamlClient) ExecuteSamlAcs(ctx echo.Context) (lastError error) {
@@ -158,11 +294,22 @@ func (s *SamlClient) ExecuteSamlAcs(ctx echo.Context) (lastError error) {
 		return err
 	}
 
+	// 監査イベントに記録するユーザとidP (特定できた場合のみ)
+	var email, idpName string
+
 	// エラーの有無によってリダイレクト先やパラメータを変更するdefer
 	defer func() {
 		// SSOのセッションを破棄する
 		net.DeleteSSOSession(ctx.Response().Writer)
 
+		// ログインの成否を監査イベントに記録する
+		s.auditor.Record(ctx, audit.Event{
+			Type:   audit.EventLogin,
+			Email:  email,
+			Err:    lastError,
+			Detail: samlAuditDetail(idpName),
+		})
+
 		if lastError != nil {
 			ctx.Logger().Errorf("failed ExecuteSamlAcs: %v", lastError)
 
@@ -185,9 +332,16 @@ func (s *SamlClient) ExecuteSamlAcs(ctx echo.Context) (lastError error) {
 		}
 	}()
 
+	// SAMLResponseのIssuerからidPを特定する
+	idp, err := s.idpByIssuer(responseIssuer(ctx.Request().PostForm))
+	if err != nil {
+		return err
+	}
+	idpName = idp.name
+
 	// SAMLResponseを解析する
 	possibleRequestIds := []string{session.AuthnRequestId}
-	assertion, err := s.delegate.ParseAuthResponse(s.sp, ctx.Request(), possibleRequestIds)
+	assertion, err := s.delegate.ParseAuthResponse(*idp.serviceProvider(), ctx.Request(), possibleRequestIds)
 	if err != nil {
 		// SAMLResponseの解析に失敗した場合 (metadata.xmlとの鍵の不一致等)
 		return lang.NewFxtError(lang.ErrCodeSSOParseResponse).SetCause(err)
@@ -199,48 +353,31 @@ func (s *SamlClient) ExecuteSamlAcs(ctx echo.Context) (lastError error) {
 		return lang.NewFxtError(lang.ErrUnexpectedAssertion).SetCause(err)
 	}
 	// アサーションのNameIdとemailとする (keycloakの設定が正しければemailになっている)
-	email := assertion.Subject.NameID.Value
+	email = assertion.Subject.NameID.Value
 
-	return func() (lastError error) {
-		// トランザクション開始
-		if err := s.dao.Begin(); err != nil {
-			return err
-		}
+	// 同じアサーションの再送を拒否する (InResponseToの検証のみでは、SSOのセッションが有効な間は同じアサーションを受け付けてしまうため)
+	if assertion.ID == "" {
+		return lang.NewFxtError(lang.ErrUnexpectedAssertion)
+	}
+	if consumed, err := s.assertions.Consume(assertion.ID, assertionExpiry(assertion, time.Now())); err != nil {
+		return err
+	} else if !consumed {
+		return lang.NewFxtError(lang.ErrSamlAssertionReplayed)
+	}
 
-		defer func() {
-			// エラーの有無に応じてRollbackまたはCommitを実行する
-			if lastError != nil {
-				err := s.dao.Rollback()
-				if err != nil {
-					// ロールバック失敗時は本来のエラーを書き換えないようにlastErrorはそのままにする
-					ctx.Logger().Errorf("failed Rollback: %v", err)
-				}
-			} else {
-				err := s.dao.Commit()
-				if err != nil {
-					lastError = err
-				}
-			}
-		}()
-
-		// ユーザが存在するか確認する
-		entity, err := s.dao.SelectWithEmail(email)
-		if errors.Is(err, db.ErrNoData) {
-			// ユーザが存在しない場合はユーザを作成する
-			entity, err = s.dao.CreateUser(email)
-			if err != nil {
-				return err
-			}
-		} else if err != nil {
+	// idPでアカウントが無効化されている場合はプロビジョニングを解除し、ログインさせない
+	if assertionDisabled(assertion) {
+		if err := net.DeprovisionUser(ctx, s.dao, email); err != nil {
 			return err
 		}
+		return lang.NewFxtError(lang.ErrAccountDisabled)
+	}
 
-		// 認証セッションを作成する
-		return net.CreateAuthSession(ctx.Response().Writer, entity.UserId, entity.Email, func(accessToken, refreshToken string) error {
-			// トークンをDBに保存
-			return s.dao.UpdateToken(entity.UserId, accessToken, refreshToken)
-		})
-	}()
+	// アサーションのロール属性からアプリケーションで使用するロールを取り出す
+	roles := assertionRoles(assertion)
+
+	// ユーザの作成・ロールと属性の同期・ログインセッションの作成を行う
+	return net.LoginUser(ctx, s.dao, email, roles, assertionAttributes(assertion), idp.name, samlSessionIndex(assertion))
 }
 
 func (c *SamlClient) ExecuteSamlLogout(ctx echo.Context, params gen.GetSamlLogoutParams) error {
@@ -250,44 +387,32 @@ func (c *SamlClient) ExecuteSamlLogout(ctx echo.Context, params gen.GetSamlLogou
 		return err
 	}
 
-	// idpURLを取得
-	idpURL := c.sp.GetSLOBindingLocation(cs.HTTPPostBinding)
+	// ログイン時に使用したidPの送信するバインディングとidpURLを取得
+	idp := c.idpByName(session.Idp)
+	sp := idp.serviceProvider()
+	binding, idpURL := selectBinding(sp.GetSLOBindingLocation)
 	// LogoutRequestの作成
-	logoutRequest, err := c.sp.MakeLogoutRequest(idpURL, session.Email)
+	logoutRequest, err := sp.MakeLogoutRequest(idpURL, session.Email)
 	if err != nil {
 		return lang.NewFxtError(lang.ErrSLOAuthnRequest)
 	}
 
 	// SLOセッションの作成
-	err = net.CreateSLOSession(ctx.Response().Writer, session.UserId, logoutRequest.ID, params.XRedirectURL, params.XRedirectURLOnError)
+	err = net.CreateSLOSession(ctx.Response().Writer, session.UserId, session.SessionId, logoutRequest.ID, params.XRedirectURL, params.XRedirectURLOnError)
 	if err != nil {
 		return err
 	}
 
-	// ヘッダの設定
-	ctx.Response().Header().Add(echo.HeaderContentSecurityPolicy, "default-src; "+
-		"script-src 'sha256-AjPdJSbZmeWHnEc5ykvJFay8FTWeTeRbs9dutfZ0HqE='; "+
-		"reflected-xss block; referrer no-referrer;")
-	ctx.Response().Header().Add(echo.HeaderContentType, echo.MIMETextHTML)
-
 	const emptyRelayState = ""
 
-	// Bodyの内容組み立て
-	var buf bytes.Buffer
-	buf.WriteString(`<!DOCTYPE html><html><body>`)
-	buf.Write(logoutRequest.Post(emptyRelayState))
-	buf.WriteString(`</body></html>`)
-
-	// text/htmlとしてレスポンスを返却する
-	if err = ctx.HTML(http.StatusOK, buf.String()); err != nil {
-		return lang.NewFxtError(lang.ErrSSOHtmlWriting).SetCause(err)
-	}
-
-	return nil
+	// LogoutRequestをidPに送信する
+	return sendSamlMessage(ctx, sp, binding, idpURL, SAMLRequest, logoutRequest, emptyRelayState, cspPostFormScript)
 }
 
+// ExecuteSamlSlo idPから受け取るLogoutRequest・LogoutResponseを処理します。
+// POSTの場合はリクエストボディ、GETの場合はクエリ文字列 (HTTP-Redirectバインディング) から取り出します
 func (c *SamlClient) ExecuteSamlSlo(ctx echo.Context) error {
-	// リクエストのFormを解析する (のちの処理で'ctx.Request().PostForm'を参照できるようにするため)
+	// リクエストのFormを解析する (のちの処理で'ctx.Request().Form'を参照できるようにするため)
 	err := ctx.Request().ParseForm()
 	if err != nil {
 		return lang.NewFxtError(lang.ErrRequestParse).SetCause(err)
@@ -307,12 +432,28 @@ func (c *SamlClient) ExecuteSamlSlo(ctx echo.Context) error {
 	return lang.NewFxtError(lang.ErrOperationNotAllow)
 }
 
+// logoutRequestReason LogoutRequestのReason属性 (ログアウトの理由を示すURI)
+type logoutRequestReason struct {
+	Reason string `xml:",attr"`
+}
+
 // executeSamlSloByOther 他SP起点のシングルサインアウトを処理する
 func (c *SamlClient) executeSamlSloByOther(ctx echo.Context) (lastError error) {
+	// 監査イベントに記録するユーザと補足情報 (特定できた場合のみ)
+	var nameId, detail string
+
 	if err := c.dao.Begin(); err != nil {
 		return err
 	}
 	defer func() {
+		// シングルログアウトの成否を監査イベントに記録する
+		c.auditor.Record(ctx, audit.Event{
+			Type:   audit.EventLogout,
+			Email:  nameId,
+			Err:    lastError,
+			Detail: detail,
+		})
+
 		if lastError != nil {
 			if err := c.dao.Rollback(); err != nil {
 				ctx.Logger().Warnf("Error in rollback: %v", err)
@@ -324,12 +465,10 @@ func (c *SamlClient) executeSamlSloByOther(ctx echo.Context) (lastError error) {
 		}
 	}()
 
-	// リクエストボディからSAMLRequestを取り出す
-	samlRequestEncoded := ctx.Request().Form.Get(SAMLRequest)
-	// base64でエンコードされているSAMLRequestをデコードする
-	samlRequestXML, err := base64.StdEncoding.DecodeString(samlRequestEncoded)
+	// リクエストからSAMLRequestを取り出し、デコードする
+	samlRequestXML, err := decodeSamlMessage(ctx.Request(), SAMLRequest, lang.ErrBase64SamlRequest)
 	if err != nil {
-		return lang.NewFxtError(lang.ErrBase64SamlRequest).SetCause(err)
+		return err
 	}
 
 	var logoutRequest cs.LogoutRequest
@@ -338,8 +477,43 @@ func (c *SamlClient) executeSamlSloByOther(ctx echo.Context) (lastError error) {
 		return lang.NewFxtError(lang.ErrUnmarshalSamlRequest).SetCause(err)
 	}
 
+	// LogoutRequestのIssuerのidPを特定する
+	idp, err := c.idpByIssuer(logoutRequest.Issuer)
+	if err != nil {
+		return err
+	}
+	detail = samlAuditDetail(idp.name)
+	sp := idp.serviceProvider()
+	if isRedirectBinding(ctx.Request()) {
+		// HTTP-Redirectバインディングの場合はクエリ文字列の署名と宛先・有効期間を検証する
+		if err := validateRedirectLogoutRequest(sp, ctx.Request(), &logoutRequest); err != nil {
+			return err
+		}
+	} else {
+		// HTTP-POSTバインディングの場合はXML署名を検証する。
+		// プロビジョニングの解除 (saml.deprovisionLogoutReasons) が有効な場合は、偽造したLogoutRequestでアカウントを無効化されないよう署名を必須とする
+		verifiedXML, err := verifyPostSignature(sp, samlRequestXML, len(common.GetConfig().Saml.DeprovisionLogoutReasons) > 0)
+		if err != nil {
+			return err
+		}
+		// 以降は署名で保護されたLogoutRequestのみを使用する
+		samlRequestXML = verifiedXML
+		logoutRequest = cs.LogoutRequest{}
+		if err := xml.Unmarshal(samlRequestXML, &logoutRequest); err != nil {
+			return lang.NewFxtError(lang.ErrUnmarshalSamlRequest).SetCause(err)
+		}
+	}
+	// ログアウトの理由を取り出す (crewjam/samlのLogoutRequestはReason属性を保持しないため)
+	var logoutReason logoutRequestReason
+	if err := xml.Unmarshal(samlRequestXML, &logoutReason); err != nil {
+		return lang.NewFxtError(lang.ErrUnmarshalSamlRequest).SetCause(err)
+	}
+	if logoutReason.Reason != "" {
+		detail += " reason=" + logoutReason.Reason
+	}
+
 	// LogoutRequestからnameIDを取り出す
-	nameId, err := func() (string, error) {
+	nameId, err = func() (string, error) {
 		if logoutRequest.NameID == nil || logoutRequest.NameID.Value == "" {
 			// nameIDが格納されていない場合
 			return "", lang.NewFxtError(lang.ErrEmptyNameId)
@@ -350,19 +524,30 @@ func (c *SamlClient) executeSamlSloByOther(ctx echo.Context) (lastError error) {
 		return err
 	}
 
-	// DBのクリア
+	// idPのセッションでログインしたセッションを失効させる (セッションインデックスが無い場合はユーザの全てのセッション)
+	sessionIndex := ""
+	if logoutRequest.SessionIndex != nil {
+		sessionIndex = logoutRequest.SessionIndex.Value
+	}
+	// ログアウトの理由がidPでのアカウントの無効化 (saml.deprovisionLogoutReasons) の場合はプロビジョニングを解除する
 	if user, err := c.dao.SelectWithEmail(nameId); err != nil {
 		ctx.Logger().Warnf("cannot select user: email=%s", nameId)
 		return err
-	} else if err = c.dao.UpdateToken(user.UserId, "", ""); err != nil {
-		ctx.Logger().Warnf("cannot update token: userId=%d", user.UserId)
+	} else if slices.Contains(common.GetConfig().Saml.DeprovisionLogoutReasons, logoutReason.Reason) {
+		if err = c.dao.DeprovisionUser(user.UserId); err != nil {
+			ctx.Logger().Warnf("cannot deprovision the user: userId=%d", user.UserId)
+			return err
+		}
+		detail += " deprovisioned"
+	} else if _, err = c.dao.DeleteSessions(user.UserId, sessionIndex); err != nil {
+		ctx.Logger().Warnf("cannot delete sessions: userId=%d", user.UserId)
 		return err
 	}
 
-	// idPのURLを取得
-	idpURL := c.sp.GetSLOBindingLocation(cs.HTTPPostBinding)
+	// 送信するバインディングとidPのURLを取得
+	binding, idpURL := selectBinding(sp.GetSLOBindingLocation)
 	// LogoutResponseを作成する
-	logoutResponse, err := c.sp.MakeLogoutResponse(idpURL, logoutRequest.ID)
+	logoutResponse, err := sp.MakeLogoutResponse(idpURL, logoutRequest.ID)
 	if err != nil {
 		return lang.NewFxtError(lang.ErrSamlLogoutResponseCreation).SetCause(err)
 	}
@@ -380,26 +565,8 @@ func (c *SamlClient) executeSamlSloByOther(ctx echo.Context) (lastError error) {
 	// Authセッションの削除
 	net.DeleteAuthSession(ctx.Response().Writer)
 
-	// ヘッダの設定
-	ctx.Response().Header().Add(echo.HeaderContentSecurityPolicy, "default-src; "+
-		"script-src 'sha256-ae3F9sw3MnGNUqmT+7gdyojm/I6ukOUOr9mHRkJJvCU='; "+
-		"reflected-xss block; referrer no-referrer;")
-	ctx.Response().Header().Add(echo.HeaderContentType, echo.MIMETextHTML)
-
-	const emptyRelayState = ""
-
-	// html作成
-	var buf bytes.Buffer
-	buf.WriteString(`<!DOCTYPE html><html><body>`)
-	buf.Write(logoutResponse.Post(emptyRelayState))
-	buf.WriteString(`</body></html>`)
-
-	// text/htmlとしてレスポンスを返却する
-	if err = ctx.HTML(http.StatusOK, buf.String()); err != nil {
-		return lang.NewFxtError(lang.ErrSSOHtmlWriting).SetCause(err)
-	}
-
-	return nil
+	// LogoutResponseをidPに送信する (LogoutRequestのRelayStateはそのまま返却する)
+	return sendSamlMessage(ctx, sp, binding, idpURL, SAMLResponse, logoutResponse, ctx.Request().Form.Get(RelayState), cspLogoutResponsePostFormScript)
 }
 
 // executeSamlSloByMySp 自SP起点のシングルサインアウトを処理する
@@ -419,6 +586,8 @@ func (c *SamlClient) executeSamlSloByMySp(ctx echo.Context) (lastError error) {
 			if err := c.dao.Rollback(); err != nil {
 				ctx.Logger().Warn("error in rollback: %v", err)
 			}
+			// シングルログアウトの失敗を監査イベントに記録する
+			c.auditor.Record(ctx, audit.Event{Type: audit.EventLogout, UserId: session.UserId, Err: lastError})
 
 			ctx.Logger().Error(lastError)
 
@@ -435,6 +604,7 @@ func (c *SamlClient) executeSamlSloByMySp(ctx echo.Context) (lastError error) {
 			lastError = ctx.Redirect(http.StatusFound, session.RedirectURLOnError+"?"+params.Encode())
 		} else {
 			if err := c.dao.Commit(); err != nil {
+				c.auditor.Record(ctx, audit.Event{Type: audit.EventLogout, UserId: session.UserId, Err: err})
 				// エラーレスポンスを作成
 				_, res := lang.ConvertToGenError(ctx, err)
 				// エラーの詳細をクッキーに保存
@@ -447,6 +617,7 @@ func (c *SamlClient) executeSamlSloByMySp(ctx echo.Context) (lastError error) {
 			}
 
 			// executeSamlSloByMySp()がエラーを返却しなかった場合
+			c.auditor.Record(ctx, audit.Event{Type: audit.EventLogout, UserId: session.UserId})
 			// エラーを空にする
 			net.CreateSamlErrorSession(ctx.Response().Writer, gen.Error{})
 			// リダイレクト要求を発効
@@ -454,12 +625,10 @@ func (c *SamlClient) executeSamlSloByMySp(ctx echo.Context) (lastError error) {
 		}
 	}()
 
-	// リクエストボディからSAMLResponseを取り出す
-	samlResponseEncoded := ctx.Request().Form.Get(SAMLResponse)
-	// base64でエンコードされているSAMLResponseをデコードする
-	samlResponseXML, err := base64.StdEncoding.DecodeString(samlResponseEncoded)
+	// リクエストからSAMLResponseを取り出し、デコードする
+	samlResponseXML, err := decodeSamlMessage(ctx.Request(), SAMLResponse, lang.ErrBase64SamlResponse)
 	if err != nil {
-		return lang.NewFxtError(lang.ErrBase64SamlResponse).SetCause(err)
+		return err
 	}
 
 	var logoutResponse cs.LogoutResponse
@@ -480,8 +649,17 @@ func (c *SamlClient) executeSamlSloByMySp(ctx echo.Context) (lastError error) {
 		return lang.NewFxtError(lang.ErrInvalidAuthnRequestId)
 	}
 
-	// LogoutResponseの検証
-	if err := c.delegate.ValidateLogoutResponseRequest(c.sp, ctx.Request()); err != nil {
+	// LogoutResponseの検証 (LogoutResponseのIssuerのidPで検証する)
+	idp, err := c.idpByIssuer(logoutResponse.Issuer)
+	if err != nil {
+		return err
+	}
+	if isRedirectBinding(ctx.Request()) {
+		// HTTP-Redirectバインディングの場合はクエリ文字列の署名を検証する
+		if err := validateRedirectLogoutResponse(idp.serviceProvider(), ctx.Request(), &logoutResponse); err != nil {
+			return err
+		}
+	} else if err := c.delegate.ValidateLogoutResponseRequest(*idp.serviceProvider(), ctx.Request()); err != nil {
 		return lang.NewFxtError(lang.ErrSLOValidation).SetCause(err)
 	}
 
@@ -489,14 +667,124 @@ func (c *SamlClient) executeSamlSloByMySp(ctx echo.Context) (lastError error) {
 	net.DeleteAuthSession(ctx.Response().Writer)
 	// SLOセッションの削除
 	net.DeleteSLOSession(ctx.Response().Writer)
-	// DBのクリア
-	if err := c.dao.UpdateToken(session.UserId, "", ""); err != nil {
+	// ログアウトしたセッションを失効させる (失効済みの場合も成功とする)
+	if err := c.dao.DeleteSession(session.UserId, session.SessionId); err != nil && !errors.Is(err, db.ErrNoData) {
 		return err
 	}
 
 	return nil
 }
 
+// assertionRoles アサーションのロール属性 (settings/config.yamlのsaml.roleAttribute) の値のうち、
+// アプリケーションで使用するロール (saml.roles) を重複なく返却する
+func assertionRoles(assertion *cs.Assertion) []string {
+	config := common.GetConfig().Saml
+	roles := []string{}
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			if attr.Name != config.RoleAttribute && attr.FriendlyName != config.RoleAttribute {
+				continue
+			}
+			for _, v := range attr.Values {
+				if slices.Contains(config.Roles, v.Value) && !slices.Contains(roles, v.Value) {
+					roles = append(roles, v.Value)
+				}
+			}
+		}
+	}
+	slices.Sort(roles)
+	return roles
+}
+
+// assertionAttributeValues アサーションの属性のうち、名前またはフレンドリ名がnameのものの値を返却する。
+// nameが空の場合や属性が無い場合はnilを返却する
+func assertionAttributeValues(assertion *cs.Assertion, name string) []string {
+	if name == "" {
+		return nil
+	}
+	var values []string
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			if attr.Name != name && attr.FriendlyName != name {
+				continue
+			}
+			if values == nil {
+				values = []string{}
+			}
+			for _, v := range attr.Values {
+				values = append(values, v.Value)
+			}
+		}
+	}
+	return values
+}
+
+// assertionAttributes アサーションからユーザに同期する属性 (settings/config.yamlのsaml.attributeMapping) を取り出す。
+// アサーションに含まれない属性は更新しない
+func assertionAttributes(assertion *cs.Assertion) *db.UserAttributesEntity {
+	mapping := common.GetConfig().Saml.AttributeMapping
+	first := func(name string) *string {
+		if values := assertionAttributeValues(assertion, name); values != nil {
+			value := ""
+			if 0 < len(values) {
+				value = values[0]
+			}
+			return &value
+		}
+		return nil
+	}
+
+	attributes := &db.UserAttributesEntity{
+		DisplayName: first(mapping.DisplayName),
+		GivenName:   first(mapping.GivenName),
+		FamilyName:  first(mapping.FamilyName),
+		Locale:      first(mapping.Locale),
+	}
+	if groups := assertionAttributeValues(assertion, mapping.Groups); groups != nil {
+		slices.Sort(groups)
+		attributes.Groups = slices.Compact(groups)
+	}
+	return attributes
+}
+
+// assertionDisabled アサーションの無効化属性 (saml.attributeMapping.disabled) がtrueの場合、idPでアカウントが無効化されていると判定する
+func assertionDisabled(assertion *cs.Assertion) bool {
+	for _, v := range assertionAttributeValues(assertion, common.GetConfig().Saml.AttributeMapping.Disabled) {
+		if strings.EqualFold(v, "true") {
+			return true
+		}
+	}
+	return false
+}
+
+// samlAuditDetail 監査イベントの補足情報 (プロトコルとidPの名前。idPを特定できなかった場合はプロトコルのみ)
+func samlAuditDetail(idpName string) string {
+	if idpName == "" {
+		return "saml"
+	}
+	return "saml idp=" + idpName
+}
+
+// samlSessionIndex アサーションのAuthnStatementからidPのセッションインデックスを取り出す
+func samlSessionIndex(assertion *cs.Assertion) string {
+	for _, statement := range assertion.AuthnStatements {
+		if statement.SessionIndex != "" {
+			return statement.SessionIndex
+		}
+	}
+	return ""
+}
+
+// ExecuteSamlMetadata SPのメタデータ (署名・暗号化用の証明書を含む) を返却します
+func (c *SamlClient) ExecuteSamlMetadata(ctx echo.Context) error {
+	// エンティティID・ACS・SLOのURLはidPに関わらず共通のため、既定のidPのSPから作成する
+	metadata, err := xml.MarshalIndent(c.idps[0].serviceProvider().Metadata(), "", "  ")
+	if err != nil {
+		return lang.NewFxtError(lang.ErrSamlMetadataCreation).SetCause(err)
+	}
+	return ctx.Blob(http.StatusOK, MIMESamlMetadata, append([]byte(xml.Header), metadata...))
+}
+
 func (c *SamlClient) ExecuteSamlError(ctx echo.Context) error {
 	session, err := net.GetSamlErrorSession(ctx.Request())
 	if err != nil {
@@ -507,8 +795,16 @@ func (c *SamlClient) ExecuteSamlError(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, session)
 }
 
+// FetchIdpMetadata 既定のidPのmetadata.xmlをフェッチします
 func (c *SamlClient) FetchIdpMetadata() (*cs.EntityDescriptor, error) {
-	idpMetadataUrl := common.GetConfig().Saml.IdpMetadataUrl
+	idps := common.GetConfig().SamlIdps()
+	if len(idps) == 0 {
+		return nil, lang.NewFxtError(lang.ErrCodeConfig)
+	}
+	return c.fetchIdpMetadata(idps[0].IdpMetadataUrl)
+}
+
+func (c *SamlClient) fetchIdpMetadata(idpMetadataUrl string) (*cs.EntityDescriptor, error) {
 	if strings.HasPrefix(idpMetadataUrl, SchemeFile) {
 		// ファイル読み込みの場合
 		return c.fetchIdpMetadataFromFile(idpMetadataUrl)
@@ -522,16 +818,10 @@ func (c *SamlClient) FetchIdpMetadata() (*cs.EntityDescriptor, error) {
 func (c *SamlClient) fetchIdpMetadataFromFile(idpMetadataUrl string) (*cs.EntityDescriptor, error) {
 	// スキーム(file://)を削除してファイルパスを抽出する
 	path := strings.TrimPrefix(idpMetadataUrl, SchemeFile)
-	f, err := c.delegate.OpenFile(path)
-	if err != nil {
-		return nil, lang.NewFxtError(lang.ErrCodeDisk).SetCause(err)
-	}
-	defer f.Close()
-
 	// ファイルを読み込む
-	bytes, err := io.ReadAll(f)
+	bytes, err := c.readFile(path)
 	if err != nil {
-		return nil, lang.NewFxtError(lang.ErrCodeDisk).SetCause(err)
+		return nil, err
 	}
 
 	// メタデータを解析する
@@ -567,3 +857,50 @@ func (c *SamlClient) fetchIdpMetadataFromNetwork(idpMetadataUrl string) (*cs.Ent
 	}
 	return descriptor, nil
 }
+
+// loadSpKeyPair SPの署名・暗号化用の証明書と秘密鍵を読み込む (いずれも未設定の場合はnilを返却する)
+func (c *SamlClient) loadSpKeyPair() (*rsa.PrivateKey, *x509.Certificate, error) {
+	config := common.GetConfig().Saml.Sp
+	if config.CertPath == "" && config.KeyPath == "" {
+		return nil, nil, nil
+	} else if config.CertPath == "" || config.KeyPath == "" {
+		return nil, nil, lang.NewFxtError(lang.ErrCodeConfig)
+	}
+
+	certPEM, err := c.readFile(config.CertPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := c.readFile(config.KeyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, nil, lang.NewFxtError(lang.ErrInvalidSpKeyPair).SetCause(err)
+	}
+	// XML署名・復号はRSAの鍵のみ対応している
+	key, ok := keyPair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, lang.NewFxtError(lang.ErrInvalidSpKeyPair)
+	}
+	certificate, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, nil, lang.NewFxtError(lang.ErrInvalidSpKeyPair).SetCause(err)
+	}
+	return key, certificate, nil
+}
+
+// readFile ファイルの内容を読み込む
+func (c *SamlClient) readFile(path string) ([]byte, error) {
+	f, err := c.delegate.OpenFile(path)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrCodeDisk).SetCause(err)
+	}
+	defer f.Close()
+	bytes, err := io.ReadAll(f)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrCodeDisk).SetCause(err)
+	}
+	return bytes, nil
+}
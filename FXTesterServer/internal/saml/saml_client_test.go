@@ -1,28 +1,45 @@
 package saml
 
 import (
+	"bytes"
+	"compress/flate"
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"database/sql"
 	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
 	"errors"
+	"fxtester/internal/audit"
 	"fxtester/internal/common"
 	"fxtester/internal/db"
 	"fxtester/internal/gen"
 	"fxtester/internal/lang"
 	"fxtester/internal/net"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/beevik/etree"
 	cs "github.com/crewjam/saml"
 	cssp "github.com/crewjam/saml/samlsp"
 	"github.com/labstack/echo/v4"
+	dsig "github.com/russellhaering/goxmldsig"
 	"golang.org/x/net/html"
 )
 
@@ -72,20 +89,53 @@ func (m *MockReaderCloser) Read(p []byte) (n int, err error) {
 
 type MockUserDao struct {
 	db.IUserEntityDao
-	delegateUpdateToken func(userId int64, accessToken, refreshToken string) error
+	delegateCreateSession  func(session *db.UserSessionEntity) error
+	delegateDeleteSession  func(userId int64, sessionId string) error
+	delegateDeleteSessions func(userId int64, samlSessionIndex string) (int64, error)
+	// 以下はnilの場合、埋め込んだIUserEntityDao (sqlmock) を呼び出す
+	delegateUpdateAttributes func(userId int64, attributes *db.UserAttributesEntity) error
+	delegateDeprovisionUser  func(userId int64) error
 }
 
-func (m *MockUserDao) UpdateToken(userId int64, accessToken, refreshToken string) error {
-	if m.delegateUpdateToken != nil {
-		return m.delegateUpdateToken(userId, accessToken, refreshToken)
+func (m *MockUserDao) UpdateAttributes(userId int64, attributes *db.UserAttributesEntity) error {
+	if m.delegateUpdateAttributes != nil {
+		return m.delegateUpdateAttributes(userId, attributes)
 	}
-	// accessTokenとrefreshTokenの値が動的となり、sqlmockでは対処が難しいためメソッドをオーバーライドして対処する
+	return m.IUserEntityDao.UpdateAttributes(userId, attributes)
+}
+
+func (m *MockUserDao) DeprovisionUser(userId int64) error {
+	if m.delegateDeprovisionUser != nil {
+		return m.delegateDeprovisionUser(userId)
+	}
+	return m.IUserEntityDao.DeprovisionUser(userId)
+}
+
+func (m *MockUserDao) CreateSession(session *db.UserSessionEntity) error {
+	if m.delegateCreateSession != nil {
+		return m.delegateCreateSession(session)
+	}
+	// セッションIDやトークンの値が動的となり、sqlmockでは対処が難しいためメソッドをオーバーライドして対処する
 	return nil
 }
 
+func (m *MockUserDao) DeleteSession(userId int64, sessionId string) error {
+	if m.delegateDeleteSession != nil {
+		return m.delegateDeleteSession(userId, sessionId)
+	}
+	return nil
+}
+
+func (m *MockUserDao) DeleteSessions(userId int64, samlSessionIndex string) (int64, error) {
+	if m.delegateDeleteSessions != nil {
+		return m.delegateDeleteSessions(userId, samlSessionIndex)
+	}
+	return 0, nil
+}
+
 func NewCookieContext[T any](values []struct {
 	name    string
-	secret  []byte
+	secret  *net.KeyRing
 	payload T
 }, w http.ResponseWriter, t *testing.T) echo.Context {
 	req := httptest.NewRequest(echo.POST, "https://localhost", nil)
@@ -446,7 +496,7 @@ func Test_SamlClient_ExecuteSamlLogin(t *testing.T) {
 					if err != nil {
 						t.Errorf("invalid cookie: %v", err)
 					}
-					claims, err := net.VerifyToken[net.SSOSessionPayload](c.Value, net.SSOSessionSecret)
+					claims, err := net.VerifyToken[net.SSOSessionPayload](c.Value, net.SSOSessionKeys)
 					if err != nil {
 						t.Errorf("invalid cookie: %v", err)
 					}
@@ -496,6 +546,8 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 		wantErr         bool
 		wantRedirectURL string
 		wantSamlErr     *uint32
+		// トークンに含まれるロール (nilの場合はチェックしない)
+		wantRoles []string
 	}{
 		{
 			name: "test1_normal",
@@ -512,6 +564,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						},
 						delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
 							return &cs.Assertion{
+								ID: "test-assertion-id",
 								Subject: &cs.Subject{
 									NameID: &cs.NameID{
 										Value: expectEmail,
@@ -525,7 +578,8 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}).AddRow(expectUserId, expectEmail, "access", "refresh"))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
+					mock.ExpectQuery(regexp.QuoteMeta(`call fxtester_schema.update_user_attributes($1, $2, $3, $4, $5, $6)`)).WithArgs(expectUserId, nil, nil, nil, nil, nil).WillReturnRows(sqlmock.NewRows([]string{}))
 					mock.ExpectCommit()
 					idb := &MockDB{
 						db: mockDB,
@@ -542,12 +596,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -560,6 +614,84 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 			wantRedirectURL: "http://localhost/test-redirect",
 			wantSamlErr:     uint32ptr(0),
 		},
+		{
+			name: "test1_normal_roles",
+			args: args{
+				samlClient: func() ISamlClient {
+					r := &MockSamlClientDelegator{
+						delegateOpenFile: func(path string) (io.ReadCloser, error) {
+							r := strings.NewReader(TestDataIdpMetadata)
+							return &MockReaderCloser{
+								deleteRead: func(p []byte) (n int, err error) {
+									return r.Read(p)
+								},
+							}, nil
+						},
+						delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
+							return &cs.Assertion{
+								ID: "test-assertion-id",
+								Subject: &cs.Subject{
+									NameID: &cs.NameID{
+										Value: expectEmail,
+									},
+								},
+								AttributeStatements: []cs.AttributeStatement{
+									{
+										Attributes: []cs.Attribute{
+											{
+												Name: "Role",
+												// アプリケーションで使用しないロールは無視する
+												Values: []cs.AttributeValue{{Value: "default-roles-my-realm"}, {Value: "admin"}, {Value: "admin"}},
+											},
+										},
+									},
+								},
+							}, nil
+						},
+					}
+					mockDB, mock, err := sqlmock.New()
+					if err != nil {
+						t.Errorf("failed sqlmock.New(): %v", err)
+					}
+					mock.ExpectBegin()
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
+					mock.ExpectQuery(regexp.QuoteMeta(`call fxtester_schema.update_user_roles($1, $2)`)).WithArgs(expectUserId, "{\"admin\"}").WillReturnRows(sqlmock.NewRows([]string{}))
+					mock.ExpectQuery(regexp.QuoteMeta(`call fxtester_schema.update_user_attributes($1, $2, $3, $4, $5, $6)`)).WithArgs(expectUserId, nil, nil, nil, nil, nil).WillReturnRows(sqlmock.NewRows([]string{}))
+					mock.ExpectCommit()
+					idb := &MockDB{
+						db: mockDB,
+					}
+					return &SamlClient{
+						delegate: r,
+						dao: &MockUserDao{
+							IUserEntityDao: db.NewUserEntityDao(idb),
+						},
+					}
+				}(),
+				idpMetadataUrl: "file://test",
+				backendURL:     common.GetConfig().Saml.BackendURL,
+				ctx: func(w http.ResponseWriter) echo.Context {
+					return NewCookieContext([]struct {
+						name    string
+						secret  *net.KeyRing
+						payload net.SSOSessionPayload
+					}{
+						{
+							name:   net.NameSSOToken,
+							secret: net.SSOSessionKeys,
+							payload: net.SSOSessionPayload{
+								AuthnRequestId:     "test-authn-request-id",
+								RedirectURL:        "http://localhost/test-redirect",
+								RedirectURLOnError: "http://localhost/test-redirect-test",
+							},
+						},
+					}, w, t)
+				},
+			},
+			wantRedirectURL: "http://localhost/test-redirect",
+			wantSamlErr:     uint32ptr(0),
+			wantRoles:       []string{"admin"},
+		},
 		{
 			name: "test2_normal",
 			args: args{
@@ -575,6 +707,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						},
 						delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
 							return &cs.Assertion{
+								ID: "test-assertion-id",
 								Subject: &cs.Subject{
 									NameID: &cs.NameID{
 										Value: expectEmail,
@@ -588,8 +721,9 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}))
 					mock.ExpectQuery(regexp.QuoteMeta(`select fxtester_schema.create_user($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"res"}).AddRow(expectUserId))
+					mock.ExpectQuery(regexp.QuoteMeta(`call fxtester_schema.update_user_attributes($1, $2, $3, $4, $5, $6)`)).WithArgs(expectUserId, nil, nil, nil, nil, nil).WillReturnRows(sqlmock.NewRows([]string{}))
 					mock.ExpectCommit()
 					idb := &MockDB{
 						db: mockDB,
@@ -606,12 +740,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -708,7 +842,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}).AddRow(expectUserId, expectEmail, "access", "refresh"))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
 					mock.ExpectCommit()
 					idb := &MockDB{
 						db: db,
@@ -720,12 +854,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -761,7 +895,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}).AddRow(expectUserId, expectEmail, "access", "refresh"))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
 					mock.ExpectCommit()
 					idb := &MockDB{
 						db: db,
@@ -773,12 +907,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -814,7 +948,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}).AddRow(expectUserId, expectEmail, "access", "refresh"))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
 					mock.ExpectCommit()
 					idb := &MockDB{
 						db: db,
@@ -826,12 +960,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -860,6 +994,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						},
 						delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
 							return &cs.Assertion{
+								ID:      "test-assertion-id",
 								Subject: &cs.Subject{},
 							}, nil // assertion.Subject.NameIdがnull
 						},
@@ -869,7 +1004,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}).AddRow(expectUserId, expectEmail, "access", "refresh"))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
 					mock.ExpectCommit()
 					idb := &MockDB{
 						db: db,
@@ -881,12 +1016,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -915,6 +1050,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						},
 						delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
 							return &cs.Assertion{
+								ID: "test-assertion-id",
 								Subject: &cs.Subject{
 									NameID: &cs.NameID{},
 								},
@@ -926,7 +1062,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}).AddRow(expectUserId, expectEmail, "access", "refresh"))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
 					mock.ExpectCommit()
 					idb := &MockDB{
 						db: db,
@@ -938,12 +1074,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -971,6 +1107,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						},
 						delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
 							return &cs.Assertion{
+								ID: "test-assertion-id",
 								Subject: &cs.Subject{
 									NameID: &cs.NameID{
 										Value: expectEmail,
@@ -994,12 +1131,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -1027,6 +1164,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						},
 						delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
 							return &cs.Assertion{
+								ID: "test-assertion-id",
 								Subject: &cs.Subject{
 									NameID: &cs.NameID{
 										Value: expectEmail,
@@ -1040,7 +1178,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}))
 					mock.ExpectQuery(regexp.QuoteMeta(`select fxtester_schema.create_user($1)`)).WillReturnError(errors.New("test-error"))
 					mock.ExpectRollback()
 					idb := &MockDB{
@@ -1053,12 +1191,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -1086,6 +1224,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						},
 						delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
 							return &cs.Assertion{
+								ID: "test-assertion-id",
 								Subject: &cs.Subject{
 									NameID: &cs.NameID{
 										Value: expectEmail,
@@ -1099,7 +1238,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WillReturnError(errors.New("test-error"))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WillReturnError(errors.New("test-error"))
 					mock.ExpectRollback()
 					idb := &MockDB{
 						db: db,
@@ -1111,12 +1250,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -1144,6 +1283,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						},
 						delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
 							return &cs.Assertion{
+								ID: "test-assertion-id",
 								Subject: &cs.Subject{
 									NameID: &cs.NameID{
 										Value: expectEmail,
@@ -1157,7 +1297,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}))
 					mock.ExpectQuery(regexp.QuoteMeta(`select fxtester_schema.create_user($1)`)).WillReturnError(errors.New("test-error"))
 					mock.ExpectRollback().WillReturnError(errors.New("test-error"))
 					idb := &MockDB{
@@ -1170,12 +1310,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -1203,6 +1343,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						},
 						delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
 							return &cs.Assertion{
+								ID: "test-assertion-id",
 								Subject: &cs.Subject{
 									NameID: &cs.NameID{
 										Value: expectEmail,
@@ -1216,7 +1357,8 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}).AddRow(expectUserId, expectEmail, "access", "refresh"))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
+					mock.ExpectQuery(regexp.QuoteMeta(`call fxtester_schema.update_user_attributes($1, $2, $3, $4, $5, $6)`)).WithArgs(expectUserId, nil, nil, nil, nil, nil).WillReturnRows(sqlmock.NewRows([]string{}))
 					mock.ExpectCommit().WillReturnError(errors.New("test-error"))
 					idb := &MockDB{
 						db: mockDB,
@@ -1233,12 +1375,12 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload net.SSOSessionPayload
 					}{
 						{
 							name:   net.NameSSOToken,
-							secret: net.SSOSessionSecret,
+							secret: net.SSOSessionKeys,
 							payload: net.SSOSessionPayload{
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
@@ -1296,7 +1438,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						if err != nil {
 							t.Errorf("invalid cookie: %v", err)
 						}
-						claims, err := net.VerifyToken[net.AuthSessionPayload](c.Value, net.AccessTokenSecret)
+						claims, err := net.VerifyToken[net.AuthSessionPayload](c.Value, net.AccessTokenKeys)
 						if err != nil {
 							t.Errorf("invalid cookie: %v", err)
 						}
@@ -1306,13 +1448,16 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 						if claims.Value.Email != expectEmail {
 							t.Errorf("ExecuteSamlAcs()=%v expectEmail=%v", claims.Value.Email, expectEmail)
 						}
+						if tt.wantRoles != nil && !slices.Equal(claims.Value.Roles, tt.wantRoles) {
+							t.Errorf("ExecuteSamlAcs()=%v wantRoles=%v", claims.Value.Roles, tt.wantRoles)
+						}
 
 						// リフレッシュトークン
 						c, err = parser.Cookie(net.NameRefreshToken)
 						if err != nil {
 							t.Errorf("invalid cookie: %v", err)
 						}
-						claims, err = net.VerifyToken[net.AuthSessionPayload](c.Value, net.RefreshTokenSecret)
+						claims, err = net.VerifyToken[net.AuthSessionPayload](c.Value, net.RefreshTokenKeys)
 						if err != nil {
 							t.Errorf("invalid cookie: %v", err)
 						}
@@ -1329,7 +1474,7 @@ func Test_SamlClient_ExecuteSamlAcs(t *testing.T) {
 					if (tt.wantSamlErr != nil) != (err == nil) {
 						t.Errorf("ExecuteSamlAcs()=%v, wantSamlErr=%v", err, tt.wantSamlErr)
 					} else if err == nil {
-						errClaims, err := net.VerifyToken[gen.ErrorWithTime](c.Value, net.SAMLErrorSessionSecret)
+						errClaims, err := net.VerifyToken[gen.ErrorWithTime](c.Value, net.SAMLErrorSessionKeys)
 						if err != nil {
 							t.Errorf("invalid cookie: %v", err)
 						}
@@ -1393,12 +1538,12 @@ func Test_SamlClient_ExecuteSamlLogout(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					return NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: 100,
 								Email:  "test-mail@test.co.jp",
@@ -1442,7 +1587,7 @@ func Test_SamlClient_ExecuteSamlLogout(t *testing.T) {
 					if err != nil {
 						t.Errorf("invalid cookie: %v", err)
 					}
-					claims, err := net.VerifyToken[net.SLOSessionPayload](c.Value, net.SLOSessionSecret)
+					claims, err := net.VerifyToken[net.SLOSessionPayload](c.Value, net.SLOSessionKeys)
 					if err != nil {
 						t.Errorf("invalid cookie: %v", err)
 					}
@@ -1525,9 +1670,9 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						delegate: r,
 						dao: &MockUserDao{
 							IUserEntityDao: db.NewUserEntityDao(idb),
-							delegateUpdateToken: func(userId int64, accessToken, refreshToken string) error {
-								if accessToken != "" || refreshToken != "" {
-									t.Errorf("couldn't clear token")
+							delegateDeleteSession: func(userId int64, sessionId string) error {
+								if userId != expectUserId || sessionId != "test-session-id" {
+									t.Errorf("couldn't delete session: userId=%v sessionId=%v", userId, sessionId)
 								}
 								return nil
 							},
@@ -1539,12 +1684,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -1552,9 +1697,10 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						},
 						{
 							name:   net.NameSLOToken,
-							secret: net.SLOSessionSecret,
+							secret: net.SLOSessionKeys,
 							payload: net.SLOSessionPayload{
 								UserId:             expectUserId,
+								SessionId:          "test-session-id",
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
 								RedirectURLOnError: "http://localhost/test-redirect-test",
@@ -1601,12 +1747,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -1660,12 +1806,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -1673,9 +1819,10 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						},
 						{
 							name:   net.NameSLOToken,
-							secret: net.SLOSessionSecret,
+							secret: net.SLOSessionKeys,
 							payload: net.SLOSessionPayload{
 								UserId:             expectUserId,
+								SessionId:          "test-session-id",
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
 								RedirectURLOnError: "http://localhost/test-redirect-test",
@@ -1729,12 +1876,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -1742,9 +1889,10 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						},
 						{
 							name:   net.NameSLOToken,
-							secret: net.SLOSessionSecret,
+							secret: net.SLOSessionKeys,
 							payload: net.SLOSessionPayload{
 								UserId:             expectUserId,
+								SessionId:          "test-session-id",
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
 								RedirectURLOnError: "http://localhost/test-redirect-test",
@@ -1799,12 +1947,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -1812,9 +1960,10 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						},
 						{
 							name:   net.NameSLOToken,
-							secret: net.SLOSessionSecret,
+							secret: net.SLOSessionKeys,
 							payload: net.SLOSessionPayload{
 								UserId:             expectUserId,
+								SessionId:          "test-session-id",
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
 								RedirectURLOnError: "http://localhost/test-redirect-test",
@@ -1869,12 +2018,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -1882,9 +2031,10 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						},
 						{
 							name:   net.NameSLOToken,
-							secret: net.SLOSessionSecret,
+							secret: net.SLOSessionKeys,
 							payload: net.SLOSessionPayload{
 								UserId:             expectUserId,
+								SessionId:          "test-session-id",
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
 								RedirectURLOnError: "http://localhost/test-redirect-test",
@@ -1939,12 +2089,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -1952,9 +2102,10 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						},
 						{
 							name:   net.NameSLOToken,
-							secret: net.SLOSessionSecret,
+							secret: net.SLOSessionKeys,
 							payload: net.SLOSessionPayload{
 								UserId:             expectUserId,
+								SessionId:          "test-session-id",
 								AuthnRequestId:     "", // authnRequestIdが空
 								RedirectURL:        "http://localhost/test-redirect",
 								RedirectURLOnError: "http://localhost/test-redirect-test",
@@ -2009,12 +2160,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -2022,9 +2173,10 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						},
 						{
 							name:   net.NameSLOToken,
-							secret: net.SLOSessionSecret,
+							secret: net.SLOSessionKeys,
 							payload: net.SLOSessionPayload{
 								UserId:             expectUserId,
+								SessionId:          "test-session-id",
 								AuthnRequestId:     "test-authn-request-id-dummy", // AuthnRequestIdの不一致を発生させる
 								RedirectURL:        "http://localhost/test-redirect",
 								RedirectURLOnError: "http://localhost/test-redirect-test",
@@ -2079,12 +2231,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -2092,9 +2244,10 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						},
 						{
 							name:   net.NameSLOToken,
-							secret: net.SLOSessionSecret,
+							secret: net.SLOSessionKeys,
 							payload: net.SLOSessionPayload{
 								UserId:             expectUserId,
+								SessionId:          "test-session-id",
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
 								RedirectURLOnError: "http://localhost/test-redirect-test",
@@ -2141,7 +2294,7 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						delegate: r,
 						dao: &MockUserDao{
 							IUserEntityDao: db.NewUserEntityDao(idb),
-							delegateUpdateToken: func(userId int64, accessToken, refreshToken string) error {
+							delegateDeleteSession: func(userId int64, sessionId string) error {
 								return lang.NewFxtError(lang.ErrDBQuery)
 							},
 						},
@@ -2152,12 +2305,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -2165,9 +2318,10 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						},
 						{
 							name:   net.NameSLOToken,
-							secret: net.SLOSessionSecret,
+							secret: net.SLOSessionKeys,
 							payload: net.SLOSessionPayload{
 								UserId:             expectUserId,
+								SessionId:          "test-session-id",
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
 								RedirectURLOnError: "http://localhost/test-redirect-test",
@@ -2222,12 +2376,12 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  "test-mail@test.co.jp",
@@ -2235,9 +2389,10 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 						},
 						{
 							name:   net.NameSLOToken,
-							secret: net.SLOSessionSecret,
+							secret: net.SLOSessionKeys,
 							payload: net.SLOSessionPayload{
 								UserId:             expectUserId,
+								SessionId:          "test-session-id",
 								AuthnRequestId:     "test-authn-request-id",
 								RedirectURL:        "http://localhost/test-redirect",
 								RedirectURLOnError: "http://localhost/test-redirect-test",
@@ -2295,7 +2450,7 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 					if err != nil {
 						t.Errorf("invalid cookie: %v", err)
 					}
-					claims, err := net.VerifyToken[net.AuthSessionPayload](c.Value, net.AccessTokenSecret)
+					claims, err := net.VerifyToken[net.AuthSessionPayload](c.Value, net.AccessTokenKeys)
 					if err == nil {
 						t.Errorf("access token wasn't removed: %v", claims)
 					}
@@ -2305,7 +2460,7 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 					if err != nil {
 						t.Errorf("invalid cookie: %v", err)
 					}
-					claims, err = net.VerifyToken[net.AuthSessionPayload](c.Value, net.RefreshTokenSecret)
+					claims, err = net.VerifyToken[net.AuthSessionPayload](c.Value, net.RefreshTokenKeys)
 					if err == nil {
 						t.Errorf("refresh token wasn't removed: %v", claims)
 					}
@@ -2316,7 +2471,7 @@ func Test_SamlClient_ExecuteSamlSlo_MySp(t *testing.T) {
 				if (tt.wantSamlErr != nil) != (err == nil) {
 					t.Errorf("ExecuteSamlSlo()=%v, wantSamlErr=%v", err, tt.wantSamlErr)
 				} else if err == nil {
-					errClaims, err := net.VerifyToken[gen.ErrorWithTime](c.Value, net.SAMLErrorSessionSecret)
+					errClaims, err := net.VerifyToken[gen.ErrorWithTime](c.Value, net.SAMLErrorSessionKeys)
 					if err != nil {
 						t.Errorf("invalid cookie: %v", err)
 					}
@@ -2371,7 +2526,7 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}).AddRow(expectUserId, expectEmail, "", ""))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
 					mock.ExpectCommit()
 					idb := &MockDB{
 						db: mockDB,
@@ -2380,11 +2535,11 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 						delegate: r,
 						dao: &MockUserDao{
 							IUserEntityDao: db.NewUserEntityDao(idb),
-							delegateUpdateToken: func(userId int64, accessToken, refreshToken string) error {
-								if accessToken != "" || refreshToken != "" {
-									t.Errorf("couldn't clear token")
+							delegateDeleteSessions: func(userId int64, samlSessionIndex string) (int64, error) {
+								if userId != expectUserId {
+									t.Errorf("couldn't delete sessions: userId=%v", userId)
 								}
-								return nil
+								return 1, nil
 							},
 						},
 					}
@@ -2394,12 +2549,12 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  expectEmail,
@@ -2443,11 +2598,11 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 						delegate: r,
 						dao: &MockUserDao{
 							IUserEntityDao: db.NewUserEntityDao(idb),
-							delegateUpdateToken: func(userId int64, accessToken, refreshToken string) error {
-								if accessToken != "" || refreshToken != "" {
-									t.Errorf("couldn't clear token")
+							delegateDeleteSessions: func(userId int64, samlSessionIndex string) (int64, error) {
+								if userId != expectUserId {
+									t.Errorf("couldn't delete sessions: userId=%v", userId)
 								}
-								return nil
+								return 1, nil
 							},
 						},
 					}
@@ -2457,12 +2612,12 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  expectEmail,
@@ -2507,11 +2662,11 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 						delegate: r,
 						dao: &MockUserDao{
 							IUserEntityDao: db.NewUserEntityDao(idb),
-							delegateUpdateToken: func(userId int64, accessToken, refreshToken string) error {
-								if accessToken != "" || refreshToken != "" {
-									t.Errorf("couldn't clear token")
+							delegateDeleteSessions: func(userId int64, samlSessionIndex string) (int64, error) {
+								if userId != expectUserId {
+									t.Errorf("couldn't delete sessions: userId=%v", userId)
 								}
-								return nil
+								return 1, nil
 							},
 						},
 					}
@@ -2521,12 +2676,12 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  expectEmail,
@@ -2571,11 +2726,11 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 						delegate: r,
 						dao: &MockUserDao{
 							IUserEntityDao: db.NewUserEntityDao(idb),
-							delegateUpdateToken: func(userId int64, accessToken, refreshToken string) error {
-								if accessToken != "" || refreshToken != "" {
-									t.Errorf("couldn't clear token")
+							delegateDeleteSessions: func(userId int64, samlSessionIndex string) (int64, error) {
+								if userId != expectUserId {
+									t.Errorf("couldn't delete sessions: userId=%v", userId)
 								}
-								return nil
+								return 1, nil
 							},
 						},
 					}
@@ -2585,12 +2740,12 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  expectEmail,
@@ -2636,11 +2791,11 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 						delegate: r,
 						dao: &MockUserDao{
 							IUserEntityDao: db.NewUserEntityDao(idb),
-							delegateUpdateToken: func(userId int64, accessToken, refreshToken string) error {
-								if accessToken != "" || refreshToken != "" {
-									t.Errorf("couldn't clear token")
+							delegateDeleteSessions: func(userId int64, samlSessionIndex string) (int64, error) {
+								if userId != expectUserId {
+									t.Errorf("couldn't delete sessions: userId=%v", userId)
 								}
-								return nil
+								return 1, nil
 							},
 						},
 					}
@@ -2650,12 +2805,12 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  expectEmail,
@@ -2693,7 +2848,7 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					// クエリー非許可 mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}).AddRow(expectUserId, expectEmail, "", ""))
+					// クエリー非許可 mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
 					mock.ExpectRollback()
 					idb := &MockDB{
 						db: mockDB,
@@ -2702,11 +2857,11 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 						delegate: r,
 						dao: &MockUserDao{
 							IUserEntityDao: db.NewUserEntityDao(idb),
-							delegateUpdateToken: func(userId int64, accessToken, refreshToken string) error {
-								if accessToken != "" || refreshToken != "" {
-									t.Errorf("couldn't clear token")
+							delegateDeleteSessions: func(userId int64, samlSessionIndex string) (int64, error) {
+								if userId != expectUserId {
+									t.Errorf("couldn't delete sessions: userId=%v", userId)
 								}
-								return nil
+								return 1, nil
 							},
 						},
 					}
@@ -2716,12 +2871,12 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  expectEmail,
@@ -2759,7 +2914,7 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 						t.Errorf("failed sqlmock.New(): %v", err)
 					}
 					mock.ExpectBegin()
-					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, access_token, refresh_token from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "access_token", "refresh_token"}).AddRow(expectUserId, expectEmail, "", ""))
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
 					mock.ExpectRollback()
 					idb := &MockDB{
 						db: mockDB,
@@ -2768,11 +2923,11 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 						delegate: r,
 						dao: &MockUserDao{
 							IUserEntityDao: db.NewUserEntityDao(idb),
-							delegateUpdateToken: func(userId int64, accessToken, refreshToken string) error {
-								if accessToken != "" || refreshToken != "" {
-									t.Errorf("couldn't clear token")
+							delegateDeleteSessions: func(userId int64, samlSessionIndex string) (int64, error) {
+								if userId != expectUserId {
+									t.Errorf("couldn't delete sessions: userId=%v", userId)
 								}
-								return lang.NewFxtError(lang.ErrDBQuery) // UpdateTokenを失敗させる
+								return 0, lang.NewFxtError(lang.ErrDBQuery) // DeleteSessionsを失敗させる
 							},
 						},
 					}
@@ -2782,12 +2937,12 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 				ctx: func(w http.ResponseWriter) echo.Context {
 					ctx := NewCookieContext([]struct {
 						name    string
-						secret  []byte
+						secret  *net.KeyRing
 						payload any
 					}{
 						{
 							name:   net.NameAccessToken,
-							secret: net.AccessTokenSecret,
+							secret: net.AccessTokenKeys,
 							payload: net.AuthSessionPayload{
 								UserId: expectUserId,
 								Email:  expectEmail,
@@ -2835,7 +2990,7 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 					if err != nil {
 						t.Errorf("invalid cookie: %v", err)
 					}
-					claims, err := net.VerifyToken[net.AuthSessionPayload](c.Value, net.AccessTokenSecret)
+					claims, err := net.VerifyToken[net.AuthSessionPayload](c.Value, net.AccessTokenKeys)
 					if err == nil {
 						// トークンが削除されていない場合
 						t.Errorf("access token wasn't removed: %v", claims)
@@ -2846,7 +3001,7 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 					if err != nil {
 						t.Errorf("invalid cookie: %v", err)
 					}
-					claims, err = net.VerifyToken[net.AuthSessionPayload](c.Value, net.RefreshTokenSecret)
+					claims, err = net.VerifyToken[net.AuthSessionPayload](c.Value, net.RefreshTokenKeys)
 					if err == nil {
 						// トークンが削除されていない場合
 						t.Errorf("refresh token wasn't removed: %v", claims)
@@ -2870,3 +3025,1325 @@ func Test_SamlClient_ExecuteSamlSlo_Other(t *testing.T) {
 		common.GetConfig().Saml.BackendURL = saveBackendURL
 	}
 }
+
+func Test_SamlClient_MultipleIdps(t *testing.T) {
+	const partnerEntityId = "http://keycloak:8080/realms/partner-realm"
+	const myEntityId = "http://keycloak:8080/realms/my-realm"
+
+	saveIdpMetadataUrl := common.GetConfig().Saml.IdpMetadataUrl
+	saveIdps := common.GetConfig().Saml.Idps
+	defer func() {
+		common.GetConfig().Saml.IdpMetadataUrl = saveIdpMetadataUrl
+		common.GetConfig().Saml.Idps = saveIdps
+	}()
+	common.GetConfig().Saml.IdpMetadataUrl = "file://my-realm"
+	common.GetConfig().Saml.Idps = []common.SamlIdpConfig{
+		{
+			Name:           "partner",
+			IdpMetadataUrl: "file://partner-realm",
+			EmailDomains:   []string{"partner.example.com"},
+		},
+	}
+
+	// ファイルパスに応じてEntityIDの異なるメタデータを返却する
+	var parsedEntityId string
+	client := NewSamlClient(&MockSamlClientDelegator{
+		delegateOpenFile: func(path string) (io.ReadCloser, error) {
+			metadata := TestDataIdpMetadata
+			if path == "partner-realm" {
+				metadata = strings.ReplaceAll(metadata, myEntityId, partnerEntityId)
+			}
+			return io.NopCloser(strings.NewReader(metadata)), nil
+		},
+		delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
+			parsedEntityId = sp.IDPMetadata.EntityID
+			return nil, errors.New("test")
+		},
+	}, &MockDB{}).(*SamlClient)
+	// DBに接続しないため監査イベントは記録しない
+	client.auditor = nil
+	if err := client.Init(); err != nil {
+		t.Fatalf("Init()=%v", err)
+	}
+
+	t.Run("selectIdp", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			params   gen.GetSamlLoginParams
+			wantIdp  string
+			wantCode lang.ErrorCode
+		}{
+			{name: "test1_未指定は既定のidP", wantIdp: common.DefaultSamlIdpName},
+			{name: "test2_idPの名前を指定", params: gen.GetSamlLoginParams{Idp: strptr("partner")}, wantIdp: "partner"},
+			{name: "test3_Emailのドメインが一致", params: gen.GetSamlLoginParams{Email: strptr("user@Partner.Example.com")}, wantIdp: "partner"},
+			{name: "test4_Emailのドメインが不一致", params: gen.GetSamlLoginParams{Email: strptr("user@example.com")}, wantIdp: common.DefaultSamlIdpName},
+			{name: "test5_名前の指定をEmailより優先", params: gen.GetSamlLoginParams{Idp: strptr("default"), Email: strptr("user@partner.example.com")}, wantIdp: common.DefaultSamlIdpName},
+			{name: "test6_存在しないidP", params: gen.GetSamlLoginParams{Idp: strptr("unknown")}, wantCode: lang.ErrInvalidParameterError},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				idp, err := client.selectIdp(tt.params)
+				if tt.wantCode != 0 {
+					if fxtErr, ok := err.(*lang.FxtError); !ok || fxtErr.ErrCode != tt.wantCode {
+						t.Errorf("selectIdp()=%v wantCode=%x", err, tt.wantCode)
+					}
+				} else if err != nil || idp.name != tt.wantIdp {
+					t.Errorf("selectIdp()=%v,%v wantIdp=%v", idp, err, tt.wantIdp)
+				}
+			})
+		}
+	})
+
+	t.Run("ExecuteSamlAcs", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			issuer       string
+			wantEntityId string
+			wantSamlErr  uint32
+		}{
+			{name: "test1_IssuerのidPで解析", issuer: partnerEntityId, wantEntityId: partnerEntityId, wantSamlErr: uint32(lang.ErrCodeSSOParseResponse)},
+			{name: "test2_既定のidP", issuer: myEntityId, wantEntityId: myEntityId, wantSamlErr: uint32(lang.ErrCodeSSOParseResponse)},
+			{name: "test3_Issuerが不明", issuer: "http://unknown", wantSamlErr: uint32(lang.ErrUnknownSamlIssuer)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				parsedEntityId = ""
+				rec := httptest.NewRecorder()
+				ctx := NewCookieContext([]struct {
+					name    string
+					secret  *net.KeyRing
+					payload net.SSOSessionPayload
+				}{
+					{
+						name:   net.NameSSOToken,
+						secret: net.SSOSessionKeys,
+						payload: net.SSOSessionPayload{
+							AuthnRequestId:     "test-authn-request-id",
+							RedirectURL:        "http://localhost/test-redirect",
+							RedirectURLOnError: "http://localhost/test-redirect-error",
+						},
+					},
+				}, rec, t)
+				samlResponse := `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="id-1" Version="2.0"><saml:Issuer>` + tt.issuer + `</saml:Issuer></samlp:Response>`
+				ctx.Request().Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+				ctx.Request().Body = io.NopCloser(strings.NewReader(url.Values{SAMLResponse: {toFormUrlencoded(samlResponse)}}.Encode()))
+
+				if err := client.ExecuteSamlAcs(ctx); err != nil {
+					t.Fatalf("ExecuteSamlAcs()=%v", err)
+				}
+				if parsedEntityId != tt.wantEntityId {
+					t.Errorf("ExecuteSamlAcs()=%v wantEntityId=%v", parsedEntityId, tt.wantEntityId)
+				}
+
+				parser := &http.Request{Header: http.Header{"Cookie": rec.Header()["Set-Cookie"]}}
+				c, err := parser.Cookie(net.NameSAMLErrorToken)
+				if err != nil {
+					t.Fatalf("invalid cookie: %v", err)
+				}
+				errClaims, err := net.VerifyToken[gen.ErrorWithTime](c.Value, net.SAMLErrorSessionKeys)
+				if err != nil {
+					t.Fatalf("invalid cookie: %v", err)
+				}
+				if errClaims.Value.Err.Code != tt.wantSamlErr {
+					t.Errorf("ExecuteSamlAcs()=%x, wantSamlErr=%x", errClaims.Value.Err.Code, tt.wantSamlErr)
+				}
+			})
+		}
+	})
+}
+
+func strptr(v string) *string {
+	return &v
+}
+
+// newTestSpKeyPair テスト用の自己署名証明書と秘密鍵(PEM)を生成する
+func newTestSpKeyPair(t *testing.T) (certPEM []byte, keyPEM []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed rsa.GenerateKey: %v", err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fx-tester-sp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed x509.CreateCertificate: %v", err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM
+}
+
+func Test_SamlClient_SpKeyPair(t *testing.T) {
+	certPEM, keyPEM := newTestSpKeyPair(t)
+	otherCertPEM, _ := newTestSpKeyPair(t)
+
+	tests := []struct {
+		name     string
+		certPath string
+		keyPath  string
+		// ファイルパス毎の内容
+		files           map[string][]byte
+		signatureMethod string
+		wantCode        lang.ErrorCode
+		wantSigned      bool
+	}{
+		{
+			name:     "test1_鍵ペアなし",
+			files:    map[string][]byte{},
+			wantCode: 0,
+		},
+		{
+			name:       "test2_鍵ペアあり",
+			certPath:   "sp.cert.pem",
+			keyPath:    "sp.key.pem",
+			files:      map[string][]byte{"sp.cert.pem": certPEM, "sp.key.pem": keyPEM},
+			wantSigned: true,
+		},
+		{
+			name:            "test3_署名アルゴリズムが不正",
+			certPath:        "sp.cert.pem",
+			keyPath:         "sp.key.pem",
+			files:           map[string][]byte{"sp.cert.pem": certPEM, "sp.key.pem": keyPEM},
+			signatureMethod: "hmac-sha256",
+			wantCode:        lang.ErrCodeConfig,
+		},
+		{
+			name:     "test4_証明書と秘密鍵が不一致",
+			certPath: "sp.cert.pem",
+			keyPath:  "sp.key.pem",
+			files:    map[string][]byte{"sp.cert.pem": otherCertPEM, "sp.key.pem": keyPEM},
+			wantCode: lang.ErrInvalidSpKeyPair,
+		},
+		{
+			name:     "test5_秘密鍵のみ指定",
+			keyPath:  "sp.key.pem",
+			files:    map[string][]byte{"sp.key.pem": keyPEM},
+			wantCode: lang.ErrCodeConfig,
+		},
+		{
+			name:     "test6_ファイルが存在しない",
+			certPath: "sp.cert.pem",
+			keyPath:  "sp.key.pem",
+			files:    map[string][]byte{"sp.key.pem": keyPEM},
+			wantCode: lang.ErrCodeDisk,
+		},
+	}
+
+	for _, tt := range tests {
+		saveIdpMetadataUrl := common.GetConfig().Saml.IdpMetadataUrl
+		saveSp := common.GetConfig().Saml.Sp
+
+		t.Run(tt.name, func(t *testing.T) {
+			common.GetConfig().Saml.IdpMetadataUrl = "file://test"
+			common.GetConfig().Saml.Sp.CertPath = tt.certPath
+			common.GetConfig().Saml.Sp.KeyPath = tt.keyPath
+			common.GetConfig().Saml.Sp.SignatureMethod = tt.signatureMethod
+
+			client := NewSamlClient(&MockSamlClientDelegator{
+				delegateOpenFile: func(path string) (io.ReadCloser, error) {
+					if path == "test" {
+						return io.NopCloser(strings.NewReader(TestDataIdpMetadata)), nil
+					}
+					data, ok := tt.files[path]
+					if !ok {
+						return nil, errors.New("not found")
+					}
+					return io.NopCloser(bytes.NewReader(data)), nil
+				},
+			}, &MockDB{})
+
+			err := client.Init()
+			if tt.wantCode != 0 {
+				if fxtErr, ok := err.(*lang.FxtError); !ok || fxtErr.ErrCode != tt.wantCode {
+					t.Errorf("Init()=%v wantCode=%x", err, tt.wantCode)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Init()=%v", err)
+			}
+
+			// AuthnRequestの署名
+			rec := httptest.NewRecorder()
+			err = client.ExecuteSamlLogin(echo.New().NewContext(httptest.NewRequest(echo.GET, "https://localhost", nil), rec), gen.GetSamlLoginParams{
+				XRedirectURL:        "https://localhost/test-redirect",
+				XRedirectURLOnError: "https://localhost/test-redirect-error",
+			})
+			if err != nil {
+				t.Fatalf("ExecuteSamlLogin()=%v", err)
+			}
+			authnRequest := func() string {
+				doc, err := html.Parse(rec.Body)
+				if err != nil {
+					t.Fatalf("invalid body: %v", err)
+				}
+				var find func(n *html.Node) string
+				find = func(n *html.Node) string {
+					if n.Type == html.ElementNode && n.Data == "input" {
+						if slices.ContainsFunc(n.Attr, func(a html.Attribute) bool { return a.Key == "name" && a.Val == SAMLRequest }) {
+							for _, a := range n.Attr {
+								if a.Key == "value" {
+									return a.Val
+								}
+							}
+						}
+					}
+					for c := n.FirstChild; c != nil; c = c.NextSibling {
+						if v := find(c); v != "" {
+							return v
+						}
+					}
+					return ""
+				}
+				decoded, err := base64.StdEncoding.DecodeString(find(doc))
+				if err != nil {
+					t.Fatalf("invalid SAMLRequest: %v", err)
+				}
+				return string(decoded)
+			}()
+			if signed := strings.Contains(authnRequest, "SignatureValue"); signed != tt.wantSigned {
+				t.Errorf("ExecuteSamlLogin() signed=%v wantSigned=%v", signed, tt.wantSigned)
+			}
+
+			// SPのメタデータ
+			rec = httptest.NewRecorder()
+			if err := client.ExecuteSamlMetadata(echo.New().NewContext(httptest.NewRequest(echo.GET, "https://localhost/saml/metadata", nil), rec)); err != nil {
+				t.Fatalf("ExecuteSamlMetadata()=%v", err)
+			}
+			if contentType := rec.Header().Get(echo.HeaderContentType); contentType != MIMESamlMetadata {
+				t.Errorf("invalid ContentType: %v", contentType)
+			}
+			metadata, err := cssp.ParseMetadata(rec.Body.Bytes())
+			if err != nil {
+				t.Fatalf("invalid metadata: %v", err)
+			}
+			uses := []string{}
+			for _, descriptor := range metadata.SPSSODescriptors {
+				if descriptor.AuthnRequestsSigned == nil || *descriptor.AuthnRequestsSigned != tt.wantSigned {
+					t.Errorf("ExecuteSamlMetadata() AuthnRequestsSigned=%v wantSigned=%v", descriptor.AuthnRequestsSigned, tt.wantSigned)
+				}
+				for _, key := range descriptor.KeyDescriptors {
+					uses = append(uses, key.Use)
+				}
+			}
+			wantUses := []string{}
+			if tt.wantSigned {
+				wantUses = []string{"encryption", "signing"}
+			}
+			if !slices.Equal(uses, wantUses) {
+				t.Errorf("ExecuteSamlMetadata() KeyDescriptors=%v want=%v", uses, wantUses)
+			}
+		})
+
+		common.GetConfig().Saml.IdpMetadataUrl = saveIdpMetadataUrl
+		common.GetConfig().Saml.Sp = saveSp
+	}
+}
+
+func Test_SamlClient_RefreshIdpMetadata(t *testing.T) {
+	const myEntityId = `entityID="http://keycloak:8080/realms/my-realm"`
+
+	saveIdpMetadataUrl := common.GetConfig().Saml.IdpMetadataUrl
+	saveIdps := common.GetConfig().Saml.Idps
+	saveRefresh := common.GetConfig().Saml.MetadataRefresh
+	defer func() {
+		common.GetConfig().Saml.IdpMetadataUrl = saveIdpMetadataUrl
+		common.GetConfig().Saml.Idps = saveIdps
+		common.GetConfig().Saml.MetadataRefresh = saveRefresh
+	}()
+	common.GetConfig().Saml.IdpMetadataUrl = "file://test"
+	common.GetConfig().Saml.Idps = nil
+	common.GetConfig().Saml.MetadataRefresh.IntervalSec = 3600
+	common.GetConfig().Saml.MetadataRefresh.RetryIntervalSec = 30
+
+	now := time.Date(2024, 8, 14, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		// 再取得時のメタデータ (nilの場合は取得に失敗する)
+		metadata          func() string
+		wantErr           bool
+		wantSwapped       bool
+		wantNextRefreshAt time.Time
+	}{
+		{
+			name:              "test1_設定の間隔で再取得",
+			metadata:          func() string { return TestDataIdpMetadata },
+			wantSwapped:       true,
+			wantNextRefreshAt: now.Add(time.Hour),
+		},
+		{
+			name: "test2_cacheDurationが短い場合はcacheDuration後",
+			metadata: func() string {
+				return strings.Replace(TestDataIdpMetadata, myEntityId, myEntityId+` cacheDuration="PT5M"`, 1)
+			},
+			wantSwapped:       true,
+			wantNextRefreshAt: now.Add(5 * time.Minute),
+		},
+		{
+			name: "test3_validUntilが近い場合は最短の間隔",
+			metadata: func() string {
+				return strings.Replace(TestDataIdpMetadata, myEntityId, myEntityId+` validUntil="`+now.Add(10*time.Second).Format(time.RFC3339)+`"`, 1)
+			},
+			wantSwapped:       true,
+			wantNextRefreshAt: now.Add(time.Minute),
+		},
+		{
+			name:              "test4_取得に失敗した場合は取得済みのメタデータを使用",
+			wantErr:           true,
+			wantNextRefreshAt: now.Add(30 * time.Second),
+		},
+		{
+			name: "test5_EntityIDが変わった場合は取得済みのメタデータを使用",
+			metadata: func() string {
+				return strings.Replace(TestDataIdpMetadata, myEntityId, `entityID="http://keycloak:8080/realms/other-realm"`, 1)
+			},
+			wantErr:           true,
+			wantNextRefreshAt: now.Add(30 * time.Second),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initialized := false
+			client := NewSamlClient(&MockSamlClientDelegator{
+				delegateOpenFile: func(path string) (io.ReadCloser, error) {
+					if !initialized {
+						return io.NopCloser(strings.NewReader(TestDataIdpMetadata)), nil
+					}
+					if tt.metadata == nil {
+						return nil, errors.New("test")
+					}
+					return io.NopCloser(strings.NewReader(tt.metadata())), nil
+				},
+			}, &MockDB{}).(*SamlClient)
+			if err := client.Init(); err != nil {
+				t.Fatalf("Init()=%v", err)
+			}
+			initialized = true
+
+			idp := client.idps[0]
+			before := idp.serviceProvider()
+			fetchedAt := idp.status.Load().FetchedAt
+
+			err := client.refreshIdpMetadata(idp, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("refreshIdpMetadata()=%v wantErr=%v", err, tt.wantErr)
+			}
+			if swapped := idp.serviceProvider() != before; swapped != tt.wantSwapped {
+				t.Errorf("refreshIdpMetadata() swapped=%v wantSwapped=%v", swapped, tt.wantSwapped)
+			}
+
+			status := client.MetadataStatus()[0]
+			if !status.NextRefreshAt.Equal(tt.wantNextRefreshAt) {
+				t.Errorf("refreshIdpMetadata() NextRefreshAt=%v want=%v", status.NextRefreshAt, tt.wantNextRefreshAt)
+			}
+			if tt.wantErr {
+				// 取得済みのメタデータの取得日時のまま、エラーを記録する
+				if status.LastError == nil || !status.FetchedAt.Equal(fetchedAt) {
+					t.Errorf("refreshIdpMetadata() LastError=%v FetchedAt=%v want=%v", status.LastError, status.FetchedAt, fetchedAt)
+				}
+			} else if status.LastError != nil || !status.FetchedAt.Equal(now) {
+				t.Errorf("refreshIdpMetadata() LastError=%v FetchedAt=%v want=%v", status.LastError, status.FetchedAt, now)
+			}
+			if status.EntityId != "http://keycloak:8080/realms/my-realm" {
+				t.Errorf("refreshIdpMetadata() EntityId=%v", status.EntityId)
+			}
+		})
+	}
+}
+
+// testIdpMetadataWithCertificate idPの署名証明書を指定した証明書に置き換えたメタデータを返却する
+func testIdpMetadataWithCertificate(t *testing.T, certPEM []byte) string {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		t.Fatalf("invalid certificate")
+	}
+	re := regexp.MustCompile(`(<ds:X509Certificate>)[^<]*(</ds:X509Certificate>)`)
+	return re.ReplaceAllString(TestDataIdpMetadata, "${1}"+base64.StdEncoding.EncodeToString(block.Bytes)+"${2}")
+}
+
+// newTestRedirectQuery SAMLメッセージをHTTP-Redirectバインディングのクエリ文字列にする (keyがnilの場合は署名しない)
+func newTestRedirectQuery(t *testing.T, keyPEM []byte, param string, message string, relayState string) string {
+	var compressed bytes.Buffer
+	writer, _ := flate.NewWriter(&compressed, flate.BestCompression)
+	writer.Write([]byte(message))
+	writer.Close()
+
+	query := param + "=" + url.QueryEscape(base64.StdEncoding.EncodeToString(compressed.Bytes()))
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	if keyPEM == nil {
+		return query
+	}
+	block, _ := pem.Decode(keyPEM)
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed x509.ParsePKCS1PrivateKey: %v", err)
+	}
+	query += "&SigAlg=" + url.QueryEscape(signatureMethods["rsa-sha256"])
+	digest := sha256.Sum256([]byte(query))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("failed rsa.SignPKCS1v15: %v", err)
+	}
+	return query + "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+}
+
+func Test_SamlClient_RedirectBinding(t *testing.T) {
+	const expectUserId = 100
+	const expectEmail = "test@test.co.jp"
+	idpCertPEM, idpKeyPEM := newTestSpKeyPair(t)
+	spCertPEM, spKeyPEM := newTestSpKeyPair(t)
+	_, otherKeyPEM := newTestSpKeyPair(t)
+	idpMetadata := testIdpMetadataWithCertificate(t, idpCertPEM)
+
+	saveIdpMetadataUrl := common.GetConfig().Saml.IdpMetadataUrl
+	saveBinding := common.GetConfig().Saml.Binding
+	saveSp := common.GetConfig().Saml.Sp
+	defer func() {
+		common.GetConfig().Saml.IdpMetadataUrl = saveIdpMetadataUrl
+		common.GetConfig().Saml.Binding = saveBinding
+		common.GetConfig().Saml.Sp = saveSp
+	}()
+	common.GetConfig().Saml.IdpMetadataUrl = "file://test"
+	common.GetConfig().Saml.Binding = common.SamlBindingRedirect
+	common.GetConfig().Saml.Sp.CertPath = "sp.cert.pem"
+	common.GetConfig().Saml.Sp.KeyPath = "sp.key.pem"
+	common.GetConfig().Saml.Sp.SignatureMethod = ""
+
+	files := map[string]string{"test": idpMetadata, "sp.cert.pem": string(spCertPEM), "sp.key.pem": string(spKeyPEM)}
+	newClient := func(dao db.IUserEntityDao) *SamlClient {
+		client := &SamlClient{
+			delegate: &MockSamlClientDelegator{
+				delegateOpenFile: func(path string) (io.ReadCloser, error) {
+					return io.NopCloser(strings.NewReader(files[path])), nil
+				},
+			},
+			dao: dao,
+		}
+		if err := client.Init(); err != nil {
+			t.Fatalf("Init()=%v", err)
+		}
+		return client
+	}
+
+	t.Run("ExecuteSamlLogin", func(t *testing.T) {
+		client := newClient(&MockUserDao{})
+		rec := httptest.NewRecorder()
+		err := client.ExecuteSamlLogin(echo.New().NewContext(httptest.NewRequest(echo.GET, "https://localhost/saml/login", nil), rec), gen.GetSamlLoginParams{
+			XRedirectURL:        "https://localhost/test-redirect",
+			XRedirectURLOnError: "https://localhost/test-redirect-error",
+		})
+		if err != nil {
+			t.Fatalf("ExecuteSamlLogin()=%v", err)
+		}
+		if rec.Code != http.StatusFound {
+			t.Fatalf("ExecuteSamlLogin() status=%v", rec.Code)
+		}
+		location, err := url.Parse(rec.Header().Get(echo.HeaderLocation))
+		if err != nil || location.Host != "keycloak:8080" {
+			t.Fatalf("invalid Location: %v", rec.Header().Get(echo.HeaderLocation))
+		}
+
+		// SPの証明書でクエリ文字列の署名を検証できること
+		metadata, err := cssp.ParseMetadata([]byte(testIdpMetadataWithCertificate(t, spCertPEM)))
+		if err != nil {
+			t.Fatalf("invalid metadata: %v", err)
+		}
+		if err := verifyRedirectSignature(&cs.ServiceProvider{IDPMetadata: metadata}, location.RawQuery, SAMLRequest); err != nil {
+			t.Errorf("verifyRedirectSignature()=%v", err)
+		}
+
+		// AuthnRequestにXML署名が含まれないこと
+		request := &http.Request{Method: http.MethodGet, URL: location, Form: location.Query()}
+		authnRequest, err := decodeSamlMessage(request, SAMLRequest, lang.ErrBase64SamlRequest)
+		if err != nil {
+			t.Fatalf("decodeSamlMessage()=%v", err)
+		}
+		if !strings.Contains(string(authnRequest), "AuthnRequest") || strings.Contains(string(authnRequest), "SignatureValue") {
+			t.Errorf("invalid AuthnRequest: %s", authnRequest)
+		}
+	})
+
+	t.Run("ExecuteSamlSlo_Other", func(t *testing.T) {
+		// 宛先をSPのSLOのURL、発行日時を現在日時としたLogoutRequest
+		sloURL := newClient(&MockUserDao{}).idps[0].serviceProvider().SloURL.String()
+		newLogoutRequest := func(issueInstant time.Time, destination string, email string) string {
+			return strings.NewReplacer(
+				`IssueInstant="2024-10-08T09:19:26.689Z"`, `IssueInstant="`+issueInstant.UTC().Format(time.RFC3339)+`"`,
+				`Destination="http://keycloak:8080/realms/my-realm/protocol/saml"`, `Destination="`+destination+`"`,
+				">test@test.co.jp<", ">"+email+"<",
+			).Replace(TestDataLogoutRequest)
+		}
+		logoutRequest := newLogoutRequest(time.Now(), sloURL, expectEmail)
+		signedQuery := newTestRedirectQuery(t, idpKeyPEM, SAMLRequest, logoutRequest, "")
+		// 署名していない別のユーザのLogoutRequest
+		forgedValue := strings.TrimPrefix(newTestRedirectQuery(t, nil, SAMLRequest, newLogoutRequest(time.Now(), sloURL, "other@test.co.jp"), ""), SAMLRequest+"=")
+
+		tests := []struct {
+			name     string
+			query    string
+			wantCode lang.ErrorCode
+		}{
+			{
+				name:  "test1_署名あり",
+				query: newTestRedirectQuery(t, idpKeyPEM, SAMLRequest, logoutRequest, "test-relay-state"),
+			},
+			{
+				name:     "test2_署名なし",
+				query:    newTestRedirectQuery(t, nil, SAMLRequest, logoutRequest, ""),
+				wantCode: lang.ErrSamlRedirectSignature,
+			},
+			{
+				name:     "test3_idP以外の鍵で署名",
+				query:    newTestRedirectQuery(t, otherKeyPEM, SAMLRequest, logoutRequest, ""),
+				wantCode: lang.ErrSamlRedirectSignature,
+			},
+			{
+				name:     "test4_署名後にRelayStateを改ざん",
+				query:    strings.Replace(newTestRedirectQuery(t, idpKeyPEM, SAMLRequest, logoutRequest, "test-relay-state"), "test-relay-state", "other-relay-state", 1),
+				wantCode: lang.ErrSamlRedirectSignature,
+			},
+			{
+				name: "test5_未対応の署名アルゴリズム",
+				query: regexp.MustCompile(`SigAlg=[^&]*`).ReplaceAllString(signedQuery,
+					"SigAlg="+url.QueryEscape("http://www.w3.org/2000/09/xmldsig#dsa-sha1")),
+				wantCode: lang.ErrSamlRedirectSignature,
+			},
+			{
+				name:     "test6_DEFLATEで圧縮されていない",
+				query:    SAMLRequest + "=" + url.QueryEscape(toFormUrlencoded(logoutRequest)),
+				wantCode: lang.ErrInflateSamlMessage,
+			},
+			{
+				name:     "test7_発行日時から期限切れ (署名したURLの再送)",
+				query:    newTestRedirectQuery(t, idpKeyPEM, SAMLRequest, newLogoutRequest(time.Now().Add(-time.Hour), sloURL, expectEmail), ""),
+				wantCode: lang.ErrSLOValidation,
+			},
+			{
+				name:     "test8_発行日時が未来",
+				query:    newTestRedirectQuery(t, idpKeyPEM, SAMLRequest, newLogoutRequest(time.Now().Add(time.Hour), sloURL, expectEmail), ""),
+				wantCode: lang.ErrSLOValidation,
+			},
+			{
+				name: "test9_NotOnOrAfterを過ぎた",
+				query: newTestRedirectQuery(t, idpKeyPEM, SAMLRequest, strings.Replace(logoutRequest, `Version="2.0"`,
+					`Version="2.0" NotOnOrAfter="`+time.Now().Add(-10*time.Minute).UTC().Format(time.RFC3339)+`"`, 1), ""),
+				wantCode: lang.ErrSLOValidation,
+			},
+			{
+				name:     "test10_宛先がSPのSLOのURLではない",
+				query:    newTestRedirectQuery(t, idpKeyPEM, SAMLRequest, newLogoutRequest(time.Now(), "https://other-sp/saml/slo", expectEmail), ""),
+				wantCode: lang.ErrSLOValidation,
+			},
+			{
+				name:     "test11_キーをURLエンコードした署名の無いSAMLRequestを先頭に追加",
+				query:    "SAML%52equest=" + forgedValue + "&" + signedQuery,
+				wantCode: lang.ErrSamlRedirectSignature,
+			},
+			{
+				name:     "test12_署名の無いSAMLRequestを重複して追加",
+				query:    signedQuery + "&" + SAMLRequest + "=" + forgedValue,
+				wantCode: lang.ErrSamlRedirectSignature,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockDB, mock, err := sqlmock.New()
+				if err != nil {
+					t.Fatalf("failed sqlmock.New(): %v", err)
+				}
+				mock.ExpectBegin()
+				if tt.wantCode == 0 {
+					mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+				client := newClient(&MockUserDao{IUserEntityDao: db.NewUserEntityDao(&MockDB{db: mockDB})})
+
+				rec := httptest.NewRecorder()
+				err = client.ExecuteSamlSlo(echo.New().NewContext(httptest.NewRequest(echo.GET, "https://localhost/saml/slo?"+tt.query, nil), rec))
+				if tt.wantCode != 0 {
+					if fxtErr, ok := err.(*lang.FxtError); !ok || fxtErr.ErrCode != tt.wantCode {
+						t.Errorf("ExecuteSamlSlo()=%v wantCode=%x", err, tt.wantCode)
+					}
+					return
+				} else if err != nil {
+					t.Fatalf("ExecuteSamlSlo()=%v", err)
+				}
+
+				// LogoutResponseがHTTP-Redirectバインディングで返却され、RelayStateが引き継がれること
+				if rec.Code != http.StatusFound {
+					t.Fatalf("ExecuteSamlSlo() status=%v", rec.Code)
+				}
+				location, err := url.Parse(rec.Header().Get(echo.HeaderLocation))
+				if err != nil {
+					t.Fatalf("invalid Location: %v", err)
+				}
+				if !location.Query().Has(SAMLResponse) || !location.Query().Has(Signature) || location.Query().Get(RelayState) != "test-relay-state" {
+					t.Errorf("invalid Location: %v", location)
+				}
+			})
+		}
+	})
+
+	t.Run("ExecuteSamlSlo_MySp", func(t *testing.T) {
+		client := newClient(&MockUserDao{})
+		sp := client.idps[0].serviceProvider()
+		logoutResponse, err := xml.Marshal(&cs.LogoutResponse{
+			ID:           "id-test-logout-response",
+			InResponseTo: "test-authn-request-id",
+			Version:      "2.0",
+			IssueInstant: time.Now(),
+			Destination:  sp.SloURL.String(),
+			Issuer:       &cs.Issuer{Value: sp.IDPMetadata.EntityID},
+			Status:       cs.Status{StatusCode: cs.StatusCode{Value: cs.StatusSuccess}},
+		})
+		if err != nil {
+			t.Fatalf("failed xml.Marshal: %v", err)
+		}
+
+		tests := []struct {
+			name            string
+			query           string
+			wantRedirectURL string
+			wantSamlErr     uint32
+		}{
+			{
+				name:            "test1_署名あり",
+				query:           newTestRedirectQuery(t, idpKeyPEM, SAMLResponse, string(logoutResponse), ""),
+				wantRedirectURL: "http://localhost/test-redirect",
+			},
+			{
+				name:            "test2_署名なし",
+				query:           newTestRedirectQuery(t, nil, SAMLResponse, string(logoutResponse), ""),
+				wantRedirectURL: "http://localhost/test-redirect-error?saml_error=1",
+				wantSamlErr:     uint32(lang.ErrSamlRedirectSignature),
+			},
+			{
+				name:            "test3_期限切れ",
+				query:           newTestRedirectQuery(t, idpKeyPEM, SAMLResponse, TestDataLogoutResponse, ""),
+				wantRedirectURL: "http://localhost/test-redirect-error?saml_error=1",
+				wantSamlErr:     uint32(lang.ErrSLOValidation),
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockDB, mock, err := sqlmock.New()
+				if err != nil {
+					t.Fatalf("failed sqlmock.New(): %v", err)
+				}
+				mock.ExpectBegin()
+				if tt.wantSamlErr == 0 {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+				client.dao = &MockUserDao{IUserEntityDao: db.NewUserEntityDao(&MockDB{db: mockDB})}
+
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(echo.GET, "https://localhost/saml/slo?"+tt.query, nil)
+				token, err := net.GenerateToken(net.SLOSessionPayload{
+					UserId:             expectUserId,
+					SessionId:          "test-session-id",
+					AuthnRequestId:     "test-authn-request-id",
+					RedirectURL:        "http://localhost/test-redirect",
+					RedirectURLOnError: "http://localhost/test-redirect-error",
+				}, time.Now().Add(time.Hour), net.SLOSessionKeys)
+				if err != nil {
+					t.Fatalf("failed net.GenerateToken: %v", err)
+				}
+				req.AddCookie(&http.Cookie{Name: net.NameSLOToken, Value: token})
+
+				if err := client.ExecuteSamlSlo(echo.New().NewContext(req, rec)); err != nil {
+					t.Fatalf("ExecuteSamlSlo()=%v", err)
+				}
+				if redirectURL := rec.Header().Get(echo.HeaderLocation); redirectURL != tt.wantRedirectURL {
+					t.Errorf("ExecuteSamlSlo()=%v wantRedirectURL=%v", redirectURL, tt.wantRedirectURL)
+				}
+				parser := &http.Request{Header: http.Header{"Cookie": rec.Header()["Set-Cookie"]}}
+				c, err := parser.Cookie(net.NameSAMLErrorToken)
+				if err != nil {
+					t.Fatalf("invalid cookie: %v", err)
+				}
+				errClaims, err := net.VerifyToken[gen.ErrorWithTime](c.Value, net.SAMLErrorSessionKeys)
+				if err != nil {
+					t.Fatalf("invalid cookie: %v", err)
+				}
+				if errClaims.Value.Err.Code != tt.wantSamlErr {
+					t.Errorf("ExecuteSamlSlo()=%x wantSamlErr=%x", errClaims.Value.Err.Code, tt.wantSamlErr)
+				}
+			})
+		}
+	})
+}
+
+func Test_SamlClient_AssertionReplay(t *testing.T) {
+	const expectUserId = 100
+	const expectEmail = "test@test.co.jp"
+	const assertionId = "test-assertion-id"
+
+	saveIdpMetadataUrl := common.GetConfig().Saml.IdpMetadataUrl
+	defer func() {
+		common.GetConfig().Saml.IdpMetadataUrl = saveIdpMetadataUrl
+	}()
+	common.GetConfig().Saml.IdpMetadataUrl = "file://test"
+
+	tests := []struct {
+		name string
+		// ACSが受け取るアサーションのID
+		assertionId string
+		assertions  func(t *testing.T) IAssertionStore
+		// 0の場合はログインに成功すること
+		wantSamlErr lang.ErrorCode
+	}{
+		{
+			name:        "test1_初回のアサーション",
+			assertionId: assertionId,
+			assertions: func(t *testing.T) IAssertionStore {
+				return NewMemoryAssertionStore()
+			},
+		},
+		{
+			name:        "test2_使用済みのアサーションを再送",
+			assertionId: assertionId,
+			assertions: func(t *testing.T) IAssertionStore {
+				store := NewMemoryAssertionStore()
+				store.Consume(assertionId, time.Now().Add(time.Minute))
+				return store
+			},
+			wantSamlErr: lang.ErrSamlAssertionReplayed,
+		},
+		{
+			name:        "test3_IDの無いアサーション",
+			assertionId: "",
+			assertions: func(t *testing.T) IAssertionStore {
+				return NewMemoryAssertionStore()
+			},
+			wantSamlErr: lang.ErrUnexpectedAssertion,
+		},
+		{
+			name:        "test4_データベースのストアで使用済み",
+			assertionId: assertionId,
+			assertions: func(t *testing.T) IAssertionStore {
+				mockDB, mock, err := sqlmock.New()
+				if err != nil {
+					t.Fatalf("failed sqlmock.New(): %v", err)
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(`select fxtester_schema.consume_saml_assertion($1, $2)`)).WithArgs(assertionId, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"consume_saml_assertion"}).AddRow(false))
+				return NewDbAssertionStore(db.NewSamlAssertionEntityDao(&MockDB{db: mockDB}))
+			},
+			wantSamlErr: lang.ErrSamlAssertionReplayed,
+		},
+		{
+			name:        "test5_データベースのストアのエラー",
+			assertionId: assertionId,
+			assertions: func(t *testing.T) IAssertionStore {
+				mockDB, mock, err := sqlmock.New()
+				if err != nil {
+					t.Fatalf("failed sqlmock.New(): %v", err)
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(`select fxtester_schema.consume_saml_assertion($1, $2)`)).WillReturnError(errors.New("test"))
+				return NewDbAssertionStore(db.NewSamlAssertionEntityDao(&MockDB{db: mockDB}))
+			},
+			wantSamlErr: lang.ErrDBQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed sqlmock.New(): %v", err)
+			}
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
+			mock.ExpectQuery(regexp.QuoteMeta(`call fxtester_schema.update_user_attributes($1, $2, $3, $4, $5, $6)`)).WithArgs(expectUserId, nil, nil, nil, nil, nil).WillReturnRows(sqlmock.NewRows([]string{}))
+			mock.ExpectCommit()
+
+			// ログインの成否を監査イベントに記録すること
+			auditDB, auditMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed sqlmock.New(): %v", err)
+			}
+			outcome, errorCode := audit.OutcomeSuccess, any(nil)
+			if tt.wantSamlErr != 0 {
+				outcome, errorCode = audit.OutcomeFailure, int64(tt.wantSamlErr)
+			}
+			auditMock.ExpectQuery(regexp.QuoteMeta(`call fxtester_schema.insert_audit_event($1, $2, $3, $4, $5, $6, $7, $8, $9)`)).
+				WithArgs(audit.EventLogin, outcome, nil, expectEmail, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), errorCode, "saml idp="+common.DefaultSamlIdpName).
+				WillReturnRows(sqlmock.NewRows([]string{}))
+
+			client := &SamlClient{
+				delegate: &MockSamlClientDelegator{
+					delegateOpenFile: func(path string) (io.ReadCloser, error) {
+						return io.NopCloser(strings.NewReader(TestDataIdpMetadata)), nil
+					},
+					delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
+						return &cs.Assertion{
+							ID:           tt.assertionId,
+							IssueInstant: time.Now(),
+							Subject: &cs.Subject{
+								NameID: &cs.NameID{
+									Value: expectEmail,
+								},
+							},
+						}, nil
+					},
+				},
+				dao: &MockUserDao{
+					IUserEntityDao: db.NewUserEntityDao(&MockDB{db: mockDB}),
+				},
+				assertions: tt.assertions(t),
+				auditor:    audit.NewRecorder(&MockDB{db: auditDB}),
+			}
+			if err := client.Init(); err != nil {
+				t.Fatalf("Init()=%v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			ctx := NewCookieContext([]struct {
+				name    string
+				secret  *net.KeyRing
+				payload net.SSOSessionPayload
+			}{
+				{
+					name:   net.NameSSOToken,
+					secret: net.SSOSessionKeys,
+					payload: net.SSOSessionPayload{
+						AuthnRequestId:     "test-authn-request-id",
+						RedirectURL:        "http://localhost/test-redirect",
+						RedirectURLOnError: "http://localhost/test-redirect-error",
+					},
+				},
+			}, rec, t)
+			if err := client.ExecuteSamlAcs(ctx); err != nil {
+				t.Fatalf("ExecuteSamlAcs()=%v", err)
+			}
+
+			wantRedirectURL := "http://localhost/test-redirect"
+			if tt.wantSamlErr != 0 {
+				wantRedirectURL = "http://localhost/test-redirect-error?saml_error=1"
+			}
+			if redirectURL := rec.Header().Get(echo.HeaderLocation); redirectURL != wantRedirectURL {
+				t.Errorf("ExecuteSamlAcs()=%v wantRedirectURL=%v", redirectURL, wantRedirectURL)
+			}
+
+			parser := &http.Request{Header: http.Header{"Cookie": rec.Header()["Set-Cookie"]}}
+			c, err := parser.Cookie(net.NameSAMLErrorToken)
+			if err != nil {
+				t.Fatalf("invalid cookie: %v", err)
+			}
+			errClaims, err := net.VerifyToken[gen.ErrorWithTime](c.Value, net.SAMLErrorSessionKeys)
+			if err != nil {
+				t.Fatalf("invalid cookie: %v", err)
+			}
+			if errClaims.Value.Err.Code != uint32(tt.wantSamlErr) {
+				t.Errorf("ExecuteSamlAcs()=%x wantSamlErr=%x", errClaims.Value.Err.Code, tt.wantSamlErr)
+			}
+
+			if err := auditMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("ExpectationsWereMet()=%v", err)
+			}
+
+			// ログインに成功したアサーションは再送できないこと
+			if tt.wantSamlErr == 0 {
+				if consumed, err := client.assertions.Consume(tt.assertionId, time.Now().Add(time.Minute)); err != nil || consumed {
+					t.Errorf("Consume()=%v, %v want=false", consumed, err)
+				}
+			}
+		})
+	}
+}
+
+func Test_SamlClient_AttributeSync(t *testing.T) {
+	const expectUserId = 100
+	const expectEmail = "test@test.co.jp"
+
+	saveIdpMetadataUrl := common.GetConfig().Saml.IdpMetadataUrl
+	saveAttributeMapping := common.GetConfig().Saml.AttributeMapping
+	defer func() {
+		common.GetConfig().Saml.IdpMetadataUrl = saveIdpMetadataUrl
+		common.GetConfig().Saml.AttributeMapping = saveAttributeMapping
+	}()
+	common.GetConfig().Saml.IdpMetadataUrl = "file://test"
+	common.GetConfig().Saml.AttributeMapping.DisplayName = "displayName"
+	common.GetConfig().Saml.AttributeMapping.GivenName = "firstName"
+	common.GetConfig().Saml.AttributeMapping.FamilyName = "lastName"
+	common.GetConfig().Saml.AttributeMapping.Groups = "groups"
+	common.GetConfig().Saml.AttributeMapping.Locale = "locale"
+	common.GetConfig().Saml.AttributeMapping.Disabled = "disabled"
+
+	attribute := func(name, friendlyName string, values ...string) cs.Attribute {
+		attr := cs.Attribute{Name: name, FriendlyName: friendlyName}
+		for _, v := range values {
+			attr.Values = append(attr.Values, cs.AttributeValue{Value: v})
+		}
+		return attr
+	}
+	ptr := func(s string) *string {
+		return &s
+	}
+
+	tests := []struct {
+		name       string
+		attributes []cs.Attribute
+		// nilの場合は属性を更新しないこと
+		wantAttributes *db.UserAttributesEntity
+		// trueの場合はプロビジョニングを解除すること
+		wantDeprovision bool
+		// 0の場合はログインに成功すること
+		wantSamlErr lang.ErrorCode
+	}{
+		{
+			name: "test1_全ての属性",
+			attributes: []cs.Attribute{
+				attribute("displayName", "", "Taro Yamada"),
+				attribute("urn:oid:2.5.4.42", "firstName", "Taro"),
+				attribute("lastName", "", "Yamada"),
+				attribute("groups", "", "traders", "admins", "traders"),
+				attribute("locale", "", "ja"),
+				attribute("disabled", "", "false"),
+			},
+			wantAttributes: &db.UserAttributesEntity{
+				DisplayName: ptr("Taro Yamada"),
+				GivenName:   ptr("Taro"),
+				FamilyName:  ptr("Yamada"),
+				Groups:      []string{"admins", "traders"},
+				Locale:      ptr("ja"),
+			},
+		},
+		{
+			name: "test2_アサーションに無い属性は更新しない",
+			attributes: []cs.Attribute{
+				attribute("lastName", "", "Yamada"),
+				attribute("groups", ""),
+			},
+			wantAttributes: &db.UserAttributesEntity{
+				FamilyName: ptr("Yamada"),
+				Groups:     []string{},
+			},
+		},
+		{
+			name: "test3_idPで無効化されたアカウント",
+			attributes: []cs.Attribute{
+				attribute("displayName", "", "Taro Yamada"),
+				attribute("disabled", "", "TRUE"),
+			},
+			wantDeprovision: true,
+			wantSamlErr:     lang.ErrAccountDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed sqlmock.New(): %v", err)
+			}
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
+			mock.ExpectCommit()
+
+			var gotAttributes *db.UserAttributesEntity
+			deprovisioned := false
+			client := &SamlClient{
+				delegate: &MockSamlClientDelegator{
+					delegateOpenFile: func(path string) (io.ReadCloser, error) {
+						return io.NopCloser(strings.NewReader(TestDataIdpMetadata)), nil
+					},
+					delegateParseAuthResponse: func(sp cs.ServiceProvider, request *http.Request, possibleRequestIds []string) (*cs.Assertion, error) {
+						return &cs.Assertion{
+							ID:           "test-assertion-id",
+							IssueInstant: time.Now(),
+							Subject: &cs.Subject{
+								NameID: &cs.NameID{
+									Value: expectEmail,
+								},
+							},
+							AttributeStatements: []cs.AttributeStatement{
+								{Attributes: tt.attributes},
+							},
+						}, nil
+					},
+				},
+				dao: &MockUserDao{
+					IUserEntityDao: db.NewUserEntityDao(&MockDB{db: mockDB}),
+					delegateUpdateAttributes: func(userId int64, attributes *db.UserAttributesEntity) error {
+						if userId != expectUserId {
+							t.Errorf("UpdateAttributes() userId=%v want=%v", userId, expectUserId)
+						}
+						gotAttributes = attributes
+						return nil
+					},
+					delegateDeprovisionUser: func(userId int64) error {
+						if userId != expectUserId {
+							t.Errorf("DeprovisionUser() userId=%v want=%v", userId, expectUserId)
+						}
+						deprovisioned = true
+						return nil
+					},
+				},
+			}
+			if err := client.Init(); err != nil {
+				t.Fatalf("Init()=%v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			ctx := NewCookieContext([]struct {
+				name    string
+				secret  *net.KeyRing
+				payload net.SSOSessionPayload
+			}{
+				{
+					name:   net.NameSSOToken,
+					secret: net.SSOSessionKeys,
+					payload: net.SSOSessionPayload{
+						AuthnRequestId:     "test-authn-request-id",
+						RedirectURL:        "http://localhost/test-redirect",
+						RedirectURLOnError: "http://localhost/test-redirect-error",
+					},
+				},
+			}, rec, t)
+			if err := client.ExecuteSamlAcs(ctx); err != nil {
+				t.Fatalf("ExecuteSamlAcs()=%v", err)
+			}
+
+			parser := &http.Request{Header: http.Header{"Cookie": rec.Header()["Set-Cookie"]}}
+			c, err := parser.Cookie(net.NameSAMLErrorToken)
+			if err != nil {
+				t.Fatalf("invalid cookie: %v", err)
+			}
+			errClaims, err := net.VerifyToken[gen.ErrorWithTime](c.Value, net.SAMLErrorSessionKeys)
+			if err != nil {
+				t.Fatalf("invalid cookie: %v", err)
+			}
+			if errClaims.Value.Err.Code != uint32(tt.wantSamlErr) {
+				t.Errorf("ExecuteSamlAcs()=%x wantSamlErr=%x", errClaims.Value.Err.Code, tt.wantSamlErr)
+			}
+			if !reflect.DeepEqual(gotAttributes, tt.wantAttributes) {
+				t.Errorf("UpdateAttributes()=%+v want=%+v", gotAttributes, tt.wantAttributes)
+			}
+			if deprovisioned != tt.wantDeprovision {
+				t.Errorf("DeprovisionUser()=%v want=%v", deprovisioned, tt.wantDeprovision)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("ExpectationsWereMet()=%v", err)
+			}
+		})
+	}
+}
+
+// newTestSignedXML SAMLメッセージにenveloped signatureのXML署名を付与する
+func newTestSignedXML(t *testing.T, certPEM []byte, keyPEM []byte, message string) string {
+	keyPair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("failed tls.X509KeyPair: %v", err)
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(message); err != nil {
+		t.Fatalf("failed ReadFromString: %v", err)
+	}
+	signed, err := dsig.NewDefaultSigningContext(dsig.TLSCertKeyStore(keyPair)).SignEnveloped(doc.Root())
+	if err != nil {
+		t.Fatalf("failed SignEnveloped: %v", err)
+	}
+	doc.SetRoot(signed)
+	signedXML, err := doc.WriteToString()
+	if err != nil {
+		t.Fatalf("failed WriteToString: %v", err)
+	}
+	return signedXML
+}
+
+func Test_SamlClient_DeprovisionBySlo(t *testing.T) {
+	const expectUserId = 100
+	const expectEmail = "test@test.co.jp"
+	const adminReason = "urn:oasis:names:tc:SAML:2.0:logout:admin"
+	idpCertPEM, idpKeyPEM := newTestSpKeyPair(t)
+	otherCertPEM, otherKeyPEM := newTestSpKeyPair(t)
+	idpMetadata := testIdpMetadataWithCertificate(t, idpCertPEM)
+
+	saveIdpMetadataUrl := common.GetConfig().Saml.IdpMetadataUrl
+	saveReasons := common.GetConfig().Saml.DeprovisionLogoutReasons
+	defer func() {
+		common.GetConfig().Saml.IdpMetadataUrl = saveIdpMetadataUrl
+		common.GetConfig().Saml.DeprovisionLogoutReasons = saveReasons
+	}()
+	common.GetConfig().Saml.IdpMetadataUrl = "file://test"
+	common.GetConfig().Saml.DeprovisionLogoutReasons = []string{adminReason}
+
+	tests := []struct {
+		name   string
+		reason string
+		// LogoutRequestに署名する鍵ペア (nilの場合は署名しない)
+		certPEM []byte
+		keyPEM  []byte
+		// 署名を付与した後にLogoutRequestを改ざんする
+		tamper func(message string) string
+		// trueの場合はプロビジョニングを解除し、falseの場合はセッションのみ削除すること
+		wantDeprovision bool
+		// 0以外の場合はエラーとし、DBのユーザを変更しないこと
+		wantCode lang.ErrorCode
+	}{
+		{
+			name:            "test1_管理者によるログアウト",
+			reason:          adminReason,
+			certPEM:         idpCertPEM,
+			keyPEM:          idpKeyPEM,
+			wantDeprovision: true,
+		},
+		{
+			name:    "test2_ユーザによるログアウト",
+			reason:  "urn:oasis:names:tc:SAML:2.0:logout:user",
+			certPEM: idpCertPEM,
+			keyPEM:  idpKeyPEM,
+		},
+		{
+			name:    "test3_理由の無いログアウト",
+			certPEM: idpCertPEM,
+			keyPEM:  idpKeyPEM,
+		},
+		{
+			name:     "test4_署名の無い偽造したLogoutRequest",
+			reason:   adminReason,
+			wantCode: lang.ErrSamlPostSignature,
+		},
+		{
+			name:     "test5_idP以外の鍵で署名したLogoutRequest",
+			reason:   adminReason,
+			certPEM:  otherCertPEM,
+			keyPEM:   otherKeyPEM,
+			wantCode: lang.ErrSamlPostSignature,
+		},
+		{
+			name:    "test6_署名後に理由を改ざんしたLogoutRequest",
+			reason:  "urn:oasis:names:tc:SAML:2.0:logout:user",
+			certPEM: idpCertPEM,
+			keyPEM:  idpKeyPEM,
+			tamper: func(message string) string {
+				return strings.Replace(message, "urn:oasis:names:tc:SAML:2.0:logout:user", adminReason, 1)
+			},
+			wantCode: lang.ErrSamlPostSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed sqlmock.New(): %v", err)
+			}
+			mock.ExpectBegin()
+			if tt.wantCode == 0 {
+				mock.ExpectQuery(regexp.QuoteMeta(`select id, email, roles from fxtester_schema.select_user_with_email($1)`)).WithArgs(expectEmail).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "roles"}).AddRow(expectUserId, expectEmail, "{}"))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			deprovisioned, sessionsDeleted := false, false
+			client := &SamlClient{
+				delegate: &MockSamlClientDelegator{
+					delegateOpenFile: func(path string) (io.ReadCloser, error) {
+						return io.NopCloser(strings.NewReader(idpMetadata)), nil
+					},
+				},
+				dao: &MockUserDao{
+					IUserEntityDao: db.NewUserEntityDao(&MockDB{db: mockDB}),
+					delegateDeleteSessions: func(userId int64, samlSessionIndex string) (int64, error) {
+						sessionsDeleted = true
+						return 1, nil
+					},
+					delegateDeprovisionUser: func(userId int64) error {
+						if userId != expectUserId {
+							t.Errorf("DeprovisionUser() userId=%v want=%v", userId, expectUserId)
+						}
+						deprovisioned = true
+						return nil
+					},
+				},
+			}
+			if err := client.Init(); err != nil {
+				t.Fatalf("Init()=%v", err)
+			}
+
+			logoutRequest := TestDataLogoutRequest
+			if tt.reason != "" {
+				logoutRequest = strings.Replace(logoutRequest, `Version="2.0"`, `Version="2.0" Reason="`+tt.reason+`"`, 1)
+			}
+			if tt.keyPEM != nil {
+				logoutRequest = newTestSignedXML(t, tt.certPEM, tt.keyPEM, logoutRequest)
+			}
+			if tt.tamper != nil {
+				logoutRequest = tt.tamper(logoutRequest)
+			}
+			rec := httptest.NewRecorder()
+			ctx := NewCookieContext[any](nil, rec, t)
+			ctx.Request().Form = url.Values{}
+			ctx.Request().Form.Add(SAMLRequest, toFormUrlencoded(logoutRequest))
+			err = client.ExecuteSamlSlo(ctx)
+			if tt.wantCode != 0 {
+				if fxtErr := lang.FindFxtError(err); fxtErr == nil || fxtErr.ErrCode != tt.wantCode {
+					t.Fatalf("ExecuteSamlSlo()=%v wantCode=%v", err, tt.wantCode)
+				}
+				if deprovisioned || sessionsDeleted {
+					t.Errorf("DeprovisionUser()=%v DeleteSessions()=%v want=false", deprovisioned, sessionsDeleted)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("ExecuteSamlSlo()=%v", err)
+				}
+				if deprovisioned != tt.wantDeprovision {
+					t.Errorf("DeprovisionUser()=%v want=%v", deprovisioned, tt.wantDeprovision)
+				}
+				if sessionsDeleted == tt.wantDeprovision {
+					t.Errorf("DeleteSessions()=%v want=%v", sessionsDeleted, !tt.wantDeprovision)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("ExpectationsWereMet()=%v", err)
+			}
+		})
+	}
+}
+
+func Test_MemoryAssertionStore_Consume(t *testing.T) {
+	now := time.Date(2024, 10, 8, 9, 0, 0, 0, time.UTC)
+	store := NewMemoryAssertionStore()
+	store.now = func() time.Time { return now }
+
+	tests := []struct {
+		name        string
+		assertionId string
+		// 現在日時からの経過時間
+		elapsed      time.Duration
+		wantConsumed bool
+	}{
+		{name: "test1_初回", assertionId: "id-1", wantConsumed: true},
+		{name: "test2_有効期限内の再送", assertionId: "id-1", elapsed: time.Minute, wantConsumed: false},
+		{name: "test3_別のアサーション", assertionId: "id-2", elapsed: time.Minute, wantConsumed: true},
+		{name: "test4_有効期限後の再送", assertionId: "id-1", elapsed: 3 * time.Minute, wantConsumed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store.now = func() time.Time { return now.Add(tt.elapsed) }
+			// 有効期限は記録した日時から2分後とする
+			consumed, err := store.Consume(tt.assertionId, now.Add(tt.elapsed).Add(2*time.Minute))
+			if err != nil {
+				t.Fatalf("Consume()=%v", err)
+			}
+			if consumed != tt.wantConsumed {
+				t.Errorf("Consume()=%v wantConsumed=%v", consumed, tt.wantConsumed)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package saml
+
+import (
+	"context"
+	"errors"
+	"fxtester/internal/common"
+	"fxtester/internal/lang"
+	"sync/atomic"
+	"time"
+
+	cs "github.com/crewjam/saml"
+	"github.com/labstack/echo/v4"
+)
+
+// minMetadataRefreshInterval idPのメタデータを再取得する最短の間隔 (cacheDurationが極端に短い場合の対策)
+const minMetadataRefreshInterval = time.Minute
+
+// defaultMetadataRetryInterval 再取得に失敗した場合に再試行するまでの既定の間隔
+const defaultMetadataRetryInterval = time.Minute
+
+var ErrIdpEntityIdChanged = errors.New("saml: idp entityID changed")
+
+// samlIdp idPとそのidPのメタデータで構成したサービスプロバイダ
+type samlIdp struct {
+	name         string
+	metadataUrl  string
+	emailDomains []string
+	// メタデータで構成したサービスプロバイダ (メタデータの再取得時に差し替える)
+	sp atomic.Pointer[cs.ServiceProvider]
+	// メタデータの取得状況
+	status atomic.Pointer[IdpMetadataStatus]
+}
+
+// IdpMetadataStatus idPのメタデータの取得状況
+type IdpMetadataStatus struct {
+	Name     string
+	EntityId string
+	// 使用中のメタデータを取得した日時
+	FetchedAt time.Time
+	// メタデータのvalidUntil (指定されていない場合はゼロ値)
+	ValidUntil time.Time
+	// 次にメタデータを再取得する日時 (再取得しない場合はゼロ値)
+	NextRefreshAt time.Time
+	// 直近の再取得のエラー (成功した場合はnil)
+	LastError error
+}
+
+// serviceProvider 最新のメタデータで構成したサービスプロバイダを返却する
+func (idp *samlIdp) serviceProvider() *cs.ServiceProvider {
+	return idp.sp.Load()
+}
+
+// MetadataStatus idP毎のメタデータの取得状況を返却します
+func (c *SamlClient) MetadataStatus() []IdpMetadataStatus {
+	statuses := make([]IdpMetadataStatus, len(c.idps))
+	for i, idp := range c.idps {
+		statuses[i] = *idp.status.Load()
+	}
+	return statuses
+}
+
+// StartMetadataRefresh idPのメタデータを定期的に再取得し、サービスプロバイダを差し替えます。ctxがキャンセルされるまで処理を続けます
+func (c *SamlClient) StartMetadataRefresh(ctx context.Context, logger echo.Logger) {
+	if common.GetConfig().Saml.MetadataRefresh.IntervalSec <= 0 {
+		return
+	}
+	for _, idp := range c.idps {
+		go func(idp *samlIdp) {
+			for {
+				timer := time.NewTimer(time.Until(idp.status.Load().NextRefreshAt))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+				if err := c.refreshIdpMetadata(idp, time.Now()); err != nil {
+					// 再取得に失敗した場合も取得済みのメタデータで処理を続ける
+					logger.Errorf("failed to refresh saml idp metadata: idp=%s: %v", idp.name, err)
+				}
+			}
+		}(idp)
+	}
+}
+
+// refreshIdpMetadata idPのメタデータを取得し、サービスプロバイダを差し替える。
+// 取得に失敗した場合は取得済みのメタデータを使用し続け、再試行の間隔の後に再取得する
+func (c *SamlClient) refreshIdpMetadata(idp *samlIdp, now time.Time) error {
+	metadata, err := c.fetchIdpMetadata(idp.metadataUrl)
+	if err == nil {
+		// 別のidPのメタデータに置き換わらないようにEntityIDの変更は許可しない
+		if current := idp.serviceProvider(); current != nil && current.IDPMetadata.EntityID != metadata.EntityID {
+			err = lang.NewFxtError(lang.ErrInvalidIdpMetadata).SetCause(ErrIdpEntityIdChanged)
+		}
+	}
+	if err != nil {
+		status := IdpMetadataStatus{Name: idp.name}
+		if current := idp.status.Load(); current != nil {
+			status = *current
+		}
+		status.LastError = err
+		status.NextRefreshAt = nextMetadataRetry(now)
+		idp.status.Store(&status)
+		return err
+	}
+
+	sp := c.spTemplate
+	sp.IDPMetadata = metadata
+	idp.sp.Store(&sp)
+	idp.status.Store(&IdpMetadataStatus{
+		Name:          idp.name,
+		EntityId:      metadata.EntityID,
+		FetchedAt:     now,
+		ValidUntil:    metadata.ValidUntil,
+		NextRefreshAt: nextMetadataRefresh(metadata, now),
+	})
+	return nil
+}
+
+// nextMetadataRefresh 次にメタデータを再取得する日時を返却する。
+// 設定の間隔、メタデータのcacheDuration、validUntilまでの期間のうち最も短い期間後とする (再取得しない場合はゼロ値)
+func nextMetadataRefresh(metadata *cs.EntityDescriptor, now time.Time) time.Time {
+	interval := time.Duration(common.GetConfig().Saml.MetadataRefresh.IntervalSec) * time.Second
+	if interval <= 0 {
+		return time.Time{}
+	}
+	if 0 < metadata.CacheDuration && metadata.CacheDuration < interval {
+		interval = metadata.CacheDuration
+	}
+	if !metadata.ValidUntil.IsZero() && metadata.ValidUntil.Sub(now) < interval {
+		interval = metadata.ValidUntil.Sub(now)
+	}
+	return now.Add(max(interval, minMetadataRefreshInterval))
+}
+
+// nextMetadataRetry 再取得に失敗した場合に、次にメタデータを再取得する日時を返却する (再取得しない場合はゼロ値)
+func nextMetadataRetry(now time.Time) time.Time {
+	config := common.GetConfig().Saml.MetadataRefresh
+	if config.IntervalSec <= 0 {
+		return time.Time{}
+	}
+	retry := time.Duration(config.RetryIntervalSec) * time.Second
+	if retry <= 0 {
+		retry = defaultMetadataRetryInterval
+	}
+	return now.Add(retry)
+}
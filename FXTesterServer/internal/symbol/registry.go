@@ -0,0 +1,195 @@
+// Package symbol シンボルのメタデータ関連のパッケージ
+package symbol
+
+import (
+	"encoding/json"
+	"fmt"
+	"fxtester/internal/common"
+	"fxtester/internal/db"
+	"fxtester/internal/lang"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// regexSessionTime 取引セッションの時刻 (HH:MM)
+var regexSessionTime = regexp.MustCompile(`^(?:[0-1][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// Session 取引セッション
+type Session struct {
+	Name string `json:"name"`
+	// 開始時刻 (UTCのHH:MM)
+	Open string `json:"open"`
+	// 終了時刻 (UTCのHH:MM)
+	Close string `json:"close"`
+}
+
+// Symbol シンボルのメタデータ
+type Symbol struct {
+	Name string
+	// 基軸通貨 (e.g. USDJPYの場合はUSD)
+	Base string
+	// 決済通貨 (e.g. USDJPYの場合はJPY)
+	Quote string
+	// 価格の小数点以下の桁数
+	Digits int
+	// 1pipの価格幅
+	PipSize float64
+	// 1ロットあたりの通貨量
+	ContractSize float64
+	Sessions     []Session
+}
+
+// ToPips 価格幅をpipsに変換する
+func (s *Symbol) ToPips(price float64) float64 {
+	return price / s.PipSize
+}
+
+// NewSymbol 設定ファイルのシンボル設定からSymbolを生成する
+func NewSymbol(name string, config common.SymbolConfig) (*Symbol, error) {
+	if name == "" || !common.RegexCurrency.MatchString(config.Base) || !common.RegexCurrency.MatchString(config.Quote) {
+		return nil, lang.NewFxtError(lang.ErrCodeConfig).SetCause(fmt.Errorf("invalid symbol: %v", name))
+	}
+	if config.Digits < 0 || config.PipSize <= 0 || config.ContractSize <= 0 {
+		return nil, lang.NewFxtError(lang.ErrCodeConfig).SetCause(fmt.Errorf("invalid symbol: %v", name))
+	}
+
+	sessions := []Session{}
+	for _, s := range config.Sessions {
+		if s.Name == "" || !regexSessionTime.MatchString(s.Open) || !regexSessionTime.MatchString(s.Close) {
+			return nil, lang.NewFxtError(lang.ErrCodeConfig).SetCause(fmt.Errorf("invalid session: %v %v", name, s.Name))
+		}
+		sessions = append(sessions, Session{Name: s.Name, Open: s.Open, Close: s.Close})
+	}
+
+	return &Symbol{
+		Name:         name,
+		Base:         config.Base,
+		Quote:        config.Quote,
+		Digits:       config.Digits,
+		PipSize:      config.PipSize,
+		ContractSize: config.ContractSize,
+		Sessions:     sessions,
+	}, nil
+}
+
+// Registry シンボルのメタデータを管理する
+type Registry struct {
+	dao     db.ISymbolEntityDao
+	mu      sync.RWMutex
+	symbols map[string]*Symbol
+}
+
+func NewRegistry(idb db.IDB) *Registry {
+	return &Registry{
+		dao:     db.NewSymbolEntityDao(idb),
+		symbols: map[string]*Symbol{},
+	}
+}
+
+// Init 設定ファイルのシンボルをDBに登録し、DBに登録済みの全てのシンボルを読み込む
+func (r *Registry) Init() error {
+	// シンボル名順に登録する
+	names := []string{}
+	for name := range common.GetConfig().Symbols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := r.dao.Begin(); err != nil {
+		return err
+	}
+	for _, name := range names {
+		s, err := NewSymbol(name, common.GetConfig().Symbols[name])
+		if err != nil {
+			r.dao.Rollback()
+			return err
+		}
+		if err := r.dao.UpsertSymbol(toEntity(s)); err != nil {
+			r.dao.Rollback()
+			return err
+		}
+	}
+	if err := r.dao.Commit(); err != nil {
+		return err
+	}
+
+	return r.Reload()
+}
+
+// Reload DBに登録済みのシンボルを読み込み直す
+func (r *Registry) Reload() error {
+	entities, err := r.dao.SelectSymbols()
+	if err != nil {
+		return err
+	}
+
+	symbols := map[string]*Symbol{}
+	for _, e := range entities {
+		s, err := fromEntity(e)
+		if err != nil {
+			return err
+		}
+		symbols[s.Name] = s
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.symbols = symbols
+	return nil
+}
+
+// Get 指定したシンボルのメタデータを返却する
+func (r *Registry) Get(name string) (*Symbol, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.symbols[name]
+	return s, ok
+}
+
+// List 全てのシンボルのメタデータをシンボル名順に返却する
+func (r *Registry) List() []*Symbol {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	symbols := []*Symbol{}
+	for _, s := range r.symbols {
+		symbols = append(symbols, s)
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i].Name < symbols[j].Name
+	})
+	return symbols
+}
+
+func toEntity(s *Symbol) *db.SymbolEntity {
+	sessions, err := json.Marshal(s.Sessions)
+	if err != nil {
+		// プログラムのミス
+		panic(err)
+	}
+	return &db.SymbolEntity{
+		Name:          s.Name,
+		BaseCurrency:  s.Base,
+		QuoteCurrency: s.Quote,
+		Digits:        s.Digits,
+		PipSize:       s.PipSize,
+		ContractSize:  s.ContractSize,
+		Sessions:      sessions,
+	}
+}
+
+func fromEntity(e db.SymbolEntity) (*Symbol, error) {
+	sessions := []Session{}
+	if err := json.Unmarshal(e.Sessions, &sessions); err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	return &Symbol{
+		Name:         e.Name,
+		Base:         e.BaseCurrency,
+		Quote:        e.QuoteCurrency,
+		Digits:       e.Digits,
+		PipSize:      e.PipSize,
+		ContractSize: e.ContractSize,
+		Sessions:     sessions,
+	}, nil
+}
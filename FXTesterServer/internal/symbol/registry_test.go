@@ -0,0 +1,88 @@
+package symbol
+
+import (
+	"fxtester/internal/common"
+	"math"
+	"testing"
+)
+
+func Test_NewSymbol(t *testing.T) {
+	valid := func() common.SymbolConfig {
+		return common.SymbolConfig{
+			Base:         "USD",
+			Quote:        "JPY",
+			Digits:       3,
+			PipSize:      0.01,
+			ContractSize: 100000,
+			Sessions: []common.SymbolSessionConfig{
+				{Name: "tokyo", Open: "00:00", Close: "09:00"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		config  func() common.SymbolConfig
+		wantErr bool
+	}{
+		{
+			name:   "正常ケース",
+			config: valid,
+		},
+		{
+			name: "不正な通貨コード",
+			config: func() common.SymbolConfig {
+				c := valid()
+				c.Quote = "yen"
+				return c
+			},
+			wantErr: true,
+		},
+		{
+			name: "pipSizeが0",
+			config: func() common.SymbolConfig {
+				c := valid()
+				c.PipSize = 0
+				return c
+			},
+			wantErr: true,
+		},
+		{
+			name: "不正なセッションの時刻",
+			config: func() common.SymbolConfig {
+				c := valid()
+				c.Sessions[0].Close = "24:00"
+				return c
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSymbol("USDJPY", tt.config()); (err != nil) != tt.wantErr {
+				t.Errorf("NewSymbol()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Symbol_ToPips(t *testing.T) {
+	tests := []struct {
+		name   string
+		symbol Symbol
+		price  float64
+		want   float64
+	}{
+		{name: "円を含む通貨ペア", symbol: Symbol{PipSize: 0.01, Digits: 3}, price: -0.253, want: -25.3},
+		{name: "円を含まない通貨ペア", symbol: Symbol{PipSize: 0.0001, Digits: 5}, price: 0.00253, want: 25.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.symbol.ToPips(tt.price); 1e-9 < math.Abs(got-tt.want) {
+				t.Errorf("ToPips()=%v want=%v", got, tt.want)
+			}
+		})
+	}
+}
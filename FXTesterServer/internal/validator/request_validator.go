@@ -6,17 +6,440 @@ import (
 	"fxtester/internal/common"
 	"fxtester/internal/gen"
 	"fxtester/internal/lang"
+	"mime/multipart"
 	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
 
 	"github.com/labstack/echo/v4"
 )
 
 func ValidatePostZigzag(ctx echo.Context) error {
+	form := ctx.Request().MultipartForm
 
-	inputDataTypes := ctx.Request().MultipartForm.Value["type"]
-	csvInfos := ctx.Request().MultipartForm.Value["csvInfo"]
-	csvs := ctx.Request().MultipartForm.File["csv"]
-	candless := ctx.Request().MultipartForm.Value["candles"]
+	// ローソク足のパラメータのチェック
+	if err := validateCandlesForm(form); err != nil {
+		return err
+	}
+
+	// 'symbol'パラメータのチェック (任意)
+	if symbols := form.Value["symbol"]; 0 < len(symbols) && (len(symbols) != 1 || symbols[0] == "") {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "symbol")
+	}
+
+	return nil
+}
+
+// ValidatePostCharts チャート描画のリクエストをチェックする
+func ValidatePostCharts(ctx echo.Context) error {
+	form := ctx.Request().MultipartForm
+
+	// ローソク足のパラメータのチェック
+	if err := validateCandlesForm(form); err != nil {
+		return err
+	}
+
+	// 'format'パラメータのチェック (任意)
+	if formats := form.Value["format"]; 0 < len(formats) {
+		if len(formats) != 1 {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "format")
+		}
+		switch gen.PostChartsRequestFormat(formats[0]) {
+		case gen.Svg, gen.Png:
+		default:
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "format")
+		}
+	}
+
+	// 'width', 'height'パラメータのチェック (任意、設定の上限以下)
+	config := common.GetConfig().Chart
+	for name, max := range map[string]int{"width": config.MaxWidth, "height": config.MaxHeight} {
+		values := form.Value[name]
+		if len(values) <= 0 {
+			continue
+		}
+		v, err := strconv.Atoi(values[0])
+		if len(values) != 1 || err != nil || v <= 0 || (0 < max && max < v) {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, name)
+		}
+	}
+
+	// 'from', 'to'パラメータのチェック (任意、fromはto以前)
+	var from, to *time.Time
+	for name, dst := range map[string]**time.Time{"from": &from, "to": &to} {
+		values := form.Value[name]
+		if len(values) <= 0 {
+			continue
+		}
+		t, err := common.ToTime(values[0])
+		if len(values) != 1 || err != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, name)
+		}
+		*dst = t
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "to")
+	}
+
+	// 'zigzag'パラメータのチェック (任意)
+	if zigzags := form.Value["zigzag"]; 0 < len(zigzags) {
+		if _, err := strconv.ParseBool(zigzags[0]); len(zigzags) != 1 || err != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "zigzag")
+		}
+	}
+
+	// 'lines'パラメータのチェック (任意)
+	if liness := form.Value["lines"]; 0 < len(liness) {
+		if len(liness) != 1 {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "lines")
+		}
+
+		var lines []gen.ChartLine
+
+		// unmarshalが可能かチェックする
+		if err := json.Unmarshal([]byte(liness[0]), &lines); err != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "lines").SetCause(err)
+		}
+
+		for i, line := range lines {
+			if line.Name == "" {
+				return lang.NewFxtError(lang.ErrCodeParameterMissing, fmt.Sprintf("lines[%d].name", i))
+			}
+			if line.Color != nil && !common.RegexHexColor.MatchString(*line.Color) {
+				return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("lines[%d].color", i))
+			}
+			for j, p := range line.Points {
+				if _, err := common.ToTime(p.Time); err != nil {
+					return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("lines[%d].points[%d].time", i, j))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidatePostIndicatorsExport インジケーターの出力のリクエストをチェックする
+func ValidatePostIndicatorsExport(ctx echo.Context) error {
+	form := ctx.Request().MultipartForm
+
+	// ローソク足のパラメータのチェック
+	if err := validateCandlesForm(form); err != nil {
+		return err
+	}
+
+	// 'indicators'パラメータのチェック (1つ以上)
+	indicatorss := form.Value["indicators"]
+	if len(indicatorss) <= 0 {
+		return lang.NewFxtError(lang.ErrCodeParameterMissing, "indicators")
+	}
+	if len(indicatorss) != 1 {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "indicators")
+	}
+
+	var indicators []gen.Indicator
+
+	// unmarshalが可能かチェックする
+	if err := json.Unmarshal([]byte(indicatorss[0]), &indicators); err != nil {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "indicators").SetCause(err)
+	}
+	if len(indicators) <= 0 {
+		return lang.NewFxtError(lang.ErrCodeParameterMissing, "indicators")
+	}
+
+	for i, indicator := range indicators {
+		switch indicator.Kind {
+		case gen.Sma, gen.Ema:
+		default:
+			return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("indicators[%d].kind", i))
+		}
+		if indicator.Period < 1 {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("indicators[%d].period", i))
+		}
+	}
+
+	return nil
+}
+
+func ValidatePostBacktest(ctx echo.Context) error {
+	form := ctx.Request().MultipartForm
+
+	// ローソク足のパラメータのチェック
+	if err := validateCandlesForm(form); err != nil {
+		return err
+	}
+
+	// 'symbol'パラメータのチェック
+	symbols := form.Value["symbol"]
+	if len(symbols) <= 0 || symbols[0] == "" {
+		return lang.NewFxtError(lang.ErrCodeParameterMissing, "symbol")
+	} else if len(symbols) != 1 {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "symbol")
+	}
+
+	// 'orders'パラメータのチェック
+	requireSizing, err := validateBacktestOrders(form, nil)
+	if err != nil {
+		return err
+	}
+
+	// 'timeframe'パラメータのチェック (任意)
+	if timeframes := form.Value["timeframe"]; 0 < len(timeframes) {
+		if len(timeframes) != 1 {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "timeframe")
+		} else if _, ok := common.Timeframes[timeframes[0]]; !ok {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "timeframe")
+		}
+	}
+
+	return validateBacktestOptions(form, requireSizing)
+}
+
+func ValidatePostBacktestPortfolio(ctx echo.Context) error {
+	form := ctx.Request().MultipartForm
+
+	// ローソク足のパラメータのチェック
+	if err := validateCandlesForm(form); err != nil {
+		return err
+	}
+
+	// 'symbol'パラメータのチェック (入力データ毎に重複なく指定する)
+	symbols := form.Value["symbol"]
+	if len(symbols) <= 0 {
+		return lang.NewFxtError(lang.ErrCodeParameterMissing, "symbol")
+	} else if len(symbols) != len(form.Value["type"]) || len(common.Set(symbols)) != len(symbols) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "symbol")
+	}
+	for _, symbol := range symbols {
+		if symbol == "" {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "symbol")
+		}
+	}
+
+	// 'accountCurrency'パラメータのチェック
+	currencies := form.Value["accountCurrency"]
+	if len(currencies) <= 0 || currencies[0] == "" {
+		return lang.NewFxtError(lang.ErrCodeParameterMissing, "accountCurrency")
+	} else if len(currencies) != 1 || !common.RegexCurrency.MatchString(currencies[0]) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "accountCurrency")
+	}
+
+	// 'orders'パラメータのチェック
+	requireSizing, err := validateBacktestOrders(form, symbols)
+	if err != nil {
+		return err
+	}
+
+	return validateBacktestOptions(form, requireSizing)
+}
+
+// ValidatePostBacktestsCompare バックテストの比較のリクエスト(JSON)をチェックする
+func ValidatePostBacktestsCompare(req gen.PostBacktestsCompareRequest) error {
+	// 'ids'パラメータのチェック (2件以上、上限以下、重複不可)
+	if len(req.Ids) <= 0 {
+		return lang.NewFxtError(lang.ErrCodeParameterMissing, "ids")
+	}
+	maxRuns := common.GetConfig().Backtest.Compare.MaxRuns
+	if len(req.Ids) < 2 || (0 < maxRuns && maxRuns < len(req.Ids)) || len(common.Set(req.Ids)) != len(req.Ids) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "ids")
+	}
+	for i, id := range req.Ids {
+		if id <= 0 {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("ids[%d]", i))
+		}
+	}
+
+	// 'timeframe'パラメータのチェック (任意)
+	if req.Timeframe != nil {
+		if _, ok := common.Timeframes[string(*req.Timeframe)]; !ok {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "timeframe")
+		}
+	}
+
+	return nil
+}
+
+// ValidatePatchMe ログインユーザの設定の更新のリクエスト(JSON)をチェックする
+func ValidatePatchMe(req gen.UserPreferences) error {
+	// 'locale'パラメータのチェック (任意)
+	if req.Locale != nil && *req.Locale != gen.En && *req.Locale != gen.Ja {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "locale")
+	}
+
+	// 'timezone'パラメータのチェック (任意、IANAのタイムゾーン名)
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" || *req.Timezone == "Local" {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "timezone").SetCause(err)
+		}
+	}
+
+	// 'csvInfoTemplates'パラメータのチェック (任意、名前の重複不可)
+	if req.CsvInfoTemplates != nil {
+		names := map[string]bool{}
+		for i, t := range *req.CsvInfoTemplates {
+			if t.Name == "" || 64 < utf8.RuneCountInString(t.Name) || names[t.Name] {
+				return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("csvInfoTemplates[%d].name", i))
+			}
+			names[t.Name] = true
+			if err := ValidateCsvInfo(t.CsvInfo); err != nil {
+				return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("csvInfoTemplates[%d].csvInfo", i)).SetCause(err)
+			}
+		}
+	}
+
+	// 'display'パラメータのチェック (任意)
+	if display := req.Display; display != nil {
+		if display.Theme != nil && *display.Theme != gen.System && *display.Theme != gen.Light && *display.Theme != gen.Dark {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "display.theme")
+		}
+		if display.Timeframe != nil {
+			if _, ok := common.Timeframes[*display.Timeframe]; !ok {
+				return lang.NewFxtError(lang.ErrInvalidParameterError, "display.timeframe")
+			}
+		}
+		if display.PageSize != nil && (*display.PageSize < 1 || 100 < *display.PageSize) {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "display.pageSize")
+		}
+	}
+
+	return nil
+}
+
+// ValidatePostTokens 個人用APIトークンの発行のリクエスト(JSON)をチェックする
+func ValidatePostTokens(req gen.PostTokensRequest) error {
+	// 'name'パラメータのチェック
+	if req.Name == "" {
+		return lang.NewFxtError(lang.ErrCodeParameterMissing, "name")
+	}
+	if 64 < utf8.RuneCountInString(req.Name) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "name")
+	}
+
+	// 'scopes'パラメータのチェック (1件以上、重複不可)
+	if len(req.Scopes) <= 0 {
+		return lang.NewFxtError(lang.ErrCodeParameterMissing, "scopes")
+	}
+	if len(common.Set(req.Scopes)) != len(req.Scopes) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "scopes")
+	}
+	for i, scope := range req.Scopes {
+		if scope != gen.Analysis && scope != gen.BacktestsRead && scope != gen.BacktestsWrite {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("scopes[%d]", i))
+		}
+	}
+
+	// 'expiresInDays'パラメータのチェック (任意)
+	if req.ExpiresInDays != nil {
+		maxDays := common.GetConfig().ApiToken.MaxExpiresDays
+		if *req.ExpiresInDays < 1 || (0 < maxDays && maxDays < *req.ExpiresInDays) {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "expiresInDays")
+		}
+	}
+
+	return nil
+}
+
+// validateBacktestOrders 'orders'パラメータをチェックし、取引数量が未指定の注文が存在するかを返却する。
+// symbolsを指定した場合は注文のシンボルが必須となり、symbolsに含まれるかをチェックする
+func validateBacktestOrders(form *multipart.Form, symbols []string) (bool, error) {
+	orders := form.Value["orders"]
+	if len(orders) <= 0 || orders[0] == "" {
+		return false, lang.NewFxtError(lang.ErrCodeParameterMissing, "orders")
+	} else if len(orders) != 1 {
+		return false, lang.NewFxtError(lang.ErrInvalidParameterError, "orders")
+	}
+
+	var ts []gen.BacktestOrder
+
+	// unmarshalが可能かチェックする
+	if err := json.Unmarshal([]byte(orders[0]), &ts); err != nil {
+		return false, lang.NewFxtError(lang.ErrInvalidParameterError, "orders").SetCause(err)
+	}
+
+	// 取引数量が未指定の注文が存在するか
+	requireSizing := false
+	for i, t := range ts {
+		// BacktestOrder型のバリデーション
+		if err := ValidateBacktestOrder(t); err != nil {
+			return false, lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("orders[%d]", i)).SetCause(err)
+		}
+		if symbols != nil && (t.Symbol == nil || !slices.Contains(symbols, *t.Symbol)) {
+			return false, lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("orders[%d]", i))
+		}
+		if t.Lots == nil {
+			requireSizing = true
+		}
+	}
+
+	return requireSizing, nil
+}
+
+// validateBacktestOptions バックテストの任意パラメータ(intrabarPath, account, sizing)をチェックする
+func validateBacktestOptions(form *multipart.Form, requireSizing bool) error {
+	// 'intrabarPath'パラメータのチェック (任意)
+	if paths := form.Value["intrabarPath"]; 0 < len(paths) {
+		if len(paths) != 1 {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "intrabarPath")
+		}
+		switch gen.BacktestIntrabarPath(paths[0]) {
+		case gen.Pessimistic, gen.Ohlc, gen.Olhc:
+		default:
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "intrabarPath")
+		}
+	}
+
+	// 'account'パラメータのチェック (任意)
+	if accounts := form.Value["account"]; 0 < len(accounts) {
+		if len(accounts) != 1 {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "account")
+		}
+
+		var t gen.BacktestAccount
+
+		// unmarshalが可能かチェックする
+		if err := json.Unmarshal([]byte(accounts[0]), &t); err != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "account").SetCause(err)
+		}
+
+		// BacktestAccount型のバリデーション
+		if err := ValidateBacktestAccount(t); err != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "account").SetCause(err)
+		}
+	}
+
+	// 'sizing'パラメータのチェック (取引数量が未指定の注文が存在する場合は必須)
+	sizings := form.Value["sizing"]
+	if len(sizings) <= 0 || sizings[0] == "" {
+		if requireSizing {
+			return lang.NewFxtError(lang.ErrCodeParameterMissing, "sizing")
+		}
+	} else if len(sizings) != 1 {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "sizing")
+	} else {
+		var t gen.BacktestSizing
+
+		// unmarshalが可能かチェックする
+		if err := json.Unmarshal([]byte(sizings[0]), &t); err != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "sizing").SetCause(err)
+		}
+
+		// BacktestSizing型のバリデーション
+		if err := ValidateBacktestSizing(t); err != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "sizing").SetCause(err)
+		}
+	}
+
+	return nil
+}
+
+// validateCandlesForm ローソク足の入力パラメータ(type, csvInfo, csv, candles)をチェックする
+func validateCandlesForm(form *multipart.Form) error {
+
+	inputDataTypes := form.Value["type"]
+	csvInfos := form.Value["csvInfo"]
+	csvs := form.File["csv"]
+	candless := form.Value["candles"]
 
 	// 入力タイプが'csv'と'candles'の個数をカウントする
 	numInputTypeCsv, numInputTypeCandles, err := func() (int, int, error) {
@@ -82,25 +505,8 @@ func ValidatePostZigzag(ctx echo.Context) error {
 			return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("csvInfo[%d]", i)).SetCause(err)
 		}
 
-		indexes := []int{t.CloseColumnIndex, t.HighColumnIndex, t.LowColumnIndex, t.OpenColumnIndex, t.TimeColumnIndex}
-		slices.Sort(indexes)
-		unique := slices.Compact(indexes)
-
-		// インデックスの重複チェック
-		if len(unique) != len(indexes) {
-			return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("csvInfo[%d]", i))
-		}
-
-		// インデックスの負数チェック
-		for _, v := range indexes {
-			if v < 0 {
-				return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("csvInfo[%d]", i))
-			}
-		}
-
-		// 区切り文字のチェック
-		if t.DelimiterChar == "" || !common.RegexCsvDelimiter.MatchString(string(t.DelimiterChar)) {
-			return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("csvInfo[%d]", i))
+		if err := ValidateCsvInfo(t); err != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, fmt.Sprintf("csvInfo[%d]", i)).SetCause(err)
 		}
 	}
 
@@ -127,3 +533,49 @@ func ValidatePostZigzag(ctx echo.Context) error {
 
 	return nil
 }
+
+// ValidateGetAdminAudit 監査イベントの検索のクエリパラメータをチェックする
+func ValidateGetAdminAudit(params gen.GetAdminAuditParams) error {
+	// 'type'パラメータのチェック (任意)
+	if params.Type != nil && !slices.Contains([]gen.AuditEventType{gen.Login, gen.Logout, gen.Access, gen.DataAnalyze, gen.DataExport, gen.DataDelete}, *params.Type) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "type")
+	}
+
+	// 'outcome'パラメータのチェック (任意)
+	if params.Outcome != nil && *params.Outcome != gen.Success && *params.Outcome != gen.Failure {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "outcome")
+	}
+
+	// 'userId'パラメータのチェック (任意)
+	if params.UserId != nil && *params.UserId <= 0 {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "userId")
+	}
+
+	// 'from'・'to'パラメータのチェック (任意、RFC3339、from < to)
+	var from, to time.Time
+	if params.From != nil {
+		var err error
+		if from, err = time.Parse(time.RFC3339, *params.From); err != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "from").SetCause(err)
+		}
+	}
+	if params.To != nil {
+		var err error
+		if to, err = time.Parse(time.RFC3339, *params.To); err != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "to").SetCause(err)
+		}
+		if params.From != nil && !from.Before(to) {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "to")
+		}
+	}
+
+	// 'limit'・'offset'パラメータのチェック (任意)
+	if params.Limit != nil && (*params.Limit < 1 || 100 < *params.Limit) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "limit")
+	}
+	if params.Offset != nil && *params.Offset < 0 {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "offset")
+	}
+
+	return nil
+}
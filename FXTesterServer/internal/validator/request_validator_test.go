@@ -684,3 +684,719 @@ func Test_ValidatePostZigzag(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidatePostBacktest(t *testing.T) {
+	type args struct {
+		ctx echo.Context
+	}
+
+	// 正常なローソク足と、指定したsymbol・ordersを持つコンテキストを作成する
+	newContext := func(symbols []string, orders []string) echo.Context {
+		req := httptest.NewRequest(echo.POST, "https://localhost:8100", nil)
+		w := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, w)
+
+		candles, err := json.Marshal([]gen.Candle{
+			{Time: "2024-01-01T00:00:00Z", Open: 100, High: 101, Low: 99, Close: 100},
+		})
+		if err != nil {
+			t.Errorf("failed to create []gen.Candle: %v", err)
+		}
+
+		ctx.Request().MultipartForm = &multipart.Form{
+			Value: map[string][]string{
+				"type": {
+					string(gen.PostBacktestRequestTypeCandles),
+				},
+				"candles": {
+					string(candles),
+				},
+				"symbol": symbols,
+				"orders": orders,
+			},
+			File: map[string][]*multipart.FileHeader{},
+		}
+		return ctx
+	}
+
+	lots := 1.0
+	validOrders := func() string {
+		bytes, err := json.Marshal([]gen.BacktestOrder{
+			{Time: "2024-01-01T00:00:00Z", Side: gen.BacktestOrderSideBuy, Lots: &lots},
+		})
+		if err != nil {
+			t.Errorf("failed to create []gen.BacktestOrder: %v", err)
+		}
+		return string(bytes)
+	}()
+
+	noLotOrders := `[{"time": "2024-01-01T00:00:00Z", "side": "buy", "stopLoss": 99}]`
+
+	// コンテキストのフォームにパラメータを追加する
+	withValue := func(ctx echo.Context, key string, value string) echo.Context {
+		ctx.Request().MultipartForm.Value[key] = []string{value}
+		return ctx
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			args: args{
+				ctx: newContext([]string{"USDJPY"}, []string{validOrders}),
+			},
+		},
+		{
+			name: "symbolが未指定",
+			args: args{
+				ctx: newContext(nil, []string{validOrders}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ordersが未指定",
+			args: args{
+				ctx: newContext([]string{"USDJPY"}, nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ordersが配列ではない",
+			args: args{
+				ctx: newContext([]string{"USDJPY"}, []string{`{"side": "buy"}`}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ordersに不正な注文が含まれる",
+			args: args{
+				ctx: newContext([]string{"USDJPY"}, []string{`[{"time": "2024-01-01T00:00:00Z", "side": "buy", "lots": -1}]`}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "数量が未指定の注文とsizingを指定",
+			args: args{
+				ctx: withValue(newContext([]string{"USDJPY"}, []string{noLotOrders}), "sizing", `{"type": "fixedFractional", "riskPercent": 2}`),
+			},
+		},
+		{
+			name: "数量が未指定の注文でsizingが未指定",
+			args: args{
+				ctx: newContext([]string{"USDJPY"}, []string{noLotOrders}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "不正なsizing",
+			args: args{
+				ctx: withValue(newContext([]string{"USDJPY"}, []string{validOrders}), "sizing", `{"type": "fixedLot"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "accountを指定",
+			args: args{
+				ctx: withValue(newContext([]string{"USDJPY"}, []string{validOrders}), "account", `{"initialBalance": 500000, "leverage": 10}`),
+			},
+		},
+		{
+			name: "不正なaccount",
+			args: args{
+				ctx: withValue(newContext([]string{"USDJPY"}, []string{validOrders}), "account", `{"leverage": 0}`),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if err := ValidatePostBacktest(tt.args.ctx); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePostBacktest()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ValidatePostBacktestPortfolio(t *testing.T) {
+	type args struct {
+		ctx echo.Context
+	}
+
+	// 2つのシンボルのローソク足と、指定したパラメータを持つコンテキストを作成する
+	newContext := func(values map[string][]string) echo.Context {
+		req := httptest.NewRequest(echo.POST, "https://localhost:8100", nil)
+		w := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, w)
+
+		candles, err := json.Marshal([]gen.Candle{
+			{Time: "2024-01-01T00:00:00Z", Open: 100, High: 101, Low: 99, Close: 100},
+		})
+		if err != nil {
+			t.Errorf("failed to create []gen.Candle: %v", err)
+		}
+
+		form := map[string][]string{
+			"type": {
+				string(gen.PostBacktestPortfolioRequestTypeCandles),
+				string(gen.PostBacktestPortfolioRequestTypeCandles),
+			},
+			"candles": {
+				string(candles),
+				string(candles),
+			},
+		}
+		for k, v := range values {
+			form[k] = v
+		}
+
+		ctx.Request().MultipartForm = &multipart.Form{
+			Value: form,
+			File:  map[string][]*multipart.FileHeader{},
+		}
+		return ctx
+	}
+
+	validOrders := `[{"symbol": "USDJPY", "time": "2024-01-01T00:00:00Z", "side": "buy", "lots": 1}, {"symbol": "EURJPY", "time": "2024-01-01T00:00:00Z", "side": "sell", "lots": 1}]`
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			args: args{
+				ctx: newContext(map[string][]string{
+					"symbol":          {"USDJPY", "EURJPY"},
+					"accountCurrency": {"JPY"},
+					"orders":          {validOrders},
+				}),
+			},
+		},
+		{
+			name: "symbolの個数が入力データと異なる",
+			args: args{
+				ctx: newContext(map[string][]string{
+					"symbol":          {"USDJPY"},
+					"accountCurrency": {"JPY"},
+					"orders":          {validOrders},
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "symbolが重複",
+			args: args{
+				ctx: newContext(map[string][]string{
+					"symbol":          {"USDJPY", "USDJPY"},
+					"accountCurrency": {"JPY"},
+					"orders":          {`[{"symbol": "USDJPY", "time": "2024-01-01T00:00:00Z", "side": "buy", "lots": 1}]`},
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "accountCurrencyが未指定",
+			args: args{
+				ctx: newContext(map[string][]string{
+					"symbol": {"USDJPY", "EURJPY"},
+					"orders": {validOrders},
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "不正なaccountCurrency",
+			args: args{
+				ctx: newContext(map[string][]string{
+					"symbol":          {"USDJPY", "EURJPY"},
+					"accountCurrency": {"yen"},
+					"orders":          {validOrders},
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "注文のsymbolが未指定",
+			args: args{
+				ctx: newContext(map[string][]string{
+					"symbol":          {"USDJPY", "EURJPY"},
+					"accountCurrency": {"JPY"},
+					"orders":          {`[{"time": "2024-01-01T00:00:00Z", "side": "buy", "lots": 1}]`},
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "注文のsymbolが入力データに存在しない",
+			args: args{
+				ctx: newContext(map[string][]string{
+					"symbol":          {"USDJPY", "EURJPY"},
+					"accountCurrency": {"JPY"},
+					"orders":          {`[{"symbol": "GBPJPY", "time": "2024-01-01T00:00:00Z", "side": "buy", "lots": 1}]`},
+				}),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if err := ValidatePostBacktestPortfolio(tt.args.ctx); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePostBacktestPortfolio()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ValidatePostBacktestsCompare(t *testing.T) {
+	h1 := gen.PostBacktestsCompareRequestTimeframeH1
+	invalid := gen.PostBacktestsCompareRequestTimeframe("W1")
+
+	tests := []struct {
+		name    string
+		req     gen.PostBacktestsCompareRequest
+		wantErr bool
+	}{
+		{
+			name:    "正常ケース",
+			req:     gen.PostBacktestsCompareRequest{Ids: []int64{1, 2}, Timeframe: &h1},
+			wantErr: false,
+		},
+		{
+			name:    "timeframeの省略",
+			req:     gen.PostBacktestsCompareRequest{Ids: []int64{1, 2, 3}},
+			wantErr: false,
+		},
+		{
+			name:    "idsが未指定",
+			req:     gen.PostBacktestsCompareRequest{},
+			wantErr: true,
+		},
+		{
+			name:    "idsが1件",
+			req:     gen.PostBacktestsCompareRequest{Ids: []int64{1}},
+			wantErr: true,
+		},
+		{
+			name:    "idsが上限超過",
+			req:     gen.PostBacktestsCompareRequest{Ids: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+			wantErr: true,
+		},
+		{
+			name:    "idsが重複",
+			req:     gen.PostBacktestsCompareRequest{Ids: []int64{1, 1}},
+			wantErr: true,
+		},
+		{
+			name:    "idsに0以下",
+			req:     gen.PostBacktestsCompareRequest{Ids: []int64{1, 0}},
+			wantErr: true,
+		},
+		{
+			name:    "不正なtimeframe",
+			req:     gen.PostBacktestsCompareRequest{Ids: []int64{1, 2}, Timeframe: &invalid},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePostBacktestsCompare(tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePostBacktestsCompare()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ValidatePatchMe(t *testing.T) {
+	ptr := func(v string) *string { return &v }
+	en := gen.En
+	dark := gen.Dark
+	pageSize := 20
+	invalidPageSize := 0
+	csvInfo := gen.CsvInfo{DelimiterChar: ",", TimeColumnIndex: 0, OpenColumnIndex: 1, HighColumnIndex: 2, LowColumnIndex: 3, CloseColumnIndex: 4}
+	duplicateCsvInfo := csvInfo
+	duplicateCsvInfo.CloseColumnIndex = 3
+
+	tests := []struct {
+		name    string
+		req     gen.UserPreferences
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			req: gen.UserPreferences{
+				Locale:           &en,
+				Timezone:         ptr("Asia/Tokyo"),
+				CsvInfoTemplates: &[]gen.CsvInfoTemplate{{Name: "MT4", CsvInfo: csvInfo}},
+				Display:          &gen.DisplayPreferences{Theme: &dark, Timeframe: ptr("H1"), PageSize: &pageSize},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "未指定",
+			req:     gen.UserPreferences{},
+			wantErr: false,
+		},
+		{
+			name:    "不正なロケール",
+			req:     gen.UserPreferences{Locale: (*gen.UserPreferencesLocale)(ptr("de"))},
+			wantErr: true,
+		},
+		{
+			name:    "不正なタイムゾーン",
+			req:     gen.UserPreferences{Timezone: ptr("Asia/Unknown")},
+			wantErr: true,
+		},
+		{
+			name:    "サーバ依存のタイムゾーン",
+			req:     gen.UserPreferences{Timezone: ptr("Local")},
+			wantErr: true,
+		},
+		{
+			name:    "テンプレート名の重複",
+			req:     gen.UserPreferences{CsvInfoTemplates: &[]gen.CsvInfoTemplate{{Name: "MT4", CsvInfo: csvInfo}, {Name: "MT4", CsvInfo: csvInfo}}},
+			wantErr: true,
+		},
+		{
+			name:    "テンプレートのカラムの重複",
+			req:     gen.UserPreferences{CsvInfoTemplates: &[]gen.CsvInfoTemplate{{Name: "MT4", CsvInfo: duplicateCsvInfo}}},
+			wantErr: true,
+		},
+		{
+			name:    "不正な時間足",
+			req:     gen.UserPreferences{Display: &gen.DisplayPreferences{Timeframe: ptr("W1")}},
+			wantErr: true,
+		},
+		{
+			name:    "不正な表示件数",
+			req:     gen.UserPreferences{Display: &gen.DisplayPreferences{PageSize: &invalidPageSize}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePatchMe(tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePatchMe()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ValidatePostTokens(t *testing.T) {
+	days := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		req     gen.PostTokensRequest
+		wantErr bool
+	}{
+		{
+			name:    "正常ケース",
+			req:     gen.PostTokensRequest{Name: "ci", Scopes: []gen.ApiTokenScope{gen.Analysis, gen.BacktestsRead}, ExpiresInDays: days(30)},
+			wantErr: false,
+		},
+		{
+			name:    "名前の未指定",
+			req:     gen.PostTokensRequest{Scopes: []gen.ApiTokenScope{gen.Analysis}},
+			wantErr: true,
+		},
+		{
+			name:    "スコープの未指定",
+			req:     gen.PostTokensRequest{Name: "ci"},
+			wantErr: true,
+		},
+		{
+			name:    "スコープの重複",
+			req:     gen.PostTokensRequest{Name: "ci", Scopes: []gen.ApiTokenScope{gen.Analysis, gen.Analysis}},
+			wantErr: true,
+		},
+		{
+			name:    "不正なスコープ",
+			req:     gen.PostTokensRequest{Name: "ci", Scopes: []gen.ApiTokenScope{"admin"}},
+			wantErr: true,
+		},
+		{
+			name:    "不正な有効日数",
+			req:     gen.PostTokensRequest{Name: "ci", Scopes: []gen.ApiTokenScope{gen.Analysis}, ExpiresInDays: days(0)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePostTokens(tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePostTokens()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ValidatePostCharts(t *testing.T) {
+	// ローソク足と、指定したパラメータを持つコンテキストを作成する
+	newContext := func(values map[string][]string) echo.Context {
+		req := httptest.NewRequest(echo.POST, "https://localhost:8100", nil)
+		w := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, w)
+
+		candles, err := json.Marshal([]gen.Candle{
+			{Time: "2024-01-01T00:00:00Z", Open: 100, High: 101, Low: 99, Close: 100},
+		})
+		if err != nil {
+			t.Errorf("failed to create []gen.Candle: %v", err)
+		}
+
+		form := map[string][]string{
+			"type":    {string(gen.PostChartsRequestTypeCandles)},
+			"candles": {string(candles)},
+		}
+		for k, v := range values {
+			form[k] = v
+		}
+
+		ctx.Request().MultipartForm = &multipart.Form{
+			Value: form,
+			File:  map[string][]*multipart.FileHeader{},
+		}
+		return ctx
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "正常ケース(省略可能なパラメータなし)",
+		},
+		{
+			name: "正常ケース(全パラメータ指定)",
+			values: map[string][]string{
+				"format": {string(gen.Png)},
+				"width":  {"800"},
+				"height": {"400"},
+				"from":   {"2024-01-01T00:00:00Z"},
+				"to":     {"2024-01-31T00:00:00Z"},
+				"zigzag": {"false"},
+				"lines":  {`[{"name": "SMA20", "color": "#2563eb", "points": [{"time": "2024-01-01T00:00:00Z", "value": 100}]}]`},
+			},
+		},
+		{
+			name:    "不正なformat",
+			values:  map[string][]string{"format": {"jpeg"}},
+			wantErr: true,
+		},
+		{
+			name:    "widthが数値でない",
+			values:  map[string][]string{"width": {"wide"}},
+			wantErr: true,
+		},
+		{
+			name:    "heightが0",
+			values:  map[string][]string{"height": {"0"}},
+			wantErr: true,
+		},
+		{
+			name:    "widthが上限超過",
+			values:  map[string][]string{"width": {"100000"}},
+			wantErr: true,
+		},
+		{
+			name:    "不正なfrom",
+			values:  map[string][]string{"from": {"yesterday"}},
+			wantErr: true,
+		},
+		{
+			name:    "toがfromより前",
+			values:  map[string][]string{"from": {"2024-02-01T00:00:00Z"}, "to": {"2024-01-01T00:00:00Z"}},
+			wantErr: true,
+		},
+		{
+			name:    "不正なzigzag",
+			values:  map[string][]string{"zigzag": {"maybe"}},
+			wantErr: true,
+		},
+		{
+			name:    "linesがJSONでない",
+			values:  map[string][]string{"lines": {"SMA20"}},
+			wantErr: true,
+		},
+		{
+			name:    "線のnameが未指定",
+			values:  map[string][]string{"lines": {`[{"points": []}]`}},
+			wantErr: true,
+		},
+		{
+			name:    "線のcolorが不正",
+			values:  map[string][]string{"lines": {`[{"name": "SMA20", "color": "blue", "points": []}]`}},
+			wantErr: true,
+		},
+		{
+			name:    "点のtimeが不正",
+			values:  map[string][]string{"lines": {`[{"name": "SMA20", "points": [{"time": "now", "value": 1}]}]`}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePostCharts(newContext(tt.values)); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePostCharts()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ValidateGetAdminAudit(t *testing.T) {
+	ptr := func(v string) *string { return &v }
+	num := func(v int) *int { return &v }
+	login := gen.Login
+	failure := gen.Failure
+	invalidType := gen.AuditEventType("admin")
+	userId := int64(1)
+	invalidUserId := int64(0)
+
+	tests := []struct {
+		name    string
+		params  gen.GetAdminAuditParams
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			params: gen.GetAdminAuditParams{
+				Type:    &login,
+				Outcome: &failure,
+				UserId:  &userId,
+				From:    ptr("2024-08-01T00:00:00Z"),
+				To:      ptr("2024-09-01T00:00:00+09:00"),
+				Limit:   num(100),
+				Offset:  num(0),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "パラメータの未指定",
+			params:  gen.GetAdminAuditParams{},
+			wantErr: false,
+		},
+		{
+			name:    "不正な種類",
+			params:  gen.GetAdminAuditParams{Type: &invalidType},
+			wantErr: true,
+		},
+		{
+			name:    "不正なユーザID",
+			params:  gen.GetAdminAuditParams{UserId: &invalidUserId},
+			wantErr: true,
+		},
+		{
+			name:    "不正な日時の形式",
+			params:  gen.GetAdminAuditParams{From: ptr("2024/08/01")},
+			wantErr: true,
+		},
+		{
+			name:    "開始が終了以降",
+			params:  gen.GetAdminAuditParams{From: ptr("2024-09-01T00:00:00Z"), To: ptr("2024-09-01T00:00:00Z")},
+			wantErr: true,
+		},
+		{
+			name:    "不正な件数",
+			params:  gen.GetAdminAuditParams{Limit: num(101)},
+			wantErr: true,
+		},
+		{
+			name:    "不正な読み飛ばす件数",
+			params:  gen.GetAdminAuditParams{Offset: num(-1)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateGetAdminAudit(tt.params); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateGetAdminAudit()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ValidatePostIndicatorsExport(t *testing.T) {
+	// ローソク足と、指定したパラメータを持つコンテキストを作成する
+	newContext := func(values map[string][]string) echo.Context {
+		req := httptest.NewRequest(echo.POST, "https://localhost:8100", nil)
+		w := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, w)
+
+		candles, err := json.Marshal([]gen.Candle{
+			{Time: "2024-01-01T00:00:00Z", Open: 100, High: 101, Low: 99, Close: 100},
+		})
+		if err != nil {
+			t.Errorf("failed to create []gen.Candle: %v", err)
+		}
+
+		form := map[string][]string{
+			"type":    {string(gen.PostIndicatorsExportRequestTypeCandles)},
+			"candles": {string(candles)},
+		}
+		for k, v := range values {
+			form[k] = v
+		}
+
+		ctx.Request().MultipartForm = &multipart.Form{
+			Value: form,
+			File:  map[string][]*multipart.FileHeader{},
+		}
+		return ctx
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string][]string
+		wantErr bool
+	}{
+		{
+			name:   "正常ケース",
+			values: map[string][]string{"indicators": {`[{"kind": "sma", "period": 20}, {"kind": "ema", "period": 1}]`}},
+		},
+		{
+			name:    "indicatorsが未指定",
+			wantErr: true,
+		},
+		{
+			name:    "indicatorsが空",
+			values:  map[string][]string{"indicators": {`[]`}},
+			wantErr: true,
+		},
+		{
+			name:    "indicatorsがJSONでない",
+			values:  map[string][]string{"indicators": {"sma"}},
+			wantErr: true,
+		},
+		{
+			name:    "不正なkind",
+			values:  map[string][]string{"indicators": {`[{"kind": "rsi", "period": 14}]`}},
+			wantErr: true,
+		},
+		{
+			name:    "periodが0",
+			values:  map[string][]string{"indicators": {`[{"kind": "sma", "period": 0}]`}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePostIndicatorsExport(newContext(tt.values)); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePostIndicatorsExport()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"fxtester/internal/common"
 	"fxtester/internal/gen"
+	"slices"
 )
 
 func ValidateCandle(candle gen.Candle) error {
@@ -27,5 +28,143 @@ func ValidateCandle(candle gen.Candle) error {
 		return fmt.Errorf("invalid low: %f", candle.Low)
 	}
 
+	// スプレッドの範囲チェック
+	if candle.Spread != nil && *candle.Spread < 0.0 {
+		return fmt.Errorf("invalid spread: %f", *candle.Spread)
+	}
+
+	return nil
+}
+
+func ValidateBacktestOrder(order gen.BacktestOrder) error {
+	// 日付の文字列フォーマットをチェックする
+	if !common.RegexISO8601.MatchString(order.Time) {
+		return fmt.Errorf("invalid time format: %v", order.Time)
+	}
+
+	// 売買方向のチェック
+	if order.Side != gen.BacktestOrderSideBuy && order.Side != gen.BacktestOrderSideSell {
+		return fmt.Errorf("invalid side: %v", order.Side)
+	}
+
+	// 数量の範囲チェック
+	if order.Lots != nil && *order.Lots <= 0.0 {
+		return fmt.Errorf("invalid lots: %f", *order.Lots)
+	}
+
+	// 損切り・利確価格の範囲チェック
+	if order.StopLoss != nil && *order.StopLoss < 0.0 {
+		return fmt.Errorf("invalid stopLoss: %f", *order.StopLoss)
+	}
+	if order.TakeProfit != nil && *order.TakeProfit < 0.0 {
+		return fmt.Errorf("invalid takeProfit: %f", *order.TakeProfit)
+	}
+
+	// 損切り価格と利確価格の論理性チェック
+	if order.StopLoss != nil && order.TakeProfit != nil && 0.0 < *order.StopLoss && 0.0 < *order.TakeProfit {
+		if order.Side == gen.BacktestOrderSideBuy && *order.TakeProfit <= *order.StopLoss {
+			return fmt.Errorf("invalid stopLoss/takeProfit: %f,%f", *order.StopLoss, *order.TakeProfit)
+		} else if order.Side == gen.BacktestOrderSideSell && *order.StopLoss <= *order.TakeProfit {
+			return fmt.Errorf("invalid stopLoss/takeProfit: %f,%f", *order.StopLoss, *order.TakeProfit)
+		}
+	}
+
+	return nil
+}
+
+func ValidateBacktestAccount(account gen.BacktestAccount) error {
+	// 初期残高・レバレッジの範囲チェック
+	if account.InitialBalance != nil && *account.InitialBalance <= 0.0 {
+		return fmt.Errorf("invalid initialBalance: %f", *account.InitialBalance)
+	}
+	if account.Leverage != nil && *account.Leverage <= 0.0 {
+		return fmt.Errorf("invalid leverage: %f", *account.Leverage)
+	}
+
+	// 証拠金維持率の範囲チェック
+	if account.MarginCallLevel != nil && *account.MarginCallLevel < 0.0 {
+		return fmt.Errorf("invalid marginCallLevel: %f", *account.MarginCallLevel)
+	}
+	if account.StopOutLevel != nil && *account.StopOutLevel < 0.0 {
+		return fmt.Errorf("invalid stopOutLevel: %f", *account.StopOutLevel)
+	}
+
+	// マージンコールとロスカットの水準の論理性チェック
+	if account.MarginCallLevel != nil && account.StopOutLevel != nil && *account.MarginCallLevel < *account.StopOutLevel {
+		return fmt.Errorf("invalid marginCallLevel/stopOutLevel: %f,%f", *account.MarginCallLevel, *account.StopOutLevel)
+	}
+
+	return nil
+}
+
+func ValidateBacktestSizing(sizing gen.BacktestSizing) error {
+	// 正数であることをチェックする関数
+	positive := func(name string, v *float64) error {
+		if v == nil || *v <= 0.0 {
+			return fmt.Errorf("invalid %s: %v", name, v)
+		}
+		return nil
+	}
+
+	// モデル毎の必須パラメータのチェック
+	var errs []error
+	switch sizing.Type {
+	case gen.FixedLot:
+		errs = append(errs, positive("lots", sizing.Lots))
+	case gen.FixedFractional:
+		errs = append(errs, positive("riskPercent", sizing.RiskPercent))
+	case gen.FixedRatio:
+		errs = append(errs, positive("lots", sizing.Lots), positive("delta", sizing.Delta))
+	case gen.Kelly:
+		if sizing.WinRate == nil || *sizing.WinRate < 0.0 || 1.0 < *sizing.WinRate {
+			return fmt.Errorf("invalid winRate: %v", sizing.WinRate)
+		}
+		if sizing.KellyFraction != nil && 1.0 < *sizing.KellyFraction {
+			return fmt.Errorf("invalid kellyFraction: %f", *sizing.KellyFraction)
+		}
+		errs = append(errs, positive("payoffRatio", sizing.PayoffRatio), positive("kellyFraction", sizing.KellyFraction))
+	case gen.Volatility:
+		if sizing.AtrPeriod == nil || *sizing.AtrPeriod < 1 {
+			return fmt.Errorf("invalid atrPeriod: %v", sizing.AtrPeriod)
+		}
+		errs = append(errs, positive("riskPercent", sizing.RiskPercent), positive("atrMultiple", sizing.AtrMultiple))
+	default:
+		return fmt.Errorf("invalid type: %v", sizing.Type)
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ValidateCsvInfo(csvInfo gen.CsvInfo) error {
+	indexes := []int{csvInfo.CloseColumnIndex, csvInfo.HighColumnIndex, csvInfo.LowColumnIndex, csvInfo.OpenColumnIndex, csvInfo.TimeColumnIndex}
+	if csvInfo.SpreadColumnIndex != nil {
+		indexes = append(indexes, *csvInfo.SpreadColumnIndex)
+	}
+	slices.Sort(indexes)
+	unique := slices.Compact(slices.Clone(indexes))
+
+	// インデックスの重複チェック
+	if len(unique) != len(indexes) {
+		return fmt.Errorf("duplicate column index: %v", indexes)
+	}
+
+	// インデックスの負数チェック
+	for _, v := range indexes {
+		if v < 0 {
+			return fmt.Errorf("invalid column index: %d", v)
+		}
+	}
+
+	// 区切り文字のチェック
+	if csvInfo.DelimiterChar == "" || !common.RegexCsvDelimiter.MatchString(string(csvInfo.DelimiterChar)) {
+		return fmt.Errorf("invalid delimiter: %q", csvInfo.DelimiterChar)
+	}
+
 	return nil
 }
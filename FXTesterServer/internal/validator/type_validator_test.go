@@ -180,3 +180,289 @@ func Test_ErrorHandler(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateBacktestOrder(t *testing.T) {
+	type args struct {
+		order gen.BacktestOrder
+	}
+
+	price := func(v float64) *float64 {
+		return &v
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "正常ケース(損切り・利確なし)",
+			args: args{
+				order: gen.BacktestOrder{
+					Time: "2024-01-01T00:00:00Z",
+					Side: gen.BacktestOrderSideBuy,
+					Lots: price(1),
+				},
+			},
+		},
+		{
+			name: "正常ケース(買い)",
+			args: args{
+				order: gen.BacktestOrder{
+					Time:       "2024-01-01T00:00:00Z",
+					Side:       gen.BacktestOrderSideBuy,
+					Lots:       price(0.1),
+					StopLoss:   price(99),
+					TakeProfit: price(101),
+				},
+			},
+		},
+		{
+			name: "正常ケース(売り)",
+			args: args{
+				order: gen.BacktestOrder{
+					Time:       "2024-01-01T00:00:00Z",
+					Side:       gen.BacktestOrderSideSell,
+					Lots:       price(0.1),
+					StopLoss:   price(101),
+					TakeProfit: price(99),
+				},
+			},
+		},
+		{
+			name: "正常ケース(数量の指定なし)",
+			args: args{
+				order: gen.BacktestOrder{
+					Time: "2024-01-01T00:00:00Z",
+					Side: gen.BacktestOrderSideBuy,
+				},
+			},
+		},
+		{
+			name: "日時がISO8601以外のフォーマット",
+			args: args{
+				order: gen.BacktestOrder{
+					Time: "2024/01/01 00:00:00",
+					Side: gen.BacktestOrderSideBuy,
+					Lots: price(1),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "不正な売買方向",
+			args: args{
+				order: gen.BacktestOrder{
+					Time: "2024-01-01T00:00:00Z",
+					Side: "hold",
+					Lots: price(1),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "数量が0",
+			args: args{
+				order: gen.BacktestOrder{
+					Time: "2024-01-01T00:00:00Z",
+					Side: gen.BacktestOrderSideBuy,
+					Lots: price(0),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "買いの利確価格が損切り価格以下",
+			args: args{
+				order: gen.BacktestOrder{
+					Time:       "2024-01-01T00:00:00Z",
+					Side:       gen.BacktestOrderSideBuy,
+					Lots:       price(1),
+					StopLoss:   price(101),
+					TakeProfit: price(99),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "売りの損切り価格が利確価格以下",
+			args: args{
+				order: gen.BacktestOrder{
+					Time:       "2024-01-01T00:00:00Z",
+					Side:       gen.BacktestOrderSideSell,
+					Lots:       price(1),
+					StopLoss:   price(99),
+					TakeProfit: price(101),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if err := ValidateBacktestOrder(tt.args.order); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBacktestOrder()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ValidateBacktestAccount(t *testing.T) {
+	type args struct {
+		account gen.BacktestAccount
+	}
+
+	value := func(v float64) *float64 {
+		return &v
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			args: args{
+				account: gen.BacktestAccount{
+					InitialBalance:  value(1000000),
+					Leverage:        value(25),
+					MarginCallLevel: value(100),
+					StopOutLevel:    value(50),
+				},
+			},
+		},
+		{
+			name: "正常ケース(全て省略)",
+			args: args{
+				account: gen.BacktestAccount{},
+			},
+		},
+		{
+			name: "初期残高が0",
+			args: args{
+				account: gen.BacktestAccount{InitialBalance: value(0)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "レバレッジが負数",
+			args: args{
+				account: gen.BacktestAccount{Leverage: value(-1)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ロスカット水準がマージンコール水準より大きい",
+			args: args{
+				account: gen.BacktestAccount{MarginCallLevel: value(50), StopOutLevel: value(100)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if err := ValidateBacktestAccount(tt.args.account); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBacktestAccount()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ValidateBacktestSizing(t *testing.T) {
+	type args struct {
+		sizing gen.BacktestSizing
+	}
+
+	value := func(v float64) *float64 {
+		return &v
+	}
+	period := func(v int) *int {
+		return &v
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "正常ケース(fixedLot)",
+			args: args{
+				sizing: gen.BacktestSizing{Type: gen.FixedLot, Lots: value(0.1)},
+			},
+		},
+		{
+			name: "正常ケース(fixedFractional)",
+			args: args{
+				sizing: gen.BacktestSizing{Type: gen.FixedFractional, RiskPercent: value(2)},
+			},
+		},
+		{
+			name: "正常ケース(fixedRatio)",
+			args: args{
+				sizing: gen.BacktestSizing{Type: gen.FixedRatio, Lots: value(0.1), Delta: value(50000)},
+			},
+		},
+		{
+			name: "正常ケース(kelly)",
+			args: args{
+				sizing: gen.BacktestSizing{Type: gen.Kelly, WinRate: value(0.55), PayoffRatio: value(1.5), KellyFraction: value(0.5)},
+			},
+		},
+		{
+			name: "正常ケース(volatility)",
+			args: args{
+				sizing: gen.BacktestSizing{Type: gen.Volatility, RiskPercent: value(1), AtrPeriod: period(14), AtrMultiple: value(2)},
+			},
+		},
+		{
+			name: "不正なタイプ",
+			args: args{
+				sizing: gen.BacktestSizing{Type: "martingale", Lots: value(0.1)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fixedLotの数量が未指定",
+			args: args{
+				sizing: gen.BacktestSizing{Type: gen.FixedLot},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fixedRatioのdeltaが0",
+			args: args{
+				sizing: gen.BacktestSizing{Type: gen.FixedRatio, Lots: value(0.1), Delta: value(0)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "kellyの勝率が1より大きい",
+			args: args{
+				sizing: gen.BacktestSizing{Type: gen.Kelly, WinRate: value(1.5), PayoffRatio: value(1.5), KellyFraction: value(0.5)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "volatilityのATR期間が0",
+			args: args{
+				sizing: gen.BacktestSizing{Type: gen.Volatility, RiskPercent: value(1), AtrPeriod: period(0), AtrMultiple: value(2)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if err := ValidateBacktestSizing(tt.args.sizing); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBacktestSizing()=%v wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
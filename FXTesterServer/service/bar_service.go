@@ -1,15 +1,20 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fxtester/internal/algo"
+	"fxtester/internal/audit"
 	"fxtester/internal/common"
 	"fxtester/internal/db"
 	"fxtester/internal/gen"
 	"fxtester/internal/lang"
+	"fxtester/internal/net"
+	"fxtester/internal/oidc"
 	"fxtester/internal/reader"
 	"fxtester/internal/saml"
+	"fxtester/internal/symbol"
 	"fxtester/internal/validator"
 	"fxtester/internal/websock"
 	"mime/multipart"
@@ -22,7 +27,12 @@ import (
 
 type BarService struct {
 	samlClient saml.ISamlClient
+	oidcClient oidc.IOidcClient
 	idb        db.IDB
+	symbols    *symbol.Registry
+	keyStore   *net.KeyStore
+	// 認証・データ操作の監査イベントの記録先
+	auditor *audit.Recorder
 
 	websockClient *websock.WebsockClient
 }
@@ -30,31 +40,81 @@ type BarService struct {
 func NewBarService() *BarService {
 	db := &db.DB{}
 	samlClient := saml.NewSamlClient(&saml.Delegator{}, db)
+	oidcClient := oidc.NewOidcClient(&oidc.Delegator{}, db)
 	websockClient := websock.NewWebsockClient()
 
 	return &BarService{
 		samlClient:    samlClient,
+		oidcClient:    oidcClient,
 		idb:           db,
+		symbols:       symbol.NewRegistry(db),
+		keyStore:      net.NewKeyStore(db),
+		auditor:       audit.NewRecorder(db),
 		websockClient: websockClient,
 	}
 }
 
 func (b *BarService) Init() error {
-	// SAMLクライアントの初期化
-	if err := b.samlClient.Init(); err != nil {
-		return err
+	// ログインに使用するidPのクライアントの初期化 (SAMLまたはOpenID Connect)
+	if common.GetConfig().IsAuthProvider(common.AuthProviderOidc) {
+		if err := b.oidcClient.Init(); err != nil {
+			return err
+		}
+	} else {
+		if err := b.samlClient.Init(); err != nil {
+			return err
+		}
 	}
 	// DBハンドルプロバイダーの初期化
 	if err := b.idb.Init(); err != nil {
 		return err
 	}
+	// シンボルのメタデータの初期化 (DBの初期化後に行う)
+	if err := b.symbols.Init(); err != nil {
+		return err
+	}
+	// JWTの署名鍵の読み込み (DBの初期化後に行う)
+	if err := b.keyStore.Init(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// StartKeyRotation JWTの署名鍵の自動ローテーションと再読み込みを開始する
+func (b *BarService) StartKeyRotation(ctx context.Context, logger echo.Logger) {
+	b.keyStore.Start(ctx, logger)
+}
+
+// StartAuditPrune 保持期間を過ぎた監査イベントの定期的な削除を開始する
+func (b *BarService) StartAuditPrune(ctx context.Context, logger echo.Logger) {
+	b.auditor.Start(ctx, logger)
+}
+
+// StartIdpMetadataRefresh SAMLのidPのメタデータの定期的な再取得を開始する
+func (b *BarService) StartIdpMetadataRefresh(ctx context.Context, logger echo.Logger) {
+	if common.GetConfig().IsAuthProvider(common.AuthProviderSaml) {
+		b.samlClient.StartMetadataRefresh(ctx, logger)
+	}
+}
+
+// AuthMiddleware OpenAPI定義に従ってaccess_tokenのCookieを検証する認証ミドルウェアを返却する
+func (b *BarService) AuthMiddleware() (echo.MiddlewareFunc, error) {
+	spec, err := gen.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	return net.NewAuthMiddleware(spec, func() db.IUserEntityDao {
+		return db.NewUserEntityDao(b.idb)
+	}, b.auditor), nil
+}
+
 // GetSamlLogin ユーザをシングルサインオンさせるログインリクエストを作成し、FormのPOSTによってidPに送信するスクリプトタグを含んだHTMLを返却するエンドポイント。
 //
 // (GET /saml/login)
 func (b *BarService) GetSamlLogin(ctx echo.Context, params gen.GetSamlLoginParams) error {
+	if err := requireAuthProvider(common.AuthProviderSaml); err != nil {
+		return err
+	}
 	return b.samlClient.ExecuteSamlLogin(ctx, params)
 }
 
@@ -62,6 +122,9 @@ func (b *BarService) GetSamlLogin(ctx echo.Context, params gen.GetSamlLoginParam
 //
 // (POST /saml/acs)
 func (b *BarService) PostSamlAcs(ctx echo.Context) error {
+	if err := requireAuthProvider(common.AuthProviderSaml); err != nil {
+		return err
+	}
 	return b.samlClient.ExecuteSamlAcs(ctx)
 }
 
@@ -72,20 +135,85 @@ func (b *BarService) GetSamlError(ctx echo.Context) error {
 	return b.samlClient.ExecuteSamlError(ctx)
 }
 
+// GetSamlMetadata SPのメタデータ (EntityDescriptor) を返却するエンドポイント。
+//
+// (GET /saml/metadata)
+func (b *BarService) GetSamlMetadata(ctx echo.Context) error {
+	if err := requireAuthProvider(common.AuthProviderSaml); err != nil {
+		return err
+	}
+	return b.samlClient.ExecuteSamlMetadata(ctx)
+}
+
 // GetSamlLogout ユーザをログアウトさせるログアウトリクエストを作成し、FormのPOSTによってidPに送信するスクリプトタグを含んだHTMLを返却するエンドポイント。
 //
 // (GET /saml/logout)
 func (b *BarService) GetSamlLogout(ctx echo.Context, params gen.GetSamlLogoutParams) error {
+	if err := requireAuthProvider(common.AuthProviderSaml); err != nil {
+		return err
+	}
 	return b.samlClient.ExecuteSamlLogout(ctx, params)
 }
 
+// GetSamlSlo IdPからHTTP-Redirectバインディングで受け取るログアウトリクエスト・レスポンスを処理するエンドポイント。
+//
+// (GET /saml/slo)
+func (b *BarService) GetSamlSlo(ctx echo.Context, params gen.GetSamlSloParams) error {
+	if err := requireAuthProvider(common.AuthProviderSaml); err != nil {
+		return err
+	}
+	// クエリ文字列の署名の検証にはエンコードされたままの値が必要なため、paramsではなくリクエストから取り出す
+	return b.samlClient.ExecuteSamlSlo(ctx)
+}
+
 // PostSamlSlo IdPから受け取るログアウトリクエストを処理し、ユーザーをログアウトさせるエンドポイント。
 //
 // (POST /saml/slo)
 func (b *BarService) PostSamlSlo(ctx echo.Context) error {
+	if err := requireAuthProvider(common.AuthProviderSaml); err != nil {
+		return err
+	}
 	return b.samlClient.ExecuteSamlSlo(ctx)
 }
 
+// GetOidcLogin OpenID Providerの認可エンドポイントへリダイレクトし、認可コードフロー(PKCE)によるログインを開始するエンドポイント。
+//
+// (GET /oidc/login)
+func (b *BarService) GetOidcLogin(ctx echo.Context, params gen.GetOidcLoginParams) error {
+	if err := requireAuthProvider(common.AuthProviderOidc); err != nil {
+		return err
+	}
+	return b.oidcClient.ExecuteOidcLogin(ctx, params)
+}
+
+// GetOidcCallback OpenID Providerから受け取る認可コードをIDトークンと交換し、ユーザをログインさせるエンドポイント。
+//
+// (GET /oidc/callback)
+func (b *BarService) GetOidcCallback(ctx echo.Context, params gen.GetOidcCallbackParams) error {
+	if err := requireAuthProvider(common.AuthProviderOidc); err != nil {
+		return err
+	}
+	return b.oidcClient.ExecuteOidcCallback(ctx, params)
+}
+
+// GetOidcLogout ユーザをログアウトさせ、OpenID Providerのログアウトエンドポイントへリダイレクトするエンドポイント。
+//
+// (GET /oidc/logout)
+func (b *BarService) GetOidcLogout(ctx echo.Context, params gen.GetOidcLogoutParams) error {
+	if err := requireAuthProvider(common.AuthProviderOidc); err != nil {
+		return err
+	}
+	return b.oidcClient.ExecuteOidcLogout(ctx, params)
+}
+
+// requireAuthProvider ログインに使用するidPのプロトコル (auth.provider) が指定したものでない場合はエラーを返却する
+func requireAuthProvider(provider string) error {
+	if !common.GetConfig().IsAuthProvider(provider) {
+		return lang.NewFxtError(lang.ErrOperationNotAllow)
+	}
+	return nil
+}
+
 // GetWsUuid Websocketと接続します。
 //
 // (GET /ws/:uuid)
@@ -93,109 +221,201 @@ func (b *BarService) GetWsUuid(ctx echo.Context) error {
 	return b.websockClient.CommunicateViaWs(ctx)
 }
 
+// GetSymbols 登録済みのシンボルのメタデータを返却します。
+//
+// (GET /symbols)
+func (b *BarService) GetSymbols(ctx echo.Context) error {
+	items := common.ArrayMap(func(s *symbol.Symbol) gen.SymbolInfo {
+		return gen.SymbolInfo{
+			Name:         s.Name,
+			Base:         s.Base,
+			Quote:        s.Quote,
+			Digits:       s.Digits,
+			PipSize:      s.PipSize,
+			ContractSize: s.ContractSize,
+			Sessions: common.ArrayMap(func(v symbol.Session) gen.SymbolSession {
+				return gen.SymbolSession{Name: v.Name, Open: v.Open, Close: v.Close}
+			}, s.Sessions),
+		}
+	}, b.symbols.List())
+
+	return ctx.JSON(http.StatusOK, gen.GetSymbolsResult{
+		Count: len(items),
+		Items: items,
+	})
+}
+
 // PostZigzag CSVまたはローソク足のデータをアップロードし、Zigzagのデータを作成します。
 //
 // (POST /zigzag)
-func (b *BarService) PostZigzag(ctx echo.Context) error {
+func (b *BarService) PostZigzag(ctx echo.Context) (lastError error) {
+	defer func() {
+		b.recordDataEvent(ctx, audit.EventDataAnalyze, lastError, uploadedFiles(ctx))
+	}()
+
+	items, err := b.calcZigzags(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, gen.PostZigzagResult{
+		Count: len(items),
+		Items: items,
+	})
+}
+
+// calcZigzags アップロードされたローソク足からジグザグを時刻順に算出する
+func (b *BarService) calcZigzags(ctx echo.Context) ([]gen.Zigzag, error) {
 	err := ctx.Request().ParseMultipartForm(1 * 1024 * 1024)
 	if err != nil {
 		if errors.Is(err, multipart.ErrMessageTooLarge) {
-			return lang.NewFxtError(lang.ErrTooLargeMessageError)
+			return nil, lang.NewFxtError(lang.ErrTooLargeMessageError)
 		} else {
-			return lang.NewFxtError(lang.ErrInvalidRequestProtocol).SetCause(err)
+			return nil, lang.NewFxtError(lang.ErrInvalidRequestProtocol).SetCause(err)
 		}
 	}
 
 	// リクエストパラメータのバリデーション
 	if err := validator.ValidatePostZigzag(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
 	form := ctx.Request().MultipartForm
+
+	// シンボルが指定された場合は値幅をpipsでも返却する
+	var sym *symbol.Symbol
+	if symbols := form.Value["symbol"]; 0 < len(symbols) {
+		var ok bool
+		if sym, ok = b.symbols.Get(symbols[0]); !ok {
+			return nil, lang.NewFxtError(lang.ErrInvalidParameterError, "symbol")
+		}
+	}
+
+	paramCandles, err := readCandles(form, net.GetUserLocation(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// ジグザグの計算
+	pbs := algo.FindZigzagPeakToBottom(paramCandles)
+	bps := algo.FindZigzagBottomToPeak(paramCandles)
+
+	// 時刻順に並び替える
+	zigzags := append(pbs, bps...)
+	sort.Slice(zigzags, func(i, j int) bool {
+		return zigzags[i].StartTime.Unix() < zigzags[j].StartTime.Unix()
+	})
+
+	items := []gen.Zigzag{}
+	for _, z := range zigzags {
+		item := gen.Zigzag{
+			BottomIndex: z.BottomIndex,
+			Delta:       float32(z.Delta),
+			Kind:        z.Kind,
+			PeakIndex:   z.PeakIndex,
+			StartTime:   z.StartTime.Format(time.RFC3339),
+			Velocity:    float32(z.Velocity),
+		}
+		if sym != nil {
+			deltaPips := float32(sym.ToPips(z.Delta))
+			velocityPips := float32(sym.ToPips(z.Velocity))
+			item.DeltaPips = &deltaPips
+			item.VelocityPips = &velocityPips
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// readCandles マルチパートフォームの入力パラメータ(type, csvInfo, csv, candles)から最初の入力データのローソク足を読み込む
+//
+// ※ バリデーション済みのフォームを指定すること
+func readCandles(form *multipart.Form, loc *time.Location) ([]common.Candle, error) {
+	series, err := readCandleSeries(form, loc)
+	if err != nil {
+		return nil, err
+	}
+	return series[0], nil
+}
+
+// readCandleSeries 入力データ('type')毎のローソク足を読み込む。csvInfo・csv・candlesは入力タイプ毎に出現順に対応させる。
+// CSVのタイムゾーンの指定が無い時間はlocのタイムゾーンとして解釈する
+//
+// ※ バリデーション済みのフォームを指定すること
+func readCandleSeries(form *multipart.Form, loc *time.Location) ([][]common.Candle, error) {
+	// multipartの動作上、空文字が指定されることがあるため除外する
+	notEmpty := func(v string) (string, bool) {
+		return v, v == ""
+	}
 	types := form.Value["type"]
-	csvInfos := form.Value["csvInfo"]
-	candless := form.Value["candles"]
+	csvInfos := common.ArrayMapSkip(notEmpty, form.Value["csvInfo"])
+	candless := common.ArrayMapSkip(notEmpty, form.Value["candles"])
 	csvs := form.File["csv"]
 
-	paramCandles, err := func() ([]common.Candle, error) {
-		t := types[0]
+	series := [][]common.Candle{}
+	numCsv, numCandles := 0, 0
+	for _, t := range types {
 		var res []common.Candle
 		switch t {
 		case string(gen.PostZigzagRequestTypeCsv):
-			v := csvInfos[0]
 			var csvInfo gen.CsvInfo
-			if err := json.Unmarshal([]byte(v), &csvInfo); err != nil {
+			if err := json.Unmarshal([]byte(csvInfos[numCsv]), &csvInfo); err != nil {
 				// バリデーション済みのため発生しない想定のエラー
 				panic("invalid csvInfo")
 			}
 
-			csvf, err := csvs[0].Open()
-			if err != nil {
-				return nil, lang.NewFxtError(lang.ErrInvalidParameterError, "csv")
-			}
-			defer csvf.Close()
+			var err error
+			res, err = func() ([]common.Candle, error) {
+				csvf, err := csvs[numCsv].Open()
+				if err != nil {
+					return nil, lang.NewFxtError(lang.ErrInvalidParameterError, "csv")
+				}
+				defer csvf.Close()
 
-			res, err = reader.ReadCandleCsv(csvInfo, csvf)
+				res, err := reader.ReadCandleCsv(csvInfo, loc, csvf)
+				if err != nil {
+					return nil, lang.NewFxtError(lang.ErrInvalidParameterError, "csv").SetCause(err)
+				}
+				return res, nil
+			}()
 			if err != nil {
-				return nil, lang.NewFxtError(lang.ErrInvalidParameterError, "csv").SetCause(err)
+				return nil, err
 			}
+			numCsv++
 
 		case string(gen.PostZigzagRequestTypeCandles):
 			candles := []gen.Candle{}
-			if err := json.Unmarshal([]byte(candless[0]), &candles); err != nil {
+			if err := json.Unmarshal([]byte(candless[numCandles]), &candles); err != nil {
 				// バリデーション済みのため発生しない想定のエラー
 				panic("invalid candles")
 			}
+			numCandles++
 
 			// gen.Candle -> common.Candle に変換
 			for _, c := range candles {
-				t, err := common.ToTime(c.Time)
+				t, err := common.ToTimeInLocation(c.Time, loc)
 				if err != nil {
 					// バリデーション済みのため発生しない想定のエラー
 					panic("invalid candles")
 				}
+				spread := 0.0
+				if c.Spread != nil {
+					spread = float64(*c.Spread)
+				}
 				res = append(res, common.Candle{
-					Time:  *t,
-					High:  float64(c.High),
-					Open:  float64(c.Open),
-					Close: float64(c.Close),
-					Low:   float64(c.Low),
+					Time:   *t,
+					High:   float64(c.High),
+					Open:   float64(c.Open),
+					Close:  float64(c.Close),
+					Low:    float64(c.Low),
+					Spread: spread,
 				})
 			}
 		default:
 			// バリデーション済みのため発生しない想定のエラー
 			panic("invalid type " + t)
 		}
-		return res, nil
-	}()
-	if err != nil {
-		return err
+		series = append(series, res)
 	}
-
-	// ジグザグの計算
-	pbs := algo.FindZigzagPeakToBottom(paramCandles)
-	bps := algo.FindZigzagBottomToPeak(paramCandles)
-
-	// 時刻順に並び替える
-	zigzags := append(pbs, bps...)
-	sort.Slice(zigzags, func(i, j int) bool {
-		return zigzags[i].StartTime.Unix() < zigzags[j].StartTime.Unix()
-	})
-
-	items := []gen.Zigzag{}
-	for _, z := range zigzags {
-		items = append(items, gen.Zigzag{
-			BottomIndex: z.BottomIndex,
-			Delta:       float32(z.Delta),
-			Kind:        z.Kind,
-			PeakIndex:   z.PeakIndex,
-			StartTime:   z.StartTime.Format(time.RFC3339),
-			Velocity:    float32(z.Velocity),
-		})
-	}
-
-	return ctx.JSON(http.StatusCreated, gen.PostZigzagResult{
-		Count: len(items),
-		Items: items,
-	})
+	return series, nil
 }
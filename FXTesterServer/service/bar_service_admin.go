@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fxtester/internal/db"
+	"fxtester/internal/gen"
+	"fxtester/internal/validator"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetAdminUsers 登録済みのユーザとロールの一覧を返却します (管理者のみ。ロールの検証は認証ミドルウェアで行う)。
+//
+// (GET /admin/users)
+func (b *BarService) GetAdminUsers(ctx echo.Context) error {
+	users, err := db.NewUserEntityDao(b.idb).SelectUsers()
+	if err != nil {
+		return err
+	}
+
+	items := make([]gen.AdminUser, len(users))
+	for i, u := range users {
+		items[i] = gen.AdminUser{
+			Id:      u.UserId,
+			Email:   u.Email,
+			Roles:   u.Roles,
+			Profile: toUserProfile(&u),
+		}
+		if u.DisabledAt != nil {
+			disabledAt := u.DisabledAt.Format(time.RFC3339)
+			items[i].DisabledAt = &disabledAt
+		}
+	}
+	return ctx.JSON(http.StatusOK, gen.GetAdminUsersResult{
+		Items: items,
+		Count: len(items),
+	})
+}
+
+// GetAdminAudit 監査イベントを条件で絞り込み、発生日時の新しい順に返却します (管理者のみ。ロールの検証は認証ミドルウェアで行う)。
+//
+// (GET /admin/audit)
+func (b *BarService) GetAdminAudit(ctx echo.Context, params gen.GetAdminAuditParams) error {
+	// リクエストパラメータのバリデーション
+	if err := validator.ValidateGetAdminAudit(params); err != nil {
+		return err
+	}
+
+	filter := &db.AuditEventFilter{
+		UserId: params.UserId,
+		Email:  params.Email,
+		Limit:  50,
+	}
+	if params.Type != nil {
+		eventType := string(*params.Type)
+		filter.EventType = &eventType
+	}
+	if params.Outcome != nil {
+		outcome := string(*params.Outcome)
+		filter.Outcome = &outcome
+	}
+	// バリデーション済みのため解析のエラーは発生しない
+	if params.From != nil {
+		from, _ := time.Parse(time.RFC3339, *params.From)
+		filter.From = &from
+	}
+	if params.To != nil {
+		to, _ := time.Parse(time.RFC3339, *params.To)
+		filter.To = &to
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+	if params.Offset != nil {
+		filter.Offset = *params.Offset
+	}
+
+	dao := db.NewAuditEventEntityDao(b.idb)
+	events, err := dao.SelectAuditEvents(filter)
+	if err != nil {
+		return err
+	}
+	total, err := dao.CountAuditEvents(filter)
+	if err != nil {
+		return err
+	}
+
+	items := make([]gen.AuditEvent, len(events))
+	for i, e := range events {
+		items[i] = gen.AuditEvent{
+			Id:         e.EventId,
+			OccurredAt: e.OccurredAt.Format(time.RFC3339),
+			Type:       gen.AuditEventType(e.EventType),
+			Outcome:    gen.AuditEventOutcome(e.Outcome),
+			UserId:     e.UserId,
+			Email:      e.Email,
+			IpAddress:  e.IpAddress,
+			UserAgent:  e.UserAgent,
+			Route:      e.Route,
+			Detail:     e.Detail,
+		}
+		if e.ErrorCode != nil {
+			errorCode := uint32(*e.ErrorCode)
+			items[i].ErrorCode = &errorCode
+		}
+	}
+	return ctx.JSON(http.StatusOK, gen.GetAdminAuditResult{
+		Items: items,
+		Count: len(items),
+		Total: total,
+	})
+}
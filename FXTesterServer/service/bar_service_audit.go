@@ -0,0 +1,42 @@
+package service
+
+import (
+	"fmt"
+	"fxtester/internal/audit"
+	"fxtester/internal/net"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// recordDataEvent データのアップロード・解析・エクスポート・削除の監査イベントを記録する (ログインしていない場合はユーザを記録しない)
+func (b *BarService) recordDataEvent(ctx echo.Context, eventType string, err error, detail string) {
+	event := audit.Event{
+		Type:   eventType,
+		Err:    err,
+		Detail: detail,
+	}
+	if session, err := net.GetAuthSession(ctx); err == nil {
+		event.UserId = session.UserId
+		event.Email = session.Email
+	}
+	b.auditor.Record(ctx, event)
+}
+
+// uploadedFiles 監査イベントの補足情報として、アップロードされたCSVのファイル名を返却する (マルチパートフォームを解析できなかった場合は空)
+func uploadedFiles(ctx echo.Context) string {
+	form := ctx.Request().MultipartForm
+	if form == nil || len(form.File["csv"]) <= 0 {
+		return ""
+	}
+	names := []string{}
+	for _, f := range form.File["csv"] {
+		names = append(names, f.Filename)
+	}
+	return "csv=" + strings.Join(names, ",")
+}
+
+// backtestAuditDetail 監査イベントの補足情報として、操作したバックテストのIDを返却する
+func backtestAuditDetail(id int64) string {
+	return fmt.Sprintf("backtest=%d", id)
+}
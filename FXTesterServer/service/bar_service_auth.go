@@ -0,0 +1,19 @@
+package service
+
+import (
+	"fxtester/internal/db"
+	"fxtester/internal/net"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PostAuthRefresh リフレッシュトークンを検証し、アクセストークンとリフレッシュトークンを再発行するエンドポイント。
+//
+// (POST /auth/refresh)
+func (b *BarService) PostAuthRefresh(ctx echo.Context) error {
+	if err := net.RefreshAuthSession(ctx, db.NewUserEntityDao(b.idb)); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
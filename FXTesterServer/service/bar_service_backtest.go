@@ -0,0 +1,421 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fxtester/internal/audit"
+	"fxtester/internal/backtest"
+	"fxtester/internal/common"
+	"fxtester/internal/gen"
+	"fxtester/internal/lang"
+	"fxtester/internal/net"
+	"fxtester/internal/reader"
+	"fxtester/internal/symbol"
+	"fxtester/internal/validator"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PostBacktest CSVまたはローソク足のデータと注文をアップロードし、バックテストを実行します。
+//
+// (POST /backtest)
+func (b *BarService) PostBacktest(ctx echo.Context) (lastError error) {
+	defer func() {
+		b.recordDataEvent(ctx, audit.EventDataAnalyze, lastError, uploadedFiles(ctx))
+	}()
+
+	err := ctx.Request().ParseMultipartForm(1 * 1024 * 1024)
+	if err != nil {
+		if errors.Is(err, multipart.ErrMessageTooLarge) {
+			return lang.NewFxtError(lang.ErrTooLargeMessageError)
+		} else {
+			return lang.NewFxtError(lang.ErrInvalidRequestProtocol).SetCause(err)
+		}
+	}
+
+	// リクエストパラメータのバリデーション
+	if err := validator.ValidatePostBacktest(ctx); err != nil {
+		return err
+	}
+
+	form := ctx.Request().MultipartForm
+	name := form.Value["symbol"][0]
+
+	// シンボルの設定を取得する
+	config, sym, err := b.newBacktestConfig(name, form)
+	if err != nil {
+		return err
+	}
+	config.Account = toBacktestAccount(form)
+
+	loc := net.GetUserLocation(ctx)
+	paramCandles, err := readCandles(form, loc)
+	if err != nil {
+		return err
+	}
+
+	orders := common.ArrayMap(func(o gen.BacktestOrder) backtest.Order {
+		return toBacktestOrder(o, loc)
+	}, readBacktestOrders(form))
+
+	// 時間足が指定された場合はアップロードされたローソク足を下位足として扱う
+	candles := paramCandles
+	if timeframes := form.Value["timeframe"]; 0 < len(timeframes) {
+		candles = reader.ResampleCandles(paramCandles, common.Timeframes[timeframes[0]])
+		config.LowerCandles = paramCandles
+	}
+
+	// バックテストの実行
+	report, err := backtest.Run(candles, orders, config)
+	if errors.Is(err, backtest.ErrOrderOutOfRange) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "orders").SetCause(err)
+	} else if err != nil {
+		return err
+	}
+
+	result := gen.PostBacktestResult{
+		Symbol:  name,
+		Summary: toGenBacktestSummary(report.Summary),
+		Trades: common.ArrayMap(func(t backtest.Trade) gen.BacktestTrade {
+			return toGenBacktestTrade(t, sym)
+		}, report.Trades),
+	}
+
+	// ログイン中の場合は実行結果を保存する
+	symbols := []string{name}
+	result.Id, err = b.saveBacktestRun(ctx, backtestRecord{
+		kind:       gen.Single,
+		parameters: toBacktestRunParameters(form, symbols, config.Account),
+		datasets:   toBacktestDatasets(form, symbols, [][]common.Candle{paramCandles}),
+		summary:    result.Summary,
+		symbols: []gen.BacktestSymbolResult{{
+			Symbol:  name,
+			Summary: result.Summary,
+			Trades:  result.Trades,
+		}},
+		trades: result.Trades,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, result)
+}
+
+// PostBacktestPortfolio 複数シンボルのローソク足と注文をアップロードし、口座を共有したバックテストを実行します。
+//
+// (POST /backtest/portfolio)
+func (b *BarService) PostBacktestPortfolio(ctx echo.Context) (lastError error) {
+	defer func() {
+		b.recordDataEvent(ctx, audit.EventDataAnalyze, lastError, uploadedFiles(ctx))
+	}()
+
+	err := ctx.Request().ParseMultipartForm(1 * 1024 * 1024)
+	if err != nil {
+		if errors.Is(err, multipart.ErrMessageTooLarge) {
+			return lang.NewFxtError(lang.ErrTooLargeMessageError)
+		} else {
+			return lang.NewFxtError(lang.ErrInvalidRequestProtocol).SetCause(err)
+		}
+	}
+
+	// リクエストパラメータのバリデーション
+	if err := validator.ValidatePostBacktestPortfolio(ctx); err != nil {
+		return err
+	}
+
+	form := ctx.Request().MultipartForm
+	symbols := form.Value["symbol"]
+	accountCurrency := form.Value["accountCurrency"][0]
+
+	loc := net.GetUserLocation(ctx)
+	series, err := readCandleSeries(form, loc)
+	if err != nil {
+		return err
+	}
+
+	orders := readBacktestOrders(form)
+
+	instruments := []backtest.PortfolioInstrument{}
+	syms := map[string]*symbol.Symbol{}
+	for i, name := range symbols {
+		config, sym, err := b.newBacktestConfig(name, form)
+		if err != nil {
+			return err
+		}
+		syms[name] = sym
+
+		instruments = append(instruments, backtest.PortfolioInstrument{
+			Base: sym.Base,
+			Instrument: backtest.Instrument{
+				Symbol:  name,
+				Quote:   sym.Quote,
+				Candles: series[i],
+				Orders: common.ArrayMapSkip(func(o gen.BacktestOrder) (backtest.Order, bool) {
+					return toBacktestOrder(o, loc), *o.Symbol != name
+				}, orders),
+				Config: config,
+			},
+		})
+	}
+
+	// バックテストの実行
+	account := toBacktestAccount(form)
+	report, err := backtest.RunPortfolio(instruments, backtest.PortfolioConfig{
+		AccountCurrency: accountCurrency,
+		Account:         account,
+	})
+	if errors.Is(err, backtest.ErrOrderOutOfRange) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "orders").SetCause(err)
+	} else if errors.Is(err, backtest.ErrRateUnavailable) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "accountCurrency").SetCause(err)
+	} else if err != nil {
+		return err
+	}
+
+	result := gen.PostBacktestPortfolioResult{
+		AccountCurrency: accountCurrency,
+		Summary:         toGenBacktestSummary(report.Summary),
+		Symbols: common.ArrayMap(func(s backtest.SymbolReport) gen.BacktestSymbolResult {
+			return gen.BacktestSymbolResult{
+				Symbol:  s.Symbol,
+				Summary: toGenBacktestSummary(s.Summary),
+				Trades: common.ArrayMap(func(t backtest.Trade) gen.BacktestTrade {
+					return toGenBacktestTrade(t, syms[t.Symbol])
+				}, s.Trades),
+			}
+		}, report.Symbols),
+		Trades: common.ArrayMap(func(t backtest.Trade) gen.BacktestTrade {
+			return toGenBacktestTrade(t, syms[t.Symbol])
+		}, report.Trades),
+		Correlation: gen.BacktestCorrelation{
+			Symbols: symbols,
+			Matrix:  report.Correlation,
+		},
+	}
+
+	// ログイン中の場合は実行結果を保存する
+	result.Id, err = b.saveBacktestRun(ctx, backtestRecord{
+		kind:       gen.Portfolio,
+		parameters: toBacktestRunParameters(form, symbols, account),
+		datasets:   toBacktestDatasets(form, symbols, series),
+		summary:    result.Summary,
+		symbols:    result.Symbols,
+		trades:     result.Trades,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, result)
+}
+
+// newBacktestConfig シンボルの設定とリクエストパラメータからシンボル毎のバックテストの設定を生成する (口座設定は含まない)
+func (b *BarService) newBacktestConfig(name string, form *multipart.Form) (backtest.Config, *symbol.Symbol, error) {
+	// シンボルのメタデータと取引コスト設定を取得する
+	sym, ok := b.symbols.Get(name)
+	if !ok {
+		return backtest.Config{}, nil, lang.NewFxtError(lang.ErrInvalidParameterError, "symbol")
+	}
+	symbolConfig, ok := common.GetConfig().Backtest.Symbols[name]
+	if !ok {
+		return backtest.Config{}, nil, lang.NewFxtError(lang.ErrInvalidParameterError, "symbol")
+	}
+	costModel, err := backtest.NewCostModel(symbolConfig)
+	if err != nil {
+		return backtest.Config{}, nil, err
+	}
+
+	config := backtest.Config{
+		ContractSize: sym.ContractSize,
+		Cost:         costModel,
+		IntrabarPath: backtest.PathPessimistic,
+		Lot: backtest.LotRule{
+			Step: symbolConfig.LotStep,
+			Min:  symbolConfig.MinLot,
+			Max:  symbolConfig.MaxLot,
+		},
+	}
+
+	// 下位足が無い場合の値動きの想定
+	if paths := form.Value["intrabarPath"]; 0 < len(paths) {
+		switch gen.BacktestIntrabarPath(paths[0]) {
+		case gen.Ohlc:
+			config.IntrabarPath = backtest.PathOHLC
+		case gen.Olhc:
+			config.IntrabarPath = backtest.PathOLHC
+		}
+	}
+
+	// 資金管理モデル
+	if sizings := form.Value["sizing"]; 0 < len(sizings) && sizings[0] != "" {
+		var sizing gen.BacktestSizing
+		if err := json.Unmarshal([]byte(sizings[0]), &sizing); err != nil {
+			// バリデーション済みのため発生しない想定のエラー
+			panic("invalid sizing")
+		}
+		config.Sizing = toSizingModel(sizing)
+	}
+
+	return config, sym, nil
+}
+
+// readBacktestOrders 'orders'パラメータを読み込む (バリデーション済みのフォームを前提とする)
+func readBacktestOrders(form *multipart.Form) []gen.BacktestOrder {
+	orders := []gen.BacktestOrder{}
+	if err := json.Unmarshal([]byte(form.Value["orders"][0]), &orders); err != nil {
+		// バリデーション済みのため発生しない想定のエラー
+		panic("invalid orders")
+	}
+	return orders
+}
+
+// toBacktestOrder gen.BacktestOrder -> backtest.Order に変換する (タイムゾーンを含まない日時はlocの日時として扱う)
+func toBacktestOrder(o gen.BacktestOrder, loc *time.Location) backtest.Order {
+	t, err := common.ToTimeInLocation(o.Time, loc)
+	if err != nil {
+		// バリデーション済みのため発生しない想定のエラー
+		panic("invalid orders")
+	}
+	order := backtest.Order{
+		Time: *t,
+		Side: backtest.Buy,
+	}
+	if o.Side == gen.BacktestOrderSideSell {
+		order.Side = backtest.Sell
+	}
+	if o.Lots != nil {
+		order.Lots = *o.Lots
+	}
+	if o.StopLoss != nil {
+		order.StopLoss = *o.StopLoss
+	}
+	if o.TakeProfit != nil {
+		order.TakeProfit = *o.TakeProfit
+	}
+	return order
+}
+
+// toBacktestAccount サーバの設定値をリクエストの'account'パラメータで上書きした口座設定を返却する
+func toBacktestAccount(form *multipart.Form) backtest.Account {
+	defaults := common.GetConfig().Backtest.Account
+	account := backtest.Account{
+		InitialBalance:  defaults.InitialBalance,
+		Leverage:        defaults.Leverage,
+		MarginCallLevel: defaults.MarginCallLevel,
+		StopOutLevel:    defaults.StopOutLevel,
+	}
+
+	accounts := form.Value["account"]
+	if len(accounts) <= 0 {
+		return account
+	}
+
+	var param gen.BacktestAccount
+	if err := json.Unmarshal([]byte(accounts[0]), &param); err != nil {
+		// バリデーション済みのため発生しない想定のエラー
+		panic("invalid account")
+	}
+	if param.InitialBalance != nil {
+		account.InitialBalance = *param.InitialBalance
+	}
+	if param.Leverage != nil {
+		account.Leverage = *param.Leverage
+	}
+	if param.MarginCallLevel != nil {
+		account.MarginCallLevel = *param.MarginCallLevel
+	}
+	if param.StopOutLevel != nil {
+		account.StopOutLevel = *param.StopOutLevel
+	}
+	return account
+}
+
+// toSizingModel gen.BacktestSizing -> backtest.SizingModel に変換する (バリデーション済みのパラメータを前提とする)
+func toSizingModel(s gen.BacktestSizing) backtest.SizingModel {
+	value := func(v *float64) float64 {
+		if v == nil {
+			return 0
+		}
+		return *v
+	}
+
+	switch s.Type {
+	case gen.FixedFractional:
+		return &backtest.FixedFractional{RiskPercent: value(s.RiskPercent)}
+	case gen.FixedRatio:
+		return &backtest.FixedRatio{BaseLots: value(s.Lots), Delta: value(s.Delta)}
+	case gen.Kelly:
+		return &backtest.Kelly{WinRate: value(s.WinRate), PayoffRatio: value(s.PayoffRatio), Fraction: value(s.KellyFraction)}
+	case gen.Volatility:
+		return &backtest.VolatilityTarget{RiskPercent: value(s.RiskPercent), ATRPeriod: *s.AtrPeriod, ATRMultiple: value(s.AtrMultiple)}
+	default:
+		return &backtest.FixedLot{Value: value(s.Lots)}
+	}
+}
+
+func toGenBacktestSummary(s backtest.Summary) gen.BacktestSummary {
+	return gen.BacktestSummary{
+		TradeCount:         s.TradeCount,
+		GrossProfit:        s.GrossProfit,
+		SpreadCost:         s.SpreadCost,
+		SlippageCost:       s.SlippageCost,
+		Commission:         s.Commission,
+		Swap:               s.Swap,
+		NetProfit:          s.NetProfit,
+		InitialBalance:     s.InitialBalance,
+		FinalBalance:       s.FinalBalance,
+		MarginCallCount:    s.MarginCallCount,
+		StopOutCount:       s.StopOutCount,
+		RejectedOrderCount: s.RejectedOrderCount,
+	}
+}
+
+func toGenBacktestTrade(t backtest.Trade, sym *symbol.Symbol) gen.BacktestTrade {
+	side := gen.BacktestTradeSideBuy
+	if t.Side == backtest.Sell {
+		side = gen.BacktestTradeSideSell
+	}
+
+	reason := gen.EndOfData
+	switch t.ExitReason {
+	case backtest.ExitStopLoss:
+		reason = gen.StopLoss
+	case backtest.ExitTakeProfit:
+		reason = gen.TakeProfit
+	case backtest.ExitStopOut:
+		reason = gen.StopOut
+	}
+
+	var name *string
+	if t.Symbol != "" {
+		name = &t.Symbol
+	}
+
+	// 約定価格から決済価格までの損益(pips)
+	pips := sym.ToPips(t.ExitPrice - t.EntryPrice)
+	if t.Side == backtest.Sell {
+		pips = -pips
+	}
+
+	return gen.BacktestTrade{
+		Symbol:       name,
+		Pips:         &pips,
+		Side:         side,
+		Lots:         t.Lots,
+		EntryTime:    t.EntryTime.Format(time.RFC3339),
+		EntryPrice:   t.EntryPrice,
+		ExitTime:     t.ExitTime.Format(time.RFC3339),
+		ExitPrice:    t.ExitPrice,
+		ExitReason:   reason,
+		GrossProfit:  t.GrossProfit,
+		SpreadCost:   t.SpreadCost,
+		SlippageCost: t.SlippageCost,
+		Commission:   t.Commission,
+		Swap:         t.Swap,
+		NetProfit:    t.NetProfit,
+	}
+}
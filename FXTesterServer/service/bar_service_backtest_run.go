@@ -0,0 +1,587 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fxtester/internal/audit"
+	"fxtester/internal/backtest"
+	"fxtester/internal/common"
+	"fxtester/internal/db"
+	"fxtester/internal/gen"
+	"fxtester/internal/lang"
+	"fxtester/internal/net"
+	"fxtester/internal/validator"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// backtestRecord 保存するバックテストの実行結果
+type backtestRecord struct {
+	kind       gen.BacktestRunKind
+	parameters gen.BacktestRunParameters
+	datasets   []gen.BacktestDataset
+	summary    gen.BacktestSummary
+	symbols    []gen.BacktestSymbolResult
+	trades     []gen.BacktestTrade
+}
+
+// GetBacktests ログイン中のユーザが保存したバックテストの実行履歴を返却します。
+//
+// (GET /backtests)
+func (b *BarService) GetBacktests(ctx echo.Context) error {
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	dao := db.NewBacktestEntityDao(b.idb)
+	runs, err := dao.SelectRuns(session.UserId)
+	if err != nil {
+		return err
+	}
+	metrics, err := dao.SelectMetrics(common.ArrayMap(func(r db.BacktestRunEntity) int64 {
+		return r.RunId
+	}, runs))
+	if err != nil {
+		return err
+	}
+
+	items := []gen.BacktestRun{}
+	for _, run := range runs {
+		item, err := toGenBacktestRun(run, toGenBacktestSummaries(run.RunId, metrics)[""])
+		if err != nil {
+			return err
+		}
+		items = append(items, *item)
+	}
+
+	return ctx.JSON(http.StatusOK, gen.GetBacktestsResult{
+		Count: len(items),
+		Items: items,
+	})
+}
+
+// GetBacktestsId 保存したバックテストの実行結果を返却します。
+//
+// (GET /backtests/:id)
+func (b *BarService) GetBacktestsId(ctx echo.Context, id int64) error {
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	dao := db.NewBacktestEntityDao(b.idb)
+	run, err := dao.SelectRun(session.UserId, id)
+	if errors.Is(err, db.ErrNoData) {
+		return lang.NewFxtError(lang.ErrResourceNotFound, "words.backtest")
+	} else if err != nil {
+		return err
+	}
+	metrics, err := dao.SelectMetrics([]int64{id})
+	if err != nil {
+		return err
+	}
+	tradeEntities, err := dao.SelectTrades(id)
+	if err != nil {
+		return err
+	}
+
+	summaries := toGenBacktestSummaries(id, metrics)
+	genRun, err := toGenBacktestRun(*run, summaries[""])
+	if err != nil {
+		return err
+	}
+
+	trades := common.ArrayMap(fromBacktestTradeEntity, tradeEntities)
+	symbols := common.ArrayMap(func(symbol string) gen.BacktestSymbolResult {
+		return gen.BacktestSymbolResult{
+			Symbol:  symbol,
+			Summary: summaries[symbol],
+			Trades: common.ArrayMapSkip(func(t gen.BacktestTrade) (gen.BacktestTrade, bool) {
+				return t, *t.Symbol != symbol
+			}, trades),
+		}
+	}, genRun.Parameters.Symbols)
+
+	return ctx.JSON(http.StatusOK, gen.GetBacktestResult{
+		Run:     *genRun,
+		Symbols: symbols,
+		Trades:  trades,
+	})
+}
+
+// DeleteBacktestsId 保存したバックテストを削除します。管理者は他のユーザのバックテストも削除できます。
+//
+// (DELETE /backtests/:id)
+func (b *BarService) DeleteBacktestsId(ctx echo.Context, id int64) (lastError error) {
+	defer func() {
+		b.recordDataEvent(ctx, audit.EventDataDelete, lastError, backtestAuditDetail(id))
+	}()
+
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	dao := db.NewBacktestEntityDao(b.idb)
+	if session.HasRole(net.RoleAdmin) {
+		// 管理者は他のユーザのバックテストも削除できる
+		err = dao.DeleteAnyRun(id)
+	} else {
+		err = dao.DeleteRun(session.UserId, id)
+	}
+	if errors.Is(err, db.ErrNoData) {
+		return lang.NewFxtError(lang.ErrResourceNotFound, "words.backtest")
+	} else if err != nil {
+		return err
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// PostBacktestsCompare 保存した複数のバックテストの指標・資産曲線・取引の差分を並べて返却します。
+//
+// (POST /backtests/compare)
+func (b *BarService) PostBacktestsCompare(ctx echo.Context) (lastError error) {
+	defer func() {
+		b.recordDataEvent(ctx, audit.EventDataAnalyze, lastError, "")
+	}()
+
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	var req gen.PostBacktestsCompareRequest
+	if err := ctx.Bind(&req); err != nil {
+		return lang.NewFxtError(lang.ErrInvalidRequestProtocol).SetCause(err)
+	}
+
+	// リクエストパラメータのバリデーション
+	if err := validator.ValidatePostBacktestsCompare(req); err != nil {
+		return err
+	}
+
+	// 集計値・取引を読み込む前に、全てのバックテストをログインユーザが所有していることを確認する
+	dao := db.NewBacktestEntityDao(b.idb)
+	entities := []db.BacktestRunEntity{}
+	for _, id := range req.Ids {
+		run, err := dao.SelectRun(session.UserId, id)
+		if errors.Is(err, db.ErrNoData) {
+			return lang.NewFxtError(lang.ErrResourceNotFound, "words.backtest")
+		} else if err != nil {
+			return err
+		}
+		entities = append(entities, *run)
+	}
+
+	metrics, err := dao.SelectMetrics(req.Ids)
+	if err != nil {
+		return err
+	}
+
+	runs := []gen.BacktestRun{}
+	trades := [][]gen.BacktestTrade{}
+	for _, run := range entities {
+		genRun, err := toGenBacktestRun(run, toGenBacktestSummaries(run.RunId, metrics)[""])
+		if err != nil {
+			return err
+		}
+		tradeEntities, err := dao.SelectTrades(run.RunId)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, *genRun)
+		trades = append(trades, common.ArrayMap(fromBacktestTradeEntity, tradeEntities))
+	}
+
+	// 資産曲線を揃える時間軸
+	step := common.Timeframes[string(gen.PostBacktestsCompareRequestTimeframeD1)]
+	if req.Timeframe != nil {
+		step = common.Timeframes[string(*req.Timeframe)]
+	}
+	start, end, ok := backtestRunsPeriod(runs, trades)
+	times := []time.Time{}
+	if ok {
+		maxPoints := common.GetConfig().Backtest.Compare.MaxPoints
+		if 0 < maxPoints && maxPoints < int(end.Sub(start.UTC().Truncate(step))/step)+1 {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "timeframe")
+		}
+		times = backtest.TimeAxis(start, end, step)
+	}
+
+	// 資産曲線と取引の差分を算出する
+	btTrades := common.ArrayMap(func(ts []gen.BacktestTrade) []backtest.Trade {
+		return common.ArrayMap(toBacktestTrade, ts)
+	}, trades)
+	curves := [][]float64{}
+	for i, run := range runs {
+		curves = append(curves, backtest.EquityCurve(btTrades[i], run.Summary.InitialBalance, times))
+	}
+	diffs := common.ArrayMap(func(d backtest.TradeDiff) gen.BacktestTradeDiff {
+		return gen.BacktestTradeDiff{
+			RunId: req.Ids[d.Run],
+			Trade: trades[d.Run][d.Index],
+			MissingIn: common.ArrayMap(func(j int) int64 {
+				return req.Ids[j]
+			}, d.MissingIn),
+		}
+	}, backtest.DiffTrades(btTrades))
+
+	return ctx.JSON(http.StatusOK, gen.PostBacktestsCompareResult{
+		Runs:    runs,
+		Metrics: toBacktestMetricRows(runs),
+		Equity: gen.BacktestEquityCurves{
+			Times: common.ArrayMap(func(t time.Time) string {
+				return t.Format(time.RFC3339)
+			}, times),
+			Values: curves,
+		},
+		TradeDiffs: diffs,
+	})
+}
+
+// backtestRunsPeriod 全てのバックテストの入力データの期間を返却する。入力データの期間が無い場合は取引の期間を返却する
+func backtestRunsPeriod(runs []gen.BacktestRun, trades [][]gen.BacktestTrade) (time.Time, time.Time, bool) {
+	var start, end time.Time
+	found := false
+	extend := func(s, e string) {
+		st, err1 := time.Parse(time.RFC3339, s)
+		et, err2 := time.Parse(time.RFC3339, e)
+		if err1 != nil || err2 != nil {
+			return
+		}
+		if !found || st.Before(start) {
+			start = st
+		}
+		if !found || end.Before(et) {
+			end = et
+		}
+		found = true
+	}
+
+	for _, run := range runs {
+		for _, d := range run.Datasets {
+			if d.StartTime != nil && d.EndTime != nil {
+				extend(*d.StartTime, *d.EndTime)
+			}
+		}
+	}
+	if !found {
+		for _, ts := range trades {
+			for _, t := range ts {
+				extend(t.EntryTime, t.ExitTime)
+			}
+		}
+	}
+	return start, end, found
+}
+
+// toBacktestMetricRows バックテスト毎の集計結果を指標名順の表に変換する
+func toBacktestMetricRows(runs []gen.BacktestRun) []gen.BacktestMetricRow {
+	values := common.ArrayMap(func(run gen.BacktestRun) map[string]float64 {
+		res := map[string]float64{}
+		for _, m := range toBacktestMetricEntities("", run.Summary) {
+			res[m.Name] = m.Value
+		}
+		return res
+	}, runs)
+
+	names := []string{}
+	for _, v := range values {
+		for name := range v {
+			names = append(names, name)
+		}
+	}
+	names = common.Set(names)
+	sort.Strings(names)
+
+	return common.ArrayMap(func(name string) gen.BacktestMetricRow {
+		return gen.BacktestMetricRow{
+			Name: name,
+			Values: common.ArrayMap(func(v map[string]float64) float64 {
+				return v[name]
+			}, values),
+		}
+	}, names)
+}
+
+// toBacktestTrade gen.BacktestTrade -> backtest.Trade に変換する (比較に使用する項目のみ)
+func toBacktestTrade(t gen.BacktestTrade) backtest.Trade {
+	entryTime, _ := time.Parse(time.RFC3339, t.EntryTime)
+	exitTime, _ := time.Parse(time.RFC3339, t.ExitTime)
+	side := backtest.Buy
+	if t.Side == gen.BacktestTradeSideSell {
+		side = backtest.Sell
+	}
+	var symbol string
+	if t.Symbol != nil {
+		symbol = *t.Symbol
+	}
+	return backtest.Trade{
+		Symbol:     symbol,
+		Side:       side,
+		Lots:       t.Lots,
+		EntryTime:  entryTime,
+		EntryPrice: t.EntryPrice,
+		ExitTime:   exitTime,
+		ExitPrice:  t.ExitPrice,
+		NetProfit:  t.NetProfit,
+	}
+}
+
+// getLoginUser 認証ミドルウェアが設定したログイン中のユーザを取得する。ログインしていない場合は認証エラーを返却する
+func getLoginUser(ctx echo.Context) (*net.AuthSessionPayload, error) {
+	return net.GetAuthSession(ctx)
+}
+
+// saveBacktestRun ログイン中の場合はバックテストの実行結果を保存し、保存したIDを返却する。ログインしていない場合は保存せずにnilを返却する
+func (b *BarService) saveBacktestRun(ctx echo.Context, record backtestRecord) (*int64, error) {
+	session, err := net.GetAuthSession(ctx)
+	if err != nil {
+		return nil, nil
+	}
+
+	parameters, err := json.Marshal(record.parameters)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+	datasets, err := json.Marshal(record.datasets)
+	if err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQuery).SetCause(err)
+	}
+
+	// 集計値 (全シンボルの集計は空文字のシンボルとして保存する)
+	metrics := toBacktestMetricEntities("", record.summary)
+	for _, s := range record.symbols {
+		metrics = append(metrics, toBacktestMetricEntities(s.Symbol, s.Summary)...)
+	}
+
+	// 取引 (単一シンボルの場合もシンボルを保存する)
+	trades := []db.BacktestTradeEntity{}
+	for i, t := range record.trades {
+		if t.Symbol == nil {
+			t.Symbol = &record.parameters.Symbols[0]
+		}
+		trades = append(trades, toBacktestTradeEntity(i, t))
+	}
+
+	var runId int64
+	dao := db.NewBacktestEntityDao(b.idb)
+	err = func() (lastError error) {
+		// トランザクション開始
+		if err := dao.Begin(); err != nil {
+			return err
+		}
+
+		defer func() {
+			// エラーの有無に応じてRollbackまたはCommitを実行する
+			if lastError != nil {
+				err := dao.Rollback()
+				if err != nil {
+					// ロールバック失敗時は本来のエラーを書き換えないようにlastErrorはそのままにする
+					ctx.Logger().Errorf("failed Rollback: %v", err)
+				}
+			} else {
+				err := dao.Commit()
+				if err != nil {
+					lastError = err
+				}
+			}
+		}()
+
+		runId, err = dao.CreateRun(&db.BacktestRunEntity{
+			UserId:     session.UserId,
+			Kind:       string(record.kind),
+			Parameters: parameters,
+			Datasets:   datasets,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dao.InsertTrades(runId, trades); err != nil {
+			return err
+		}
+		return dao.InsertMetrics(runId, metrics)
+	}()
+	if err != nil {
+		return nil, err
+	}
+	return &runId, nil
+}
+
+// toBacktestRunParameters 保存する実行パラメータを生成する (バリデーション済みのフォームを前提とする)
+func toBacktestRunParameters(form *multipart.Form, symbols []string, account backtest.Account) gen.BacktestRunParameters {
+	params := gen.BacktestRunParameters{
+		Symbols:      symbols,
+		IntrabarPath: gen.Pessimistic,
+		Account: gen.BacktestAccount{
+			InitialBalance:  &account.InitialBalance,
+			Leverage:        &account.Leverage,
+			MarginCallLevel: &account.MarginCallLevel,
+			StopOutLevel:    &account.StopOutLevel,
+		},
+		Orders: readBacktestOrders(form),
+	}
+	if v := form.Value["accountCurrency"]; 0 < len(v) {
+		params.AccountCurrency = &v[0]
+	}
+	if v := form.Value["timeframe"]; 0 < len(v) {
+		params.Timeframe = &v[0]
+	}
+	if v := form.Value["intrabarPath"]; 0 < len(v) {
+		params.IntrabarPath = gen.BacktestIntrabarPath(v[0])
+	}
+	if v := form.Value["sizing"]; 0 < len(v) && v[0] != "" {
+		var sizing gen.BacktestSizing
+		if err := json.Unmarshal([]byte(v[0]), &sizing); err != nil {
+			// バリデーション済みのため発生しない想定のエラー
+			panic("invalid sizing")
+		}
+		params.Sizing = &sizing
+	}
+	return params
+}
+
+// toBacktestDatasets 入力データ('type')毎のローソク足から保存する入力データの情報を生成する (バリデーション済みのフォームを前提とする)
+func toBacktestDatasets(form *multipart.Form, symbols []string, series [][]common.Candle) []gen.BacktestDataset {
+	datasets := []gen.BacktestDataset{}
+	numCsv := 0
+	for i, t := range form.Value["type"] {
+		dataset := gen.BacktestDataset{
+			Symbol:      symbols[i],
+			Type:        gen.BacktestDatasetType(t),
+			CandleCount: len(series[i]),
+		}
+		if t == string(gen.BacktestDatasetTypeCsv) {
+			if csvs := form.File["csv"]; numCsv < len(csvs) {
+				dataset.FileName = &csvs[numCsv].Filename
+			}
+			numCsv++
+		}
+		if candles := series[i]; 0 < len(candles) {
+			start := candles[0].Time.Format(time.RFC3339)
+			end := candles[len(candles)-1].Time.Format(time.RFC3339)
+			dataset.StartTime = &start
+			dataset.EndTime = &end
+		}
+		datasets = append(datasets, dataset)
+	}
+	return datasets
+}
+
+// toBacktestMetricEntities 集計結果を名前(JSONのプロパティ名)と値の組に変換する
+func toBacktestMetricEntities(symbol string, summary gen.BacktestSummary) []db.BacktestMetricEntity {
+	values := map[string]float64{}
+	bytes, err := json.Marshal(summary)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(bytes, &values); err != nil {
+		panic(err)
+	}
+
+	metrics := []db.BacktestMetricEntity{}
+	for name, value := range values {
+		metrics = append(metrics, db.BacktestMetricEntity{
+			Symbol: symbol,
+			Name:   name,
+			Value:  value,
+		})
+	}
+	return metrics
+}
+
+// toGenBacktestSummaries 指定したバックテストの集計値をシンボル毎の集計結果に変換する (キーが空文字の場合は全シンボルの集計)
+func toGenBacktestSummaries(runId int64, metrics []db.BacktestMetricEntity) map[string]gen.BacktestSummary {
+	values := map[string]map[string]float64{}
+	for _, m := range metrics {
+		if m.RunId != runId {
+			continue
+		}
+		if _, ok := values[m.Symbol]; !ok {
+			values[m.Symbol] = map[string]float64{}
+		}
+		values[m.Symbol][m.Name] = m.Value
+	}
+
+	summaries := map[string]gen.BacktestSummary{}
+	for symbol, v := range values {
+		var summary gen.BacktestSummary
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			panic(err)
+		}
+		if err := json.Unmarshal(bytes, &summary); err != nil {
+			panic(err)
+		}
+		summaries[symbol] = summary
+	}
+	return summaries
+}
+
+// toGenBacktestRun db.BacktestRunEntity -> gen.BacktestRun に変換する
+func toGenBacktestRun(run db.BacktestRunEntity, summary gen.BacktestSummary) (*gen.BacktestRun, error) {
+	res := gen.BacktestRun{
+		Id:        run.RunId,
+		Kind:      gen.BacktestRunKind(run.Kind),
+		CreatedAt: run.CreatedAt.Format(time.RFC3339),
+		Summary:   summary,
+	}
+	if err := json.Unmarshal(run.Parameters, &res.Parameters); err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	if err := json.Unmarshal(run.Datasets, &res.Datasets); err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	return &res, nil
+}
+
+// toBacktestTradeEntity gen.BacktestTrade -> db.BacktestTradeEntity に変換する
+func toBacktestTradeEntity(seq int, t gen.BacktestTrade) db.BacktestTradeEntity {
+	entryTime, _ := time.Parse(time.RFC3339, t.EntryTime)
+	exitTime, _ := time.Parse(time.RFC3339, t.ExitTime)
+	return db.BacktestTradeEntity{
+		Seq:          seq,
+		Symbol:       *t.Symbol,
+		Side:         string(t.Side),
+		Lots:         t.Lots,
+		EntryTime:    entryTime,
+		EntryPrice:   t.EntryPrice,
+		ExitTime:     exitTime,
+		ExitPrice:    t.ExitPrice,
+		ExitReason:   string(t.ExitReason),
+		GrossProfit:  t.GrossProfit,
+		SpreadCost:   t.SpreadCost,
+		SlippageCost: t.SlippageCost,
+		Commission:   t.Commission,
+		Swap:         t.Swap,
+		NetProfit:    t.NetProfit,
+		Pips:         t.Pips,
+	}
+}
+
+// fromBacktestTradeEntity db.BacktestTradeEntity -> gen.BacktestTrade に変換する
+func fromBacktestTradeEntity(t db.BacktestTradeEntity) gen.BacktestTrade {
+	return gen.BacktestTrade{
+		Symbol:       &t.Symbol,
+		Pips:         t.Pips,
+		Side:         gen.BacktestTradeSide(t.Side),
+		Lots:         t.Lots,
+		EntryTime:    t.EntryTime.Format(time.RFC3339),
+		EntryPrice:   t.EntryPrice,
+		ExitTime:     t.ExitTime.Format(time.RFC3339),
+		ExitPrice:    t.ExitPrice,
+		ExitReason:   gen.BacktestTradeExitReason(t.ExitReason),
+		GrossProfit:  t.GrossProfit,
+		SpreadCost:   t.SpreadCost,
+		SlippageCost: t.SlippageCost,
+		Commission:   t.Commission,
+		Swap:         t.Swap,
+		NetProfit:    t.NetProfit,
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"database/sql"
+	"fxtester/internal/lang"
+	"fxtester/internal/net"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/labstack/echo/v4"
+)
+
+type MockDB struct {
+	db *sql.DB
+}
+
+func (m *MockDB) Init() error {
+	return nil
+}
+
+func (m *MockDB) GetDB() *sql.DB {
+	return m.db
+}
+
+// 他のユーザのバックテストを含む比較は、集計値・取引を読み込む前に拒否すること
+func Test_PostBacktestsCompare_Ownership(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed sqlmock.New(): %v", err)
+	}
+	defer mockDB.Close()
+
+	columns := []string{"id", "user_id", "kind", "parameters", "datasets", "created_at"}
+	mock.ExpectQuery(regexp.QuoteMeta("from fxtester_schema.select_backtest_run($1, $2)")).WithArgs(int64(100), int64(1)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 100, "single", "{}", "[]", time.Now()))
+	// ID=2は他のユーザのバックテストのため取得できない
+	mock.ExpectQuery(regexp.QuoteMeta("from fxtester_schema.select_backtest_run($1, $2)")).WithArgs(int64(100), int64(2)).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	req := httptest.NewRequest(echo.POST, "/backtests/compare", strings.NewReader(`{"ids":[1,2]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	ctx := echo.New().NewContext(req, httptest.NewRecorder())
+	ctx.Set(net.ContextKeyAuthSession, &net.AuthSessionPayload{UserId: 100})
+
+	b := &BarService{idb: &MockDB{db: mockDB}}
+	err = b.PostBacktestsCompare(ctx)
+	if fxtErr := lang.FindFxtError(err); fxtErr == nil || fxtErr.ErrCode != lang.ErrResourceNotFound {
+		t.Errorf("PostBacktestsCompare()=%v want=%v", err, lang.ErrResourceNotFound)
+	}
+
+	// 集計値(select_backtest_metrics)は読み込まない
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("ExpectationsWereMet()=%v", err)
+	}
+}
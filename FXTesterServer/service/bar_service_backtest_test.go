@@ -0,0 +1,109 @@
+package service
+
+import (
+	"encoding/json"
+	"fxtester/internal/backtest"
+	"fxtester/internal/gen"
+	"fxtester/internal/net"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// サーバと異なるタイムゾーンを設定したユーザのバックテストで、タイムゾーン指定の無い日時をユーザのタイムゾーンとして約定させること
+func Test_BacktestOrder_UserLocation(t *testing.T) {
+	// サーバのタイムゾーンはUTCとする
+	local := time.Local
+	time.Local = time.UTC
+	defer func() { time.Local = local }()
+
+	// 2024-01-02T00:00:00Zから1時間毎のローソク足 (始値はUTCの時)
+	testCandles := func(format func(t time.Time) string) string {
+		candles := []gen.Candle{}
+		base := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 12; i++ {
+			v := float32(100 + i)
+			candles = append(candles, gen.Candle{
+				Time: format(base.Add(time.Duration(i) * time.Hour)),
+				Open: v, High: v + 0.5, Low: v - 0.5, Close: v,
+			})
+		}
+		data, _ := json.Marshal(candles)
+		return string(data)
+	}
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	timezone, lots := "Asia/Tokyo", 1.0
+
+	tests := []struct {
+		name          string
+		timezone      *string
+		candles       string
+		orderTime     string
+		wantEntryTime time.Time
+		wantOpen      float64
+	}{
+		{
+			name:          "test1_タイムゾーン指定の無い発注日時はユーザのタイムゾーンで約定する",
+			timezone:      &timezone,
+			candles:       testCandles(func(t time.Time) string { return t.Format(time.RFC3339) }),
+			orderTime:     "2024-01-02T10:00:00",
+			wantEntryTime: time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+			wantOpen:      101,
+		},
+		{
+			name:     "test2_タイムゾーン指定の無いローソク足もユーザのタイムゾーンで解釈する",
+			timezone: &timezone,
+			candles: testCandles(func(t time.Time) string {
+				return t.In(tokyo).Format("2006-01-02T15:04:05")
+			}),
+			orderTime:     "2024-01-02T11:00:00",
+			wantEntryTime: time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC),
+			wantOpen:      102,
+		},
+		{
+			name:          "test3_タイムゾーン未設定の場合はサーバのタイムゾーンで約定する",
+			candles:       testCandles(func(t time.Time) string { return t.Format(time.RFC3339) }),
+			orderTime:     "2024-01-02T10:00:00",
+			wantEntryTime: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+			wantOpen:      110,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := echo.New().NewContext(httptest.NewRequest("POST", "/backtest", nil), httptest.NewRecorder())
+			ctx.Set(net.ContextKeyUserPreferences, &gen.UserPreferences{Timezone: tt.timezone})
+			loc := net.GetUserLocation(ctx)
+
+			form := &multipart.Form{Value: map[string][]string{
+				"type":    {string(gen.PostZigzagRequestTypeCandles)},
+				"candles": {tt.candles},
+			}}
+			candles, err := readCandles(form, loc)
+			if err != nil {
+				t.Fatalf("readCandles()=%v", err)
+			}
+			order := toBacktestOrder(gen.BacktestOrder{Time: tt.orderTime, Side: gen.BacktestOrderSideBuy, Lots: &lots}, loc)
+
+			report, err := backtest.Run(candles, []backtest.Order{order}, backtest.Config{
+				ContractSize: 1,
+				Cost: &backtest.CostModel{
+					Spread:   &backtest.FixedSpread{},
+					Slippage: &backtest.NoSlippage{},
+				},
+			})
+			if err != nil {
+				t.Fatalf("backtest.Run()=%v", err)
+			}
+			if len(report.Trades) != 1 {
+				t.Fatalf("len(backtest.Run().Trades)=%v want=1", len(report.Trades))
+			}
+			if got := report.Trades[0]; !got.EntryTime.Equal(tt.wantEntryTime) || got.EntryPrice != tt.wantOpen {
+				t.Errorf("backtest.Run().Trades[0]=(%v, %v) want=(%v, %v)", got.EntryTime, got.EntryPrice, tt.wantEntryTime, tt.wantOpen)
+			}
+		})
+	}
+}
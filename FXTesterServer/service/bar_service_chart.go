@@ -0,0 +1,148 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fxtester/internal/algo"
+	"fxtester/internal/audit"
+	"fxtester/internal/chart"
+	"fxtester/internal/common"
+	"fxtester/internal/gen"
+	"fxtester/internal/lang"
+	"fxtester/internal/net"
+	"fxtester/internal/validator"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PostCharts CSVまたはローソク足のデータをアップロードし、ジグザグ・インジケーターを重ねたチャートをSVGまたはPNGで描画します。
+//
+// (POST /charts)
+func (b *BarService) PostCharts(ctx echo.Context) (lastError error) {
+	defer func() {
+		b.recordDataEvent(ctx, audit.EventDataAnalyze, lastError, uploadedFiles(ctx))
+	}()
+
+	err := ctx.Request().ParseMultipartForm(1 * 1024 * 1024)
+	if err != nil {
+		if errors.Is(err, multipart.ErrMessageTooLarge) {
+			return lang.NewFxtError(lang.ErrTooLargeMessageError)
+		} else {
+			return lang.NewFxtError(lang.ErrInvalidRequestProtocol).SetCause(err)
+		}
+	}
+
+	// リクエストパラメータのバリデーション
+	if err := validator.ValidatePostCharts(ctx); err != nil {
+		return err
+	}
+
+	form := ctx.Request().MultipartForm
+
+	loc := net.GetUserLocation(ctx)
+	candles, err := readCandles(form, loc)
+	if err != nil {
+		return err
+	}
+
+	// 描画する期間のローソク足に絞り込む
+	from, to := formTime(form, "from", loc), formTime(form, "to", loc)
+	candles = common.ArrayMapSkip(func(c common.Candle) (common.Candle, bool) {
+		return c, (from != nil && c.Time.Before(*from)) || (to != nil && c.Time.After(*to))
+	}, candles)
+	if len(candles) <= 0 {
+		if from != nil {
+			return lang.NewFxtError(lang.ErrInvalidParameterError, "from")
+		}
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "to")
+	}
+
+	config := common.GetConfig().Chart
+	c := &chart.Chart{
+		Candles: candles,
+		Width:   formInt(form, "width", config.DefaultWidth),
+		Height:  formInt(form, "height", config.DefaultHeight),
+	}
+
+	// ジグザグは絞り込んだローソク足から算出する (既定で描画する)
+	enabled := true
+	if zigzags := form.Value["zigzag"]; 0 < len(zigzags) {
+		enabled, _ = strconv.ParseBool(zigzags[0])
+	}
+	if enabled {
+		c.Zigzags = append(algo.FindZigzagPeakToBottom(candles), algo.FindZigzagBottomToPeak(candles)...)
+	}
+
+	if liness := form.Value["lines"]; 0 < len(liness) {
+		var lines []gen.ChartLine
+		if err := json.Unmarshal([]byte(liness[0]), &lines); err != nil {
+			// バリデーション済みのため発生しない想定のエラー
+			panic("invalid lines")
+		}
+		c.Lines = common.ArrayMap(func(line gen.ChartLine) chart.Line {
+			return toChartLine(line, loc)
+		}, lines)
+	}
+
+	format := chart.FormatSVG
+	if formats := form.Value["format"]; 0 < len(formats) {
+		format = chart.Format(formats[0])
+	}
+
+	var buf bytes.Buffer
+	if err := c.Render(format, &buf); err != nil {
+		return err
+	}
+	return ctx.Blob(http.StatusOK, format.ContentType(), buf.Bytes())
+}
+
+// toChartLine 重ねて描画する線をチャートの線に変換する (タイムゾーンを含まない日時はlocの日時として扱う)
+func toChartLine(line gen.ChartLine, loc *time.Location) chart.Line {
+	l := chart.Line{Name: line.Name}
+	if line.Color != nil {
+		l.Color = *line.Color
+	}
+	l.Points = common.ArrayMapSkip(func(p gen.ChartPoint) (chart.Point, bool) {
+		t, err := common.ToTimeInLocation(p.Time, loc)
+		if err != nil {
+			return chart.Point{}, true
+		}
+		return chart.Point{Time: *t, Value: p.Value}, false
+	}, line.Points)
+	return l
+}
+
+// formTime フォームの日時の値を返却する。未指定の場合はnil (タイムゾーンを含まない日時はlocの日時として扱う)
+//
+// ※ バリデーション済みのフォームを指定すること
+func formTime(form *multipart.Form, name string, loc *time.Location) *time.Time {
+	values := form.Value[name]
+	if len(values) <= 0 {
+		return nil
+	}
+	t, err := common.ToTimeInLocation(values[0], loc)
+	if err != nil {
+		return nil
+	}
+	return t
+}
+
+// formInt フォームの整数値を返却する。未指定の場合はdefを返却する
+//
+// ※ バリデーション済みのフォームを指定すること
+func formInt(form *multipart.Form, name string, def int) int {
+	values := form.Value[name]
+	if len(values) <= 0 {
+		return def
+	}
+	v, err := strconv.Atoi(values[0])
+	if err != nil {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fxtester/internal/algo"
+	"fxtester/internal/audit"
+	"fxtester/internal/common"
+	"fxtester/internal/db"
+	"fxtester/internal/export"
+	"fxtester/internal/gen"
+	"fxtester/internal/lang"
+	"fxtester/internal/net"
+	"fxtester/internal/validator"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 出力ファイルの列 (見出しは辞書のwords.columns.<キー>で多言語化する)
+var (
+	zigzagColumnKeys = []string{
+		"startTime", "kind", "peakIndex", "bottomIndex", "delta", "velocity", "deltaPips", "velocityPips",
+	}
+	backtestTradeColumnKeys = []string{
+		"symbol", "side", "lots", "entryTime", "entryPrice", "exitTime", "exitPrice", "exitReason", "pips",
+		"grossProfit", "spreadCost", "slippageCost", "commission", "swap", "netProfit",
+	}
+	// インジケーターの列はローソク足の列の後に指定した順に出力する
+	indicatorCandleColumnKeys = []string{"time", "open", "high", "low", "close"}
+)
+
+// インジケーターの種類毎の算出関数
+var indicatorFuncs = map[gen.IndicatorKind]func(candles []common.Candle, period int) []*float64{
+	gen.Sma: algo.SimpleMovingAverage,
+	gen.Ema: algo.ExponentialMovingAverage,
+}
+
+// PostZigzagExport CSVまたはローソク足のデータをアップロードし、ジグザグをCSV・JSON Lines・Excel形式で出力します。
+//
+// (POST /zigzag/export)
+func (b *BarService) PostZigzagExport(ctx echo.Context, params gen.PostZigzagExportParams) (lastError error) {
+	defer func() {
+		b.recordDataEvent(ctx, audit.EventDataExport, lastError, uploadedFiles(ctx))
+	}()
+
+	var format string
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+	f, err := negotiateExportFormat(ctx, format)
+	if err != nil {
+		return err
+	}
+
+	items, err := b.calcZigzags(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := func(write func(values []any) error) error {
+		for _, z := range items {
+			kind := z.Kind
+			if k, ok := z.Kind.(algo.Kind); ok {
+				kind = int(k)
+			}
+			if err := write([]any{z.StartTime, kind, z.PeakIndex, z.BottomIndex, z.Delta, z.Velocity, z.DeltaPips, z.VelocityPips}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return writeExport(ctx, f, "zigzag", exportColumns(ctx, zigzagColumnKeys), rows)
+}
+
+// PostIndicatorsExport CSVまたはローソク足のデータをアップロードし、インジケーターをCSV・JSON Lines・Excel形式で出力します。
+//
+// (POST /indicators/export)
+func (b *BarService) PostIndicatorsExport(ctx echo.Context, params gen.PostIndicatorsExportParams) (lastError error) {
+	defer func() {
+		b.recordDataEvent(ctx, audit.EventDataExport, lastError, uploadedFiles(ctx))
+	}()
+
+	var format string
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+	f, err := negotiateExportFormat(ctx, format)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.Request().ParseMultipartForm(1 * 1024 * 1024)
+	if err != nil {
+		if errors.Is(err, multipart.ErrMessageTooLarge) {
+			return lang.NewFxtError(lang.ErrTooLargeMessageError)
+		} else {
+			return lang.NewFxtError(lang.ErrInvalidRequestProtocol).SetCause(err)
+		}
+	}
+
+	// リクエストパラメータのバリデーション
+	if err := validator.ValidatePostIndicatorsExport(ctx); err != nil {
+		return err
+	}
+
+	form := ctx.Request().MultipartForm
+
+	candles, err := readCandles(form, net.GetUserLocation(ctx))
+	if err != nil {
+		return err
+	}
+
+	var indicators []gen.Indicator
+	if err := json.Unmarshal([]byte(form.Value["indicators"][0]), &indicators); err != nil {
+		// バリデーション済みのため発生しない想定のエラー
+		panic("invalid indicators")
+	}
+
+	// 見出しはインジケーターの名前(多言語化)に期間を付ける (e.g. SMA(20))
+	columns := exportColumns(ctx, indicatorCandleColumnKeys)
+	series := make([][]*float64, len(indicators))
+	for i, indicator := range indicators {
+		columns = append(columns, export.Column{
+			Key:    fmt.Sprintf("%s%d", indicator.Kind, indicator.Period),
+			Header: fmt.Sprintf("%s(%d)", lang.GetDict(ctx, []string{"words", "columns", string(indicator.Kind)}), indicator.Period),
+		})
+		series[i] = indicatorFuncs[indicator.Kind](candles, indicator.Period)
+	}
+
+	rows := func(write func(values []any) error) error {
+		for i, c := range candles {
+			values := []any{c.Time, c.Open, c.High, c.Low, c.Close}
+			for _, s := range series {
+				values = append(values, s[i])
+			}
+			if err := write(values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return writeExport(ctx, f, "indicators", columns, rows)
+}
+
+// GetBacktestsIdExport 保存したバックテストの取引をCSV・JSON Lines・Excel形式で出力します。
+//
+// (GET /backtests/:id/export)
+func (b *BarService) GetBacktestsIdExport(ctx echo.Context, id int64, params gen.GetBacktestsIdExportParams) (lastError error) {
+	defer func() {
+		b.recordDataEvent(ctx, audit.EventDataExport, lastError, backtestAuditDetail(id))
+	}()
+
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	var format string
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+	f, err := negotiateExportFormat(ctx, format)
+	if err != nil {
+		return err
+	}
+
+	dao := db.NewBacktestEntityDao(b.idb)
+	if _, err := dao.SelectRun(session.UserId, id); errors.Is(err, db.ErrNoData) {
+		return lang.NewFxtError(lang.ErrResourceNotFound, "words.backtest")
+	} else if err != nil {
+		return err
+	}
+
+	// 取引は全件を読み込まずにDBから読み込んだ順に出力する
+	rows := func(write func(values []any) error) error {
+		return dao.ScanTrades(id, func(t db.BacktestTradeEntity) error {
+			return write([]any{t.Symbol, t.Side, t.Lots, t.EntryTime, t.EntryPrice, t.ExitTime, t.ExitPrice, t.ExitReason, t.Pips,
+				t.GrossProfit, t.SpreadCost, t.SlippageCost, t.Commission, t.Swap, t.NetProfit})
+		})
+	}
+
+	return writeExport(ctx, f, fmt.Sprintf("backtest_%d", id), exportColumns(ctx, backtestTradeColumnKeys), rows)
+}
+
+// negotiateExportFormat 'format'パラメータまたはAcceptヘッダから出力形式を決定する
+func negotiateExportFormat(ctx echo.Context, format string) (export.Format, error) {
+	f, ok := export.NegotiateFormat(format, ctx.Request().Header.Get(echo.HeaderAccept))
+	if !ok {
+		return "", lang.NewFxtError(lang.ErrInvalidParameterError, "format")
+	}
+	return f, nil
+}
+
+// exportColumns 列のキーから見出しをAccept-Languageで多言語化した列を作成する
+func exportColumns(ctx echo.Context, keys []string) []export.Column {
+	return common.ArrayMap(func(key string) export.Column {
+		return export.Column{
+			Key:    key,
+			Header: lang.GetDict(ctx, []string{"words", "columns", key}),
+		}
+	}, keys)
+}
+
+// writeExport rowsが生成した行を生成した順にレスポンスに書き出す (全ての行をメモリに保持しない)
+func writeExport(ctx echo.Context, format export.Format, name string, columns []export.Column, rows export.Rows) error {
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, format.ContentType())
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	res.WriteHeader(http.StatusOK)
+
+	// ヘッダ送信後のエラーはレスポンスに反映できないためログ出力のみ行う
+	w, err := export.NewWriter(format, res, columns, name)
+	if err != nil {
+		ctx.Logger().Errorf("failed to export %s: %v", name, err)
+		return nil
+	}
+	if err := export.WriteRows(w, rows); err != nil {
+		ctx.Logger().Errorf("failed to export %s: %v", name, err)
+	}
+	return nil
+}
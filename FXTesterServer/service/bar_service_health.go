@@ -0,0 +1,51 @@
+package service
+
+import (
+	"fxtester/internal/common"
+	"fxtester/internal/gen"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetHealth サーバの状態 (idPのメタデータの取得日時等) を返却します。
+//
+// (GET /health)
+func (b *BarService) GetHealth(ctx echo.Context) error {
+	config := common.GetConfig()
+	res := gen.Health{
+		Status:       gen.Ok,
+		AuthProvider: common.AuthProviderOidc,
+		SamlIdps:     []gen.SamlIdpHealth{},
+	}
+	if !config.IsAuthProvider(common.AuthProviderSaml) {
+		return ctx.JSON(http.StatusOK, res)
+	}
+
+	res.AuthProvider = common.AuthProviderSaml
+	now := time.Now()
+	for _, status := range b.samlClient.MetadataStatus() {
+		idp := gen.SamlIdpHealth{
+			Name:           status.Name,
+			EntityId:       status.EntityId,
+			FetchedAt:      status.FetchedAt.Format(time.RFC3339),
+			MetadataAgeSec: int64(now.Sub(status.FetchedAt) / time.Second),
+			RefreshFailed:  status.LastError != nil,
+		}
+		if !status.ValidUntil.IsZero() {
+			validUntil := status.ValidUntil.Format(time.RFC3339)
+			idp.ValidUntil = &validUntil
+		}
+		if !status.NextRefreshAt.IsZero() {
+			nextRefreshAt := status.NextRefreshAt.Format(time.RFC3339)
+			idp.NextRefreshAt = &nextRefreshAt
+		}
+		// 再取得に失敗している、またはメタデータの有効期限が切れている場合
+		if idp.RefreshFailed || (!status.ValidUntil.IsZero() && status.ValidUntil.Before(now)) {
+			res.Status = gen.Degraded
+		}
+		res.SamlIdps = append(res.SamlIdps, idp)
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fxtester/internal/db"
+	"fxtester/internal/gen"
+	"fxtester/internal/lang"
+	"fxtester/internal/validator"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetMe ログインユーザの情報と設定を返却します。
+//
+// (GET /me)
+func (b *BarService) GetMe(ctx echo.Context) error {
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	me, err := selectMe(db.NewUserEntityDao(b.idb), session.UserId)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, me)
+}
+
+// PatchMe ログインユーザの設定のうち、指定した項目を更新します。
+//
+// (PATCH /me)
+func (b *BarService) PatchMe(ctx echo.Context) error {
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	var req gen.UserPreferences
+	if err := ctx.Bind(&req); err != nil {
+		return lang.NewFxtError(lang.ErrInvalidRequestProtocol).SetCause(err)
+	}
+
+	// リクエストパラメータのバリデーション
+	if err := validator.ValidatePatchMe(req); err != nil {
+		return err
+	}
+
+	// 未指定の項目はJSONに含まれないため、指定した項目のみが上書きされる
+	preferences, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	dao := db.NewUserEntityDao(b.idb)
+	if err := dao.UpdatePreferences(session.UserId, preferences); err != nil {
+		return err
+	}
+
+	me, err := selectMe(dao, session.UserId)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, me)
+}
+
+// selectMe 指定ユーザの情報と設定を取得する
+func selectMe(dao db.IUserEntityDao, userId int64) (*gen.Me, error) {
+	user, err := dao.SelectWithUserId(userId)
+	if errors.Is(err, db.ErrNoData) {
+		// トークンの発行後にユーザが削除されている場合
+		return nil, lang.NewFxtError(lang.ErrUnauthorized).SetCause(err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var preferences gen.UserPreferences
+	if err := json.Unmarshal(user.Preferences, &preferences); err != nil {
+		return nil, lang.NewFxtError(lang.ErrDBQueryResult).SetCause(err)
+	}
+	return &gen.Me{
+		Id:          user.UserId,
+		Email:       user.Email,
+		Roles:       user.Roles,
+		Profile:     toUserProfile(user),
+		Preferences: preferences,
+	}, nil
+}
+
+// toUserProfile ユーザのSAMLアサーションから同期した属性をレスポンスの形式に変換する
+func toUserProfile(user *db.UserEntity) gen.UserProfile {
+	groups := user.Groups
+	if groups == nil {
+		groups = []string{}
+	}
+	return gen.UserProfile{
+		DisplayName: user.DisplayName,
+		GivenName:   user.GivenName,
+		FamilyName:  user.FamilyName,
+		Groups:      groups,
+		Locale:      user.Locale,
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"fxtester/internal/db"
+	"fxtester/internal/gen"
+	"fxtester/internal/lang"
+	"fxtester/internal/net"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetSessions ログインユーザのログイン中のセッション(端末)の一覧を返却します。
+//
+// (GET /sessions)
+func (b *BarService) GetSessions(ctx echo.Context) error {
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := db.NewUserEntityDao(b.idb).SelectSessions(session.UserId)
+	if err != nil {
+		return err
+	}
+
+	items := make([]gen.UserSession, len(sessions))
+	for i, s := range sessions {
+		items[i] = gen.UserSession{
+			Id:         s.SessionId,
+			Device:     s.Device,
+			IpAddress:  s.IpAddress,
+			UserAgent:  s.UserAgent,
+			CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+			LastSeenAt: s.LastSeenAt.Format(time.RFC3339),
+			Current:    s.SessionId == session.SessionId,
+		}
+	}
+	return ctx.JSON(http.StatusOK, gen.GetSessionsResult{
+		Items: items,
+		Count: len(items),
+	})
+}
+
+// DeleteSessionsId 指定したセッションを失効させ、その端末をログアウトさせます。
+//
+// (DELETE /sessions/{id})
+func (b *BarService) DeleteSessionsId(ctx echo.Context, id string) error {
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = db.NewUserEntityDao(b.idb).DeleteSession(session.UserId, id)
+	if errors.Is(err, db.ErrNoData) {
+		return lang.NewFxtError(lang.ErrResourceNotFound, "words.session")
+	} else if err != nil {
+		return err
+	}
+
+	if id == session.SessionId {
+		// 自身のセッションを失効させた場合はCookieも削除する
+		net.DeleteAuthSession(ctx.Response().Writer)
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
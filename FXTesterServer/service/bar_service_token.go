@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"fxtester/internal/common"
+	"fxtester/internal/db"
+	"fxtester/internal/gen"
+	"fxtester/internal/lang"
+	"fxtester/internal/net"
+	"fxtester/internal/validator"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// GetTokens ログインユーザの個人用APIトークンの一覧を返却します。
+//
+// (GET /tokens)
+func (b *BarService) GetTokens(ctx echo.Context) error {
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := db.NewUserEntityDao(b.idb).SelectApiTokens(session.UserId)
+	if err != nil {
+		return err
+	}
+
+	items := make([]gen.ApiToken, len(tokens))
+	for i, t := range tokens {
+		items[i] = toGenApiToken(t)
+	}
+	return ctx.JSON(http.StatusOK, gen.GetTokensResult{
+		Items: items,
+		Count: len(items),
+	})
+}
+
+// PostTokens 個人用APIトークンを発行します。トークンは応答でのみ返却し、DBにはハッシュ値のみ保存します。
+//
+// (POST /tokens)
+func (b *BarService) PostTokens(ctx echo.Context) error {
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	var req gen.PostTokensRequest
+	if err := ctx.Bind(&req); err != nil {
+		return lang.NewFxtError(lang.ErrInvalidRequestProtocol).SetCause(err)
+	}
+
+	// リクエストパラメータのバリデーション
+	if err := validator.ValidatePostTokens(req); err != nil {
+		return err
+	}
+
+	config := common.GetConfig().ApiToken
+	dao := db.NewUserEntityDao(b.idb)
+	tokens, err := dao.SelectApiTokens(session.UserId)
+	if err != nil {
+		return err
+	}
+	if 0 < config.MaxPerUser && config.MaxPerUser <= len(tokens) {
+		return lang.NewFxtError(lang.ErrResourceLimitExceeded, "words.apiToken", config.MaxPerUser)
+	}
+
+	token, tokenHash, tokenPrefix, err := net.GenerateApiToken()
+	if err != nil {
+		return err
+	}
+	expiresInDays := config.DefaultExpiresDays
+	if req.ExpiresInDays != nil {
+		expiresInDays = *req.ExpiresInDays
+	}
+	now := time.Now()
+	entity := db.ApiTokenEntity{
+		TokenId:     uuid.NewString(),
+		UserId:      session.UserId,
+		Name:        req.Name,
+		TokenHash:   tokenHash,
+		TokenPrefix: tokenPrefix,
+		Scopes:      common.ArrayMap(func(v gen.ApiTokenScope) string { return string(v) }, req.Scopes),
+		CreatedAt:   now,
+		ExpiresAt:   now.AddDate(0, 0, expiresInDays),
+	}
+	if err := dao.CreateApiToken(&entity); errors.Is(err, db.ErrAlreadyExists) {
+		return lang.NewFxtError(lang.ErrInvalidParameterError, "name")
+	} else if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, gen.PostTokensResult{
+		Token: token,
+		Item:  toGenApiToken(entity),
+	})
+}
+
+// DeleteTokensId 指定した個人用APIトークンを失効させます。
+//
+// (DELETE /tokens/{id})
+func (b *BarService) DeleteTokensId(ctx echo.Context, id string) error {
+	session, err := getLoginUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = db.NewUserEntityDao(b.idb).DeleteApiToken(session.UserId, id)
+	if errors.Is(err, db.ErrNoData) {
+		return lang.NewFxtError(lang.ErrResourceNotFound, "words.apiToken")
+	} else if err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// toGenApiToken 個人用APIトークンのエンティティをAPIの型に変換する
+func toGenApiToken(t db.ApiTokenEntity) gen.ApiToken {
+	token := gen.ApiToken{
+		Id:        t.TokenId,
+		Name:      t.Name,
+		Prefix:    t.TokenPrefix,
+		Scopes:    common.ArrayMap(func(v string) gen.ApiTokenScope { return gen.ApiTokenScope(v) }, t.Scopes),
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: t.ExpiresAt.Format(time.RFC3339),
+	}
+	if t.LastUsedAt != nil {
+		lastUsedAt := t.LastUsedAt.Format(time.RFC3339)
+		token.LastUsedAt = &lastUsedAt
+	}
+	return token
+}